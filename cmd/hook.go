@@ -372,12 +372,14 @@ Gitea or set your environment appropriately.`, "")
 	total := 0
 	wasEmpty := false
 	masterPushed := false
+	wikiPushed := false
 	results := make([]private.HookPostReceiveBranchResult, 0)
 
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		// TODO: support news feeds for wiki
 		if isWiki {
+			wikiPushed = true
 			continue
 		}
 
@@ -415,6 +417,15 @@ Gitea or set your environment appropriately.`, "")
 		}
 	}
 
+	if wikiPushed {
+		// only tell the wiki has been updated, so the pages are reindexed
+		hookOptions.IsWiki = true
+		if _, extra := private.HookPostReceive(ctx, repoUser, repoName, hookOptions); extra.HasError() {
+			_ = dWriter.Close()
+			return fail(ctx, extra.UserMsg, "HookPostReceive failed: %v", extra.Error)
+		}
+	}
+
 	if count == 0 {
 		if wasEmpty && masterPushed {
 			// We need to tell the repo to reset the default branch to master
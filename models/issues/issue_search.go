@@ -46,6 +46,8 @@ type IssuesOptions struct { //nolint
 	IssueIDs           []int64
 	UpdatedAfterUnix   int64
 	UpdatedBeforeUnix  int64
+	CreatedAfterUnix   int64
+	CreatedBeforeUnix  int64
 	// prioritize issues from this repo
 	PriorityRepoID int64
 	IsArchived     optional.Option[bool]
@@ -258,6 +260,12 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 	if opts.UpdatedBeforeUnix != 0 {
 		sess.And(builder.Lte{"issue.updated_unix": opts.UpdatedBeforeUnix})
 	}
+	if opts.CreatedAfterUnix != 0 {
+		sess.And(builder.Gte{"issue.created_unix": opts.CreatedAfterUnix})
+	}
+	if opts.CreatedBeforeUnix != 0 {
+		sess.And(builder.Lte{"issue.created_unix": opts.CreatedBeforeUnix})
+	}
 
 	applyProjectCondition(sess, opts)
 
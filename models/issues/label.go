@@ -501,6 +501,21 @@ func GetLabelIDsByNames(ctx context.Context, labelNames []string) ([]int64, erro
 		Find(&labelIDs)
 }
 
+// GetLabelsByNamesInRepos returns the labels with the given names which can be used by issues of the given repositories,
+// including the labels of the organizations owning them.
+// If repoIDs is empty, the labels of all repositories and organizations are returned.
+func GetLabelsByNamesInRepos(ctx context.Context, repoIDs []int64, labelNames []string) ([]*Label, error) {
+	cond := builder.NewCond().And(builder.In("name", labelNames))
+	if len(repoIDs) > 0 {
+		cond = cond.And(builder.Or(
+			builder.In("repo_id", repoIDs),
+			builder.In("org_id", builder.Select("owner_id").From("repository").Where(builder.In("id", repoIDs))),
+		))
+	}
+	labels := make([]*Label, 0, len(labelNames))
+	return labels, db.GetEngine(ctx).Where(cond).Asc("id").Find(&labels)
+}
+
 // CountLabelsByOrgID count all labels that belong to given organization by ID.
 func CountLabelsByOrgID(ctx context.Context, orgID int64) (int64, error) {
 	return db.GetEngine(ctx).Where("org_id = ?", orgID).Count(&Label{})
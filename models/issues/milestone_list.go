@@ -89,6 +89,20 @@ func GetMilestoneIDsByNames(ctx context.Context, names []string) ([]int64, error
 		Find(&ids)
 }
 
+// GetMilestoneIDsByNamesInRepos returns the ids of the milestones with the given names in the given repositories.
+// If repoIDs is empty, the milestones of all repositories are returned.
+func GetMilestoneIDsByNamesInRepos(ctx context.Context, repoIDs []int64, names []string) ([]int64, error) {
+	cond := db.BuildCaseInsensitiveIn("name", names)
+	if len(repoIDs) > 0 {
+		cond = cond.And(builder.In("repo_id", repoIDs))
+	}
+	var ids []int64
+	return ids, db.GetEngine(ctx).Table("milestone").
+		Where(cond).
+		Cols("id").
+		Find(&ids)
+}
+
 // LoadTotalTrackedTimes loads for every milestone in the list the TotalTrackedTime by a batch request
 func (milestones MilestoneList) LoadTotalTrackedTimes(ctx context.Context) error {
 	type totalTimesByMilestone struct {
@@ -377,6 +377,15 @@ func prepareMigrationTasks() []*migration {
 		newMigration(314, "Update OwnerID as zero for repository level action tables", v1_24.UpdateOwnerIDOfRepoLevelActionsTables),
 		newMigration(315, "Add Ephemeral to ActionRunner", v1_24.AddEphemeralToActionRunner),
 		newMigration(316, "Add description for secrets and variables", v1_24.AddDescriptionForSecretsAndVariables),
+		newMigration(317, "Add delivery lease to hook_task", v1_24.AddDeliveryLeaseToHookTask),
+		newMigration(318, "Add transport settings to webhook", v1_24.AddTransportSettingsToWebhook),
+		newMigration(319, "Create package proxy tables", v1_24.CreatePackageProxyTables),
+		newMigration(320, "Add package visibility and access grants", v1_24.AddPackageVisibilityAndAccess),
+		newMigration(321, "Add package download statistics", v1_24.AddPackageDownloadStat),
+		newMigration(322, "Add package build provenance", v1_24.AddPackageBuildProvenance),
+		newMigration(323, "Add package signing keys", v1_24.AddPackageSigningKeys),
+		newMigration(324, "Add repository code symbols", v1_24.AddRepoCodeSymbol),
+		newMigration(325, "Add repository indexer refs", v1_24.AddRepoIndexerRef),
 	}
 	return preparedMigrations
 }
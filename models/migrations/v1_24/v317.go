@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddDeliveryLeaseToHookTask(x *xorm.Engine) error {
+	type HookTask struct {
+		ClaimedBy    string
+		ClaimedUntil timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Attempts     int                `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(HookTask))
+}
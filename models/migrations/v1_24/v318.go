@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddTransportSettingsToWebhook(x *xorm.Engine) error {
+	type Webhook struct {
+		ClientCertificate  string `xorm:"TEXT"`
+		ClientKeyEncrypted string `xorm:"TEXT"`
+		CACertificate      string `xorm:"ca_certificate TEXT"`
+		TLSServerName      string `xorm:"tls_server_name"`
+		ProxyURLEncrypted  string `xorm:"proxy_url_encrypted TEXT"`
+	}
+
+	return x.Sync(new(Webhook))
+}
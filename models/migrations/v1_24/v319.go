@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageProxyTables(x *xorm.Engine) error {
+	type PackageProxy struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Enabled     bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Type        string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		UpstreamURL string             `xorm:"TEXT NOT NULL"`
+		MetadataTTL int64              `xorm:"NOT NULL DEFAULT 0"`
+		Allowlist   string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	type PackageProxyMetadata struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProxyID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Path        string             `xorm:"UNIQUE(s) NOT NULL"`
+		Content     []byte             `xorm:"LONGBLOB"`
+		FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageProxy), new(PackageProxyMetadata))
+}
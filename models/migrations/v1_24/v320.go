@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageVisibilityAndAccess(x *xorm.Engine) error {
+	type Package struct {
+		ID               int64  `xorm:"pk autoincr"`
+		OwnerID          int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		RepoID           int64  `xorm:"INDEX"`
+		Type             string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Name             string `xorm:"NOT NULL"`
+		LowerName        string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		SemverCompatible bool   `xorm:"NOT NULL DEFAULT false"`
+		IsInternal       bool   `xorm:"NOT NULL DEFAULT false"`
+		Visibility       int    `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageAccess struct {
+		ID          int64              `xorm:"pk autoincr"`
+		PackageID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		TeamID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		AccessMode  int                `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(Package), new(PackageAccess))
+}
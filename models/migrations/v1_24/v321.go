@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageDownloadStat(x *xorm.Engine) error {
+	type PackageDownloadStat struct {
+		ID          int64              `xorm:"pk autoincr"`
+		VersionID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Client      string             `xorm:"UNIQUE(s) NOT NULL"`
+		Granularity int                `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		PeriodUnix  timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Count       int64              `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageDownloadStat))
+}
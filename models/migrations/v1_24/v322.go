@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageBuildProvenance(x *xorm.Engine) error {
+	type PackageVersion struct {
+		SourceRepoID     int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+		SourceCommitSHA  string `xorm:"INDEX VARCHAR(64) NOT NULL DEFAULT ''"`
+		SourceWorkflowID string `xorm:"NOT NULL DEFAULT ''"`
+		SourceRunID      int64  `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageProvenance struct {
+		ID                 int64              `xorm:"pk autoincr"`
+		VersionID          int64              `xorm:"INDEX NOT NULL"`
+		CreatorID          int64              `xorm:"NOT NULL DEFAULT 0"`
+		PredicateType      string             `xorm:"NOT NULL"`
+		SourceURI          string             `xorm:"TEXT"`
+		SourceDigest       string             `xorm:"VARCHAR(64)"`
+		HasSignatures      bool               `xorm:"NOT NULL DEFAULT false"`
+		MatchesFiles       bool               `xorm:"NOT NULL DEFAULT false"`
+		MatchesBuildSource bool               `xorm:"NOT NULL DEFAULT false"`
+		Content            string             `xorm:"LONGTEXT NOT NULL"`
+		CreatedUnix        timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(PackageVersion), new(PackageProvenance))
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"xorm.io/xorm"
+)
+
+type packageSigningKeyV323 struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type        string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Fingerprint string             `xorm:"UNIQUE(s) NOT NULL"`
+	PublicKey   string             `xorm:"LONGTEXT NOT NULL"`
+	PrivateKey  string             `xorm:"LONGTEXT NOT NULL"`
+	Status      int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RetireUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RevokedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+func (packageSigningKeyV323) TableName() string {
+	return "package_signing_key"
+}
+
+type userSettingV323 struct {
+	ID           int64  `xorm:"pk autoincr"`
+	UserID       int64  `xorm:"index unique(key_userid)"`
+	SettingKey   string `xorm:"varchar(255) index unique(key_userid)"`
+	SettingValue string `xorm:"text"`
+}
+
+func (userSettingV323) TableName() string {
+	return "user_setting"
+}
+
+// AddPackageSigningKeys moves the Debian, RPM and Terraform signing keys from the user settings into their own table
+func AddPackageSigningKeys(x *xorm.Engine) error {
+	if err := x.Sync(new(packageSigningKeyV323)); err != nil {
+		return err
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	for _, packageType := range []string{"debian", "rpm", "terraform"} {
+		if err := moveSigningKeysFromUserSettings(sess, packageType); err != nil {
+			return err
+		}
+	}
+
+	return sess.Commit()
+}
+
+// moveSigningKeysFromUserSettings moves the signing keys of a package type from the user settings into the package_signing_key table
+func moveSigningKeysFromUserSettings(sess *xorm.Session, packageType string) error {
+	privateKeys := make([]*userSettingV323, 0, 10)
+	if err := sess.Where("setting_key = ?", packageType+".key.private").Find(&privateKeys); err != nil {
+		return err
+	}
+
+	for _, priv := range privateKeys {
+		var pub userSettingV323
+		has, err := sess.Where("user_id = ? AND setting_key = ?", priv.UserID, packageType+".key.public").Get(&pub)
+		if err != nil {
+			return err
+		}
+		if !has {
+			continue
+		}
+
+		keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(pub.SettingValue))
+		if err != nil || len(keyring) == 0 {
+			log.Warn("Skipping invalid %s signing key of owner %d: %v", packageType, priv.UserID, err)
+			continue
+		}
+
+		if _, err := sess.Insert(&packageSigningKeyV323{
+			OwnerID:     priv.UserID,
+			Type:        packageType,
+			Fingerprint: strings.ToUpper(hex.EncodeToString(keyring[0].PrimaryKey.Fingerprint)),
+			PublicKey:   pub.SettingValue,
+			PrivateKey:  priv.SettingValue,
+		}); err != nil {
+			return err
+		}
+	}
+
+	_, err := sess.In("setting_key", packageType+".key.private", packageType+".key.public").Delete(&userSettingV323{})
+	return err
+}
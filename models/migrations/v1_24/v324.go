@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddRepoCodeSymbol(x *xorm.Engine) error {
+	type RepoCodeSymbol struct {
+		ID        int64  `xorm:"pk autoincr"`
+		RepoID    int64  `xorm:"INDEX(s) NOT NULL"`
+		Filename  string `xorm:"VARCHAR(500) NOT NULL"`
+		Name      string `xorm:"VARCHAR(255) NOT NULL"`
+		LowerName string `xorm:"INDEX(s) VARCHAR(255) NOT NULL"`
+		Kind      string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		Line      int    `xorm:"NOT NULL"`
+		Container string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+		Language  string `xorm:"VARCHAR(100) NOT NULL DEFAULT ''"`
+		Content   string `xorm:"TEXT"`
+	}
+
+	return x.Sync(new(RepoCodeSymbol))
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_24 //nolint
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddRepoIndexerRef(x *xorm.Engine) error {
+	type RepoIndexerRef struct {
+		ID        int64  `xorm:"pk autoincr"`
+		RepoID    int64  `xorm:"UNIQUE(s) NOT NULL"`
+		RefName   string `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		CommitSha string `xorm:"VARCHAR(64) NOT NULL"`
+	}
+
+	return x.Sync(new(RepoIndexerRef))
+}
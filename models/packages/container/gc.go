@@ -0,0 +1,164 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+
+	"xorm.io/builder"
+)
+
+// GetImages gets all images of the owner or of all owners if ownerID is 0
+func GetImages(ctx context.Context, ownerID int64) ([]*packages.Package, error) {
+	var cond builder.Cond = builder.Eq{
+		"package.type":        packages.TypeContainer,
+		"package.is_internal": false,
+	}
+	if ownerID != 0 {
+		cond = cond.And(builder.Eq{"package.owner_id": ownerID})
+	}
+
+	ps := make([]*packages.Package, 0, 10)
+	return ps, db.GetEngine(ctx).
+		Where(cond).
+		Asc("package.owner_id", "package.id").
+		Find(&ps)
+}
+
+// ImageManifest is a manifest version of an image with the relations to other manifests
+type ImageManifest struct {
+	Version    *packages.PackageVersion
+	Digest     string
+	IsTagged   bool
+	References []string // manifests listed in an image index
+	Subjects   []string // manifests a signature, SBOM or attestation refers to
+}
+
+// GetImageManifests gets all manifest versions of the image
+func GetImageManifests(ctx context.Context, packageID int64) ([]*ImageManifest, error) {
+	pvs := make([]*packages.PackageVersion, 0, 10)
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"package_id": packageID, "is_internal": false}).
+		Find(&pvs); err != nil {
+		return nil, err
+	}
+
+	manifests := make(map[int64]*ImageManifest, len(pvs))
+	result := make([]*ImageManifest, 0, len(pvs))
+	for _, pv := range pvs {
+		m := &ImageManifest{Version: pv}
+		manifests[pv.ID] = m
+		result = append(result, m)
+	}
+	if len(pvs) == 0 {
+		return result, nil
+	}
+
+	versionIDs := builder.Select("id").From("package_version").Where(builder.Eq{"package_id": packageID, "is_internal": false})
+
+	pps := make([]*packages.PackageProperty, 0, len(pvs))
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"ref_type": packages.PropertyTypeVersion}).
+		And(builder.In("name", container_module.PropertyManifestTagged, container_module.PropertyManifestReference, container_module.PropertyManifestSubject)).
+		And(builder.In("ref_id", versionIDs)).
+		Find(&pps); err != nil {
+		return nil, err
+	}
+	for _, pp := range pps {
+		m, ok := manifests[pp.RefID]
+		if !ok {
+			continue
+		}
+		switch pp.Name {
+		case container_module.PropertyManifestTagged:
+			m.IsTagged = true
+		case container_module.PropertyManifestReference:
+			m.References = append(m.References, pp.Value)
+		case container_module.PropertyManifestSubject:
+			m.Subjects = append(m.Subjects, pp.Value)
+		}
+	}
+
+	type fileDigest struct {
+		VersionID int64
+		Value     string
+	}
+	fds := make([]*fileDigest, 0, len(pvs))
+	if err := db.GetEngine(ctx).
+		Table("package_file").
+		Select("package_file.version_id, package_property.value").
+		Join("INNER", "package_property", "package_property.ref_id = package_file.id").
+		Where(builder.Eq{
+			"package_file.lower_name":   ManifestFilename,
+			"package_property.ref_type": packages.PropertyTypeFile,
+			"package_property.name":     container_module.PropertyDigest,
+		}).
+		And(builder.In("package_file.version_id", versionIDs)).
+		Find(&fds); err != nil {
+		return nil, err
+	}
+	for _, fd := range fds {
+		if m, ok := manifests[fd.VersionID]; ok {
+			m.Digest = fd.Value
+		}
+	}
+
+	return result, nil
+}
+
+// StorageUsage is the storage used by the container images of an owner
+type StorageUsage struct {
+	OwnerID   int64
+	BlobCount int64
+	Size      int64
+}
+
+// GetStorageUsage gets the number and the size of the distinct blobs referenced by the images of every owner
+// Blobs shared by multiple images of an owner are counted once.
+func GetStorageUsage(ctx context.Context, ownerID int64) ([]*StorageUsage, error) {
+	var cond builder.Cond = builder.Eq{
+		"package.type": packages.TypeContainer,
+	}
+	if ownerID != 0 {
+		cond = cond.And(builder.Eq{"package.owner_id": ownerID})
+	}
+
+	blobs := builder.
+		Select("DISTINCT package.owner_id, package_blob.id, package_blob.size").
+		From("package_file").
+		InnerJoin("package_version", "package_version.id = package_file.version_id").
+		InnerJoin("package", "package.id = package_version.package_id").
+		InnerJoin("package_blob", "package_blob.id = package_file.blob_id").
+		Where(cond)
+
+	query := builder.
+		Select("blobs.owner_id, COUNT(*) AS blob_count, SUM(blobs.size) AS size").
+		From(blobs, "blobs").
+		GroupBy("blobs.owner_id").
+		OrderBy("blobs.owner_id")
+
+	usages := make([]*StorageUsage, 0, 10)
+	return usages, db.GetEngine(ctx).SQL(query).Find(&usages)
+}
+
+// GetBlobsOnlyReferencedByVersions gets the blobs which are referenced by files of the versions but by no other file
+func GetBlobsOnlyReferencedByVersions(ctx context.Context, versionIDs []int64) ([]*packages.PackageBlob, error) {
+	pbs := make([]*packages.PackageBlob, 0, len(versionIDs))
+	if len(versionIDs) == 0 {
+		return pbs, nil
+	}
+
+	return pbs, db.GetEngine(ctx).
+		Where(builder.In("id", builder.Select("blob_id").From("package_file").Where(builder.In("version_id", versionIDs)))).
+		And(builder.NotExists(
+			builder.Select("package_file.id").
+				From("package_file").
+				Where(builder.Expr("package_file.blob_id = package_blob.id").And(builder.NotIn("package_file.version_id", versionIDs))),
+		)).
+		Find(&pbs)
+}
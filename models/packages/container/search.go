@@ -91,7 +91,7 @@ func getContainerBlobsLimit(ctx context.Context, opts *BlobSearchOptions, limit
 	sess := db.GetEngine(ctx).
 		Join("INNER", "package_version", "package_version.id = package_file.version_id").
 		Join("INNER", "package", "package.id = package_version.package_id").
-		Where(opts.toConds())
+		Where(opts.toConds().And(packages.AccessCond(ctx)))
 
 	if limit > 0 {
 		sess = sess.Limit(limit)
@@ -106,7 +106,7 @@ func getContainerBlobsLimit(ctx context.Context, opts *BlobSearchOptions, limit
 
 // GetManifestVersions gets all package versions representing the matching manifest
 func GetManifestVersions(ctx context.Context, opts *BlobSearchOptions) ([]*packages.PackageVersion, error) {
-	cond := opts.toConds().And(builder.Eq{"package_version.is_internal": false})
+	cond := opts.toConds().And(builder.Eq{"package_version.is_internal": false}).And(packages.AccessCond(ctx))
 
 	pvs := make([]*packages.PackageVersion, 0, 10)
 	return pvs, db.GetEngine(ctx).
@@ -137,6 +137,7 @@ func GetImageTags(ctx context.Context, ownerID int64, image string, n int, last
 	}
 
 	cond = cond.And(builder.In("package_version.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
+	cond = cond.And(packages.AccessCond(ctx))
 
 	if last != "" {
 		cond = cond.And(builder.Gt{"package_version.lower_version": strings.ToLower(last)})
@@ -216,7 +217,7 @@ func (opts *ImageTagsSearchOptions) configureOrderBy(e db.Engine) {
 func SearchImageTags(ctx context.Context, opts *ImageTagsSearchOptions) ([]*packages.PackageVersion, int64, error) {
 	sess := db.GetEngine(ctx).
 		Join("INNER", "package", "package.id = package_version.package_id").
-		Where(opts.toConds())
+		Where(opts.toConds().And(packages.AccessCond(ctx)))
 
 	opts.configureOrderBy(sess)
 
@@ -31,6 +31,7 @@ type Type string
 // List of supported packages
 const (
 	TypeAlpine    Type = "alpine"
+	TypeAnsible   Type = "ansible"
 	TypeArch      Type = "arch"
 	TypeCargo     Type = "cargo"
 	TypeChef      Type = "chef"
@@ -43,6 +44,7 @@ const (
 	TypeGeneric   Type = "generic"
 	TypeGo        Type = "go"
 	TypeHelm      Type = "helm"
+	TypeHex       Type = "hex"
 	TypeMaven     Type = "maven"
 	TypeNpm       Type = "npm"
 	TypeNuGet     Type = "nuget"
@@ -51,11 +53,13 @@ const (
 	TypeRpm       Type = "rpm"
 	TypeRubyGems  Type = "rubygems"
 	TypeSwift     Type = "swift"
+	TypeTerraform Type = "terraform"
 	TypeVagrant   Type = "vagrant"
 )
 
 var TypeList = []Type{
 	TypeAlpine,
+	TypeAnsible,
 	TypeArch,
 	TypeCargo,
 	TypeChef,
@@ -68,6 +72,7 @@ var TypeList = []Type{
 	TypeGeneric,
 	TypeGo,
 	TypeHelm,
+	TypeHex,
 	TypeMaven,
 	TypeNpm,
 	TypeNuGet,
@@ -76,6 +81,7 @@ var TypeList = []Type{
 	TypeRpm,
 	TypeRubyGems,
 	TypeSwift,
+	TypeTerraform,
 	TypeVagrant,
 }
 
@@ -84,6 +90,8 @@ func (pt Type) Name() string {
 	switch pt {
 	case TypeAlpine:
 		return "Alpine"
+	case TypeAnsible:
+		return "Ansible"
 	case TypeArch:
 		return "Arch"
 	case TypeCargo:
@@ -108,6 +116,8 @@ func (pt Type) Name() string {
 		return "Go"
 	case TypeHelm:
 		return "Helm"
+	case TypeHex:
+		return "Hex"
 	case TypeMaven:
 		return "Maven"
 	case TypeNpm:
@@ -124,17 +134,31 @@ func (pt Type) Name() string {
 		return "RubyGems"
 	case TypeSwift:
 		return "Swift"
+	case TypeTerraform:
+		return "Terraform"
 	case TypeVagrant:
 		return "Vagrant"
 	}
 	panic(fmt.Sprintf("unknown package type: %s", string(pt)))
 }
 
+// HasSharedRepositoryIndex returns true if the registry serves one repository index of all packages of the owner.
+// The access to these packages can't be restricted individually because the index would reveal them.
+func (pt Type) HasSharedRepositoryIndex() bool {
+	switch pt {
+	case TypeAlpine, TypeArch, TypeDebian, TypeRpm:
+		return true
+	}
+	return false
+}
+
 // SVGName gets the name of the package type svg image
 func (pt Type) SVGName() string {
 	switch pt {
 	case TypeAlpine:
 		return "gitea-alpine"
+	case TypeAnsible:
+		return "gitea-ansible"
 	case TypeArch:
 		return "gitea-arch"
 	case TypeCargo:
@@ -159,6 +183,8 @@ func (pt Type) SVGName() string {
 		return "gitea-go"
 	case TypeHelm:
 		return "gitea-helm"
+	case TypeHex:
+		return "gitea-hex"
 	case TypeMaven:
 		return "gitea-maven"
 	case TypeNpm:
@@ -175,22 +201,58 @@ func (pt Type) SVGName() string {
 		return "gitea-rubygems"
 	case TypeSwift:
 		return "gitea-swift"
+	case TypeTerraform:
+		return "gitea-terraform"
 	case TypeVagrant:
 		return "gitea-vagrant"
 	}
 	panic(fmt.Sprintf("unknown package type: %s", string(pt)))
 }
 
+// Visibility defines who can access a package in addition to the explicit access grants
+type Visibility int
+
+const (
+	// VisibilityInherit follows the visibility and the team package permissions of the owner
+	VisibilityInherit Visibility = iota
+	// VisibilityPublic allows everyone who can see packages at all to read the package, even if the owner is private
+	VisibilityPublic
+	// VisibilityPrivate restricts the package to owner administrators and explicit access grants
+	VisibilityPrivate
+)
+
+var visibilityNames = map[Visibility]string{
+	VisibilityInherit: "inherit",
+	VisibilityPublic:  "public",
+	VisibilityPrivate: "private",
+}
+
+// String returns the name of the visibility
+func (v Visibility) String() string {
+	return visibilityNames[v]
+}
+
+// ParseVisibility returns the visibility with the given name
+func ParseVisibility(name string) (Visibility, bool) {
+	for v, n := range visibilityNames {
+		if n == name {
+			return v, true
+		}
+	}
+	return VisibilityInherit, false
+}
+
 // Package represents a package
 type Package struct {
-	ID               int64  `xorm:"pk autoincr"`
-	OwnerID          int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	RepoID           int64  `xorm:"INDEX"`
-	Type             Type   `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	Name             string `xorm:"NOT NULL"`
-	LowerName        string `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	SemverCompatible bool   `xorm:"NOT NULL DEFAULT false"`
-	IsInternal       bool   `xorm:"NOT NULL DEFAULT false"`
+	ID               int64      `xorm:"pk autoincr"`
+	OwnerID          int64      `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	RepoID           int64      `xorm:"INDEX"`
+	Type             Type       `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name             string     `xorm:"NOT NULL"`
+	LowerName        string     `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	SemverCompatible bool       `xorm:"NOT NULL DEFAULT false"`
+	IsInternal       bool       `xorm:"NOT NULL DEFAULT false"`
+	Visibility       Visibility `xorm:"NOT NULL DEFAULT 0"`
 }
 
 // TryInsertPackage inserts a package. If a package exists already, ErrDuplicatePackage is returned
@@ -216,12 +278,21 @@ func TryInsertPackage(ctx context.Context, p *Package) (*Package, error) {
 	return p, nil
 }
 
-// DeletePackageByID deletes a package by id
+// DeletePackageByID deletes a package and its access grants by id
 func DeletePackageByID(ctx context.Context, packageID int64) error {
+	if _, err := db.GetEngine(ctx).Where("package_id = ?", packageID).Delete(&PackageAccess{}); err != nil {
+		return err
+	}
 	_, err := db.GetEngine(ctx).ID(packageID).Delete(&Package{})
 	return err
 }
 
+// SetVisibility sets the visibility of a package
+func SetVisibility(ctx context.Context, packageID int64, visibility Visibility) error {
+	_, err := db.GetEngine(ctx).ID(packageID).Cols("visibility").Update(&Package{Visibility: visibility})
+	return err
+}
+
 // SetRepositoryLink sets the linked repository
 func SetRepositoryLink(ctx context.Context, packageID, repoID int64) error {
 	_, err := db.GetEngine(ctx).ID(packageID).Cols("repo_id").Update(&Package{RepoID: repoID})
@@ -277,7 +348,7 @@ func GetPackageByName(ctx context.Context, ownerID int64, packageType Type, name
 	p := &Package{}
 
 	has, err := db.GetEngine(ctx).
-		Where(cond).
+		Where(cond.And(AccessCond(ctx))).
 		Get(p)
 	if err != nil {
 		return nil, err
@@ -298,7 +369,7 @@ func GetPackagesByType(ctx context.Context, ownerID int64, packageType Type) ([]
 
 	ps := make([]*Package, 0, 10)
 	return ps, db.GetEngine(ctx).
-		Where(cond).
+		Where(cond.And(AccessCond(ctx))).
 		Find(&ps)
 }
 
@@ -0,0 +1,295 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageAccess))
+}
+
+// PackageAccess grants a team or a single user access to a package independent of the owner permissions
+type PackageAccess struct {
+	ID          int64              `xorm:"pk autoincr"`
+	PackageID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	TeamID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	AccessMode  perm.AccessMode    `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// SetAccess inserts or updates the access grant of a team or user
+func SetAccess(ctx context.Context, pa *PackageAccess) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing := &PackageAccess{}
+		has, err := db.GetEngine(ctx).
+			Where(builder.Eq{"package_id": pa.PackageID, "team_id": pa.TeamID, "user_id": pa.UserID}).
+			Get(existing)
+		if err != nil {
+			return err
+		}
+		if has {
+			pa.ID = existing.ID
+			_, err = db.GetEngine(ctx).ID(pa.ID).Cols("access_mode").Update(pa)
+			return err
+		}
+		return db.Insert(ctx, pa)
+	})
+}
+
+// DeleteAccess removes the access grant of a team or user. It returns false if there was no such grant.
+func DeleteAccess(ctx context.Context, packageID, teamID, userID int64) (bool, error) {
+	n, err := db.GetEngine(ctx).
+		Where(builder.Eq{"package_id": packageID, "team_id": teamID, "user_id": userID}).
+		Delete(&PackageAccess{})
+	return n > 0, err
+}
+
+// GetAccessesByPackageID gets all access grants of a package
+func GetAccessesByPackageID(ctx context.Context, packageID int64) ([]*PackageAccess, error) {
+	pas := make([]*PackageAccess, 0, 10)
+	return pas, db.GetEngine(ctx).
+		Where("package_id = ?", packageID).
+		OrderBy("team_id, user_id").
+		Find(&pas)
+}
+
+// userGrantsCond returns the condition for access grants of the user, either direct or through one of the teams of the user
+func userGrantsCond(userID int64) builder.Cond {
+	return builder.Eq{"package_access.user_id": userID}.Or(
+		builder.In("package_access.team_id", builder.Select("team_user.team_id").From("team_user").Where(builder.Eq{"team_user.uid": userID})),
+	)
+}
+
+// GetGrantedAccessMode returns the highest access mode granted to the user for the package
+func GetGrantedAccessMode(ctx context.Context, packageID, userID int64) (perm.AccessMode, error) {
+	if userID <= 0 {
+		return perm.AccessModeNone, nil
+	}
+
+	pas := make([]*PackageAccess, 0, 5)
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"package_access.package_id": packageID}.And(userGrantsCond(userID))).
+		Find(&pas); err != nil {
+		return perm.AccessModeNone, err
+	}
+
+	mode := perm.AccessModeNone
+	for _, pa := range pas {
+		mode = max(mode, pa.AccessMode)
+	}
+	return mode, nil
+}
+
+// AccessScope describes which packages of an owner a user can access.
+// It is attached to the context of package requests and restricts the package lookups to the accessible packages.
+type AccessScope struct {
+	OwnerID int64
+	// UserID is the id of the signed in user or 0
+	UserID int64
+	// OwnerAccessMode is the access mode derived from the visibility and the team permissions of the owner
+	OwnerAccessMode perm.AccessMode
+	// AllowPublic is false if public packages must not be accessible, for example if sign in is required
+	AllowPublic bool
+	// RepoAccessMode is the access mode for packages which are not linked to a repository or linked to the repository RepoID,
+	// it is used for the tasks of Actions runs
+	RepoID         int64
+	RepoAccessMode perm.AccessMode
+	// PublicOnly restricts the scope to the packages visible to everyone, it is used for tokens limited to public resources.
+	// The packages which inherit the visibility of the owner are visible to everyone if OwnerIsPublic is true.
+	PublicOnly    bool
+	OwnerIsPublic bool
+}
+
+type accessScopeContextKeyType struct{}
+
+// AccessScopeContextKey is the context key of the access scope
+var AccessScopeContextKey accessScopeContextKeyType
+
+// WithAccessScope returns a context with the access scope. A nil scope lifts the restrictions of an outer scope.
+func WithAccessScope(ctx context.Context, scope *AccessScope) context.Context {
+	return context.WithValue(ctx, AccessScopeContextKey, scope)
+}
+
+// GetAccessScope returns the access scope of the context or nil
+func GetAccessScope(ctx context.Context) *AccessScope {
+	scope, _ := ctx.Value(AccessScopeContextKey).(*AccessScope)
+	return scope
+}
+
+// TxContext starts a transaction like db.TxContext which keeps the access scope and the build source of ctx
+func TxContext(ctx context.Context) (context.Context, db.Committer, error) {
+	txCtx, committer, err := db.TxContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return WithBuildSource(WithAccessScope(txCtx, GetAccessScope(ctx)), GetBuildSource(ctx)), committer, nil
+}
+
+// isPublic returns true if the package is visible to everyone in a public only scope
+func (s *AccessScope) isPublic(p *Package) bool {
+	return p.Visibility == VisibilityPublic || p.Visibility == VisibilityInherit && s.OwnerIsPublic
+}
+
+// Cond returns the condition for packages the scope allows to read
+func (s *AccessScope) Cond() builder.Cond {
+	cond := s.ownerCond()
+	if s.PublicOnly {
+		var publicCond builder.Cond = builder.Eq{"package.visibility": VisibilityPublic}
+		if s.OwnerIsPublic {
+			publicCond = publicCond.Or(builder.Eq{"package.visibility": VisibilityInherit})
+		}
+		cond = builder.And(cond, publicCond)
+	}
+	if !cond.IsValid() {
+		return cond // all the packages of the owner are accessible
+	}
+	return builder.Neq{"package.owner_id": s.OwnerID}.Or(cond)
+}
+
+// ownerCond returns the condition for the packages of the owner the scope allows to read, it's empty if all of them are accessible
+func (s *AccessScope) ownerCond() builder.Cond {
+	if s.OwnerAccessMode >= perm.AccessModeAdmin {
+		return builder.NewCond()
+	}
+
+	conds := make([]builder.Cond, 0, 3)
+	if s.OwnerAccessMode >= perm.AccessModeRead {
+		conds = append(conds, builder.Neq{"package.visibility": VisibilityPrivate})
+	} else if s.AllowPublic {
+		conds = append(conds, builder.Eq{"package.visibility": VisibilityPublic})
+	}
+	if s.UserID > 0 {
+		conds = append(conds, builder.In("package.id", builder.Select("package_access.package_id").From("package_access").Where(userGrantsCond(s.UserID))))
+	}
+	if len(conds) == 0 {
+		return builder.Expr("0 = 1")
+	}
+	return builder.Or(conds...)
+}
+
+// AccessMode returns the access mode of the scope for the package
+func (s *AccessScope) AccessMode(ctx context.Context, p *Package) (perm.AccessMode, error) {
+	if p.OwnerID != s.OwnerID || s.PublicOnly && !s.isPublic(p) {
+		return perm.AccessModeNone, nil
+	}
+
+	mode := s.OwnerAccessMode
+	if s.RepoID != 0 && (p.RepoID == 0 || p.RepoID == s.RepoID) {
+		mode = max(mode, s.RepoAccessMode)
+	}
+	switch p.Visibility {
+	case VisibilityPublic:
+		if s.AllowPublic {
+			mode = max(mode, perm.AccessModeRead)
+		}
+	case VisibilityPrivate:
+		if mode < perm.AccessModeAdmin {
+			mode = perm.AccessModeNone
+		}
+	}
+
+	if mode < perm.AccessModeWrite {
+		granted, err := GetGrantedAccessMode(ctx, p.ID, s.UserID)
+		if err != nil {
+			return perm.AccessModeNone, err
+		}
+		mode = max(mode, granted)
+	}
+	return mode, nil
+}
+
+// MaxAccessMode returns the highest access mode of the scope for any package of the owner
+func (s *AccessScope) MaxAccessMode(ctx context.Context) (perm.AccessMode, error) {
+	mode := s.OwnerAccessMode
+	if s.RepoID != 0 {
+		// new packages are not linked to a repository
+		mode = max(mode, s.RepoAccessMode)
+	}
+	if mode >= perm.AccessModeWrite {
+		return mode, nil
+	}
+
+	if mode < perm.AccessModeRead && s.AllowPublic {
+		has, err := db.GetEngine(ctx).
+			Where(builder.Eq{"owner_id": s.OwnerID, "visibility": VisibilityPublic}).
+			Exist(&Package{})
+		if err != nil {
+			return perm.AccessModeNone, err
+		}
+		if has {
+			mode = perm.AccessModeRead
+		}
+	}
+
+	if s.UserID > 0 {
+		pas := make([]*PackageAccess, 0, 5)
+		if err := db.GetEngine(ctx).
+			Select("package_access.*").
+			Join("INNER", "package", "package.id = package_access.package_id").
+			Where(builder.Eq{"package.owner_id": s.OwnerID}.And(userGrantsCond(s.UserID))).
+			Find(&pas); err != nil {
+			return perm.AccessModeNone, err
+		}
+		for _, pa := range pas {
+			mode = max(mode, pa.AccessMode)
+		}
+	}
+	return mode, nil
+}
+
+// UserReadableCond returns the condition for packages of all owners which the user may read if the owner is visible to the user.
+// It is meant for lookups across owners without an access scope, private packages are only included
+// for administrators of the owner and for explicit grants.
+func UserReadableCond(doer *user_model.User) builder.Cond {
+	if doer != nil && doer.IsAdmin {
+		return builder.NewCond()
+	}
+
+	cond := builder.Neq{"package.visibility": VisibilityPrivate}
+	if doer == nil || doer.IsGhost() {
+		return cond
+	}
+
+	return cond.Or(
+		builder.Eq{"package.owner_id": doer.ID},
+		builder.In("package.owner_id", builder.Select("team.org_id").From("team").
+			InnerJoin("team_user", "team_user.team_id = team.id").
+			Where(builder.Eq{"team_user.uid": doer.ID}.And(builder.Gte{"team.authorize": perm.AccessModeAdmin})),
+		),
+		builder.In("package.id", builder.Select("package_access.package_id").From("package_access").Where(userGrantsCond(doer.ID))),
+	)
+}
+
+// AccessCond returns the condition for packages accessible in the context, it requires the package table
+func AccessCond(ctx context.Context) builder.Cond {
+	if scope := GetAccessScope(ctx); scope != nil {
+		return scope.Cond()
+	}
+	return builder.NewCond()
+}
+
+// fileAccessCond returns the condition for package files accessible in the context
+func fileAccessCond(ctx context.Context) builder.Cond {
+	scope := GetAccessScope(ctx)
+	if scope == nil {
+		return builder.NewCond()
+	}
+	return builder.In("package_file.version_id", builder.
+		Select("package_version.id").
+		From("package_version").
+		InnerJoin("package", "package.id = package_version.package_id").
+		Where(scope.Cond()),
+	)
+}
@@ -152,3 +152,12 @@ func IsBlobAccessibleForUser(ctx context.Context, blobID int64, user *user_model
 		Where(cond).
 		Exist(&PackageBlob{})
 }
+
+// DeleteBlobIfUnreferenced deletes a blob by id if no file references it
+func DeleteBlobIfUnreferenced(ctx context.Context, blobID int64) (bool, error) {
+	n, err := db.GetEngine(ctx).
+		Where(builder.Eq{"id": blobID}).
+		And(builder.NotExists(builder.Select("id").From("package_file").Where(builder.Eq{"blob_id": blobID}))).
+		Delete(&PackageBlob{})
+	return n > 0, err
+}
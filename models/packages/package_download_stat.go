@@ -0,0 +1,230 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageDownloadStat))
+}
+
+// DownloadStatGranularity is the length of the period a download statistic covers
+type DownloadStatGranularity int
+
+const (
+	// DownloadStatGranularityDay statistics cover a single day
+	DownloadStatGranularityDay DownloadStatGranularity = iota
+	// DownloadStatGranularityMonth statistics cover a calendar month and are created by rolling up daily statistics
+	DownloadStatGranularityMonth
+)
+
+func (g DownloadStatGranularity) String() string {
+	if g == DownloadStatGranularityMonth {
+		return "month"
+	}
+	return "day"
+}
+
+// PackageDownloadStat counts the downloads of a package version by a client in a period
+type PackageDownloadStat struct {
+	ID          int64                   `xorm:"pk autoincr"`
+	VersionID   int64                   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Client      string                  `xorm:"UNIQUE(s) NOT NULL"`
+	Granularity DownloadStatGranularity `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	PeriodUnix  timeutil.TimeStamp      `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Count       int64                   `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// StartOfDay returns the start of the UTC day of the time
+func StartOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StartOfMonth returns the start of the UTC month of the time
+func StartOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// RecordDownload counts a download of the package version by the client
+func RecordDownload(ctx context.Context, versionID int64, client string, t time.Time) error {
+	return addDownloadStat(ctx, &PackageDownloadStat{
+		VersionID:   versionID,
+		Client:      client,
+		Granularity: DownloadStatGranularityDay,
+		PeriodUnix:  timeutil.TimeStamp(StartOfDay(t).Unix()),
+	}, 1)
+}
+
+// addDownloadStat adds n to the statistic with the same key or inserts it
+func addDownloadStat(ctx context.Context, s *PackageDownloadStat, n int64) error {
+	cond := builder.Eq{
+		"version_id":  s.VersionID,
+		"client":      s.Client,
+		"granularity": s.Granularity,
+		"period_unix": s.PeriodUnix,
+	}
+
+	affected, err := db.GetEngine(ctx).Where(cond).Incr("count", n).Update(new(PackageDownloadStat))
+	if err != nil || affected > 0 {
+		return err
+	}
+
+	s.Count = n
+	if err := db.Insert(ctx, s); err != nil {
+		// a concurrent request may have inserted the statistic in the meantime
+		_, err = db.GetEngine(ctx).Where(cond).Incr("count", n).Update(new(PackageDownloadStat))
+		return err
+	}
+	return nil
+}
+
+// TransferDownloadStats moves the statistics of a version to another version
+func TransferDownloadStats(ctx context.Context, fromVersionID, toVersionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", fromVersionID).Cols("version_id").Update(&PackageDownloadStat{VersionID: toVersionID})
+	return err
+}
+
+// DownloadStatsSearchOptions are the options to aggregate download statistics
+type DownloadStatsSearchOptions struct {
+	PackageID      int64
+	VersionID      int64
+	Since          time.Time
+	Before         time.Time
+	GroupByClient  bool
+	GroupByVersion bool
+}
+
+func (opts *DownloadStatsSearchOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.VersionID != 0 {
+		cond = cond.And(builder.Eq{"version_id": opts.VersionID})
+	}
+	if opts.PackageID != 0 {
+		cond = cond.And(builder.In("version_id", builder.Select("id").From("package_version").Where(builder.Eq{"package_id": opts.PackageID})))
+	}
+	if !opts.Since.IsZero() {
+		// include the rolled up month which contains the start of the range
+		cond = cond.And(builder.Or(
+			builder.Eq{"granularity": DownloadStatGranularityDay}.And(builder.Gte{"period_unix": StartOfDay(opts.Since).Unix()}),
+			builder.Eq{"granularity": DownloadStatGranularityMonth}.And(builder.Gte{"period_unix": StartOfMonth(opts.Since).Unix()}),
+		))
+	}
+	if !opts.Before.IsZero() {
+		cond = cond.And(builder.Lt{"period_unix": opts.Before.Unix()})
+	}
+	return cond
+}
+
+// DownloadStatsPoint is the aggregated download count of a period
+type DownloadStatsPoint struct {
+	PeriodUnix  timeutil.TimeStamp
+	Granularity DownloadStatGranularity
+	VersionID   int64
+	Client      string
+	Count       int64
+}
+
+// SearchDownloadStats aggregates the download statistics per period and optionally per client or version
+func SearchDownloadStats(ctx context.Context, opts *DownloadStatsSearchOptions) ([]*DownloadStatsPoint, error) {
+	groupBy := "period_unix, granularity"
+	if opts.GroupByVersion {
+		groupBy += ", version_id"
+	}
+	if opts.GroupByClient {
+		groupBy += ", client"
+	}
+
+	points := make([]*DownloadStatsPoint, 0, 30)
+	return points, db.GetEngine(ctx).
+		Table("package_download_stat").
+		Select(groupBy + ", SUM(count) AS count").
+		Where(opts.toConds()).
+		GroupBy(groupBy).
+		OrderBy(groupBy).
+		Find(&points)
+}
+
+// RollupDownloadStats merges the daily statistics of periods before the time into monthly statistics
+func RollupDownloadStats(ctx context.Context, before time.Time) (int64, error) {
+	const batchSize = 500
+
+	var rolledUp int64
+	for {
+		n, err := rollupDownloadStatsBatch(ctx, before, batchSize)
+		if err != nil {
+			return rolledUp, err
+		}
+		rolledUp += int64(n)
+		if n < batchSize {
+			return rolledUp, nil
+		}
+	}
+}
+
+func rollupDownloadStatsBatch(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	var n int
+	return n, db.WithTx(ctx, func(ctx context.Context) error {
+		stats := make([]*PackageDownloadStat, 0, batchSize)
+		if err := db.GetEngine(ctx).
+			Where(builder.Eq{"granularity": DownloadStatGranularityDay}.And(builder.Lt{"period_unix": StartOfDay(before).Unix()})).
+			Limit(batchSize).
+			Find(&stats); err != nil {
+			return err
+		}
+		n = len(stats)
+		if n == 0 {
+			return nil
+		}
+
+		type key struct {
+			VersionID  int64
+			Client     string
+			PeriodUnix timeutil.TimeStamp
+		}
+		sums := make(map[key]int64)
+		ids := make([]int64, 0, len(stats))
+		for _, s := range stats {
+			k := key{s.VersionID, s.Client, timeutil.TimeStamp(StartOfMonth(s.PeriodUnix.AsTime()).Unix())}
+			sums[k] += s.Count
+			ids = append(ids, s.ID)
+		}
+
+		if _, err := db.GetEngine(ctx).In("id", ids).Delete(&PackageDownloadStat{}); err != nil {
+			return err
+		}
+		for k, count := range sums {
+			if err := addDownloadStat(ctx, &PackageDownloadStat{
+				VersionID:   k.VersionID,
+				Client:      k.Client,
+				Granularity: DownloadStatGranularityMonth,
+				PeriodUnix:  k.PeriodUnix,
+			}, count); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteDownloadStatsBefore deletes the statistics of periods before the time
+func DeleteDownloadStatsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return db.GetEngine(ctx).Where(builder.Lt{"period_unix": before.Unix()}).Delete(&PackageDownloadStat{})
+}
+
+// DeleteOrphanedDownloadStats deletes the statistics of versions which do not exist anymore
+func DeleteOrphanedDownloadStats(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).
+		Where(builder.NotIn("version_id", builder.Select("id").From("package_version"))).
+		Delete(&PackageDownloadStat{})
+}
@@ -210,7 +210,7 @@ func (opts *PackageFileSearchOptions) toConds() builder.Cond {
 // SearchFiles gets all files of packages matching the search options
 func SearchFiles(ctx context.Context, opts *PackageFileSearchOptions) ([]*PackageFile, int64, error) {
 	sess := db.GetEngine(ctx).
-		Where(opts.toConds())
+		Where(opts.toConds().And(fileAccessCond(ctx)))
 
 	if opts.Paginator != nil {
 		sess = db.SetSessionPagination(sess, opts)
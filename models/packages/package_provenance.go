@@ -0,0 +1,104 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrProvenanceNotExist indicates a provenance attachment not exist error
+var ErrProvenanceNotExist = util.NewNotExistErrorf("package provenance does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageProvenance))
+}
+
+// BuildSource describes the Actions workflow run a package version is built by
+type BuildSource struct {
+	RepoID     int64
+	CommitSHA  string
+	WorkflowID string
+	RunID      int64
+}
+
+type buildSourceContextKeyType struct{}
+
+// BuildSourceContextKey is the context key of the build source
+var BuildSourceContextKey buildSourceContextKeyType
+
+// WithBuildSource returns a context whose new package versions are recorded as built from the source
+func WithBuildSource(ctx context.Context, src *BuildSource) context.Context {
+	return context.WithValue(ctx, BuildSourceContextKey, src)
+}
+
+// GetBuildSource returns the build source of the context or nil
+func GetBuildSource(ctx context.Context) *BuildSource {
+	src, _ := ctx.Value(BuildSourceContextKey).(*BuildSource)
+	return src
+}
+
+// PackageProvenance is an in-toto attestation attached to a package version
+type PackageProvenance struct {
+	ID            int64  `xorm:"pk autoincr"`
+	VersionID     int64  `xorm:"INDEX NOT NULL"`
+	CreatorID     int64  `xorm:"NOT NULL DEFAULT 0"`
+	PredicateType string `xorm:"NOT NULL"`
+	// the source repository and commit stated by the predicate
+	SourceURI    string `xorm:"TEXT"`
+	SourceDigest string `xorm:"VARCHAR(64)"`
+	// HasSignatures is set if the statement was wrapped in a DSSE envelope with signatures, they are not verified
+	HasSignatures bool `xorm:"NOT NULL DEFAULT false"`
+	// MatchesFiles is set if all subjects match files of the version
+	MatchesFiles bool `xorm:"NOT NULL DEFAULT false"`
+	// MatchesBuildSource is set if the stated source commit is the recorded build source of the version
+	MatchesBuildSource bool               `xorm:"NOT NULL DEFAULT false"`
+	Content            string             `xorm:"LONGTEXT NOT NULL"`
+	CreatedUnix        timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// InsertProvenance inserts a provenance attachment
+func InsertProvenance(ctx context.Context, pp *PackageProvenance) error {
+	return db.Insert(ctx, pp)
+}
+
+// GetProvenancesByVersionID gets all provenance attachments of a version
+func GetProvenancesByVersionID(ctx context.Context, versionID int64) ([]*PackageProvenance, error) {
+	pps := make([]*PackageProvenance, 0, 2)
+	return pps, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("id").Find(&pps)
+}
+
+// GetProvenanceByID gets a provenance attachment of a version
+func GetProvenanceByID(ctx context.Context, versionID, provenanceID int64) (*PackageProvenance, error) {
+	pp := &PackageProvenance{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND version_id = ?", provenanceID, versionID).Get(pp)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrProvenanceNotExist
+	}
+	return pp, nil
+}
+
+// DeleteProvenanceByID deletes a provenance attachment of a version
+func DeleteProvenanceByID(ctx context.Context, versionID, provenanceID int64) error {
+	n, err := db.GetEngine(ctx).Where("id = ? AND version_id = ?", provenanceID, versionID).Delete(&PackageProvenance{})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrProvenanceNotExist
+	}
+	return nil
+}
+
+// DeleteProvenancesByVersionID deletes all provenance attachments of a version
+func DeleteProvenancesByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageProvenance{})
+	return err
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrPackageProxyNotExist = util.NewNotExistErrorf("package proxy does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageProxy))
+	db.RegisterModel(new(PackageProxyMetadata))
+}
+
+// ProxyTypes are the package types which can be configured as a pull-through proxy
+var ProxyTypes = []Type{
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// IsProxyType returns true if the package type can be configured as a pull-through proxy
+func IsProxyType(t Type) bool {
+	for _, pt := range ProxyTypes {
+		if pt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageProxy represents a registry of an owner which pulls the missing packages from an upstream registry
+type PackageProxy struct {
+	ID          int64                  `xorm:"pk autoincr"`
+	Enabled     bool                   `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID     int64                  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type        Type                   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	UpstreamURL string                 `xorm:"TEXT NOT NULL"`
+	MetadataTTL int64                  `xorm:"NOT NULL DEFAULT 0"` // in seconds
+	Allowlist   string                 `xorm:"TEXT"`               // newline separated glob patterns of package names
+	Matchers    []*setting.GlobMatcher `xorm:"-"`
+	CreatedUnix timeutil.TimeStamp     `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp     `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// AllowlistPatterns returns the non-empty patterns of the allowlist
+func (pp *PackageProxy) AllowlistPatterns() []string {
+	var patterns []string
+	for _, pattern := range strings.Split(pp.Allowlist, "\n") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// CompilePatterns compiles the patterns of the allowlist
+func (pp *PackageProxy) CompilePatterns() error {
+	if pp.Matchers != nil {
+		return nil
+	}
+
+	patterns := pp.AllowlistPatterns()
+	matchers := make([]*setting.GlobMatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		m, err := setting.GlobMatcherCompile(strings.ToLower(pattern))
+		if err != nil {
+			return err
+		}
+		matchers = append(matchers, m)
+	}
+	pp.Matchers = matchers
+	return nil
+}
+
+// IsAllowed returns true if the package can be pulled from the upstream registry.
+// All packages are allowed if the allowlist is empty.
+func (pp *PackageProxy) IsAllowed(name string) bool {
+	if err := pp.CompilePatterns(); err != nil {
+		return false
+	}
+	if len(pp.Matchers) == 0 {
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, m := range pp.Matchers {
+		if m.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func InsertProxy(ctx context.Context, pp *PackageProxy) (*PackageProxy, error) {
+	return pp, db.Insert(ctx, pp)
+}
+
+func GetProxyByID(ctx context.Context, id int64) (*PackageProxy, error) {
+	pp := &PackageProxy{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pp)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageProxyNotExist
+	}
+	return pp, nil
+}
+
+// GetProxyByOwnerAndType gets the proxy of the owner for the package type, it may be disabled
+func GetProxyByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageProxy, error) {
+	pp := &PackageProxy{}
+
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND type = ?", ownerID, packageType).Get(pp)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageProxyNotExist
+	}
+	return pp, nil
+}
+
+func UpdateProxy(ctx context.Context, pp *PackageProxy) error {
+	_, err := db.GetEngine(ctx).ID(pp.ID).AllCols().Update(pp)
+	return err
+}
+
+func GetProxiesByOwner(ctx context.Context, ownerID int64) ([]*PackageProxy, error) {
+	pps := make([]*PackageProxy, 0, len(ProxyTypes))
+	return pps, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&pps)
+}
+
+// DeleteProxyByID deletes the proxy and its cached metadata
+func DeleteProxyByID(ctx context.Context, proxyID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("proxy_id = ?", proxyID).Delete(&PackageProxyMetadata{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(proxyID).Delete(&PackageProxy{})
+		return err
+	})
+}
+
+func HasOwnerProxyForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageProxy{})
+}
+
+// PackageProxyMetadata is a metadata document fetched from the upstream registry of a proxy
+type PackageProxyMetadata struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProxyID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Path        string             `xorm:"UNIQUE(s) NOT NULL"`
+	Content     []byte             `xorm:"LONGBLOB"`
+	FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// GetProxyMetadata gets the cached metadata document, it returns nil if there is none
+func GetProxyMetadata(ctx context.Context, proxyID int64, path string) (*PackageProxyMetadata, error) {
+	ppm := &PackageProxyMetadata{}
+
+	has, err := db.GetEngine(ctx).Where("proxy_id = ? AND path = ?", proxyID, path).Get(ppm)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return ppm, nil
+}
+
+// SetProxyMetadata inserts or replaces the cached metadata document
+func SetProxyMetadata(ctx context.Context, proxyID int64, path string, content []byte) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		ppm, err := GetProxyMetadata(ctx, proxyID, path)
+		if err != nil {
+			return err
+		}
+		if ppm == nil {
+			return db.Insert(ctx, &PackageProxyMetadata{
+				ProxyID:     proxyID,
+				Path:        path,
+				Content:     content,
+				FetchedUnix: timeutil.TimeStampNow(),
+			})
+		}
+		ppm.Content = content
+		ppm.FetchedUnix = timeutil.TimeStampNow()
+		_, err = db.GetEngine(ctx).ID(ppm.ID).Cols("content", "fetched_unix").Update(ppm)
+		return err
+	})
+}
+
+// DeleteProxyMetadata deletes all cached metadata documents of the proxy
+func DeleteProxyMetadata(ctx context.Context, proxyID int64) error {
+	_, err := db.GetEngine(ctx).Where("proxy_id = ?", proxyID).Delete(&PackageProxyMetadata{})
+	return err
+}
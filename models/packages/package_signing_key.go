@@ -0,0 +1,164 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrSigningKeyNotExist = util.NewNotExistErrorf("package signing key does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageSigningKey))
+}
+
+// SigningKeyTypes are the package types whose repository metadata or checksums are signed with rotatable PGP keys
+var SigningKeyTypes = []Type{
+	TypeDebian,
+	TypeRpm,
+	TypeTerraform,
+}
+
+// IsSigningKeyType returns true if the repository metadata or checksums of the package type are signed with rotatable PGP keys
+func IsSigningKeyType(t Type) bool {
+	for _, st := range SigningKeyTypes {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SigningKeyStatus is the state of a repository signing key
+type SigningKeyStatus int
+
+const (
+	// SigningKeyStatusActive keys sign the repository metadata and are published
+	SigningKeyStatusActive SigningKeyStatus = iota
+	// SigningKeyStatusRetired keys were replaced by a newer key and are neither used nor published anymore
+	SigningKeyStatusRetired
+	// SigningKeyStatusRevoked keys were compromised and are published together with their revocation signature
+	SigningKeyStatusRevoked
+)
+
+func (s SigningKeyStatus) String() string {
+	switch s {
+	case SigningKeyStatusRetired:
+		return "retired"
+	case SigningKeyStatusRevoked:
+		return "revoked"
+	}
+	return "active"
+}
+
+// PackageSigningKey is a PGP key of an owner which signs the repository metadata of a package type
+type PackageSigningKey struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type        Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Fingerprint string             `xorm:"UNIQUE(s) NOT NULL"`
+	PublicKey   string             `xorm:"LONGTEXT NOT NULL"`
+	PrivateKey  string             `xorm:"LONGTEXT NOT NULL"`
+	Status      SigningKeyStatus   `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RetireUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"` // the end of the overlap window after a rotation
+	RevokedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// IsActive returns true if the key signs the repository metadata
+func (k *PackageSigningKey) IsActive() bool {
+	return k.Status == SigningKeyStatusActive
+}
+
+// IsPublished returns true if the public key is served to the clients
+func (k *PackageSigningKey) IsPublished() bool {
+	return k.Status != SigningKeyStatusRetired
+}
+
+// InsertSigningKey inserts a signing key
+func InsertSigningKey(ctx context.Context, k *PackageSigningKey) error {
+	return db.Insert(ctx, k)
+}
+
+// UpdateSigningKeyCols updates the columns of a signing key
+func UpdateSigningKeyCols(ctx context.Context, k *PackageSigningKey, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(k.ID).Cols(cols...).Update(k)
+	return err
+}
+
+// GetSigningKeyByID gets a signing key of an owner
+func GetSigningKeyByID(ctx context.Context, ownerID int64, packageType Type, keyID int64) (*PackageSigningKey, error) {
+	k := &PackageSigningKey{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": keyID, "owner_id": ownerID, "type": packageType}).Get(k)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrSigningKeyNotExist
+	}
+	return k, nil
+}
+
+// GetSigningKeys gets the signing keys of an owner with one of the states, newest first
+func GetSigningKeys(ctx context.Context, ownerID int64, packageType Type, states ...SigningKeyStatus) ([]*PackageSigningKey, error) {
+	var cond builder.Cond = builder.Eq{"owner_id": ownerID, "type": packageType}
+	if len(states) > 0 {
+		cond = cond.And(builder.In("status", states))
+	}
+
+	keys := make([]*PackageSigningKey, 0, 2)
+	return keys, db.GetEngine(ctx).Where(cond).OrderBy("created_unix DESC, id DESC").Find(&keys)
+}
+
+// ScheduleSigningKeysRetirement sets the end of the overlap window of all active keys except the given one
+func ScheduleSigningKeysRetirement(ctx context.Context, ownerID int64, packageType Type, exceptKeyID int64, retireUnix timeutil.TimeStamp) error {
+	_, err := db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID, "type": packageType, "status": SigningKeyStatusActive, "retire_unix": 0}.And(builder.Neq{"id": exceptKeyID})).
+		Cols("retire_unix").
+		Update(&PackageSigningKey{RetireUnix: retireUnix})
+	return err
+}
+
+// SigningKeySet identifies the signing keys of a package type of an owner
+type SigningKeySet struct {
+	OwnerID int64
+	Type    Type
+}
+
+// GetSigningKeySetsToRetire gets the key sets with active keys whose overlap window ended before the time
+func GetSigningKeySetsToRetire(ctx context.Context, before timeutil.TimeStamp) ([]*SigningKeySet, error) {
+	sets := make([]*SigningKeySet, 0, 10)
+	return sets, db.GetEngine(ctx).
+		Table("package_signing_key").
+		Select("DISTINCT owner_id, type").
+		Where(builder.Eq{"status": SigningKeyStatusActive}.And(builder.Gt{"retire_unix": 0}, builder.Lte{"retire_unix": before})).
+		Find(&sets)
+}
+
+// RetireSigningKeys retires the active keys of the key set whose overlap window ended before the time
+func RetireSigningKeys(ctx context.Context, ownerID int64, packageType Type, before timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID, "type": packageType, "status": SigningKeyStatusActive}.And(builder.Gt{"retire_unix": 0}, builder.Lte{"retire_unix": before})).
+		Cols("status").
+		Update(&PackageSigningKey{Status: SigningKeyStatusRetired})
+}
+
+// GetSigningKeySetsToRotate gets the key sets whose newest active key was created before the time
+func GetSigningKeySetsToRotate(ctx context.Context, createdBefore timeutil.TimeStamp) ([]*SigningKeySet, error) {
+	sets := make([]*SigningKeySet, 0, 10)
+	return sets, db.GetEngine(ctx).
+		Table("package_signing_key").
+		Select("owner_id, type").
+		Where(builder.Eq{"status": SigningKeyStatusActive}).
+		GroupBy("owner_id, type").
+		Having(fmt.Sprintf("MAX(created_unix) < %d", createdBefore)).
+		Find(&sets)
+}
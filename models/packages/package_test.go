@@ -5,17 +5,21 @@ package packages_test
 
 import (
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	_ "code.gitea.io/gitea/models"
 	_ "code.gitea.io/gitea/models/actions"
 	_ "code.gitea.io/gitea/models/activities"
 
 	"github.com/stretchr/testify/assert"
+	"xorm.io/builder"
 )
 
 func TestMain(m *testing.M) {
@@ -65,3 +69,271 @@ func TestHasOwnerPackages(t *testing.T) {
 	assert.True(t, has)
 	assert.NoError(t, err)
 }
+
+func TestAccessScope(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	const ownerID = 3
+
+	insertPackage := func(name string, visibility packages_model.Visibility) *packages_model.Package {
+		p, err := packages_model.TryInsertPackage(db.DefaultContext, &packages_model.Package{
+			OwnerID:    ownerID,
+			Type:       packages_model.TypeGeneric,
+			Name:       name,
+			LowerName:  name,
+			Visibility: visibility,
+		})
+		assert.NoError(t, err)
+		return p
+	}
+
+	pInherit := insertPackage("inherit", packages_model.VisibilityInherit)
+	pPublic := insertPackage("public", packages_model.VisibilityPublic)
+	pPrivate := insertPackage("private", packages_model.VisibilityPrivate)
+
+	// team 2 of org 3 has the members 2 and 4
+	assert.NoError(t, packages_model.SetAccess(db.DefaultContext, &packages_model.PackageAccess{PackageID: pPrivate.ID, TeamID: 2, AccessMode: perm.AccessModeWrite}))
+	assert.NoError(t, packages_model.SetAccess(db.DefaultContext, &packages_model.PackageAccess{PackageID: pInherit.ID, UserID: 5, AccessMode: perm.AccessModeRead}))
+
+	cases := []struct {
+		Name          string
+		Scope         *packages_model.AccessScope
+		Expected      []int64
+		PrivateMode   perm.AccessMode
+		MaxAccessMode perm.AccessMode
+	}{
+		{
+			Name:          "Anonymous",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, AllowPublic: true},
+			Expected:      []int64{pPublic.ID},
+			PrivateMode:   perm.AccessModeNone,
+			MaxAccessMode: perm.AccessModeRead,
+		},
+		{
+			Name:          "OwnerRead",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, UserID: 8, OwnerAccessMode: perm.AccessModeRead, AllowPublic: true},
+			Expected:      []int64{pInherit.ID, pPublic.ID},
+			PrivateMode:   perm.AccessModeNone,
+			MaxAccessMode: perm.AccessModeRead,
+		},
+		{
+			Name:          "TeamGrant",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, UserID: 4, OwnerAccessMode: perm.AccessModeRead, AllowPublic: true},
+			Expected:      []int64{pInherit.ID, pPublic.ID, pPrivate.ID},
+			PrivateMode:   perm.AccessModeWrite,
+			MaxAccessMode: perm.AccessModeWrite,
+		},
+		{
+			Name:          "UserGrant",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, UserID: 5},
+			Expected:      []int64{pInherit.ID},
+			PrivateMode:   perm.AccessModeNone,
+			MaxAccessMode: perm.AccessModeRead,
+		},
+		{
+			// the write access of an Actions task doesn't extend to private packages
+			Name:          "ActionsTask",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, OwnerAccessMode: perm.AccessModeRead, RepoID: 1, RepoAccessMode: perm.AccessModeWrite},
+			Expected:      []int64{pInherit.ID, pPublic.ID},
+			PrivateMode:   perm.AccessModeNone,
+			MaxAccessMode: perm.AccessModeWrite,
+		},
+		{
+			Name:          "OwnerAdmin",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, UserID: 1, OwnerAccessMode: perm.AccessModeAdmin},
+			Expected:      []int64{pInherit.ID, pPublic.ID, pPrivate.ID},
+			PrivateMode:   perm.AccessModeAdmin,
+			MaxAccessMode: perm.AccessModeAdmin,
+		},
+		{
+			// a token limited to public resources doesn't reach the private packages of a public owner
+			Name:          "PublicOnlyPublicOwner",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, UserID: 1, OwnerAccessMode: perm.AccessModeAdmin, PublicOnly: true, OwnerIsPublic: true},
+			Expected:      []int64{pInherit.ID, pPublic.ID},
+			PrivateMode:   perm.AccessModeNone,
+			MaxAccessMode: perm.AccessModeAdmin,
+		},
+		{
+			Name:          "PublicOnlyLimitedOwner",
+			Scope:         &packages_model.AccessScope{OwnerID: ownerID, UserID: 4, OwnerAccessMode: perm.AccessModeRead, PublicOnly: true},
+			Expected:      []int64{pPublic.ID},
+			PrivateMode:   perm.AccessModeNone,
+			MaxAccessMode: perm.AccessModeWrite,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := packages_model.WithAccessScope(db.DefaultContext, c.Scope)
+
+			ps, err := packages_model.GetPackagesByType(ctx, ownerID, packages_model.TypeGeneric)
+			assert.NoError(t, err)
+			ids := make([]int64, 0, len(ps))
+			for _, p := range ps {
+				ids = append(ids, p.ID)
+			}
+			assert.ElementsMatch(t, c.Expected, ids)
+
+			_, err = packages_model.GetPackageByName(ctx, ownerID, packages_model.TypeGeneric, pPrivate.Name)
+			if c.PrivateMode == perm.AccessModeNone {
+				assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mode, err := c.Scope.AccessMode(ctx, pPrivate)
+			assert.NoError(t, err)
+			assert.Equal(t, c.PrivateMode, mode)
+
+			mode, err = c.Scope.MaxAccessMode(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, c.MaxAccessMode, mode)
+		})
+	}
+}
+
+func TestUserReadableCond(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	const ownerID = 3
+
+	insertPackage := func(name string, visibility packages_model.Visibility) *packages_model.Package {
+		p, err := packages_model.TryInsertPackage(db.DefaultContext, &packages_model.Package{
+			OwnerID:    ownerID,
+			Type:       packages_model.TypeGeneric,
+			Name:       name,
+			LowerName:  name,
+			Visibility: visibility,
+		})
+		assert.NoError(t, err)
+		return p
+	}
+
+	pInherit := insertPackage("inherit", packages_model.VisibilityInherit)
+	pPublic := insertPackage("public", packages_model.VisibilityPublic)
+	pPrivate := insertPackage("private", packages_model.VisibilityPrivate)
+
+	// team 2 of org 3 has the members 2 and 4
+	assert.NoError(t, packages_model.SetAccess(db.DefaultContext, &packages_model.PackageAccess{PackageID: pPrivate.ID, TeamID: 2, AccessMode: perm.AccessModeRead}))
+
+	cases := []struct {
+		Name     string
+		Doer     *user_model.User
+		Expected []int64
+	}{
+		{
+			Name:     "Anonymous",
+			Expected: []int64{pInherit.ID, pPublic.ID},
+		},
+		{
+			Name:     "User",
+			Doer:     unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8}),
+			Expected: []int64{pInherit.ID, pPublic.ID},
+		},
+		{
+			Name:     "TeamGrant",
+			Doer:     unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4}),
+			Expected: []int64{pInherit.ID, pPublic.ID, pPrivate.ID},
+		},
+		{
+			// user 2 is a member of the owners team of org 3
+			Name:     "OwnerAdmin",
+			Doer:     unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2}),
+			Expected: []int64{pInherit.ID, pPublic.ID, pPrivate.ID},
+		},
+		{
+			Name:     "SiteAdmin",
+			Doer:     unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1}),
+			Expected: []int64{pInherit.ID, pPublic.ID, pPrivate.ID},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var ids []int64
+			assert.NoError(t, db.GetEngine(db.DefaultContext).
+				Table("package").
+				Cols("package.id").
+				Where(builder.Eq{"package.owner_id": ownerID}.And(packages_model.UserReadableCond(c.Doer))).
+				Find(&ids))
+			assert.ElementsMatch(t, c.Expected, ids)
+		})
+	}
+}
+
+func TestDownloadStats(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	p, err := packages_model.TryInsertPackage(db.DefaultContext, &packages_model.Package{
+		OwnerID:   1,
+		LowerName: "download-stats",
+	})
+	assert.NoError(t, err)
+	pv1, err := packages_model.GetOrInsertVersion(db.DefaultContext, &packages_model.PackageVersion{PackageID: p.ID, LowerVersion: "1.0"})
+	assert.NoError(t, err)
+	pv2, err := packages_model.GetOrInsertVersion(db.DefaultContext, &packages_model.PackageVersion{PackageID: p.ID, LowerVersion: "2.0"})
+	assert.NoError(t, err)
+
+	day1 := time.Date(2026, time.January, 30, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, time.February, 2, 23, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, packages_model.RecordDownload(db.DefaultContext, pv1.ID, "npm", day1))
+	assert.NoError(t, packages_model.RecordDownload(db.DefaultContext, pv1.ID, "npm", day1.Add(time.Hour)))
+	assert.NoError(t, packages_model.RecordDownload(db.DefaultContext, pv1.ID, "yarn", day1))
+	assert.NoError(t, packages_model.RecordDownload(db.DefaultContext, pv2.ID, "npm", day2))
+
+	unittest.AssertCount(t, &packages_model.PackageDownloadStat{}, 3)
+
+	points, err := packages_model.SearchDownloadStats(db.DefaultContext, &packages_model.DownloadStatsSearchOptions{PackageID: p.ID})
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.EqualValues(t, packages_model.StartOfDay(day1).Unix(), points[0].PeriodUnix)
+	assert.EqualValues(t, 3, points[0].Count)
+	assert.EqualValues(t, 1, points[1].Count)
+
+	points, err = packages_model.SearchDownloadStats(db.DefaultContext, &packages_model.DownloadStatsSearchOptions{VersionID: pv1.ID, GroupByClient: true})
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, "npm", points[0].Client)
+	assert.EqualValues(t, 2, points[0].Count)
+	assert.Equal(t, "yarn", points[1].Client)
+
+	points, err = packages_model.SearchDownloadStats(db.DefaultContext, &packages_model.DownloadStatsSearchOptions{PackageID: p.ID, Since: day2})
+	assert.NoError(t, err)
+	assert.Len(t, points, 1)
+	assert.EqualValues(t, 1, points[0].Count)
+
+	t.Run("Rollup", func(t *testing.T) {
+		n, err := packages_model.RollupDownloadStats(db.DefaultContext, day2)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, n)
+
+		unittest.AssertExistsAndLoadBean(t, &packages_model.PackageDownloadStat{
+			VersionID:   pv1.ID,
+			Client:      "npm",
+			Granularity: packages_model.DownloadStatGranularityMonth,
+			PeriodUnix:  timeutil.TimeStamp(packages_model.StartOfMonth(day1).Unix()),
+			Count:       2,
+		})
+
+		points, err := packages_model.SearchDownloadStats(db.DefaultContext, &packages_model.DownloadStatsSearchOptions{PackageID: p.ID, GroupByVersion: true})
+		assert.NoError(t, err)
+		assert.Len(t, points, 2)
+		assert.Equal(t, packages_model.DownloadStatGranularityMonth, points[0].Granularity)
+		assert.EqualValues(t, 3, points[0].Count)
+		assert.Equal(t, pv2.ID, points[1].VersionID)
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		n, err := packages_model.DeleteDownloadStatsBefore(db.DefaultContext, packages_model.StartOfDay(day2))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, n)
+
+		assert.NoError(t, packages_model.DeleteVersionByID(db.DefaultContext, pv2.ID))
+		n, err = packages_model.DeleteOrphanedDownloadStats(db.DefaultContext)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, n)
+
+		unittest.AssertCount(t, &packages_model.PackageDownloadStat{}, 0)
+	})
+}
@@ -34,6 +34,17 @@ type PackageVersion struct {
 	IsInternal    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
 	MetadataJSON  string             `xorm:"metadata_json LONGTEXT"`
 	DownloadCount int64              `xorm:"NOT NULL DEFAULT 0"`
+
+	// the source of versions built by an Actions workflow run
+	SourceRepoID     int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SourceCommitSHA  string `xorm:"INDEX VARCHAR(64) NOT NULL DEFAULT ''"`
+	SourceWorkflowID string `xorm:"NOT NULL DEFAULT ''"`
+	SourceRunID      int64  `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// HasBuildSource checks if the source the version was built from is known
+func (pv *PackageVersion) HasBuildSource() bool {
+	return pv.SourceRepoID != 0 && pv.SourceCommitSHA != ""
 }
 
 // GetOrInsertVersion inserts a version. If the same version exist already ErrDuplicatePackageVersion is returned
@@ -52,6 +63,12 @@ func GetOrInsertVersion(ctx context.Context, pv *PackageVersion) (*PackageVersio
 	if has {
 		return existing, ErrDuplicatePackageVersion
 	}
+	if src := GetBuildSource(ctx); src != nil && !pv.IsInternal && !pv.HasBuildSource() {
+		pv.SourceRepoID = src.RepoID
+		pv.SourceCommitSHA = src.CommitSHA
+		pv.SourceWorkflowID = src.WorkflowID
+		pv.SourceRunID = src.RunID
+	}
 	if _, err = e.Insert(pv); err != nil {
 		return nil, err
 	}
@@ -177,15 +194,19 @@ const (
 // All fields optional and are not used if they have their default value (nil, "", 0)
 type PackageSearchOptions struct {
 	OwnerID         int64
+	OwnerCond       builder.Cond // only results are found whose owners match the condition on the user table
+	PackageCond     builder.Cond // only results are found whose packages match the condition on the package table
 	RepoID          int64
 	Type            Type
 	PackageID       int64
+	PackageIDs      []int64
 	Name            SearchValue       // only results with the specific name are found
 	Version         SearchValue       // only results with the specific version are found
 	Properties      map[string]string // only results are found which contain all listed version properties with the specific value
 	IsInternal      optional.Option[bool]
 	HasFileWithName string                // only results are found which are associated with a file with the specific name
 	HasFiles        optional.Option[bool] // only results are found which have associated files
+	SourceCommitSHA string                // only results are found which were built from the commit, a prefix of the commit id is allowed
 	Sort            VersionSort
 	db.Paginator
 }
@@ -201,6 +222,12 @@ func (opts *PackageSearchOptions) ToConds() builder.Cond {
 	if opts.OwnerID != 0 {
 		cond = cond.And(builder.Eq{"package.owner_id": opts.OwnerID})
 	}
+	if opts.OwnerCond != nil {
+		cond = cond.And(builder.In("package.owner_id", builder.Select("`user`.id").From("`user`").Where(opts.OwnerCond)))
+	}
+	if opts.PackageCond != nil {
+		cond = cond.And(opts.PackageCond)
+	}
 	if opts.RepoID != 0 {
 		cond = cond.And(builder.Eq{"package.repo_id": opts.RepoID})
 	}
@@ -210,6 +237,9 @@ func (opts *PackageSearchOptions) ToConds() builder.Cond {
 	if opts.PackageID != 0 {
 		cond = cond.And(builder.Eq{"package.id": opts.PackageID})
 	}
+	if len(opts.PackageIDs) != 0 {
+		cond = cond.And(builder.In("package.id", opts.PackageIDs))
+	}
 	if opts.Name.Value != "" {
 		if opts.Name.ExactMatch {
 			cond = cond.And(builder.Eq{"package.lower_name": strings.ToLower(opts.Name.Value)})
@@ -245,6 +275,15 @@ func (opts *PackageSearchOptions) ToConds() builder.Cond {
 		})
 	}
 
+	if opts.SourceCommitSHA != "" {
+		sha := strings.ToLower(opts.SourceCommitSHA)
+		if len(sha) == 40 || len(sha) == 64 {
+			cond = cond.And(builder.Eq{"package_version.source_commit_sha": sha})
+		} else {
+			cond = cond.And(builder.Expr("package_version.source_commit_sha LIKE ?", sha+"%"))
+		}
+	}
+
 	if opts.HasFileWithName != "" {
 		fileCond := builder.Expr("package_file.version_id = package_version.id").And(builder.Eq{"package_file.lower_name": strings.ToLower(opts.HasFileWithName)})
 
@@ -290,7 +329,7 @@ func SearchVersions(ctx context.Context, opts *PackageSearchOptions) ([]*Package
 		Select("package_version.*").
 		Table("package_version").
 		Join("INNER", "package", "package.id = package_version.package_id").
-		Where(opts.ToConds())
+		Where(opts.ToConds().And(AccessCond(ctx)))
 
 	opts.configureOrderBy(sess)
 
@@ -309,7 +348,7 @@ func SearchLatestVersions(ctx context.Context, opts *PackageSearchOptions) ([]*P
 		Select("MAX(package_version.id)").
 		From("package_version").
 		InnerJoin("package", "package.id = package_version.package_id").
-		Where(opts.ToConds()).
+		Where(opts.ToConds().And(AccessCond(ctx))).
 		GroupBy("package_version.package_id")
 
 	sess := db.GetEngine(ctx).
@@ -0,0 +1,140 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+
+	"xorm.io/builder"
+)
+
+// RepoCodeSymbol is a definition of a function, method, type or constant found by the code indexer.
+// For now, implicitly refers to default branch
+type RepoCodeSymbol struct { //revive:disable-line:exported
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"INDEX(s) NOT NULL"`
+	Filename  string `xorm:"VARCHAR(500) NOT NULL"`
+	Name      string `xorm:"VARCHAR(255) NOT NULL"`
+	LowerName string `xorm:"INDEX(s) VARCHAR(255) NOT NULL"`
+	Kind      string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	Line      int    `xorm:"NOT NULL"`
+	Container string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	Language  string `xorm:"VARCHAR(100) NOT NULL DEFAULT ''"`
+	Content   string `xorm:"TEXT"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoCodeSymbol))
+}
+
+// ReplaceCodeSymbols removes the symbols of the files and inserts the new symbols
+func ReplaceCodeSymbols(ctx context.Context, repoID int64, filenames []string, symbols []*RepoCodeSymbol) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for i := 0; i < len(filenames); i += db.DefaultMaxInSize {
+			chunk := filenames[i:min(i+db.DefaultMaxInSize, len(filenames))]
+			if _, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}.And(builder.In("filename", chunk))).Delete(&RepoCodeSymbol{}); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range symbols {
+			s.RepoID = repoID
+			s.LowerName = strings.ToLower(s.Name)
+		}
+		for i := 0; i < len(symbols); i += db.DefaultMaxInSize {
+			if err := db.Insert(ctx, symbols[i:min(i+db.DefaultMaxInSize, len(symbols))]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCodeSymbols removes all symbols of the repository
+func DeleteCodeSymbols(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&RepoCodeSymbol{})
+	return err
+}
+
+// SearchCodeSymbolsOptions are the options to search symbols
+type SearchCodeSymbolsOptions struct {
+	db.ListOptions
+	RepoIDs  []int64
+	Keyword  string
+	Exact    bool // the name has to be equal to the keyword instead of containing it
+	Kind     string
+	Language string
+}
+
+func (opts *SearchCodeSymbolsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if len(opts.RepoIDs) > 0 {
+		cond = cond.And(builder.In("repo_id", opts.RepoIDs))
+	}
+	if opts.Keyword != "" {
+		if opts.Exact {
+			cond = cond.And(builder.Eq{"lower_name": strings.ToLower(opts.Keyword)})
+		} else {
+			cond = cond.And(builder.Like{"lower_name", strings.ToLower(opts.Keyword)})
+		}
+	}
+	if opts.Kind != "" {
+		cond = cond.And(builder.Eq{"kind": opts.Kind})
+	}
+	if opts.Language != "" {
+		cond = cond.And(builder.Eq{"language": opts.Language})
+	}
+	return cond
+}
+
+func (opts *SearchCodeSymbolsOptions) ToOrders() string {
+	return "lower_name, repo_id, filename, line"
+}
+
+// SearchCodeSymbols returns the symbols matching the options
+func SearchCodeSymbols(ctx context.Context, opts *SearchCodeSymbolsOptions) ([]*RepoCodeSymbol, int64, error) {
+	return db.FindAndCount[RepoCodeSymbol](ctx, opts)
+}
+
+// CodeSymbolLanguageCount is the number of symbols of a language
+type CodeSymbolLanguageCount struct {
+	Language string
+	Count    int
+}
+
+// CountCodeSymbolLanguages returns the number of matching symbols per language, most used first
+func CountCodeSymbolLanguages(ctx context.Context, opts *SearchCodeSymbolsOptions) ([]*CodeSymbolLanguageCount, error) {
+	langOpts := *opts
+	langOpts.Language = ""
+
+	counts := make([]*CodeSymbolLanguageCount, 0, 10)
+	return counts, db.GetEngine(ctx).Table("repo_code_symbol").
+		Select("language, COUNT(*) AS count").
+		Where(langOpts.ToConds()).
+		GroupBy("language").
+		OrderBy("count DESC, language").
+		Limit(10).
+		Find(&counts)
+}
+
+// GetCodeSymbolsByNames returns the symbols of the repository with one of the names
+func GetCodeSymbolsByNames(ctx context.Context, repoID int64, names []string) ([]*RepoCodeSymbol, error) {
+	symbols := make([]*RepoCodeSymbol, 0, len(names))
+	for i := 0; i < len(names); i += db.DefaultMaxInSize {
+		chunk := make([]string, 0, db.DefaultMaxInSize)
+		for _, name := range names[i:min(i+db.DefaultMaxInSize, len(names))] {
+			chunk = append(chunk, strings.ToLower(name))
+		}
+		if err := db.GetEngine(ctx).
+			Where(builder.Eq{"repo_id": repoID}.And(builder.In("lower_name", chunk))).
+			OrderBy("filename, line").
+			Find(&symbols); err != nil {
+			return nil, err
+		}
+	}
+	return symbols, nil
+}
@@ -210,6 +210,15 @@ func GetReleaseByID(ctx context.Context, id int64) (*Release, error) {
 	return rel, nil
 }
 
+// GetReleasesMapByIDs returns the releases by the given IDs as a map
+func GetReleasesMapByIDs(ctx context.Context, ids []int64) (map[int64]*Release, error) {
+	rels := make(map[int64]*Release, len(ids))
+	if len(ids) == 0 {
+		return rels, nil
+	}
+	return rels, db.GetEngine(ctx).In("id", ids).Find(&rels)
+}
+
 // GetReleaseForRepoByID returns release with given ID.
 func GetReleaseForRepoByID(ctx context.Context, repoID, id int64) (*Release, error) {
 	rel := new(Release)
@@ -38,3 +38,17 @@ func Test_FindTagsByCommitIDs(t *testing.T) {
 	assert.Equal(t, "delete-tag", rels[1].TagName)
 	assert.Equal(t, "v1.0", rels[2].TagName)
 }
+
+func TestGetReleasesMapByIDs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	rels, err := GetReleasesMapByIDs(db.DefaultContext, []int64{1, 2, 999})
+	assert.NoError(t, err)
+	assert.Len(t, rels, 2)
+	assert.EqualValues(t, 1, rels[1].RepoID)
+	assert.EqualValues(t, 40, rels[2].RepoID)
+
+	rels, err = GetReleasesMapByIDs(db.DefaultContext, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, rels)
+}
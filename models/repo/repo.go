@@ -450,6 +450,11 @@ func (repo *Repository) MustGetUnit(ctx context.Context, tp unit.Type) *RepoUnit
 			Type:   tp,
 			Config: new(ActionsConfig),
 		}
+	} else if tp == unit.TypeCode {
+		return &RepoUnit{
+			Type:   tp,
+			Config: new(CodeConfig),
+		}
 	} else if tp == unit.TypeProjects {
 		cfg := new(ProjectsConfig)
 		cfg.ProjectsMode = ProjectsModeNone
@@ -20,6 +20,12 @@ const (
 	RepoIndexerTypeCode RepoIndexerType = iota // 0
 	// RepoIndexerTypeStats repository stats indexer
 	RepoIndexerTypeStats // 1
+	// RepoIndexerTypeSymbol code symbols extracted by the code indexer
+	RepoIndexerTypeSymbol // 2
+	// RepoIndexerTypeCommit commit indexer
+	RepoIndexerTypeCommit // 3
+	// RepoIndexerTypeWiki wiki indexer
+	RepoIndexerTypeWiki // 4
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
@@ -33,6 +39,15 @@ type RepoIndexerStatus struct { //revive:disable-line:exported
 
 func init() {
 	db.RegisterModel(new(RepoIndexerStatus))
+	db.RegisterModel(new(RepoIndexerRef))
+}
+
+// RepoIndexerRef is a branch or tag indexed by the code indexer besides the default branch
+type RepoIndexerRef struct { //revive:disable-line:exported
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(s) NOT NULL"`
+	RefName   string `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	CommitSha string `xorm:"VARCHAR(64) NOT NULL"`
 }
 
 // GetUnindexedRepos returns repos which do not have an indexer status
@@ -112,3 +127,85 @@ func UpdateIndexerStatus(ctx context.Context, repo *Repository, indexerType Repo
 	}
 	return nil
 }
+
+// DeleteIndexerStatus removes the indexer status of the repository, so it's indexed from scratch the next time
+func DeleteIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoIndexerType) error {
+	if _, err := db.GetEngine(ctx).Where("repo_id = ? AND indexer_type = ?", repo.ID, indexerType).Delete(&RepoIndexerStatus{}); err != nil {
+		return err
+	}
+	switch indexerType {
+	case RepoIndexerTypeCode:
+		repo.CodeIndexerStatus = nil
+	case RepoIndexerTypeStats:
+		repo.StatsIndexerStatus = nil
+	}
+	return nil
+}
+
+// CountIndexedRepos returns the number of repositories indexed by the indexer type
+// and the number of all the repositories which can be indexed, the empty ones can't
+func CountIndexedRepos(ctx context.Context, indexerType RepoIndexerType) (indexed, total int64, err error) {
+	indexed, err = db.GetEngine(ctx).Table("repo_indexer_status").
+		Join("INNER", "repository", "repository.id = repo_indexer_status.repo_id").
+		Where("repo_indexer_status.indexer_type = ? AND repository.is_empty = ?", indexerType, false).
+		Count()
+	if err != nil {
+		return 0, 0, err
+	}
+	total, err = db.GetEngine(ctx).Where("is_empty = ?", false).Count(&Repository{})
+	if err != nil {
+		return 0, 0, err
+	}
+	return indexed, total, nil
+}
+
+// GetIndexerCommitShas returns the indexed commit of the repositories by their ID
+func GetIndexerCommitShas(ctx context.Context, indexerType RepoIndexerType, repoIDs []int64) (map[int64]string, error) {
+	statuses := make([]*RepoIndexerStatus, 0, len(repoIDs))
+	if err := db.GetEngine(ctx).
+		Where(builder.In("repo_id", repoIDs).And(builder.Eq{"indexer_type": indexerType})).
+		Find(&statuses); err != nil {
+		return nil, err
+	}
+	shas := make(map[int64]string, len(statuses))
+	for _, status := range statuses {
+		shas[status.RepoID] = status.CommitSha
+	}
+	return shas, nil
+}
+
+// GetIndexerRefs returns the indexed commits of the branches and tags of the repository by their full ref name
+func GetIndexerRefs(ctx context.Context, repoID int64) (map[string]string, error) {
+	refs := make([]*RepoIndexerRef, 0, 10)
+	if err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Find(&refs); err != nil {
+		return nil, err
+	}
+	commits := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		commits[ref.RefName] = ref.CommitSha
+	}
+	return commits, nil
+}
+
+// UpdateIndexerRefs replaces the indexed branches and tags of the repository
+func UpdateIndexerRefs(ctx context.Context, repoID int64, commits map[string]string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := DeleteIndexerRefs(ctx, repoID); err != nil {
+			return err
+		}
+		refs := make([]*RepoIndexerRef, 0, len(commits))
+		for refName, commitSha := range commits {
+			refs = append(refs, &RepoIndexerRef{RepoID: repoID, RefName: refName, CommitSha: commitSha})
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, refs)
+	})
+}
+
+// DeleteIndexerRefs removes the indexed branches and tags of the repository
+func DeleteIndexerRefs(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&RepoIndexerRef{})
+	return err
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerStatus(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo_model.UpdateIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode, "65f1bf27bc3bf70f64657658635e66094edbcb4d"))
+
+	indexed, total, err := repo_model.CountIndexedRepos(t.Context(), repo_model.RepoIndexerTypeCode)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, indexed)
+	assert.Positive(t, total)
+
+	require.NoError(t, repo_model.DeleteIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode))
+	status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode)
+	require.NoError(t, err)
+	assert.Empty(t, status.CommitSha)
+
+	indexed, _, err = repo_model.CountIndexedRepos(t.Context(), repo_model.RepoIndexerTypeCode)
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+}
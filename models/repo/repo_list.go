@@ -731,6 +731,11 @@ func FindUserCodeAccessibleRepoIDs(ctx context.Context, user *user_model.User) (
 	return SearchRepositoryIDsByCondition(ctx, AccessibleRepositoryCondition(user, unit.TypeCode))
 }
 
+// FindUserWikiAccessibleRepoIDs finds all at Wiki level accessible repositories' ID by the user's id
+func FindUserWikiAccessibleRepoIDs(ctx context.Context, user *user_model.User) ([]int64, error) {
+	return SearchRepositoryIDsByCondition(ctx, AccessibleRepositoryCondition(user, unit.TypeWiki))
+}
+
 // FindUserCodeAccessibleOwnerRepoIDs finds all repository IDs for the given owner whose code the user can see.
 func FindUserCodeAccessibleOwnerRepoIDs(ctx context.Context, ownerID int64, user *user_model.User) ([]int64, error) {
 	return SearchRepositoryIDsByCondition(ctx, builder.NewCond().And(
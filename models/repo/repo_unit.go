@@ -67,6 +67,22 @@ func (cfg *UnitConfig) ToDB() ([]byte, error) {
 	return json.Marshal(cfg)
 }
 
+// CodeConfig describes code config
+type CodeConfig struct {
+	// IndexerRefPatterns are the globs of the branches and tags indexed by the code indexer besides the default branch
+	IndexerRefPatterns []string `json:",omitempty"`
+}
+
+// FromDB fills up a CodeConfig from serialized format.
+func (cfg *CodeConfig) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &cfg)
+}
+
+// ToDB exports a CodeConfig to a serialized format.
+func (cfg *CodeConfig) ToDB() ([]byte, error) {
+	return json.Marshal(cfg)
+}
+
 // ExternalWikiConfig describes external wiki config
 type ExternalWikiConfig struct {
 	ExternalWikiURL string
@@ -268,7 +284,9 @@ func (r *RepoUnit) BeforeSet(colName string, val xorm.Cell) {
 			r.Config = new(ActionsConfig)
 		case unit.TypeProjects:
 			r.Config = new(ProjectsConfig)
-		case unit.TypeCode, unit.TypeReleases, unit.TypeWiki, unit.TypePackages:
+		case unit.TypeCode:
+			r.Config = new(CodeConfig)
+		case unit.TypeReleases, unit.TypeWiki, unit.TypePackages:
 			fallthrough
 		default:
 			r.Config = new(UnitConfig)
@@ -282,8 +300,8 @@ func (r *RepoUnit) Unit() unit.Unit {
 }
 
 // CodeConfig returns config for unit.TypeCode
-func (r *RepoUnit) CodeConfig() *UnitConfig {
-	return r.Config.(*UnitConfig)
+func (r *RepoUnit) CodeConfig() *CodeConfig {
+	return r.Config.(*CodeConfig)
 }
 
 // PullRequestsConfig returns config for unit.TypePullRequests
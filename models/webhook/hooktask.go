@@ -56,6 +56,11 @@ type HookTask struct {
 	IsDelivered bool
 	Delivered   timeutil.TimeStampNano
 
+	// Delivery lease, a task is only delivered by the node which holds an unexpired lease on it.
+	ClaimedBy    string
+	ClaimedUntil timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Attempts     int                `xorm:"NOT NULL DEFAULT 0"`
+
 	// History info.
 	IsSucceed       bool
 	RequestContent  string        `xorm:"LONGTEXT"`
@@ -170,8 +175,9 @@ func ReplayHookTask(ctx context.Context, hookID int64, uuid string) (*HookTask,
 	})
 }
 
-// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID
-func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, error) {
+// FindClaimableHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID,
+// which have no unexpired delivery lease and have been attempted less than maxAttempts times
+func FindClaimableHookTaskIDs(ctx context.Context, lowerID int64, maxAttempts int) ([]int64, error) {
 	const batchSize = 100
 
 	tasks := make([]int64, 0, batchSize)
@@ -180,18 +186,84 @@ func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, er
 		Table(new(HookTask)).
 		Where("is_delivered=?", false).
 		And("id > ?", lowerID).
+		And("claimed_until < ?", timeutil.TimeStampNow()).
+		And("attempts < ?", maxAttempts).
 		Asc("id").
 		Limit(batchSize).
 		Find(&tasks)
 }
 
-func MarkTaskDelivered(ctx context.Context, task *HookTask) (bool, error) {
-	count, err := db.GetEngine(ctx).ID(task.ID).Where("is_delivered = ?", false).Cols("is_delivered").Update(&HookTask{
-		ID:          task.ID,
-		IsDelivered: true,
-	})
+// FindUnclaimedHookTaskIDs returns the undelivered hook tasks of the given webhooks which have never been claimed
+func FindUnclaimedHookTaskIDs(ctx context.Context, hookIDs []int64) ([]int64, error) {
+	if len(hookIDs) == 0 {
+		return nil, nil
+	}
+	var tasks []int64
+	return tasks, db.GetEngine(ctx).
+		Select("id").
+		Table(new(HookTask)).
+		Where(builder.In("hook_id", hookIDs)).
+		And("is_delivered=?", false).
+		And("claimed_until=?", 0).
+		Asc("id").
+		Find(&tasks)
+}
+
+// ClaimHookTask takes a delivery lease on an undelivered hook task for the given node.
+// It returns false if the task has been delivered, another node holds an unexpired lease on it,
+// or it has been attempted maxAttempts times already.
+func ClaimHookTask(ctx context.Context, task *HookTask, node string, lease time.Duration, maxAttempts int) (bool, error) {
+	now := timeutil.TimeStampNow()
+	claimedUntil := now.AddDuration(lease)
+	count, err := db.GetEngine(ctx).ID(task.ID).
+		Where("is_delivered = ? AND claimed_until < ? AND attempts < ?", false, now, maxAttempts).
+		Cols("claimed_by", "claimed_until").
+		Incr("attempts").
+		Update(&HookTask{
+			ClaimedBy:    node,
+			ClaimedUntil: claimedUntil,
+		})
+	if err != nil || count == 0 {
+		return false, err
+	}
+
+	task.ClaimedBy = node
+	task.ClaimedUntil = claimedUntil
+	task.Attempts++
+	return true, nil
+}
+
+// ResetHookTaskAttempts releases the delivery lease of an undelivered hook task and resets its delivery attempts,
+// so a task which has reached the maximum delivery attempts can be claimed again.
+func ResetHookTaskAttempts(ctx context.Context, id int64) error {
+	count, err := db.GetEngine(ctx).ID(id).
+		Where("is_delivered = ?", false).
+		Cols("claimed_by", "claimed_until", "attempts").
+		Update(&HookTask{})
+	if err != nil {
+		return err
+	} else if count == 0 {
+		return ErrHookTaskNotExist{TaskID: id}
+	}
+	return nil
+}
+
+// FindStuckHookTasksOptions represents the options to find the stuck hook tasks
+type FindStuckHookTasksOptions struct {
+	db.ListOptions
+}
+
+// ToConds implements db.FindOptions
+func (opts FindStuckHookTasksOptions) ToConds() builder.Cond {
+	// the lease of a stuck task has expired, but the node holding it didn't finish the delivery
+	return builder.Eq{"is_delivered": false}.
+		And(builder.Gt{"claimed_until": 0}).
+		And(builder.Lt{"claimed_until": timeutil.TimeStampNow()})
+}
 
-	return count != 0, err
+// ToOrders implements db.FindOptions
+func (opts FindStuckHookTasksOptions) ToOrders() string {
+	return "claimed_until ASC"
 }
 
 // CleanupHookTaskTable deletes rows from hook_task as needed.
@@ -7,6 +7,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
@@ -139,6 +140,15 @@ type Webhook struct {
 	// HeaderAuthorizationEncrypted should be accessed using HeaderAuthorization() and SetHeaderAuthorization()
 	HeaderAuthorizationEncrypted string `xorm:"TEXT"`
 
+	// Transport settings of the deliveries, they override the global [webhook] settings
+	ClientCertificate string `xorm:"TEXT"` // PEM encoded client certificate chain for mutual TLS
+	// ClientKeyEncrypted should be accessed using ClientKey() and SetClientKey()
+	ClientKeyEncrypted string `xorm:"TEXT"`
+	CACertificate      string `xorm:"ca_certificate TEXT"` // PEM encoded CA bundle to verify the receiver, replaces the system roots
+	TLSServerName      string `xorm:"tls_server_name"`
+	// ProxyURLEncrypted should be accessed using ProxyURL() and SetProxyURL()
+	ProxyURLEncrypted string `xorm:"proxy_url_encrypted TEXT"`
+
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
 }
@@ -228,6 +238,70 @@ func (w *Webhook) SetHeaderAuthorization(cleartext string) error {
 	return nil
 }
 
+// ClientKey returns the decrypted PEM encoded private key of the client certificate.
+func (w *Webhook) ClientKey() (string, error) {
+	if w.ClientKeyEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, w.ClientKeyEncrypted)
+}
+
+// SetClientKey encrypts and sets the private key of the client certificate.
+func (w *Webhook) SetClientKey(cleartext string) error {
+	if cleartext == "" {
+		w.ClientKeyEncrypted = ""
+		return nil
+	}
+	ciphertext, err := secret.EncryptSecret(setting.SecretKey, cleartext)
+	if err != nil {
+		return err
+	}
+	w.ClientKeyEncrypted = ciphertext
+	return nil
+}
+
+// ProxyURL returns the decrypted proxy URL, it may contain credentials.
+func (w *Webhook) ProxyURL() (string, error) {
+	if w.ProxyURLEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, w.ProxyURLEncrypted)
+}
+
+// SetProxyURL encrypts and sets the proxy URL.
+func (w *Webhook) SetProxyURL(cleartext string) error {
+	if cleartext == "" {
+		w.ProxyURLEncrypted = ""
+		return nil
+	}
+	ciphertext, err := secret.EncryptSecret(setting.SecretKey, cleartext)
+	if err != nil {
+		return err
+	}
+	w.ProxyURLEncrypted = ciphertext
+	return nil
+}
+
+// RedactedProxyURL returns the proxy URL with its password replaced, to be displayed.
+// Not on the reference (*w), to be accessible on WebhooksNew.
+func (w Webhook) RedactedProxyURL() string {
+	proxyURL, err := w.ProxyURL()
+	if err != nil || proxyURL == "" {
+		return ""
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return ""
+	}
+	return u.Redacted()
+}
+
+// HasCustomTransport returns true if the deliveries of the webhook can't use the default HTTP client.
+// Not on the reference (*w), to be accessible on WebhooksNew.
+func (w Webhook) HasCustomTransport() bool {
+	return w.ClientCertificate != "" || w.CACertificate != "" || w.TLSServerName != "" || w.ProxyURLEncrypted != ""
+}
+
 // CreateWebhook creates a new web hook.
 func CreateWebhook(ctx context.Context, w *Webhook) error {
 	w.Type = strings.TrimSpace(w.Type)
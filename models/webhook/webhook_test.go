@@ -73,7 +73,8 @@ func TestWebhook_EventsArray(t *testing.T) {
 		"pull_request", "pull_request_assign", "pull_request_label", "pull_request_milestone",
 		"pull_request_comment", "pull_request_review_approved", "pull_request_review_rejected",
 		"pull_request_review_comment", "pull_request_sync", "pull_request_review_request", "wiki", "repository", "release",
-		"package", "status", "workflow_job",
+		"package", "status", "organization", "membership", "team", "member", "branch_protection_rule", "star", "user",
+		"workflow_job",
 	},
 		(&Webhook{
 			HookEvent: &webhook_module.HookEvent{SendEverything: true},
@@ -231,6 +232,79 @@ func TestUpdateHookTask(t *testing.T) {
 	unittest.AssertExistsAndLoadBean(t, hook)
 }
 
+func TestClaimHookTask(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	hookTask, err := CreateHookTask(db.DefaultContext, &HookTask{
+		HookID:         3,
+		PayloadVersion: 2,
+	})
+	assert.NoError(t, err)
+
+	ids, err := FindClaimableHookTaskIDs(db.DefaultContext, hookTask.ID-1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{hookTask.ID}, ids)
+
+	ids, err = FindUnclaimedHookTaskIDs(db.DefaultContext, []int64{3})
+	assert.NoError(t, err)
+	assert.Contains(t, ids, hookTask.ID)
+
+	claimed, err := ClaimHookTask(db.DefaultContext, hookTask, "node1", time.Minute, 2)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, 1, hookTask.Attempts)
+
+	ids, err = FindUnclaimedHookTaskIDs(db.DefaultContext, []int64{3})
+	assert.NoError(t, err)
+	assert.NotContains(t, ids, hookTask.ID)
+
+	// the lease of node1 hasn't expired
+	other := unittest.AssertExistsAndLoadBean(t, &HookTask{ID: hookTask.ID})
+	claimed, err = ClaimHookTask(db.DefaultContext, other, "node2", time.Minute, 2)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+	ids, err = FindClaimableHookTaskIDs(db.DefaultContext, hookTask.ID-1, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// node1 stopped before the delivery finished
+	_, err = db.GetEngine(db.DefaultContext).ID(hookTask.ID).Cols("claimed_until").Update(&HookTask{ClaimedUntil: timeutil.TimeStampNow().Add(-1)})
+	assert.NoError(t, err)
+	stuck, err := db.Find[HookTask](db.DefaultContext, FindStuckHookTasksOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, stuck, 1) {
+		assert.Equal(t, "node1", stuck[0].ClaimedBy)
+	}
+
+	claimed, err = ClaimHookTask(db.DefaultContext, other, "node2", time.Minute, 2)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, 2, other.Attempts)
+	unittest.AssertExistsAndLoadBean(t, &HookTask{ID: hookTask.ID, ClaimedBy: "node2", Attempts: 2})
+
+	// the maximum number of attempts has been reached
+	_, err = db.GetEngine(db.DefaultContext).ID(hookTask.ID).Cols("claimed_until").Update(&HookTask{ClaimedUntil: timeutil.TimeStampNow().Add(-1)})
+	assert.NoError(t, err)
+	claimed, err = ClaimHookTask(db.DefaultContext, other, "node1", time.Minute, 2)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	// an admin retries the task
+	assert.NoError(t, ResetHookTaskAttempts(db.DefaultContext, hookTask.ID))
+	ids, err = FindClaimableHookTaskIDs(db.DefaultContext, hookTask.ID-1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{hookTask.ID}, ids)
+	claimed, err = ClaimHookTask(db.DefaultContext, other, "node1", time.Minute, 2)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	unittest.AssertExistsAndLoadBean(t, &HookTask{ID: hookTask.ID, ClaimedBy: "node1", Attempts: 1})
+
+	// a delivered task can't be retried
+	other.IsDelivered = true
+	assert.NoError(t, UpdateHookTask(db.DefaultContext, other))
+	assert.True(t, IsErrHookTaskNotExist(ResetHookTaskAttempts(db.DefaultContext, hookTask.ID)))
+}
+
 func TestCleanupHookTaskTable_PerWebhook_DeletesDelivered(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 	hookTask := &HookTask{
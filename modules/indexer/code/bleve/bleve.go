@@ -59,6 +59,7 @@ type RepoIndexerData struct {
 	Content   string
 	Filename  string
 	Language  string
+	Refs      []string
 	UpdatedAt time.Time
 }
 
@@ -72,7 +73,7 @@ const (
 	filenameIndexerAnalyzer  = "filenameIndexerAnalyzer"
 	filenameIndexerTokenizer = "filenameIndexerTokenizer"
 	repoIndexerDocType       = "repoIndexerDocType"
-	repoIndexerLatestVersion = 8
+	repoIndexerLatestVersion = 9
 )
 
 // generateBleveIndexMapping generates a bleve index mapping for the repo indexer
@@ -96,6 +97,7 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 	termFieldMapping.Analyzer = analyzer_keyword.Name
 	docMapping.AddFieldMappingsAt("Language", termFieldMapping)
 	docMapping.AddFieldMappingsAt("CommitID", termFieldMapping)
+	docMapping.AddFieldMappingsAt("Refs", termFieldMapping)
 
 	timeFieldMapping := bleve.NewDateTimeFieldMapping()
 	timeFieldMapping.IncludeInAll = false
@@ -130,7 +132,7 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 	return mapping, nil
 }
 
-var _ internal.Indexer = &Indexer{}
+var _ internal.GenerationIndexer = &Indexer{}
 
 // Indexer represents a bleve indexer implementation
 type Indexer struct {
@@ -151,6 +153,28 @@ func NewIndexer(indexDir string) *Indexer {
 	}
 }
 
+// NewGeneration creates an empty generation of the index beside the one in use
+func (b *Indexer) NewGeneration(ctx context.Context) (internal.GenerationIndexer, error) {
+	inner, err := b.inner.NewGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}, nil
+}
+
+// CompleteGeneration makes the generation replace the one in use
+func (b *Indexer) CompleteGeneration(ctx context.Context) error {
+	return b.inner.CompleteGeneration(ctx)
+}
+
+// Destroy closes the indexer and removes its generation of the index
+func (b *Indexer) Destroy(ctx context.Context) error {
+	return b.inner.Destroy(ctx)
+}
+
 func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserError, batchReader *bufio.Reader, commitSha string,
 	update internal.FileUpdate, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch,
 ) error {
@@ -174,7 +198,7 @@ func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserErro
 	}
 
 	if size > setting.Indexer.MaxIndexerFileSize {
-		return b.addDelete(update.Filename, repo, batch)
+		return b.addDelete(internal.FileVersion{Filename: update.Filename, BlobSha: update.BlobSha}, repo, batch)
 	}
 
 	if _, err := batchWriter.Write([]byte(update.BlobSha + "\n")); err != nil {
@@ -197,19 +221,20 @@ func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserErro
 	if _, err = batchReader.Discard(1); err != nil {
 		return err
 	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
+	id := internal.FileIndexerID(repo.ID, update.Filename, update.BlobSha)
 	return batch.Index(id, &RepoIndexerData{
 		RepoID:    repo.ID,
 		CommitID:  commitSha,
 		Filename:  update.Filename,
 		Content:   string(charset.ToUTF8DropErrors(fileContents, charset.ConvertOpts{})),
 		Language:  analyze.GetCodeLanguage(update.Filename, fileContents),
+		Refs:      update.Refs,
 		UpdatedAt: time.Now().UTC(),
 	})
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch) error {
-	id := internal.FilenameIndexerID(repo.ID, filename)
+func (b *Indexer) addDelete(version internal.FileVersion, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch) error {
+	id := internal.FileIndexerID(repo.ID, version.Filename, version.BlobSha)
 	return batch.Delete(id)
 }
 
@@ -235,8 +260,8 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		}
 		gitBatch.Close()
 	}
-	for _, filename := range changes.RemovedFilenames {
-		if err := b.addDelete(filename, repo, batch); err != nil {
+	for _, version := range changes.Removals {
+		if err := b.addDelete(version, repo, batch); err != nil {
 			return err
 		}
 	}
@@ -293,7 +318,9 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		contentQuery = q
 	}
 
-	keywordQuery = bleve.NewDisjunctionQuery(contentQuery, pathQuery)
+	refQuery := bleve.NewTermQuery(opts.SearchRef())
+	refQuery.FieldVal = "Refs"
+	keywordQuery = bleve.NewConjunctionQuery(bleve.NewDisjunctionQuery(contentQuery, pathQuery), refQuery)
 
 	if len(opts.RepoIDs) > 0 {
 		repoQueries := make([]query.Query, 0, len(opts.RepoIDs))
@@ -32,14 +32,14 @@ import (
 )
 
 const (
-	esRepoIndexerLatestVersion = 3
+	esRepoIndexerLatestVersion = 4
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
 	esMultiMatchTypePhrasePrefix = "phrase_prefix"
 )
 
-var _ internal.Indexer = &Indexer{}
+var _ internal.GenerationIndexer = &Indexer{}
 
 // Indexer implements Indexer interface
 type Indexer struct {
@@ -61,6 +61,28 @@ func NewIndexer(url, indexerName string) *Indexer {
 	return indexer
 }
 
+// NewGeneration creates an empty generation of the index beside the one in use
+func (b *Indexer) NewGeneration(ctx context.Context) (internal.GenerationIndexer, error) {
+	inner, err := b.inner.NewGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}, nil
+}
+
+// CompleteGeneration makes the generation replace the one in use
+func (b *Indexer) CompleteGeneration(ctx context.Context) error {
+	return b.inner.CompleteGeneration(ctx)
+}
+
+// Destroy closes the indexer and removes its generation of the index
+func (b *Indexer) Destroy(ctx context.Context) error {
+	return b.inner.Destroy(ctx)
+}
+
 const (
 	defaultMapping = `{
 		"settings": {
@@ -129,6 +151,10 @@ const (
 					"type": "keyword",
 					"index": true
 				},
+				"refs": {
+					"type": "keyword",
+					"index": true
+				},
 				"updated_at": {
 					"type": "long",
 					"index": true
@@ -158,7 +184,7 @@ func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserErro
 	}
 
 	if size > setting.Indexer.MaxIndexerFileSize {
-		return []elastic.BulkableRequest{b.addDelete(update.Filename, repo)}, nil
+		return []elastic.BulkableRequest{b.addDelete(internal.FileVersion{Filename: update.Filename, BlobSha: update.BlobSha}, repo)}, nil
 	}
 
 	if _, err := batchWriter.Write([]byte(update.BlobSha + "\n")); err != nil {
@@ -181,7 +207,7 @@ func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserErro
 	if _, err = batchReader.Discard(1); err != nil {
 		return nil, err
 	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
+	id := internal.FileIndexerID(repo.ID, update.Filename, update.BlobSha)
 
 	return []elastic.BulkableRequest{
 		elastic.NewBulkIndexRequest().
@@ -193,13 +219,14 @@ func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserErro
 				"content":    string(charset.ToUTF8DropErrors(fileContents, charset.ConvertOpts{})),
 				"commit_id":  sha,
 				"language":   analyze.GetCodeLanguage(update.Filename, fileContents),
+				"refs":       update.Refs,
 				"updated_at": timeutil.TimeStampNow(),
 			}),
 	}, nil
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository) elastic.BulkableRequest {
-	id := internal.FilenameIndexerID(repo.ID, filename)
+func (b *Indexer) addDelete(version internal.FileVersion, repo *repo_model.Repository) elastic.BulkableRequest {
+	id := internal.FileIndexerID(repo.ID, version.Filename, version.BlobSha)
 	return elastic.NewBulkDeleteRequest().
 		Index(b.inner.VersionedIndexName()).
 		Id(id)
@@ -232,8 +259,8 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		batch.Close()
 	}
 
-	for _, filename := range changes.RemovedFilenames {
-		reqs = append(reqs, b.addDelete(filename, repo))
+	for _, version := range changes.Removals {
+		reqs = append(reqs, b.addDelete(version, repo))
 	}
 
 	if len(reqs) > 0 {
@@ -379,6 +406,7 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 	)
 	query := elastic.NewBoolQuery()
 	query = query.Must(kwQuery)
+	query = query.Filter(elastic.NewTermQuery("refs", opts.SearchRef()))
 	if len(opts.RepoIDs) > 0 {
 		repoStrs := make([]any, 0, len(opts.RepoIDs))
 		for _, repoID := range opts.RepoIDs {
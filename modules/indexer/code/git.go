@@ -5,14 +5,18 @@ package code
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 )
 
 func getDefaultBranchSha(ctx context.Context, repo *repo_model.Repository) (string, error) {
@@ -23,9 +27,9 @@ func getDefaultBranchSha(ctx context.Context, repo *repo_model.Repository) (stri
 	return strings.TrimSpace(stdout), nil
 }
 
-// getRepoChanges returns changes to repo since last indexer update
-func getRepoChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+// getRepoChanges returns changes of the default branch since last update of the indexer type
+func getRepoChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, indexerType repo_model.RepoIndexerType, revision string) (*internal.RepoChanges, error) {
+	status, err := repo_model.GetIndexerStatus(ctx, repo, indexerType)
 	if err != nil {
 		return nil, err
 	}
@@ -38,9 +42,21 @@ func getRepoChanges(ctx context.Context, repo *repo_model.Repository, revision s
 	}
 
 	if needGenesis {
+		// remove the files which do not exist anymore
+		if err := resetIndex(ctx, indexer, repo, indexerType); err != nil {
+			return nil, err
+		}
 		return genesisChanges(ctx, repo, revision)
 	}
-	return nonGenesisChanges(ctx, repo, revision)
+	return nonGenesisChanges(ctx, indexer, repo, indexerType, status.CommitSha, revision)
+}
+
+// resetIndex removes everything the indexer type indexed from the repository
+func resetIndex(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, indexerType repo_model.RepoIndexerType) error {
+	if indexerType == repo_model.RepoIndexerTypeSymbol {
+		return repo_model.DeleteCodeSymbols(ctx, repo.ID)
+	}
+	return indexer.Delete(ctx, repo.ID)
 }
 
 func isIndexable(entry *git.TreeEntry) bool {
@@ -61,8 +77,8 @@ func isIndexable(entry *git.TreeEntry) bool {
 	return len(setting.Indexer.IncludePatterns) == 0
 }
 
-// parseGitLsTreeOutput parses the output of a `git ls-tree -r --full-name` command
-func parseGitLsTreeOutput(stdout []byte) ([]internal.FileUpdate, error) {
+// parseGitLsTreeOutput parses the output of a `git ls-tree -r --full-name` command of the ref
+func parseGitLsTreeOutput(stdout []byte, ref string) ([]internal.FileUpdate, error) {
 	entries, err := git.ParseTreeEntries(stdout)
 	if err != nil {
 		return nil, err
@@ -76,6 +92,7 @@ func parseGitLsTreeOutput(stdout []byte) ([]internal.FileUpdate, error) {
 				BlobSha:  entry.ID.String(),
 				Size:     entry.Size(),
 				Sized:    true,
+				Refs:     []string{ref},
 			}
 			idxCount++
 		}
@@ -83,28 +100,32 @@ func parseGitLsTreeOutput(stdout []byte) ([]internal.FileUpdate, error) {
 	return updates[:idxCount], nil
 }
 
-// genesisChanges get changes to add repo to the indexer for the first time
-func genesisChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	var changes internal.RepoChanges
+// lsTree returns the indexable files of the revision
+func lsTree(ctx context.Context, repo *repo_model.Repository, revision, ref string) ([]internal.FileUpdate, error) {
 	stdout, _, runErr := git.NewCommand("ls-tree", "--full-tree", "-l", "-r").AddDynamicArguments(revision).RunStdBytes(ctx, &git.RunOpts{Dir: repo.RepoPath()})
 	if runErr != nil {
 		return nil, runErr
 	}
+	return parseGitLsTreeOutput(stdout, ref)
+}
 
+// genesisChanges get changes to add repo to the indexer for the first time
+func genesisChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
+	var changes internal.RepoChanges
 	var err error
-	changes.Updates, err = parseGitLsTreeOutput(stdout)
+	changes.Updates, err = lsTree(ctx, repo, revision, internal.DefaultBranchRef)
 	return &changes, err
 }
 
 // nonGenesisChanges get changes since the previous indexer update
-func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	diffCmd := git.NewCommand("diff", "--name-status").AddDynamicArguments(repo.CodeIndexerStatus.CommitSha, revision)
+func nonGenesisChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, indexerType repo_model.RepoIndexerType, indexedSha, revision string) (*internal.RepoChanges, error) {
+	diffCmd := git.NewCommand("diff", "--raw", "--no-abbrev").AddDynamicArguments(indexedSha, revision)
 	stdout, _, runErr := diffCmd.RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath()})
 	if runErr != nil {
 		// previous commit sha may have been removed by a force push, so
 		// try rebuilding from scratch
 		log.Warn("git diff: %v", runErr)
-		if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		if err := resetIndex(ctx, indexer, repo, indexerType); err != nil {
 			return nil, err
 		}
 		return genesisChanges(ctx, repo, revision)
@@ -122,7 +143,7 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 			return err
 		}
 
-		updates, err1 := parseGitLsTreeOutput(lsTreeStdout)
+		updates, err1 := parseGitLsTreeOutput(lsTreeStdout, internal.DefaultBranchRef)
 		if err1 != nil {
 			return err1
 		}
@@ -135,11 +156,14 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 		if len(line) == 0 {
 			continue
 		}
+		// :<old mode> <new mode> <old blob> <new blob> <status>\t<path>[\t<destination path>]
 		fields := strings.Split(line, "\t")
-		if len(fields) < 2 {
-			log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+		info := strings.Fields(fields[0])
+		if len(fields) < 2 || len(info) < 5 {
+			log.Warn("Unparseable output for diff --raw: `%s`)", line)
 			continue
 		}
+		oldBlobSha, status := info[2], info[4][0]
 		filename := fields[1]
 		if len(filename) == 0 {
 			continue
@@ -150,19 +174,22 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 			}
 		}
 
-		switch status := fields[0][0]; status {
-		case 'M', 'A':
+		switch status {
+		case 'A':
+			updatedFilenames = append(updatedFilenames, filename)
+		case 'M', 'T':
+			changes.Removals = append(changes.Removals, internal.FileVersion{Filename: filename, BlobSha: oldBlobSha})
 			updatedFilenames = append(updatedFilenames, filename)
 		case 'D':
-			changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+			changes.Removals = append(changes.Removals, internal.FileVersion{Filename: filename, BlobSha: oldBlobSha})
 		case 'R', 'C':
 			if len(fields) < 3 {
-				log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+				log.Warn("Unparseable output for diff --raw: `%s`)", line)
 				continue
 			}
 			dest := fields[2]
 			if len(dest) == 0 {
-				log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+				log.Warn("Unparseable output for diff --raw: `%s`)", line)
 				continue
 			}
 			if dest[0] == '"' {
@@ -172,7 +199,7 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 				}
 			}
 			if status == 'R' {
-				changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+				changes.Removals = append(changes.Removals, internal.FileVersion{Filename: filename, BlobSha: oldBlobSha})
 			}
 			updatedFilenames = append(updatedFilenames, dest)
 		default:
@@ -197,3 +224,176 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 
 	return &changes, err
 }
+
+// getRefPatterns returns the patterns of the branches and tags the repository admins want to be indexed
+func getRefPatterns(ctx context.Context, repo *repo_model.Repository) ([]*setting.GlobMatcher, error) {
+	if setting.Indexer.MaxIndexerRefs <= 0 {
+		return nil, nil
+	}
+	codeUnit, err := repo.GetUnit(ctx, unit.TypeCode)
+	if repo_model.IsErrUnitTypeNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	patterns := make([]*setting.GlobMatcher, 0, len(codeUnit.CodeConfig().IndexerRefPatterns))
+	for _, pattern := range codeUnit.CodeConfig().IndexerRefPatterns {
+		g, err := setting.GlobMatcherCompile(pattern, '/')
+		if err != nil {
+			log.Warn("Invalid indexer ref pattern %q of %s: %v", pattern, repo.FullName(), err)
+			continue
+		}
+		patterns = append(patterns, g)
+	}
+	return patterns, nil
+}
+
+func matchRefPatterns(patterns []*setting.GlobMatcher, refName git.RefName) bool {
+	if !refName.IsBranch() && !refName.IsTag() {
+		return false
+	}
+	for _, g := range patterns {
+		if g.Match(refName.ShortName()) {
+			return true
+		}
+	}
+	return false
+}
+
+// getIndexedRefs returns the commits of the branches and tags which are indexed besides the default branch by their full name.
+// The most recently created refs are indexed if there are more matching refs than allowed.
+func getIndexedRefs(ctx context.Context, repo *repo_model.Repository) (map[string]string, error) {
+	patterns, err := getRefPatterns(ctx, repo)
+	if err != nil || len(patterns) == 0 {
+		return nil, err
+	}
+
+	stdout, _, err := git.NewCommand("for-each-ref", "--sort=-creatordate", "--format=%(objectname) %(*objectname) %(refname)", git.BranchPrefix, git.TagPrefix).
+		RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath()})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]string)
+	for _, line := range strings.Split(stdout, "\n") {
+		// annotated tags are followed by the commit they point to
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		refName := git.RefName(fields[len(fields)-1])
+		if refName == git.RefNameFromBranch(repo.DefaultBranch) || !matchRefPatterns(patterns, refName) {
+			continue
+		}
+		refs[refName.String()] = fields[len(fields)-2]
+		if len(refs) >= setting.Indexer.MaxIndexerRefs {
+			break
+		}
+	}
+	return refs, nil
+}
+
+// IsIndexedRef returns true if the branch or tag is indexed by the code indexer
+func IsIndexedRef(ctx context.Context, repo *repo_model.Repository, refName git.RefName) bool {
+	if refName == git.RefNameFromBranch(repo.DefaultBranch) {
+		return true
+	}
+	patterns, err := getRefPatterns(ctx, repo)
+	if err != nil {
+		log.Error("getRefPatterns: %v", err)
+		return false
+	}
+	return matchRefPatterns(patterns, refName)
+}
+
+// ResolveIndexedRef returns the full name of an indexed branch or tag given by its full or short name to search in,
+// an empty string for the default branch.
+func ResolveIndexedRef(ctx context.Context, repo *repo_model.Repository, ref string) (string, error) {
+	if ref == "" || ref == repo.DefaultBranch || git.RefName(ref) == git.RefNameFromBranch(repo.DefaultBranch) {
+		return "", nil
+	}
+	refs, err := repo_model.GetIndexerRefs(ctx, repo.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, refName := range []git.RefName{git.RefName(ref), git.RefNameFromBranch(ref), git.RefNameFromTag(ref)} {
+		if _, ok := refs[refName.String()]; ok {
+			return refName.String(), nil
+		}
+	}
+	return "", util.NewNotExistErrorf("ref %q is not indexed", ref)
+}
+
+// refsFileVersions returns the indexable file versions of the refs with the refs containing them
+func refsFileVersions(ctx context.Context, repo *repo_model.Repository, commits map[string]string) (map[internal.FileVersion]*internal.FileUpdate, error) {
+	versions := make(map[internal.FileVersion]*internal.FileUpdate)
+	for _, ref := range slices.Sorted(maps.Keys(commits)) {
+		updates, err := lsTree(ctx, repo, commits[ref], ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, update := range updates {
+			v := internal.FileVersion{Filename: update.Filename, BlobSha: update.BlobSha}
+			if existing, ok := versions[v]; ok {
+				existing.Refs = append(existing.Refs, ref)
+			} else {
+				versions[v] = &update
+			}
+		}
+	}
+	return versions, nil
+}
+
+// getCodeChanges returns the changes of the documents of the default branch and the other indexed refs since the last update.
+// The versions of the files which are the same in several refs are indexed once with all the refs.
+func getCodeChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, revision string, refs map[string]string) (*internal.RepoChanges, error) {
+	indexedRefs, err := repo_model.GetIndexerRefs(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexedRefs) == 0 && len(refs) == 0 {
+		return getRepoChanges(ctx, indexer, repo, repo_model.RepoIndexerTypeCode, revision)
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return nil, err
+	}
+	if status.CommitSha != "" {
+		indexedRefs[internal.DefaultBranchRef] = status.CommitSha
+	}
+	oldVersions, err := refsFileVersions(ctx, repo, indexedRefs)
+	if err != nil {
+		// previous commits may have been removed by a force push, so
+		// try rebuilding from scratch
+		log.Warn("Unable to list the indexed files of %s: %v", repo.FullName(), err)
+		if err := resetIndex(ctx, indexer, repo, repo_model.RepoIndexerTypeCode); err != nil {
+			return nil, err
+		}
+		oldVersions = nil
+	}
+
+	commits := maps.Clone(refs)
+	commits[internal.DefaultBranchRef] = revision
+	newVersions, err := refsFileVersions(ctx, repo, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes internal.RepoChanges
+	for v, update := range newVersions {
+		if old, ok := oldVersions[v]; !ok || !slices.Equal(old.Refs, update.Refs) {
+			changes.Updates = append(changes.Updates, *update)
+		}
+	}
+	for v := range oldVersions {
+		if _, ok := newVersions[v]; !ok {
+			changes.Removals = append(changes.Removals, v)
+		}
+	}
+	slices.SortFunc(changes.Updates, func(a, b internal.FileUpdate) int {
+		return strings.Compare(a.Filename, b.Filename)
+	})
+	return &changes, nil
+}
@@ -18,6 +18,7 @@ import (
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
 	"code.gitea.io/gitea/modules/indexer/code/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/trigram"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
@@ -40,6 +41,12 @@ func init() {
 func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
 	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
 	if repo_model.IsErrRepoNotExist(err) {
+		if err := repo_model.DeleteCodeSymbols(ctx, repoID); err != nil {
+			return err
+		}
+		if err := repo_model.DeleteIndexerRefs(ctx, repoID); err != nil {
+			return err
+		}
 		return indexer.Delete(ctx, repoID)
 	}
 	if err != nil {
@@ -76,7 +83,11 @@ func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
 	if err != nil {
 		return err
 	}
-	changes, err := getRepoChanges(ctx, repo, sha)
+	refs, err := getIndexedRefs(ctx, repo)
+	if err != nil {
+		return err
+	}
+	changes, err := getCodeChanges(ctx, indexer, repo, sha, refs)
 	if err != nil {
 		return err
 	} else if changes == nil {
@@ -87,7 +98,22 @@ func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
 		return err
 	}
 
-	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha)
+	if err := repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha); err != nil {
+		return err
+	}
+	if err := repo_model.UpdateIndexerRefs(ctx, repo.ID, refs); err != nil {
+		return err
+	}
+
+	// the symbols of the default branch have their own status, so they can be extracted from repositories which were indexed before
+	symbolChanges, err := getRepoChanges(ctx, indexer, repo, repo_model.RepoIndexerTypeSymbol, sha)
+	if err != nil {
+		return err
+	}
+	if err := indexSymbols(ctx, repo, symbolChanges); err != nil {
+		return err
+	}
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeSymbol, sha)
 }
 
 // Init initialize the repo indexer
@@ -116,12 +142,16 @@ func Init() {
 
 	// Create the Queue
 	switch setting.Indexer.RepoType {
-	case "bleve", "elasticsearch":
+	case "bleve", "elasticsearch", "trigram":
 		handler := func(items ...*internal.IndexerData) (unhandled []*internal.IndexerData) {
-			indexer := *globalIndexer.Load()
+			indexer, release := activeIndexers()
+			defer release()
 			for _, indexerData := range items {
 				log.Trace("IndexerData Process Repo: %d", indexerData.RepoID)
-				if err := index(ctx, indexer, indexerData.RepoID); err != nil {
+				repoIndexing.RLock()
+				err := index(ctx, indexer, indexerData.RepoID)
+				repoIndexing.RUnlock()
+				if err != nil {
 					if !setting.IsInTesting {
 						log.Error("Codes indexer handler: index error for repo %v: %v", indexerData.RepoID, err)
 					}
@@ -184,6 +214,24 @@ func Init() {
 				log.Fatal("PID: %d Unable to initialize the elasticsearch Repository Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.RepoConnStr, err)
 			}
 
+		case "trigram":
+			log.Info("PID: %d Initializing Repository Indexer at: %s", os.Getpid(), setting.Indexer.RepoPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing repository indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.RepoPath)
+				}
+			}()
+
+			rIndexer = trigram.NewIndexer(setting.Indexer.RepoPath)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the trigram Repository Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.RepoPath, err)
+			}
 		default:
 			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.RepoType)
 		}
@@ -195,6 +243,8 @@ func Init() {
 
 		if !existed { // populate the index because it's created for the first time
 			go graceful.GetManager().RunWithShutdownContext(populateRepoIndexer)
+		} else {
+			go graceful.GetManager().RunWithShutdownContext(populateSymbols)
 		}
 		select {
 		case waitChannel <- time.Since(start):
@@ -243,7 +293,9 @@ func UpdateRepoIndexer(repo *repo_model.Repository) {
 
 // IsAvailable checks if issue indexer is available
 func IsAvailable(ctx context.Context) bool {
-	return (*globalIndexer.Load()).Ping(ctx) == nil
+	ix, release := useGlobalIndexer()
+	defer release()
+	return ix.Ping(ctx) == nil
 }
 
 // populateRepoIndexer populate the repo indexer with pre-existing data. This
@@ -264,9 +316,28 @@ func populateRepoIndexer(ctx context.Context) {
 	if err := db.DeleteAllRecords("repo_indexer_status"); err != nil {
 		log.Fatal("System error: %v", err)
 	}
+	if err := db.DeleteAllRecords("repo_indexer_ref"); err != nil {
+		log.Fatal("System error: %v", err)
+	}
 
-	var maxRepoID int64
-	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+	if pushUnindexedRepos(ctx, repo_model.RepoIndexerTypeCode) {
+		log.Info("Done (re)populating the repo indexer with existing repositories")
+	}
+}
+
+// populateSymbols queues the repositories whose symbols were not extracted yet, like the ones indexed
+// before the symbol extraction was added
+func populateSymbols(ctx context.Context) {
+	if pushUnindexedRepos(ctx, repo_model.RepoIndexerTypeSymbol) {
+		log.Debug("Done queuing the repositories without extracted symbols")
+	}
+}
+
+// pushUnindexedRepos pushes the repositories without a status of the indexer type to the queue.
+// It returns false if the population was interrupted.
+func pushUnindexedRepos(ctx context.Context, indexerType repo_model.RepoIndexerType) bool {
+	maxRepoID, err := db.GetMaxID("repository")
+	if err != nil {
 		log.Fatal("System error: %v", err)
 	}
 
@@ -277,13 +348,13 @@ func populateRepoIndexer(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			log.Info("Repository Indexer population shutdown before completion")
-			return
+			return false
 		default:
 		}
-		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeCode, maxRepoID, 0, 50)
+		ids, err := repo_model.GetUnindexedRepos(ctx, indexerType, maxRepoID, 0, 50)
 		if err != nil {
 			log.Error("populateRepoIndexer: %v", err)
-			return
+			return false
 		} else if len(ids) == 0 {
 			break
 		}
@@ -291,17 +362,17 @@ func populateRepoIndexer(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				log.Info("Repository Indexer population shutdown before completion")
-				return
+				return false
 			default:
 			}
 			if err := indexerQueue.Push(&internal.IndexerData{RepoID: id}); err != nil {
 				log.Error("indexerQueue.Push: %v", err)
-				return
+				return false
 			}
 			maxRepoID = id - 1
 		}
 	}
-	log.Info("Done (re)populating the repo indexer with existing repositories")
+	return true
 }
 
 func SupportedSearchModes() []indexer.SearchMode {
@@ -309,5 +380,10 @@ func SupportedSearchModes() []indexer.SearchMode {
 	if gi == nil {
 		return nil
 	}
-	return (*gi).SupportedSearchModes()
+	modes := (*gi).SupportedSearchModes()
+	if len(modes) == 0 {
+		return nil
+	}
+	// the symbols are extracted by all indexers
+	return append(modes, indexer.SymbolSearchMode())
 }
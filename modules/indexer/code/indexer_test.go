@@ -6,15 +6,19 @@ package code
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"slices"
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	indexer_module "code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
 	"code.gitea.io/gitea/modules/indexer/code/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/trigram"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/modules/util"
@@ -296,6 +300,171 @@ func TestBleveIndexAndSearch(t *testing.T) {
 	testIndexer("bleve", t, idx)
 }
 
+func TestRebuildCodeIndexer(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	indexDir := filepath.Join(t.TempDir(), "code.bleve")
+
+	var live internal.Indexer = bleve.NewIndexer(indexDir)
+	_, err := live.Init(t.Context())
+	require.NoError(t, err)
+	oldGlobalIndexer := globalIndexer.Load()
+	globalIndexer.Store(&live)
+	defer func() {
+		(*globalIndexer.Load()).Close()
+		globalIndexer.Store(oldGlobalIndexer)
+	}()
+	require.NoError(t, index(t.Context(), live, 1))
+	assert.True(t, CanRebuild())
+
+	// a search which loaded the replaced generation keeps it until it completes
+	inUse, release := useGlobalIndexer()
+	rebuilt := make(chan error, 1)
+	go func() { rebuilt <- RebuildCodeIndexer(t.Context()) }()
+	require.Eventually(t, func() bool { return *globalIndexer.Load() != live }, 10*time.Second, 10*time.Millisecond)
+	// the searches with the new generation aren't blocked meanwhile
+	total, _, _, err := PerformSearch(t.Context(), &SearchOptions{Keyword: "Description", RepoIDs: []int64{1}, Paginator: &db.ListOptions{Page: 1, PageSize: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	_, _, _, err = inUse.Search(t.Context(), &internal.SearchOptions{Keyword: "Description", Paginator: &db.ListOptions{Page: 1, PageSize: 10}})
+	require.NoError(t, err)
+	assert.DirExists(t, indexDir)
+	release()
+
+	require.NoError(t, <-rebuilt)
+	assert.Nil(t, RebuildProgress())
+	assert.NoDirExists(t, indexDir)
+	assert.DirExists(t, indexDir+".gen1")
+
+	total, results, _, err := PerformSearch(t.Context(), &SearchOptions{Keyword: "Description", RepoIDs: []int64{1}, Paginator: &db.ListOptions{Page: 1, PageSize: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "README.md", results[0].Filename)
+	}
+}
+
+func TestTrigramIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	dir := t.TempDir()
+
+	idx := trigram.NewIndexer(dir)
+	defer idx.Close()
+
+	existed, err := idx.Init(t.Context())
+	require.NoError(t, err)
+	assert.False(t, existed)
+
+	testIndexer("trigram", t, idx)
+
+	// the indexer status of the repositories is up to date after the shared tests, index them again from scratch
+	require.NoError(t, db.DeleteAllRecords("repo_indexer_status"))
+	require.NoError(t, setupRepositoryIndexes(t.Context(), idx))
+
+	search := func(t *testing.T, opts *internal.SearchOptions) []string {
+		opts.Paginator = &db.ListOptions{Page: 1, PageSize: 10}
+		total, res, _, err := idx.Search(t.Context(), opts)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(res), total)
+
+		filenames := make([]string, 0, len(res))
+		for _, r := range res {
+			filenames = append(filenames, r.Filename)
+			assert.LessOrEqual(t, r.StartIndex, r.EndIndex)
+		}
+		return filenames
+	}
+
+	cases := []struct {
+		Name     string
+		Opts     *internal.SearchOptions
+		Expected []string
+	}{
+		{
+			Name:     "Regexp",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: `console\.\w+\("Hello`, SearchMode: indexer_module.SearchModeRegexp},
+			Expected: []string{"example-file.js"},
+		},
+		{
+			Name:     "RegexpIsCaseSensitive",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: `HELLO, \w+`, SearchMode: indexer_module.SearchModeRegexp},
+			Expected: []string{},
+		},
+		{
+			Name:     "RegexpCaseInsensitiveFlag",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: `(?i)HELLO, \w+`, SearchMode: indexer_module.SearchModeRegexp},
+			Expected: []string{"example-file.js"},
+		},
+		{
+			Name:     "RegexpWithoutLiterals",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: `^[A-Z]\w+ is`, SearchMode: indexer_module.SearchModeRegexp},
+			Expected: []string{"cucumber.md", "ham.md", "potato/ham.md"},
+		},
+		{
+			Name:     "Substring",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: `log("hel`, SearchMode: indexer_module.SearchModeExact},
+			Expected: []string{"example-file.js"},
+		},
+		{
+			Name:     "CaseSensitive",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "hello", SearchMode: indexer_module.SearchModeExactCase},
+			Expected: []string{},
+		},
+		{
+			Name:     "CaseSensitiveMatch",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "Hello", SearchMode: indexer_module.SearchModeExactCase},
+			Expected: []string{"example-file.js"},
+		},
+		{
+			Name:     "Path",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "cheese", Path: "potato/**"},
+			Expected: []string{"potato/ham.md"},
+		},
+		{
+			Name:     "Language",
+			Opts:     &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "is", Language: "JavaScript"},
+			Expected: []string{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			assert.Equal(t, c.Expected, search(t, c.Opts))
+		})
+	}
+
+	t.Run("InvalidRegexp", func(t *testing.T) {
+		_, _, _, err := idx.Search(t.Context(), &internal.SearchOptions{Keyword: "(", SearchMode: indexer_module.SearchModeRegexp})
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		idx.Close()
+
+		idx = trigram.NewIndexer(dir)
+		existed, err := idx.Init(t.Context())
+		require.NoError(t, err)
+		assert.True(t, existed)
+
+		assert.Equal(t, []string{"potato/ham.md"}, search(t, &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "cheese", Path: "potato/*"}))
+	})
+
+	t.Run("IncrementalUpdate", func(t *testing.T) {
+		repo, err := repo_model.GetRepositoryByID(t.Context(), 62)
+		require.NoError(t, err)
+
+		changes, err := genesisChanges(t.Context(), repo, "HEAD")
+		require.NoError(t, err)
+		i := slices.IndexFunc(changes.Updates, func(u internal.FileUpdate) bool { return u.Filename == "potato/ham.md" })
+		require.NotEqual(t, -1, i)
+		removal := internal.FileVersion{Filename: "potato/ham.md", BlobSha: changes.Updates[i].BlobSha}
+
+		require.NoError(t, idx.Index(t.Context(), repo, "", &internal.RepoChanges{Removals: []internal.FileVersion{removal}}))
+		assert.Equal(t, []string{"ham.md"}, search(t, &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "cheese"}))
+	})
+
+	require.NoError(t, tearDownRepositoryIndexes(t.Context(), idx))
+	assert.Empty(t, search(t, &internal.SearchOptions{Keyword: "cheese"}))
+}
+
 func TestESIndexAndSearch(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 
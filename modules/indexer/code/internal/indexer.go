@@ -22,16 +22,38 @@ type Indexer interface {
 	SupportedSearchModes() []indexer.SearchMode
 }
 
+// GenerationIndexer is an indexer which can build a new generation of its index beside the one in use,
+// so the index can be rebuilt without degrading the search
+type GenerationIndexer interface {
+	Indexer
+	// NewGeneration creates an empty generation of the index, which isn't used until it's completed
+	NewGeneration(ctx context.Context) (GenerationIndexer, error)
+	// CompleteGeneration makes the generation replace the one in use
+	CompleteGeneration(ctx context.Context) error
+	// Destroy closes the indexer and removes its generation of the index
+	Destroy(ctx context.Context) error
+}
+
 type SearchOptions struct {
 	RepoIDs  []int64
 	Keyword  string
 	Language string
+	Path     string // glob the file paths have to match, only supported by the trigram indexer
+	Ref      string // full name of the indexed branch or tag, empty for the default branch
 
 	SearchMode indexer.SearchModeType
 
 	db.Paginator
 }
 
+// SearchRef returns the ref the documents have to be contained in
+func (opts *SearchOptions) SearchRef() string {
+	if opts.Ref == "" {
+		return DefaultBranchRef
+	}
+	return opts.Ref
+}
+
 // NewDummyIndexer returns a dummy indexer
 func NewDummyIndexer() Indexer {
 	return &dummyIndexer{
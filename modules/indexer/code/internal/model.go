@@ -5,17 +5,37 @@ package internal
 
 import "code.gitea.io/gitea/modules/timeutil"
 
+// DefaultBranchRef is the ref of the documents of the default branch, so they don't have to be updated when it is renamed
+const DefaultBranchRef = "HEAD"
+
 type FileUpdate struct {
 	Filename string
 	BlobSha  string
 	Size     int64
 	Sized    bool
+	Refs     []string // the indexed refs containing this version of the file
+}
+
+// FileVersion identifies the document of a file with a specific content
+type FileVersion struct {
+	Filename string
+	BlobSha  string
 }
 
 // RepoChanges changes (file additions/updates/removals) to a repo
 type RepoChanges struct {
-	Updates          []FileUpdate
-	RemovedFilenames []string
+	Updates []FileUpdate
+	// Removals are the file versions which are not contained in any indexed ref anymore
+	Removals []FileVersion
+}
+
+// RemovedFilenames returns the filenames of the removed file versions
+func (c *RepoChanges) RemovedFilenames() []string {
+	filenames := make([]string, 0, len(c.Removals))
+	for _, r := range c.Removals {
+		filenames = append(filenames, r.Filename)
+	}
+	return filenames
 }
 
 // IndexerData represents data stored in the code indexer
@@ -12,25 +12,29 @@ import (
 
 const filenameMatchNumberOfLines = 7 // Copied from GitHub search
 
-func FilenameIndexerID(repoID int64, filename string) string {
-	return internal.Base36(repoID) + "_" + filename
+// FileIndexerID returns the ID of the document of a file version, the versions of a file in different refs share it if they have the same content
+func FileIndexerID(repoID int64, filename, blobSha string) string {
+	return internal.Base36(repoID) + "_" + blobSha + "_" + filename
 }
 
+// ParseIndexerID returns the repository ID and the filename of a document ID
 func ParseIndexerID(indexerID string) (int64, string) {
 	index := strings.IndexByte(indexerID, '_')
 	if index == -1 {
 		log.Error("Unexpected ID in repo indexer: %s", indexerID)
 	}
 	repoID, _ := internal.ParseBase36(indexerID[:index])
-	return repoID, indexerID[index+1:]
+	return repoID, FilenameOfIndexerID(indexerID)
 }
 
+// FilenameOfIndexerID returns the filename of a document ID
 func FilenameOfIndexerID(indexerID string) string {
-	index := strings.IndexByte(indexerID, '_')
-	if index == -1 {
+	parts := strings.SplitN(indexerID, "_", 3)
+	if len(parts) != 3 {
 		log.Error("Unexpected ID in repo indexer: %s", indexerID)
+		return ""
 	}
-	return indexerID[index+1:]
+	return parts[2]
 }
 
 // FilenameMatchIndexPos returns the boundaries of its first seven lines.
@@ -0,0 +1,234 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	db_model "code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+)
+
+var (
+	// ErrRebuildInProgress is returned when rebuilding the code indexer while it's already being rebuilt
+	ErrRebuildInProgress = errors.New("the code indexer is already being rebuilt")
+	// ErrRebuildNotSupported is returned when rebuilding the code indexer with a backend which can't build a new generation
+	ErrRebuildNotSupported = errors.New("the code indexer can't be rebuilt beside the index in use")
+)
+
+var (
+	// rebuildProgress is the progress of the running rebuild, it's nil if the indexer isn't being rebuilt
+	rebuildProgress atomic.Pointer[indexer.Progress]
+	// rebuildingIndexer is the generation of the index built by the running rebuild,
+	// the changed repositories are indexed into it besides the global indexer
+	rebuildingIndexer atomic.Pointer[internal.Indexer]
+	// generationRefs counts the users of the generations loaded from globalIndexer and rebuildingIndexer,
+	// so a generation which was replaced is only destroyed after the searches and indexing using it completed
+	generationRefs indexer.GenerationRefs[internal.Indexer]
+	// repoIndexing is read locked while a repository is indexed by the queue, and locked while the rebuild copies a repository
+	// into the new generation, so the indexed commits of the repository don't change meanwhile
+	repoIndexing sync.RWMutex
+)
+
+// RebuildProgress returns the progress of the running rebuild of the code indexer, or nil if it isn't being rebuilt
+func RebuildProgress() *indexer.Progress {
+	return rebuildProgress.Load()
+}
+
+// CanRebuild returns true if the code indexer can build a new generation of its index beside the one in use
+func CanRebuild() bool {
+	ix, release := useGlobalIndexer()
+	defer release()
+	_, ok := ix.(internal.GenerationIndexer)
+	return ok
+}
+
+// RebuildCodeIndexer builds a new generation of the index beside the one in use with the repositories which are indexed,
+// and replaces the one in use when it's complete, so the search isn't degraded while rebuilding.
+// The progress is counted in repositories.
+func RebuildCodeIndexer(ctx context.Context) error {
+	total, _, err := repo_model.CountIndexedRepos(ctx, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+	progress := indexer.NewProgress(total)
+	if !rebuildProgress.CompareAndSwap(nil, progress) {
+		return ErrRebuildInProgress
+	}
+	defer rebuildProgress.Store(nil)
+
+	livePtr := globalIndexer.Load()
+	generationIndexer, ok := (*livePtr).(internal.GenerationIndexer)
+	if !ok {
+		return ErrRebuildNotSupported
+	}
+
+	next, err := generationIndexer.NewGeneration(ctx)
+	if err != nil {
+		return fmt.Errorf("create a new generation of the code index: %w", err)
+	}
+	nextIndexer := internal.Indexer(next)
+	rebuildingIndexer.Store(&nextIndexer)
+
+	err = populateGeneration(ctx, next, progress)
+	if err == nil {
+		err = next.CompleteGeneration(ctx)
+	}
+	if err != nil {
+		rebuildingIndexer.Store(nil)
+		generationRefs.WaitReleased(&nextIndexer)
+		if err := next.Destroy(ctx); err != nil {
+			log.Error("Unable to remove the incomplete generation of the code index: %v", err)
+		}
+		return err
+	}
+
+	// the new generation is used before it stops being the rebuilding one, so no changed repository misses it
+	globalIndexer.Store(&nextIndexer)
+	rebuildingIndexer.Store(nil)
+	generationRefs.WaitReleased(livePtr)
+	if err := generationIndexer.Destroy(ctx); err != nil {
+		log.Error("Unable to remove the replaced generation of the code index: %v", err)
+	}
+
+	log.Info("Code indexer rebuilt with %d repositories in %v", progress.Done(), time.Since(progress.Started))
+	return nil
+}
+
+// useGlobalIndexer returns the global indexer, release must be called when it isn't used anymore
+func useGlobalIndexer() (ix internal.Indexer, release func()) {
+	gens, release := generationRefs.Use(globalIndexer.Load)
+	return *gens[0], release
+}
+
+// activeIndexers returns the indexer which the changed repositories are indexed with:
+// the global indexer, and the generation being rebuilt if there is one.
+// release must be called when it isn't used anymore.
+func activeIndexers() (ix internal.Indexer, release func()) {
+	// the rebuilding generation is loaded first, because it becomes the global indexer before it stops being the rebuilding one
+	gens, release := generationRefs.Use(rebuildingIndexer.Load, globalIndexer.Load)
+	rebuilding, global := gens[0], gens[1]
+	ixs := &indexers{Indexer: *global}
+	if rebuilding != nil && *rebuilding != ixs.Indexer {
+		ixs.rebuilding = *rebuilding
+	}
+	return ixs, release
+}
+
+// indexers searches with the global indexer, and indexes into both the global indexer and the generation being rebuilt
+type indexers struct {
+	internal.Indexer
+	rebuilding internal.Indexer
+}
+
+func (ixs *indexers) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+	if err := ixs.Indexer.Index(ctx, repo, sha, changes); err != nil {
+		return err
+	}
+	if ixs.rebuilding != nil {
+		return ixs.rebuilding.Index(ctx, repo, sha, changes)
+	}
+	return nil
+}
+
+func (ixs *indexers) Delete(ctx context.Context, repoID int64) error {
+	if err := ixs.Indexer.Delete(ctx, repoID); err != nil {
+		return err
+	}
+	if ixs.rebuilding != nil {
+		return ixs.rebuilding.Delete(ctx, repoID)
+	}
+	return nil
+}
+
+// populateGeneration indexes the repositories which are indexed into the new generation directly instead of pushing them to the queue
+func populateGeneration(ctx context.Context, next internal.Indexer, progress *indexer.Progress) error {
+	for page := 1; ; page++ {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("shutdown before completion: %w", ctx.Err())
+		default:
+		}
+		repos, _, err := repo_model.SearchRepositoryByName(ctx, &repo_model.SearchRepoOptions{
+			ListOptions: db_model.ListOptions{Page: page, PageSize: repo_model.RepositoryListDefaultPageSize},
+			OrderBy:     db_model.SearchOrderByID,
+			Private:     true,
+			Collaborate: optional.Some(false),
+		})
+		if err != nil {
+			return fmt.Errorf("SearchRepositoryByName: %w", err)
+		}
+		if len(repos) == 0 {
+			return nil
+		}
+
+		for _, repo := range repos {
+			indexed, err := copyRepoIntoGeneration(ctx, next, repo)
+			if err != nil {
+				return fmt.Errorf("index repo %d: %w", repo.ID, err)
+			}
+			if indexed {
+				progress.Add(1)
+			}
+		}
+	}
+}
+
+// copyRepoIntoGeneration indexes the commits of the repository which are indexed in the generation in use into the new generation.
+// It returns false if the repository isn't indexed yet, then it's indexed into both generations by the queue.
+func copyRepoIntoGeneration(ctx context.Context, next internal.Indexer, repo *repo_model.Repository) (bool, error) {
+	// the repository is pushed to the queue after the lock is released, the queue handler may be waiting for it
+	reindex := false
+	defer func() {
+		if reindex {
+			UpdateRepoIndexer(repo)
+		}
+	}()
+	repoIndexing.Lock()
+	defer repoIndexing.Unlock()
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+	if err != nil || status.CommitSha == "" {
+		return false, err
+	}
+	commits, err := repo_model.GetIndexerRefs(ctx, repo.ID)
+	if err != nil {
+		return false, err
+	}
+	commits[internal.DefaultBranchRef] = status.CommitSha
+
+	versions, err := refsFileVersions(ctx, repo, commits)
+	if err != nil {
+		// the indexed commits may have been removed by a force push, so the repository is reindexed from scratch
+		log.Warn("Unable to list the indexed files of %s, reindexing it: %v", repo.FullName(), err)
+		if err := repo_model.DeleteIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode); err != nil {
+			return false, err
+		}
+		if err := repo_model.DeleteIndexerRefs(ctx, repo.ID); err != nil {
+			return false, err
+		}
+		reindex = true
+		return true, nil
+	}
+
+	changes := &internal.RepoChanges{Updates: make([]internal.FileUpdate, 0, len(versions))}
+	for _, update := range versions {
+		changes.Updates = append(changes.Updates, *update)
+	}
+	slices.SortFunc(changes.Updates, func(a, b internal.FileUpdate) int {
+		return strings.Compare(a.Filename, b.Filename)
+	})
+	return true, next.Index(ctx, repo, status.CommitSha, changes)
+}
@@ -6,9 +6,12 @@ package code
 import (
 	"bytes"
 	"context"
+	"html"
 	"html/template"
+	"regexp"
 	"strings"
 
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/highlight"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
 	"code.gitea.io/gitea/modules/timeutil"
@@ -30,6 +33,13 @@ type ResultLine struct {
 	FormattedContent template.HTML
 }
 
+var htmlTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// PlainContent returns the text of the highlighted line
+func (l *ResultLine) PlainContent() string {
+	return html.UnescapeString(htmlTagRe.ReplaceAllString(string(l.FormattedContent), ""))
+}
+
 type SearchResultLanguages = internal.SearchResultLanguages
 
 type SearchOptions = internal.SearchOptions
@@ -134,11 +144,40 @@ func PerformSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, []
 		return 0, nil, nil, nil
 	}
 
-	total, results, resultLanguages, err := (*globalIndexer.Load()).Search(ctx, opts)
+	if isSymbolSearch(opts) {
+		if opts.Ref != "" {
+			// only the symbols of the default branches are extracted
+			return 0, nil, nil, nil
+		}
+		return performSymbolSearch(ctx, opts)
+	}
+
+	ix, release := useGlobalIndexer()
+	total, results, resultLanguages, err := ix.Search(ctx, opts)
+	release()
 	if err != nil {
 		return 0, nil, nil, err
 	}
 
+	if opts.Ref != "" {
+		// the documents shared by several refs keep the commit they were indexed from
+		refCommits := make(map[int64]string)
+		for _, result := range results {
+			commitID, ok := refCommits[result.RepoID]
+			if !ok {
+				refs, err := repo_model.GetIndexerRefs(ctx, result.RepoID)
+				if err != nil {
+					return 0, nil, nil, err
+				}
+				commitID = refs[opts.Ref]
+				refCommits[result.RepoID] = commitID
+			}
+			if commitID != "" {
+				result.CommitID = commitID
+			}
+		}
+	}
+
 	displayResults := make([]*Result, len(results))
 
 	for i, result := range results {
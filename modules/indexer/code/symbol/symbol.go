@@ -0,0 +1,349 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package symbol extracts the definitions of functions, methods, types and constants from source files.
+// Like ctags it works line by line with regular expressions per language, so it is fast but not exact.
+package symbol
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kind is the kind of a symbol
+type Kind string
+
+const (
+	KindFunction Kind = "function"
+	KindMethod   Kind = "method"
+	KindType     Kind = "type"
+	KindConstant Kind = "constant"
+)
+
+// Kinds are all symbol kinds
+var Kinds = []Kind{KindFunction, KindMethod, KindType, KindConstant}
+
+// IsValidKind returns true if the kind is known
+func IsValidKind(k Kind) bool {
+	for _, kind := range Kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Symbol is a definition in a source file
+type Symbol struct {
+	Name      string
+	Kind      Kind
+	Line      int    // 1-based line number of the definition
+	Container string // the type or class the symbol is defined in
+	Content   string // the trimmed source line of the definition
+}
+
+const maxContentLength = 255
+
+// blockStyle defines how the body of a container is delimited
+type blockStyle int
+
+const (
+	blockBraces blockStyle = iota
+	blockIndent
+)
+
+type rule struct {
+	re   *regexp.Regexp
+	kind Kind
+	// opensContainer marks definitions whose body contains methods, like classes
+	opensContainer bool
+	// containerGroup is the index of the sub-match holding the container of a method, like the Go receiver type
+	containerGroup int
+}
+
+type language struct {
+	block blockStyle
+	rules []rule
+	// constBlock matches the start of a block of constant or type definitions, like `const (` in Go
+	constBlock *regexp.Regexp
+}
+
+var (
+	stringLiteralRe = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`")
+	lineCommentRe   = regexp.MustCompile(`(?://|#).*$`)
+	goBlockItemRe   = regexp.MustCompile(`^\s+([A-Za-z_]\w*)\b`)
+)
+
+var (
+	// control keywords which look like function definitions to the C-like rules
+	controlKeywords = map[string]bool{
+		"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true,
+		"else": true, "do": true, "sizeof": true, "typeof": true, "foreach": true, "using": true, "lock": true,
+	}
+
+	cLikeMethodRule = rule{
+		re:   regexp.MustCompile(`^\s+(?:(?:public|private|protected|internal|static|final|abstract|override|virtual|async|synchronized|native|extern|inline|const|unsafe|partial|sealed|open|suspend|fun|def)\s+)*(?:[\w.<>\[\],?]+\s+)*?([A-Za-z_]\w*)\s*\([^;]*$`),
+		kind: KindMethod,
+	}
+
+	javaLikeTypeRule = rule{
+		re:             regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|final|abstract|sealed|partial|data|open|export|default|readonly)\s+)*(?:class|interface|enum|record|struct|object|trait)\s+([A-Za-z_]\w*)`),
+		kind:           KindType,
+		opensContainer: true,
+	}
+)
+
+var languages = map[string]*language{
+	"Go": {
+		block: blockBraces,
+		rules: []rule{
+			{re: regexp.MustCompile(`^func\s*\(\s*(?:\w+\s+)?\*?\s*([A-Za-z_]\w*)(?:\[[^\]]*\])?\s*\)\s*([A-Za-z_]\w*)`), kind: KindMethod, containerGroup: 1},
+			{re: regexp.MustCompile(`^func\s+([A-Za-z_]\w*)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^type\s+([A-Za-z_]\w*)`), kind: KindType},
+			{re: regexp.MustCompile(`^const\s+([A-Za-z_]\w*)`), kind: KindConstant},
+		},
+		constBlock: regexp.MustCompile(`^(const|type)\s*\(\s*$`),
+	},
+	"Python": {
+		block: blockIndent,
+		rules: []rule{
+			{re: regexp.MustCompile(`^\s*class\s+([A-Za-z_]\w*)`), kind: KindType, opensContainer: true},
+			{re: regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^([A-Z][A-Z0-9_]*)\s*(?::[^=]+)?=[^=]`), kind: KindConstant},
+		},
+	},
+	"Ruby": {
+		block: blockIndent,
+		rules: []rule{
+			{re: regexp.MustCompile(`^\s*(?:class|module)\s+(?:[A-Z]\w*::)*([A-Z]\w*)`), kind: KindType, opensContainer: true},
+			{re: regexp.MustCompile(`^\s*def\s+(?:self\.)?([A-Za-z_]\w*[?!=]?)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^\s*([A-Z][A-Z0-9_]*)\s*=[^=]`), kind: KindConstant},
+		},
+	},
+	"JavaScript": javaScriptLanguage(),
+	"TypeScript": javaScriptLanguage(),
+	"TSX":        javaScriptLanguage(),
+	"Java":       javaLikeLanguage(regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|final)\s+)+[\w<>\[\],]+\s+([A-Z][A-Z0-9_]*)\s*=`)),
+	"C#":         javaLikeLanguage(regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static)\s+)*const\s+[\w<>\[\],]+\s+([A-Za-z_]\w*)\s*=`)),
+	"Kotlin": {
+		block: blockBraces,
+		rules: []rule{
+			javaLikeTypeRule,
+			{re: regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|override|open|suspend|inline|operator)\s+)*fun\s+(?:<[^>]*>\s*)?(?:[\w.]+\.)?([A-Za-z_]\w*)\s*\(`), kind: KindFunction},
+			{re: regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal)\s+)*const\s+val\s+([A-Za-z_]\w*)`), kind: KindConstant},
+		},
+	},
+	"Rust": {
+		block: blockBraces,
+		rules: []rule{
+			{re: regexp.MustCompile(`^\s*impl(?:<[^>]*>)?\s+(?:[\w:<>]+\s+for\s+)?([A-Za-z_]\w*)`), opensContainer: true},
+			{re: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:const\s+|async\s+|unsafe\s+|extern\s+"[^"]*"\s+)*fn\s+([A-Za-z_]\w*)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|trait|union|type)\s+([A-Za-z_]\w*)`), kind: KindType, opensContainer: true},
+			{re: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:const|static)\s+(?:mut\s+)?([A-Za-z_]\w*)\s*:`), kind: KindConstant},
+		},
+	},
+	"PHP": {
+		block: blockBraces,
+		rules: []rule{
+			{re: regexp.MustCompile(`^\s*(?:(?:abstract|final|readonly)\s+)*(?:class|interface|trait|enum)\s+([A-Za-z_]\w*)`), kind: KindType, opensContainer: true},
+			{re: regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|abstract|final)\s+)*function\s+&?\s*([A-Za-z_]\w*)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^\s*(?:(?:public|private|protected|final)\s+)*const\s+(?:\w+\s+)?([A-Za-z_]\w*)\s*=`), kind: KindConstant},
+			{re: regexp.MustCompile(`^\s*define\(\s*['"]([A-Za-z_]\w*)['"]`), kind: KindConstant},
+		},
+	},
+	"C":   cLanguage(),
+	"C++": cLanguage(),
+}
+
+func javaScriptLanguage() *language {
+	return &language{
+		block: blockBraces,
+		rules: []rule{
+			{re: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`), kind: KindType, opensContainer: true},
+			{re: regexp.MustCompile(`^\s*(?:export\s+)?(?:declare\s+)?(?:interface|enum)\s+([A-Za-z_$][\w$]*)`), kind: KindType},
+			{re: regexp.MustCompile(`^\s*(?:export\s+)?(?:declare\s+)?type\s+([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*=`), kind: KindType},
+			{re: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|(?:\([^)]*\)|[A-Za-z_$][\w$]*)\s*(?::[^=]+)?=>)`), kind: KindFunction},
+			{re: regexp.MustCompile(`^\s*(?:export\s+)?const\s+([A-Z][A-Z0-9_]*)\s*(?::[^=]+)?=`), kind: KindConstant},
+			{re: regexp.MustCompile(`^\s+(?:(?:public|private|protected|static|async|readonly|override|get|set)\s+)*\*?([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*\([^;]*\)\s*(?::[^{]+)?\{`), kind: KindMethod},
+		},
+	}
+}
+
+func javaLikeLanguage(constantRe *regexp.Regexp) *language {
+	return &language{
+		block: blockBraces,
+		rules: []rule{
+			javaLikeTypeRule,
+			{re: constantRe, kind: KindConstant},
+			cLikeMethodRule,
+		},
+	}
+}
+
+func cLanguage() *language {
+	return &language{
+		block: blockBraces,
+		rules: []rule{
+			{re: regexp.MustCompile(`^\s*#\s*define\s+([A-Za-z_]\w*)`), kind: KindConstant},
+			{re: regexp.MustCompile(`^\s*(?:typedef\s+)?(?:struct|class|union|enum(?:\s+class)?)\s+([A-Za-z_]\w*)\s*(?:[:{]|$)`), kind: KindType, opensContainer: true},
+			{re: regexp.MustCompile(`^\s*typedef\s+.*?\b([A-Za-z_]\w*)\s*;`), kind: KindType},
+			{re: regexp.MustCompile(`^(?:[\w*&<>,:]+\s+)+\**&?(?:([A-Za-z_]\w*)::)?(~?[A-Za-z_]\w*)\s*\([^;]*$`), kind: KindFunction, containerGroup: 1},
+			cLikeMethodRule,
+		},
+	}
+}
+
+// IsSupportedLanguage returns true if symbols can be extracted from files of the language
+func IsSupportedLanguage(lang string) bool {
+	_, ok := languages[lang]
+	return ok
+}
+
+type container struct {
+	name  string
+	level int // the brace depth or indentation of the container body
+}
+
+// Extract returns the symbols defined in the content of a file of the language detected by enry
+func Extract(lang, content string) []*Symbol {
+	l, ok := languages[lang]
+	if !ok {
+		return nil
+	}
+
+	var (
+		symbols    []*Symbol
+		containers []container
+		depth      int
+		inBlock    Kind // the kind of the definitions in the current `const (` block
+	)
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		code := lineCommentRe.ReplaceAllString(stringLiteralRe.ReplaceAllString(line, `""`), "")
+		if l.block == blockIndent {
+			if strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			for len(containers) > 0 && containers[len(containers)-1].level >= indent {
+				containers = containers[:len(containers)-1]
+			}
+		} else {
+			for len(containers) > 0 && containers[len(containers)-1].level > depth {
+				containers = containers[:len(containers)-1]
+			}
+		}
+
+		if inBlock != "" {
+			if strings.HasPrefix(trimmed, ")") {
+				inBlock = ""
+			} else if m := goBlockItemRe.FindStringSubmatch(line); m != nil && !strings.HasPrefix(trimmed, "//") && depth == 0 {
+				symbols = append(symbols, newSymbol(m[1], inBlock, i, "", trimmed))
+			}
+		} else if l.constBlock != nil {
+			if m := l.constBlock.FindStringSubmatch(line); m != nil {
+				inBlock = KindConstant
+				if m[1] == "type" {
+					inBlock = KindType
+				}
+			}
+		}
+
+		if inBlock == "" {
+			if s, opens := matchRules(l, line, containers, depth); s != nil || opens != "" {
+				if s != nil {
+					s.Line = i + 1
+					s.Content = truncateContent(trimmed)
+					symbols = append(symbols, s)
+				}
+				if opens != "" {
+					if l.block == blockIndent {
+						containers = append(containers, container{name: opens, level: len(line) - len(strings.TrimLeft(line, " \t"))})
+					} else {
+						containers = append(containers, container{name: opens, level: depth + 1})
+					}
+				}
+			}
+		}
+
+		if l.block == blockBraces {
+			depth += strings.Count(code, "{") - strings.Count(code, "}")
+			depth = max(depth, 0)
+		}
+	}
+	return symbols
+}
+
+func matchRules(l *language, line string, containers []container, depth int) (*Symbol, string) {
+	for _, r := range l.rules {
+		m := r.re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		name := m[len(m)-1]
+		if controlKeywords[name] {
+			continue
+		}
+
+		if r.kind == "" {
+			// the rule only opens a container, like an impl block in Rust
+			return nil, name
+		}
+
+		s := &Symbol{Name: name, Kind: r.kind}
+		switch {
+		case r.containerGroup > 0 && m[r.containerGroup] != "":
+			s.Container = m[r.containerGroup]
+			s.Kind = KindMethod
+		case r.kind == KindMethod && r.containerGroup == 0:
+			// member definitions are only recognized directly in the body of a container,
+			// elsewhere they are most likely calls
+			if len(containers) == 0 || (l.block == blockBraces && containers[len(containers)-1].level != depth) {
+				continue
+			}
+			s.Container = containers[len(containers)-1].name
+		case len(containers) > 0:
+			s.Container = containers[len(containers)-1].name
+			if s.Kind == KindFunction {
+				s.Kind = KindMethod
+			}
+		}
+
+		opens := ""
+		if r.opensContainer {
+			opens = name
+		}
+		return s, opens
+	}
+	return nil, ""
+}
+
+func newSymbol(name string, kind Kind, lineIndex int, container, content string) *Symbol {
+	return &Symbol{
+		Name:      name,
+		Kind:      kind,
+		Line:      lineIndex + 1,
+		Container: container,
+		Content:   truncateContent(content),
+	}
+}
+
+func truncateContent(content string) string {
+	if len(content) <= maxContentLength {
+		return content
+	}
+	// cut at a rune boundary
+	for i := maxContentLength; i > 0; i-- {
+		if (content[i] & 0xC0) != 0x80 {
+			return content[:i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func formatSymbols(symbols []*Symbol) []string {
+	result := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		result = append(result, fmt.Sprintf("%d %s %s %s", s.Line, s.Kind, s.Container, s.Name))
+	}
+	return result
+}
+
+func TestExtract(t *testing.T) {
+	cases := []struct {
+		Language string
+		Content  string
+		Expected []string
+	}{
+		{
+			Language: "Go",
+			Content: `package main
+
+const MaxSize = 10
+
+const (
+	// Red is red
+	Red = iota
+	Green
+)
+
+type (
+	Point struct {
+		X int
+	}
+	Names []string
+)
+
+type Handler interface {
+	ServeHTTP()
+}
+
+func NewHandler() *Handler {
+	if x := foo(); x {
+	}
+	return nil
+}
+
+func (h *Handler) Serve(w Writer) {}
+
+func (l List[T]) Len() int { return 0 }
+`,
+			Expected: []string{
+				"3 constant  MaxSize",
+				"7 constant  Red",
+				"8 constant  Green",
+				"12 type  Point",
+				"15 type  Names",
+				"18 type  Handler",
+				"22 function  NewHandler",
+				"28 method Handler Serve",
+				"30 method List Len",
+			},
+		},
+		{
+			Language: "Python",
+			Content: `MAX_RETRIES = 3
+
+class Client:
+    TIMEOUT = 5
+
+    def __init__(self):
+        pass
+
+    async def fetch(self, url):
+        return url
+
+def main():
+    Client().fetch("x")
+`,
+			Expected: []string{
+				"1 constant  MAX_RETRIES",
+				"3 type  Client",
+				"6 method Client __init__",
+				"9 method Client fetch",
+				"12 function  main",
+			},
+		},
+		{
+			Language: "TypeScript",
+			Content: `export const API_URL = "https://example.com";
+
+export interface Options {
+  debug: boolean;
+}
+
+export class Api {
+  private count = 0;
+
+  constructor(options: Options) {
+    if (options.debug) {
+      console.log("x");
+    }
+  }
+
+  async request(path: string): Promise<string> {
+    return fetch(path);
+  }
+}
+
+export function createApi(): Api {
+  return new Api({debug: false});
+}
+
+const handler = async (e: Event) => {
+  e.preventDefault();
+};
+`,
+			Expected: []string{
+				"1 constant  API_URL",
+				"3 type  Options",
+				"7 type  Api",
+				"10 method Api constructor",
+				"16 method Api request",
+				"21 function  createApi",
+				"25 function  handler",
+			},
+		},
+		{
+			Language: "Java",
+			Content: `package org.example;
+
+public class Greeter {
+    public static final int MAX_LENGTH = 100;
+
+    @Override
+    public String greet(String name) {
+        if (name == null) {
+            return format("nobody");
+        }
+        return format(name);
+    }
+}
+`,
+			Expected: []string{
+				"3 type  Greeter",
+				"4 constant Greeter MAX_LENGTH",
+				"7 method Greeter greet",
+			},
+		},
+		{
+			Language: "C",
+			Content: `#define BUFFER_SIZE 1024
+
+struct buffer {
+	char *data;
+};
+
+typedef unsigned long size_type;
+
+static int buffer_init(struct buffer *b)
+{
+	if (b == NULL) {
+		return -1;
+	}
+	return 0;
+}
+`,
+			Expected: []string{
+				"1 constant  BUFFER_SIZE",
+				"3 type  buffer",
+				"7 type  size_type",
+				"9 function  buffer_init",
+			},
+		},
+		{
+			Language: "Rust",
+			Content: `pub const LIMIT: u32 = 5;
+
+pub struct Counter {
+    count: u32,
+}
+
+impl Counter {
+    pub fn new() -> Self {
+        Counter { count: 0 }
+    }
+}
+
+fn main() {}
+`,
+			Expected: []string{
+				"1 constant  LIMIT",
+				"3 type  Counter",
+				"8 method Counter new",
+				"13 function  main",
+			},
+		},
+		{
+			Language: "Markdown",
+			Content:  "# Title\n\nfunc main() {}\n",
+			Expected: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Language, func(t *testing.T) {
+			assert.Equal(t, c.Expected, formatSymbols(Extract(c.Language, c.Content)))
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/analyze"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/symbol"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/typesniffer"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// SymbolSearchPrefix switches a code search to the symbol search mode, like "symbol:NewIndexer"
+const SymbolSearchPrefix = "symbol:"
+
+// maxLinkedIdentifiers limits the number of distinct identifiers of a file looked up for definitions
+const maxLinkedIdentifiers = 1000
+
+var (
+	identifierRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+	// identifierSpanRe matches an identifier which is the whole content of a highlighted token
+	identifierSpanRe = regexp.MustCompile(`>[A-Za-z_][A-Za-z0-9_]*</span>`)
+)
+
+// mayContainSymbols returns false if the language of the file is known from its name and symbols can't be extracted from it
+func mayContainSymbols(filename string) bool {
+	if lang, ok := enry.GetLanguageByExtension(filename); ok {
+		return symbol.IsSupportedLanguage(lang)
+	}
+	if lang, ok := enry.GetLanguageByFilename(filename); ok {
+		return symbol.IsSupportedLanguage(lang)
+	}
+	return true
+}
+
+// indexSymbols extracts the symbols of the changed files
+func indexSymbols(ctx context.Context, repo *repo_model.Repository, changes *internal.RepoChanges) error {
+	filenames := make([]string, 0, len(changes.Updates)+len(changes.Removals))
+	filenames = append(filenames, changes.RemovedFilenames()...)
+	var symbols []*repo_model.RepoCodeSymbol
+
+	var updates []internal.FileUpdate
+	for _, update := range changes.Updates {
+		filenames = append(filenames, update.Filename)
+		if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
+			continue
+		}
+		if update.Sized && update.Size > setting.Indexer.MaxIndexerFileSize {
+			continue
+		}
+		if mayContainSymbols(update.Filename) {
+			updates = append(updates, update)
+		}
+	}
+
+	if len(updates) > 0 {
+		r, err := gitrepo.OpenRepository(ctx, repo)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		gitBatch, err := r.NewBatch(ctx)
+		if err != nil {
+			return err
+		}
+		defer gitBatch.Close()
+
+		for _, update := range updates {
+			if _, err := gitBatch.Writer.Write([]byte(update.BlobSha + "\n")); err != nil {
+				return err
+			}
+			_, _, size, err := git.ReadBatchLine(gitBatch.Reader)
+			if err != nil {
+				return err
+			}
+			if size > setting.Indexer.MaxIndexerFileSize {
+				if err := git.DiscardFull(gitBatch.Reader, size+1); err != nil {
+					return err
+				}
+				continue
+			}
+			content, err := io.ReadAll(io.LimitReader(gitBatch.Reader, size))
+			if err != nil {
+				return err
+			}
+			if _, err := gitBatch.Reader.Discard(1); err != nil {
+				return err
+			}
+			if !typesniffer.DetectContentType(content).IsText() {
+				continue
+			}
+
+			language := analyze.GetCodeLanguage(update.Filename, content)
+			for _, s := range symbol.Extract(language, string(charset.ToUTF8DropErrors(content, charset.ConvertOpts{}))) {
+				symbols = append(symbols, &repo_model.RepoCodeSymbol{
+					Filename:  update.Filename,
+					Name:      s.Name,
+					Kind:      string(s.Kind),
+					Line:      s.Line,
+					Container: s.Container,
+					Language:  language,
+					Content:   s.Content,
+				})
+			}
+		}
+	}
+
+	return repo_model.ReplaceCodeSymbols(ctx, repo.ID, filenames, symbols)
+}
+
+// performSymbolSearch searches the extracted symbols and shows the definition lines as results
+func performSymbolSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, []*SearchResultLanguages, error) {
+	searchOpts := &repo_model.SearchCodeSymbolsOptions{
+		RepoIDs:  opts.RepoIDs,
+		Keyword:  strings.TrimSpace(strings.TrimPrefix(opts.Keyword, SymbolSearchPrefix)),
+		Language: opts.Language,
+	}
+	if searchOpts.Keyword == "" {
+		return 0, nil, nil, nil
+	}
+	if opts.Paginator != nil {
+		skip, take := opts.GetSkipTake()
+		if take > 0 {
+			searchOpts.ListOptions.PageSize = take
+			searchOpts.ListOptions.Page = skip/take + 1
+		}
+	}
+
+	symbols, total, err := repo_model.SearchCodeSymbols(ctx, searchOpts)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	repoIDs := container.FilterSlice(symbols, func(s *repo_model.RepoCodeSymbol) (int64, bool) {
+		return s.RepoID, true
+	})
+	commitShas, err := repo_model.GetIndexerCommitShas(ctx, repo_model.RepoIndexerTypeSymbol, repoIDs)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	results := make([]*Result, 0, len(symbols))
+	for _, s := range symbols {
+		results = append(results, &Result{
+			RepoID:   s.RepoID,
+			Filename: s.Filename,
+			CommitID: commitShas[s.RepoID],
+			Language: s.Language,
+			Color:    enry.GetColor(s.Language),
+			Lines:    HighlightSearchResultCode(s.Filename, s.Language, []int{s.Line}, s.Content),
+		})
+	}
+
+	counts, err := repo_model.CountCodeSymbolLanguages(ctx, searchOpts)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	languages := make([]*SearchResultLanguages, 0, len(counts))
+	for _, c := range counts {
+		languages = append(languages, &SearchResultLanguages{
+			Language: c.Language,
+			Color:    enry.GetColor(c.Language),
+			Count:    c.Count,
+		})
+	}
+	return int(total), results, languages, nil
+}
+
+// isSymbolSearch returns true if the options search for symbols instead of the file contents
+func isSymbolSearch(opts *SearchOptions) bool {
+	return opts.SearchMode == indexer.SearchModeSymbol || strings.HasPrefix(opts.Keyword, SymbolSearchPrefix)
+}
+
+// LinkSymbolDefinitions links the identifiers of the highlighted lines of a file of the default branch to their definitions.
+// If an identifier is defined more than once, it links to the symbol search instead.
+func LinkSymbolDefinitions(ctx context.Context, repo *repo_model.Repository, filename string, code []byte, lines []template.HTML) []template.HTML {
+	names := make([]string, 0, 100)
+	seen := make(map[string]bool)
+	for _, name := range identifierRe.FindAll(code, -1) {
+		if !seen[string(name)] {
+			seen[string(name)] = true
+			names = append(names, string(name))
+			if len(names) >= maxLinkedIdentifiers {
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return lines
+	}
+
+	symbols, err := repo_model.GetCodeSymbolsByNames(ctx, repo.ID, names)
+	if err != nil {
+		log.Error("GetCodeSymbolsByNames: %v", err)
+		return lines
+	}
+
+	definitions := make(map[string][]*repo_model.RepoCodeSymbol)
+	for _, s := range symbols {
+		// the symbols are looked up case-insensitively, but the identifiers are case-sensitive in most languages
+		if seen[s.Name] {
+			definitions[s.Name] = append(definitions[s.Name], s)
+		}
+	}
+	if len(definitions) == 0 {
+		return lines
+	}
+
+	branchLink := repo.Link() + "/src/branch/" + util.PathEscapeSegments(repo.DefaultBranch) + "/"
+	links := make(map[string]string, len(definitions))
+	ownLines := make(map[string]int, len(definitions))
+	for name, defs := range definitions {
+		if len(defs) == 1 {
+			links[name] = branchLink + util.PathEscapeSegments(defs[0].Filename) + "#L" + strconv.Itoa(defs[0].Line)
+			if defs[0].Filename == filename {
+				ownLines[name] = defs[0].Line
+			}
+		} else {
+			links[name] = fmt.Sprintf("%s/search?q=%s&search_mode=%s", repo.Link(), url.QueryEscape(name), indexer.SearchModeSymbol)
+		}
+	}
+
+	result := make([]template.HTML, len(lines))
+	for i, line := range lines {
+		result[i] = template.HTML(identifierSpanRe.ReplaceAllStringFunc(string(line), func(s string) string {
+			name := s[1 : len(s)-len("</span>")]
+			link, ok := links[name]
+			if !ok || ownLines[name] == i+1 {
+				return s
+			}
+			return fmt.Sprintf(`><a class="symbol-definition" href="%s">%s</a></span>`, template.HTMLEscapeString(link), name)
+		}))
+	}
+	return result
+}
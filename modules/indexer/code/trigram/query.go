@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// query is a compiled search keyword
+type query struct {
+	// patterns all have to match the content (or the filename) of a document
+	patterns []*regexp.Regexp
+	// phrase is the whole keyword of a words search, documents containing it rank higher
+	phrase *regexp.Regexp
+	// trigrams are the lower-cased trigrams every matching content has to contain
+	trigrams []uint32
+}
+
+func newQuery(keyword string, mode indexer.SearchModeType) (*query, error) {
+	q := &query{}
+	switch mode {
+	case indexer.SearchModeExact:
+		q.patterns = []*regexp.Regexp{regexp.MustCompile("(?i)" + regexp.QuoteMeta(keyword))}
+		q.trigrams = stringTrigrams(keyword)
+	case indexer.SearchModeExactCase:
+		q.patterns = []*regexp.Regexp{regexp.MustCompile(regexp.QuoteMeta(keyword))}
+		q.trigrams = stringTrigrams(keyword)
+	case indexer.SearchModeRegexp:
+		re, err := regexp.Compile(keyword)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid regexp: %v", err)
+		}
+		parsed, err := syntax.Parse(keyword, syntax.Perl)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid regexp: %v", err)
+		}
+		q.patterns = []*regexp.Regexp{re}
+		for _, literal := range requiredLiterals(parsed.Simplify()) {
+			q.trigrams = append(q.trigrams, stringTrigrams(literal)...)
+		}
+	default: // words
+		words := strings.Fields(keyword)
+		for _, word := range words {
+			q.patterns = append(q.patterns, regexp.MustCompile("(?i)"+regexp.QuoteMeta(word)))
+			q.trigrams = append(q.trigrams, stringTrigrams(word)...)
+		}
+		if len(words) > 1 {
+			q.phrase = regexp.MustCompile("(?i)" + regexp.QuoteMeta(keyword))
+		}
+	}
+	return q, nil
+}
+
+// matchContent returns the boundaries of the first match if all patterns match the content
+func (q *query) matchContent(content string) (int, int, bool) {
+	start, end := -1, -1
+	for _, p := range q.patterns {
+		loc := p.FindStringIndex(content)
+		if loc == nil {
+			return 0, 0, false
+		}
+		if start == -1 || loc[0] < start {
+			start, end = loc[0], loc[1]
+		}
+	}
+	return start, end, start != -1
+}
+
+// matchFilename returns true if all patterns match the filename
+func (q *query) matchFilename(filename string) bool {
+	for _, p := range q.patterns {
+		if !p.MatchString(filename) {
+			return false
+		}
+	}
+	return len(q.patterns) > 0
+}
+
+// requiredLiterals returns the literal strings every match of the regexp has to contain
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var literals []string
+		var run strings.Builder
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				run.WriteString(string(sub.Rune))
+				continue
+			}
+			if run.Len() > 0 {
+				literals = append(literals, run.String())
+				run.Reset()
+			}
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		if run.Len() > 0 {
+			literals = append(literals, run.String())
+		}
+		return literals
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"regexp/syntax"
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	cases := map[string][]string{
+		`func.*Handler\(`:  {"func", "Handler("},
+		`(foo|bar)baz`:     {"baz"},
+		`a(bcd)+e`:         {"a", "bcd", "e"},
+		`x?yz{2,}`:         {"y", "z", "z"},
+		`(?i)Hello`:        {"HELLO"},
+		`[a-z]+`:           nil,
+		`(Get|Post)Handle`: {"Handle"},
+	}
+	for expr, expected := range cases {
+		re, err := syntax.Parse(expr, syntax.Perl)
+		require.NoError(t, err)
+		assert.Equal(t, expected, requiredLiterals(re.Simplify()), expr)
+	}
+}
+
+func TestShardCandidates(t *testing.T) {
+	s := (&shard{RepoID: 1}).withChanges("sha", []*document{
+		{Filename: "a.go", Content: "func ServeHandler() {}"},
+		{Filename: "b.go", Content: "func main() {}"},
+		{Filename: "c.go", Content: "type Handler interface{}"},
+	}, nil)
+
+	q, err := newQuery(`func.*Handler\(`, indexer.SearchModeRegexp)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0}, s.candidates(q.trigrams))
+
+	q, err = newQuery("HANDLER", indexer.SearchModeExact)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0, 2}, s.candidates(q.trigrams))
+
+	q, err = newQuery("fu", indexer.SearchModeExact)
+	require.NoError(t, err)
+	assert.Nil(t, s.candidates(q.trigrams))
+
+	s = s.withChanges("sha2", []*document{{Filename: "b.go", Content: "func MainHandler() {}"}}, []internal.FileVersion{{Filename: "a.go"}})
+	assert.Equal(t, "sha2", s.CommitID)
+	assert.Len(t, s.Docs, 2)
+	q, err = newQuery("handler", indexer.SearchModeWords)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0, 1}, s.candidates(q.trigrams))
+}
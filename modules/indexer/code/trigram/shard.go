@@ -0,0 +1,207 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const shardFileExtension = ".shard"
+
+// document is an indexed file of a repository
+type document struct {
+	Filename    string
+	BlobSha     string
+	Refs        []string
+	Content     string
+	Language    string
+	UpdatedUnix timeutil.TimeStamp
+}
+
+// shard holds the indexed files of a single repository
+type shard struct {
+	RepoID   int64
+	CommitID string
+	Docs     []*document
+
+	// postings maps the lower-cased trigrams to the sorted indexes of the documents containing them
+	postings map[uint32][]uint32
+}
+
+func (d *document) version() internal.FileVersion {
+	return internal.FileVersion{Filename: d.Filename, BlobSha: d.BlobSha}
+}
+
+func shardFilename(dir string, repoID int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%d%s", repoID, shardFileExtension))
+}
+
+func readShard(filename string) (*shard, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := &shard{}
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(s); err != nil {
+		return nil, fmt.Errorf("decode shard %s: %w", filename, err)
+	}
+	s.buildPostings()
+	return s, nil
+}
+
+// writeShard writes the shard to a temporary file first so a crash never leaves a partially written shard behind
+func writeShard(dir string, s *shard) error {
+	f, err := os.CreateTemp(dir, "shard-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := f.Name()
+	defer util.Remove(tmpName)
+
+	w := bufio.NewWriter(f)
+	if err := gob.NewEncoder(w).Encode(s); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return util.Rename(tmpName, shardFilename(dir, s.RepoID))
+}
+
+// withChanges returns a copy of the shard with the document versions added, replaced or removed
+func (s *shard) withChanges(commitID string, updates []*document, removed []internal.FileVersion) *shard {
+	changed := make(map[internal.FileVersion]*document, len(updates))
+	for _, d := range updates {
+		changed[d.version()] = d
+	}
+	for _, v := range removed {
+		if _, ok := changed[v]; !ok {
+			changed[v] = nil
+		}
+	}
+
+	ns := &shard{
+		RepoID:   s.RepoID,
+		CommitID: commitID,
+		Docs:     make([]*document, 0, len(s.Docs)+len(updates)),
+	}
+	for _, d := range s.Docs {
+		if _, ok := changed[d.version()]; !ok {
+			ns.Docs = append(ns.Docs, d)
+		}
+	}
+	for _, d := range changed {
+		if d != nil {
+			ns.Docs = append(ns.Docs, d)
+		}
+	}
+	slices.SortFunc(ns.Docs, func(a, b *document) int {
+		if c := strings.Compare(a.Filename, b.Filename); c != 0 {
+			return c
+		}
+		return strings.Compare(a.BlobSha, b.BlobSha)
+	})
+	ns.buildPostings()
+	return ns
+}
+
+// getDocument returns the indexed document of the file version
+func (s *shard) getDocument(v internal.FileVersion) *document {
+	i, ok := slices.BinarySearchFunc(s.Docs, v, func(d *document, v internal.FileVersion) int {
+		if c := strings.Compare(d.Filename, v.Filename); c != 0 {
+			return c
+		}
+		return strings.Compare(d.BlobSha, v.BlobSha)
+	})
+	if !ok {
+		return nil
+	}
+	return s.Docs[i]
+}
+
+func (s *shard) buildPostings() {
+	s.postings = make(map[uint32][]uint32)
+	for i, d := range s.Docs {
+		for _, t := range stringTrigrams(d.Content) {
+			s.postings[t] = append(s.postings[t], uint32(i))
+		}
+	}
+}
+
+// candidates returns the indexes of the documents which contain all trigrams, nil means every document
+func (s *shard) candidates(trigrams []uint32) []uint32 {
+	if len(trigrams) == 0 {
+		return nil
+	}
+
+	var result []uint32
+	for i, t := range trigrams {
+		docs := s.postings[t]
+		if len(docs) == 0 {
+			return []uint32{}
+		}
+		if i == 0 {
+			result = docs
+		} else {
+			result = intersect(result, docs)
+		}
+		if len(result) == 0 {
+			return []uint32{}
+		}
+	}
+	return result
+}
+
+func intersect(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// stringTrigrams returns the distinct trigrams of the lower-cased string
+func stringTrigrams(s string) []uint32 {
+	b := []byte(strings.ToLower(s))
+	if len(b) < 3 {
+		return nil
+	}
+
+	seen := make(map[uint32]struct{}, len(b))
+	trigrams := make([]uint32, 0, len(b))
+	for i := 0; i+3 <= len(b); i++ {
+		t := uint32(b[i])<<16 | uint32(b[i+1])<<8 | uint32(b[i+2])
+		if _, ok := seen[t]; !ok {
+			seen[t] = struct{}{}
+			trigrams = append(trigrams, t)
+		}
+	}
+	return trigrams
+}
@@ -0,0 +1,435 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/analyze"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/typesniffer"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/ethantkoenig/rupture"
+	"github.com/go-enry/go-enry/v2"
+)
+
+const (
+	trigramIndexerLatestVersion = 2
+	maxLanguageFacets           = 10
+	filenameMatchScore          = 100
+	phraseMatchScore            = 10
+	maxCountedMatches           = 10
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer is a self-contained code indexer which keeps a trigram index shard per repository on the local disk.
+// Substring and regexp searches use the trigrams to find the candidate files and verify the matches on their contents.
+type Indexer struct {
+	indexDir string
+
+	lock   sync.RWMutex
+	shards map[int64]*shard
+}
+
+// NewIndexer creates a new trigram indexer
+func NewIndexer(indexDir string) *Indexer {
+	return &Indexer{
+		indexDir: indexDir,
+	}
+}
+
+// Init loads the shards from the index directory.
+// It returns false if the index was created or a shard was unreadable, so all repositories get indexed again.
+func (b *Indexer) Init(_ context.Context) (bool, error) {
+	if b.shards != nil {
+		return false, errors.New("indexer is already initialized")
+	}
+
+	existed := true
+	metadata, err := rupture.ReadIndexMetadata(b.indexDir)
+	if err != nil {
+		return false, err
+	}
+	if metadata.Version < trigramIndexerLatestVersion {
+		if metadata.Version != 0 {
+			log.Warn("Found older trigram index with version %d, Gitea will remove it and rebuild", metadata.Version)
+		}
+		if err := util.RemoveAll(b.indexDir); err != nil {
+			return false, err
+		}
+		existed = false
+	}
+
+	if err := os.MkdirAll(b.indexDir, os.ModePerm); err != nil {
+		return false, err
+	}
+	if !existed {
+		if err := rupture.WriteIndexMetadata(b.indexDir, &rupture.IndexMetadata{Version: trigramIndexerLatestVersion}); err != nil {
+			return false, err
+		}
+	}
+
+	entries, err := os.ReadDir(b.indexDir)
+	if err != nil {
+		return false, err
+	}
+
+	shards := make(map[int64]*shard, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, shardFileExtension) {
+			continue
+		}
+		if _, err := strconv.ParseInt(strings.TrimSuffix(name, shardFileExtension), 10, 64); err != nil {
+			continue
+		}
+		s, err := readShard(filepath.Join(b.indexDir, name))
+		if err != nil {
+			log.Error("Unable to read trigram index shard, the repositories will be indexed again: %v", err)
+			if err := util.Remove(filepath.Join(b.indexDir, name)); err != nil {
+				return false, err
+			}
+			existed = false
+			continue
+		}
+		shards[s.RepoID] = s
+	}
+
+	b.lock.Lock()
+	b.shards = shards
+	b.lock.Unlock()
+
+	return existed, nil
+}
+
+// Ping checks if the indexer is available
+func (b *Indexer) Ping(_ context.Context) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.shards == nil {
+		return errors.New("indexer is not initialized")
+	}
+	return nil
+}
+
+// Close releases the loaded shards, they are persisted on every change
+func (b *Indexer) Close() {
+	b.lock.Lock()
+	b.shards = nil
+	b.lock.Unlock()
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.TrigramSupportedSearchModes()
+}
+
+func (b *Indexer) getShard(repoID int64) *shard {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.shards[repoID]
+}
+
+func (b *Indexer) readUpdate(ctx context.Context, batchWriter git.WriteCloserError, batchReader *bufio.Reader, update internal.FileUpdate, repo *repo_model.Repository) (*document, error) {
+	// Ignore vendored files in code search
+	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
+		return nil, nil
+	}
+
+	size := update.Size
+
+	var err error
+	if !update.Sized {
+		var stdout string
+		stdout, _, err = git.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha).RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath()})
+		if err != nil {
+			return nil, err
+		}
+		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
+			return nil, fmt.Errorf("misformatted git cat-file output: %w", err)
+		}
+	}
+
+	if size > setting.Indexer.MaxIndexerFileSize {
+		return nil, nil
+	}
+
+	if _, err := batchWriter.Write([]byte(update.BlobSha + "\n")); err != nil {
+		return nil, err
+	}
+
+	_, _, size, err = git.ReadBatchLine(batchReader)
+	if err != nil {
+		return nil, err
+	}
+
+	fileContents, err := io.ReadAll(io.LimitReader(batchReader, size))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = batchReader.Discard(1); err != nil {
+		return nil, err
+	}
+	if !typesniffer.DetectContentType(fileContents).IsText() {
+		return nil, nil
+	}
+
+	return &document{
+		Filename:    update.Filename,
+		BlobSha:     update.BlobSha,
+		Refs:        update.Refs,
+		Content:     string(charset.ToUTF8DropErrors(fileContents, charset.ConvertOpts{})),
+		Language:    analyze.GetCodeLanguage(update.Filename, fileContents),
+		UpdatedUnix: timeutil.TimeStampNow(),
+	}, nil
+}
+
+// Index applies the changes to the shard of the repository
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+	old := b.getShard(repo.ID)
+	if old == nil {
+		old = &shard{RepoID: repo.ID}
+	}
+
+	updates := make([]*document, 0, len(changes.Updates))
+	// files which are vendored, too large or binary are not indexed
+	removed := slices.Clone(changes.Removals)
+
+	// the content of the file versions which were already indexed for other refs is reused
+	var reads []internal.FileUpdate
+	for _, update := range changes.Updates {
+		if d := old.getDocument(internal.FileVersion{Filename: update.Filename, BlobSha: update.BlobSha}); d != nil {
+			nd := *d
+			nd.Refs = update.Refs
+			updates = append(updates, &nd)
+		} else {
+			reads = append(reads, update)
+		}
+	}
+
+	if len(reads) > 0 {
+		r, err := gitrepo.OpenRepository(ctx, repo)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		gitBatch, err := r.NewBatch(ctx)
+		if err != nil {
+			return err
+		}
+		defer gitBatch.Close()
+
+		for _, update := range reads {
+			d, err := b.readUpdate(ctx, gitBatch.Writer, gitBatch.Reader, update, repo)
+			if err != nil {
+				return err
+			}
+			if d == nil {
+				removed = append(removed, internal.FileVersion{Filename: update.Filename, BlobSha: update.BlobSha})
+			} else {
+				updates = append(updates, d)
+			}
+		}
+		gitBatch.Close()
+	}
+
+	s := old.withChanges(sha, updates, removed)
+
+	if err := writeShard(b.indexDir, s); err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.shards == nil {
+		return errors.New("indexer is not initialized")
+	}
+	b.shards[repo.ID] = s
+	return nil
+}
+
+// Delete removes the shard of the repository
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	b.lock.Lock()
+	delete(b.shards, repoID)
+	b.lock.Unlock()
+
+	if err := util.Remove(shardFilename(b.indexDir, repoID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+type hit struct {
+	repoID     int64
+	commitID   string
+	doc        *document
+	startIndex int
+	endIndex   int
+	score      int
+}
+
+// Search searches for files in the specified repos
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+	q, err := newQuery(opts.Keyword, util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	var pathMatcher *setting.GlobMatcher
+	if opts.Path != "" {
+		if pathMatcher, err = setting.GlobMatcherCompile(opts.Path, '/'); err != nil {
+			return 0, nil, nil, util.NewInvalidArgumentErrorf("invalid path pattern: %v", err)
+		}
+	}
+
+	shards := b.searchShards(opts.RepoIDs)
+	ref := opts.SearchRef()
+
+	var hits []*hit
+	languageCounts := make(map[string]int)
+	for _, s := range shards {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, nil, err
+		}
+
+		candidates := s.candidates(q.trigrams)
+		next := 0
+		for i, d := range s.Docs {
+			isCandidate := candidates == nil
+			if !isCandidate {
+				for next < len(candidates) && candidates[next] < uint32(i) {
+					next++
+				}
+				isCandidate = next < len(candidates) && candidates[next] == uint32(i)
+			}
+
+			if !slices.Contains(d.Refs, ref) {
+				continue
+			}
+			if pathMatcher != nil && !pathMatcher.Match(d.Filename) {
+				continue
+			}
+
+			h := &hit{repoID: s.RepoID, commitID: s.CommitID, doc: d}
+			if isCandidate {
+				if start, end, ok := q.matchContent(d.Content); ok {
+					h.startIndex, h.endIndex = start, end
+					h.score = len(q.patterns[0].FindAllStringIndex(d.Content, maxCountedMatches))
+					if q.phrase != nil && q.phrase.MatchString(d.Content) {
+						h.score += phraseMatchScore
+					}
+				}
+			}
+			if q.matchFilename(d.Filename) {
+				h.startIndex, h.endIndex = internal.FilenameMatchIndexPos(d.Content)
+				h.score += filenameMatchScore
+			}
+			if h.score == 0 {
+				continue
+			}
+
+			if d.Language != "" {
+				languageCounts[d.Language]++
+			}
+			if opts.Language == "" || opts.Language == d.Language {
+				hits = append(hits, h)
+			}
+		}
+	}
+
+	slices.SortStableFunc(hits, func(a, b *hit) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		if c := strings.Compare(a.doc.Filename, b.doc.Filename); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.repoID, b.repoID)
+	})
+
+	total := int64(len(hits))
+	if opts.Paginator != nil && !opts.IsListAll() {
+		skip, take := opts.GetSkipTake()
+		hits = hits[min(skip, len(hits)):min(skip+take, len(hits))]
+	}
+
+	searchResults := make([]*internal.SearchResult, 0, len(hits))
+	for _, h := range hits {
+		searchResults = append(searchResults, &internal.SearchResult{
+			RepoID:      h.repoID,
+			StartIndex:  h.startIndex,
+			EndIndex:    h.endIndex,
+			Filename:    h.doc.Filename,
+			Content:     h.doc.Content,
+			CommitID:    h.commitID,
+			UpdatedUnix: h.doc.UpdatedUnix,
+			Language:    h.doc.Language,
+			Color:       enry.GetColor(h.doc.Language),
+		})
+	}
+
+	searchResultLanguages := make([]*internal.SearchResultLanguages, 0, len(languageCounts))
+	for language, count := range languageCounts {
+		searchResultLanguages = append(searchResultLanguages, &internal.SearchResultLanguages{
+			Language: language,
+			Color:    enry.GetColor(language),
+			Count:    count,
+		})
+	}
+	slices.SortFunc(searchResultLanguages, func(a, b *internal.SearchResultLanguages) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	if len(searchResultLanguages) > maxLanguageFacets {
+		searchResultLanguages = searchResultLanguages[:maxLanguageFacets]
+	}
+
+	return total, searchResults, searchResultLanguages, nil
+}
+
+// searchShards returns the shards of the repositories, all shards if no repository is given
+func (b *Indexer) searchShards(repoIDs []int64) []*shard {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	shards := make([]*shard, 0, max(len(repoIDs), len(b.shards)))
+	if len(repoIDs) == 0 {
+		for _, s := range b.shards {
+			shards = append(shards, s)
+		}
+	} else {
+		for _, repoID := range repoIDs {
+			if s, ok := b.shards[repoID]; ok {
+				shards = append(shards, s)
+			}
+		}
+	}
+	return shards
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/commits/internal"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	analyzer_keyword "github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/token/camelcase"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	commitIndexerAnalyzer      = "commitIndexer"
+	commitIndexerDocType       = "commitIndexerDocType"
+	commitIndexerLatestVersion = 1
+)
+
+const unicodeNormalizeName = "unicodeNormalize"
+
+const maxBatchSize = 16
+
+// IndexerData an update to the commit indexer
+type IndexerData internal.IndexerData
+
+// Type returns the document type, for bleve's mapping.Classifier interface.
+func (i *IndexerData) Type() string {
+	return commitIndexerDocType
+}
+
+// generateCommitIndexMapping generates the bleve index mapping for commits
+func generateCommitIndexMapping() (mapping.IndexMapping, error) {
+	mapping := bleve.NewIndexMapping()
+	docMapping := bleve.NewDocumentMapping()
+
+	numericFieldMapping := bleve.NewNumericFieldMapping()
+	numericFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("repo_id", numericFieldMapping)
+	docMapping.AddFieldMappingsAt("committed_unix", numericFieldMapping)
+
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("message", textFieldMapping)
+	docMapping.AddFieldMappingsAt("author_name", textFieldMapping)
+	docMapping.AddFieldMappingsAt("committer_name", textFieldMapping)
+
+	termFieldMapping := bleve.NewTextFieldMapping()
+	termFieldMapping.IncludeInAll = false
+	termFieldMapping.Analyzer = analyzer_keyword.Name
+	docMapping.AddFieldMappingsAt("commit_id", termFieldMapping)
+	docMapping.AddFieldMappingsAt("author_email", termFieldMapping)
+	docMapping.AddFieldMappingsAt("committer_email", termFieldMapping)
+	docMapping.AddFieldMappingsAt("paths", termFieldMapping)
+
+	if err := mapping.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
+		"type": unicodenorm.Name,
+		"form": unicodenorm.NFC,
+	}); err != nil {
+		return nil, err
+	} else if err = mapping.AddCustomAnalyzer(commitIndexerAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{unicodeNormalizeName, camelcase.Name, lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
+	mapping.DefaultAnalyzer = commitIndexerAnalyzer
+	mapping.AddDocumentMapping(commitIndexerDocType, docMapping)
+	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
+	mapping.DefaultMapping = bleve.NewDocumentDisabledMapping() // disable default mapping, avoid indexing unexpected structs
+
+	return mapping, nil
+}
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_bleve.Indexer
+	indexer_internal.Indexer // do not composite inner_bleve.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWordsFuzzy()
+}
+
+// NewIndexer creates a new bleve local indexer
+func NewIndexer(indexDir string) *Indexer {
+	inner := inner_bleve.NewIndexer(indexDir, commitIndexerLatestVersion, generateCommitIndexMapping)
+	return &Indexer{
+		Indexer: inner,
+		inner:   inner,
+	}
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, commits ...*internal.IndexerData) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, commit := range commits {
+		data := *commit
+		// the emails are matched case-insensitively by the keyword analyzer
+		data.AuthorEmail = strings.ToLower(data.AuthorEmail)
+		data.CommitterEmail = strings.ToLower(data.CommitterEmail)
+		if err := batch.Index(commit.DocID(), (*IndexerData)(&data)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Delete deletes the commits of a repository
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	searchRequest := bleve.NewSearchRequestOptions(inner_bleve.NumericEqualityQuery(repoID, "repo_id"), math.MaxInt32, 0, false)
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return err
+	}
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, hit := range result.Hits {
+		if err := batch.Delete(hit.ID); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Search searches for commits by given conditions.
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	var queries []query.Query
+
+	if options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		var keywordQueries []query.Query
+		if searchMode == indexer.SearchModeWords || searchMode == indexer.SearchModeFuzzy {
+			fuzziness := 0
+			if searchMode == indexer.SearchModeFuzzy {
+				fuzziness = inner_bleve.GuessFuzzinessByKeyword(options.Keyword)
+			}
+			keywordQueries = []query.Query{
+				inner_bleve.MatchAndQuery(options.Keyword, "message", commitIndexerAnalyzer, fuzziness),
+				inner_bleve.MatchAndQuery(options.Keyword, "author_name", commitIndexerAnalyzer, fuzziness),
+				inner_bleve.MatchAndQuery(options.Keyword, "committer_name", commitIndexerAnalyzer, fuzziness),
+			}
+		} else /* exact */ {
+			keywordQueries = []query.Query{
+				inner_bleve.MatchPhraseQuery(options.Keyword, "message", commitIndexerAnalyzer, 0),
+				inner_bleve.MatchPhraseQuery(options.Keyword, "author_name", commitIndexerAnalyzer, 0),
+				inner_bleve.MatchPhraseQuery(options.Keyword, "committer_name", commitIndexerAnalyzer, 0),
+			}
+		}
+		pathQuery := bleve.NewTermQuery(options.Keyword)
+		pathQuery.SetField("paths")
+		keywordQueries = append(keywordQueries, pathQuery)
+		queries = append(queries, bleve.NewDisjunctionQuery(keywordQueries...))
+	}
+
+	if len(options.RepoIDs) > 0 {
+		repoQueries := make([]query.Query, 0, len(options.RepoIDs))
+		for _, repoID := range options.RepoIDs {
+			repoQueries = append(repoQueries, inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(repoQueries...))
+	}
+
+	if options.Author != "" {
+		emailQuery := bleve.NewTermQuery(strings.ToLower(options.Author))
+		emailQuery.SetField("author_email")
+		queries = append(queries, bleve.NewDisjunctionQuery(
+			inner_bleve.MatchAndQuery(options.Author, "author_name", commitIndexerAnalyzer, 0),
+			emailQuery,
+		))
+	}
+
+	if options.Path != "" {
+		pathQuery := bleve.NewPrefixQuery(options.Path)
+		pathQuery.SetField("paths")
+		queries = append(queries, pathQuery)
+	}
+
+	if options.CommittedAfterUnix.Has() || options.CommittedBeforeUnix.Has() {
+		queries = append(queries, inner_bleve.NumericRangeInclusiveQuery(
+			options.CommittedAfterUnix,
+			options.CommittedBeforeUnix,
+			"committed_unix"))
+	}
+
+	var indexerQuery query.Query = bleve.NewConjunctionQuery(queries...)
+	if len(queries) == 0 {
+		indexerQuery = bleve.NewMatchAllQuery()
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
+	search := bleve.NewSearchRequestOptions(indexerQuery, limit, skip, false)
+	search.Fields = []string{"repo_id", "commit_id", "message", "author_name", "author_email", "committer_name", "committer_email", "paths", "committed_unix"}
+	search.SortBy([]string{"-committed_unix", "-_id"})
+
+	result, err := b.inner.Indexer.SearchInContext(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &internal.SearchResult{
+		Total: int64(result.Total),
+		Hits:  make([]*internal.IndexerData, 0, len(result.Hits)),
+	}
+	for _, hit := range result.Hits {
+		repoID, _ := hit.Fields["repo_id"].(float64)
+		committedUnix, _ := hit.Fields["committed_unix"].(float64)
+		ret.Hits = append(ret.Hits, &internal.IndexerData{
+			RepoID:         int64(repoID),
+			CommitID:       stringField(hit.Fields, "commit_id"),
+			Message:        stringField(hit.Fields, "message"),
+			AuthorName:     stringField(hit.Fields, "author_name"),
+			AuthorEmail:    stringField(hit.Fields, "author_email"),
+			CommitterName:  stringField(hit.Fields, "committer_name"),
+			CommitterEmail: stringField(hit.Fields, "committer_email"),
+			Paths:          stringsField(hit.Fields, "paths"),
+			CommittedUnix:  timeutil.TimeStamp(committedUnix),
+		})
+	}
+	return ret, nil
+}
+
+func stringField(fields map[string]any, name string) string {
+	s, _ := fields[name].(string)
+	return s
+}
+
+// stringsField returns the values of an array field, bleve returns a single value for arrays with one item
+func stringsField(fields map[string]any, name string) []string {
+	switch v := fields[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		ret := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer/commits/internal/tests"
+)
+
+func TestBleveIndexer(t *testing.T) {
+	indexer := NewIndexer(t.TempDir())
+	defer indexer.Close()
+
+	tests.TestIndexer(t, indexer)
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/commits/internal"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_elasticsearch "code.gitea.io/gitea/modules/indexer/internal/elasticsearch"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	commitIndexerLatestVersion = 1
+	// multi-match-types, currently only 2 types are used
+	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
+	esMultiMatchTypeBestFields   = "best_fields"
+	esMultiMatchTypePhrasePrefix = "phrase_prefix"
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_elasticsearch.Indexer
+	indexer_internal.Indexer // do not composite inner_elasticsearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new elasticsearch indexer
+func NewIndexer(url, indexerName string) *Indexer {
+	inner := inner_elasticsearch.NewIndexer(url, indexerName, commitIndexerLatestVersion, defaultMapping)
+	indexer := &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+	return indexer
+}
+
+const (
+	defaultMapping = `
+{
+	"settings": {
+		"analysis": {
+			"normalizer": {
+				"lowercase_normalizer": {
+					"type": "custom",
+					"filter": ["lowercase"]
+				}
+			}
+		}
+	},
+	"mappings": {
+		"properties": {
+			"repo_id": { "type": "long", "index": true },
+			"commit_id": { "type": "keyword", "index": true },
+
+			"message": { "type": "text", "index": true },
+			"author_name": { "type": "text", "index": true },
+			"author_email": { "type": "keyword", "index": true, "normalizer": "lowercase_normalizer" },
+			"committer_name": { "type": "text", "index": true },
+			"committer_email": { "type": "keyword", "index": true, "normalizer": "lowercase_normalizer" },
+			"paths": { "type": "keyword", "index": true },
+
+			"committed_unix": { "type": "long", "index": true }
+		}
+	}
+}
+`
+)
+
+// Index will save the index data
+func (b *Indexer) Index(ctx context.Context, commits ...*internal.IndexerData) error {
+	if len(commits) == 0 {
+		return nil
+	} else if len(commits) == 1 {
+		commit := commits[0]
+		_, err := b.inner.Client.Index().
+			Index(b.inner.VersionedIndexName()).
+			Id(commit.DocID()).
+			BodyJson(commit).
+			Do(ctx)
+		return err
+	}
+
+	reqs := make([]elastic.BulkableRequest, 0, len(commits))
+	for _, commit := range commits {
+		reqs = append(reqs,
+			elastic.NewBulkIndexRequest().
+				Index(b.inner.VersionedIndexName()).
+				Id(commit.DocID()).
+				Doc(commit),
+		)
+	}
+
+	_, err := b.inner.Client.Bulk().
+		Index(b.inner.VersionedIndexName()).
+		Add(reqs...).
+		Do(graceful.GetManager().HammerContext())
+	return err
+}
+
+// Delete deletes the commits of a repository
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	_, err := b.inner.Client.DeleteByQuery(b.inner.VersionedIndexName()).
+		Query(elastic.NewTermsQuery("repo_id", repoID)).
+		Do(ctx)
+	return err
+}
+
+// Search searches for commits by given conditions.
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	query := elastic.NewBoolQuery()
+
+	if options.Keyword != "" {
+		keywordQuery := elastic.NewBoolQuery()
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		if searchMode == indexer.SearchModeExact {
+			keywordQuery.Should(elastic.NewMultiMatchQuery(options.Keyword, "message", "author_name", "committer_name").Type(esMultiMatchTypePhrasePrefix))
+		} else /* words */ {
+			keywordQuery.Should(elastic.NewMultiMatchQuery(options.Keyword, "message", "author_name", "committer_name").Type(esMultiMatchTypeBestFields).Operator("and"))
+		}
+		keywordQuery.Should(elastic.NewTermQuery("paths", options.Keyword))
+		query.Must(keywordQuery)
+	}
+
+	if len(options.RepoIDs) > 0 {
+		query.Must(elastic.NewTermsQuery("repo_id", toAnySlice(options.RepoIDs)...))
+	}
+
+	if options.Author != "" {
+		query.Must(elastic.NewBoolQuery().
+			Should(elastic.NewMatchQuery("author_name", options.Author).Operator("and")).
+			Should(elastic.NewTermQuery("author_email", strings.ToLower(options.Author))))
+	}
+
+	if options.Path != "" {
+		query.Must(elastic.NewPrefixQuery("paths", options.Path))
+	}
+
+	if options.CommittedAfterUnix.Has() || options.CommittedBeforeUnix.Has() {
+		q := elastic.NewRangeQuery("committed_unix")
+		if options.CommittedAfterUnix.Has() {
+			q.Gte(options.CommittedAfterUnix.Value())
+		}
+		if options.CommittedBeforeUnix.Has() {
+			q.Lte(options.CommittedBeforeUnix.Value())
+		}
+		query.Must(q)
+	}
+
+	// See https://stackoverflow.com/questions/35206409/elasticsearch-2-1-result-window-is-too-large-index-max-result-window/35221900
+	const maxPageSize = 10000
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator, maxPageSize)
+	searchResult, err := b.inner.Client.Search().
+		Index(b.inner.VersionedIndexName()).
+		Query(query).
+		SortBy(elastic.NewFieldSort("committed_unix").Desc(), elastic.NewFieldSort("commit_id").Desc()).
+		From(skip).Size(limit).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*internal.IndexerData, 0, len(searchResult.Hits.Hits))
+	for _, hit := range searchResult.Hits.Hits {
+		var data internal.IndexerData
+		if err := json.Unmarshal(hit.Source, &data); err != nil {
+			return nil, err
+		}
+		hits = append(hits, &data)
+	}
+
+	return &internal.SearchResult{
+		Total: searchResult.TotalHits(),
+		Hits:  hits,
+	}, nil
+}
+
+func toAnySlice[T any](s []T) []any {
+	ret := make([]any, 0, len(s))
+	for _, item := range s {
+		ret = append(ret, item)
+	}
+	return ret
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package commits
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/indexer/commits/internal"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+const (
+	logRecordSeparator = "\x1e"
+	logFieldSeparator  = "\x1f"
+	logFieldCount      = 8
+)
+
+// logFormat is the format of `git log`, each commit starts with a record separator and its fields are
+// separated by unit separators, the last field is filled with the touched paths by `--name-only -z`
+const logFormat = "--format=%x1e%H%x1f%an%x1f%ae%x1f%cn%x1f%ce%x1f%ct%x1f%B%x1f"
+
+func getDefaultBranchSha(ctx context.Context, repo *repo_model.Repository) (string, error) {
+	stdout, _, err := git.NewCommand("show-ref", "-s").AddDynamicArguments(git.BranchPrefix+repo.DefaultBranch).RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath()})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// getNewCommits returns the commits of the default branch since the last update of the indexer,
+// at most setting.Indexer.CommitIndexerMaxDepth of them. needReset is true if the indexed history
+// was rewritten, and the commits of the repository have to be removed before indexing the new ones.
+func getNewCommits(ctx context.Context, repo *repo_model.Repository, revision string) (commits []*internal.IndexerData, needReset bool, err error) {
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCommit)
+	if err != nil {
+		return nil, false, err
+	}
+	if status.CommitSha == revision {
+		return nil, false, nil
+	}
+
+	needReset = len(status.CommitSha) == 0
+	if !needReset {
+		isAncestorCmd := git.NewCommand("merge-base", "--is-ancestor").AddDynamicArguments(status.CommitSha, revision)
+		_, _, err := isAncestorCmd.RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath()})
+		needReset = err != nil
+	}
+
+	cmd := git.NewCommand("log", logFormat, "--name-only", "-z").
+		AddOptionFormat("--max-count=%d", setting.Indexer.CommitIndexerMaxDepth)
+	if needReset {
+		cmd.AddDynamicArguments(revision)
+	} else {
+		cmd.AddDynamicArguments(status.CommitSha + ".." + revision)
+	}
+	stdout, _, runErr := cmd.RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath()})
+	if runErr != nil {
+		return nil, false, runErr
+	}
+
+	commits, err = parseGitLogOutput(repo.ID, stdout)
+	if err != nil {
+		return nil, false, err
+	}
+	return commits, needReset, nil
+}
+
+// parseGitLogOutput parses the output of `git log` with logFormat and `--name-only -z`
+func parseGitLogOutput(repoID int64, stdout string) ([]*internal.IndexerData, error) {
+	records := strings.Split(stdout, logRecordSeparator)
+	commits := make([]*internal.IndexerData, 0, len(records))
+	for _, record := range records {
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, logFieldSeparator, logFieldCount)
+		if len(fields) != logFieldCount {
+			return nil, fmt.Errorf("invalid git log record: %q", record)
+		}
+		committedUnix, err := strconv.ParseInt(fields[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit time of %s: %w", fields[0], err)
+		}
+
+		var paths []string
+		for _, path := range strings.Split(fields[7], "\x00") {
+			if path = strings.TrimPrefix(path, "\n"); path != "" {
+				paths = append(paths, path)
+			}
+		}
+
+		commits = append(commits, &internal.IndexerData{
+			RepoID:         repoID,
+			CommitID:       fields[0],
+			Message:        strings.TrimSpace(fields[6]),
+			AuthorName:     fields[1],
+			AuthorEmail:    fields[2],
+			CommitterName:  fields[3],
+			CommitterEmail: fields[4],
+			Paths:          paths,
+			CommittedUnix:  timeutil.TimeStamp(committedUnix),
+		})
+	}
+	return commits, nil
+}
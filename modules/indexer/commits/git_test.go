@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package commits
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer/commits/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitLogOutput(t *testing.T) {
+	stdout := "\x1e4e5d9090\x1fA\x1fa@example.com\x1fA\x1fa@example.com\x1f1792387407\x1fempty\n\x1f\x00" +
+		"\x1e0178e38a\x1fB\x1fB@Example.com\x1fC\x1fc@example.com\x1f1792387406\x1ffix ABC-123\n\nbody\n\x1f\x00\nc\x00d/b c\x00"
+
+	commits, err := parseGitLogOutput(1, stdout)
+	require.NoError(t, err)
+	assert.Equal(t, []*internal.IndexerData{
+		{
+			RepoID:         1,
+			CommitID:       "4e5d9090",
+			Message:        "empty",
+			AuthorName:     "A",
+			AuthorEmail:    "a@example.com",
+			CommitterName:  "A",
+			CommitterEmail: "a@example.com",
+			CommittedUnix:  1792387407,
+		},
+		{
+			RepoID:         1,
+			CommitID:       "0178e38a",
+			Message:        "fix ABC-123\n\nbody",
+			AuthorName:     "B",
+			AuthorEmail:    "B@Example.com",
+			CommitterName:  "C",
+			CommitterEmail: "c@example.com",
+			Paths:          []string{"c", "d/b c"},
+			CommittedUnix:  1792387406,
+		},
+	}, commits)
+
+	commits, err = parseGitLogOutput(1, "")
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+
+	_, err = parseGitLogOutput(1, "\x1e4e5d9090\x1fA")
+	assert.Error(t, err)
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package commits
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/commits/bleve"
+	"code.gitea.io/gitea/modules/indexer/commits/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/commits/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// indexBatchSize is the number of commits sent to the indexer at once
+const indexBatchSize = 100
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[int64]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if repo_model.IsErrRepoNotExist(err) {
+		return indexer.Delete(ctx, repoID)
+	}
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	sha, err := getDefaultBranchSha(ctx, repo)
+	if err != nil {
+		return err
+	}
+	commits, needReset, err := getNewCommits(ctx, repo, sha)
+	if err != nil {
+		return err
+	}
+	if needReset {
+		if err := indexer.Delete(ctx, repo.ID); err != nil {
+			return err
+		}
+	}
+
+	for len(commits) > 0 {
+		n := min(len(commits), indexBatchSize)
+		if err := indexer.Index(ctx, commits[:n]...); err != nil {
+			return err
+		}
+		commits = commits[n:]
+	}
+
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCommit, sha)
+}
+
+// Init initialize the commit indexer
+func Init() {
+	if !setting.Indexer.CommitIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: CommitIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing commit indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Commit Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.CommitType {
+	case "bleve", "elasticsearch":
+		handler := func(items ...int64) (unhandled []int64) {
+			indexer := *globalIndexer.Load()
+			for _, repoID := range items {
+				log.Trace("Commit Indexer Process Repo: %d", repoID)
+				if err := index(ctx, indexer, repoID); err != nil {
+					if !setting.IsInTesting {
+						log.Error("Commits indexer handler: index error for repo %v: %v", repoID, err)
+					}
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken repo will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "commit_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create commits indexer queue")
+		}
+	default:
+		log.Fatal("Unknown commits indexer type; %s", setting.Indexer.CommitType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			cIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.CommitType {
+		case "bleve":
+			log.Info("PID: %d Initializing Commit Indexer at: %s", os.Getpid(), setting.Indexer.CommitPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing commit indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.CommitPath)
+				}
+			}()
+
+			cIndexer = bleve.NewIndexer(setting.Indexer.CommitPath)
+			existed, err = cIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the bleve Commit Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.CommitPath, err)
+			}
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Commit Indexer at: %s", os.Getpid(), setting.Indexer.CommitConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing commit indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.CommitConnStr)
+				}
+			}()
+
+			cIndexer = elasticsearch.NewIndexer(setting.Indexer.CommitConnStr, setting.Indexer.CommitIndexerName)
+			existed, err = cIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the elasticsearch Commit Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.CommitConnStr, err)
+			}
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.CommitType)
+		}
+
+		globalIndexer.Store(&cIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populateCommitIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Commit Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Commit Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Commit Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Commit Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+// UpdateRepoIndexer update a repository's commits in the indexer
+func UpdateRepoIndexer(repo *repo_model.Repository) {
+	if indexerQueue == nil {
+		return
+	}
+	if err := indexerQueue.Push(repo.ID); err != nil && err != queue.ErrAlreadyInQueue {
+		log.Error("Update commit index of repo %d failed: %v", repo.ID, err)
+	}
+}
+
+// IsAvailable checks if commit indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// populateCommitIndexer populate the commit indexer with the commits of the existing repositories,
+// it should only be run when the indexer is created for the first time.
+func populateCommitIndexer(ctx context.Context) {
+	log.Info("Populating the commit indexer with existing repositories")
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	// the statuses are stale since the index has been created afresh
+	if _, err := db.GetEngine(ctx).Where("indexer_type = ?", repo_model.RepoIndexerTypeCommit).Delete(&repo_model.RepoIndexerStatus{}); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	maxRepoID, err := db.GetMaxID("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, so that we
+	// don't include repos that are created after gitea starts; such repos will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxRepoID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Commit Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeCommit, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateCommitIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			if err := indexerQueue.Push(id); err != nil && err != queue.ErrAlreadyInQueue {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done populating the commit indexer with existing repositories")
+}
+
+// SearchCommits searches the commits by the given options
+func SearchCommits(ctx context.Context, opts *SearchOptions) (*SearchResult, error) {
+	return (*globalIndexer.Load()).Search(ctx, opts)
+}
+
+// SupportedSearchModes returns the search modes of the commit indexer
+func SupportedSearchModes() []indexer.SearchMode {
+	return (*globalIndexer.Load()).SupportedSearchModes()
+}
+
+type (
+	IndexerData   = internal.IndexerData
+	SearchOptions = internal.SearchOptions
+	SearchResult  = internal.SearchResult
+)
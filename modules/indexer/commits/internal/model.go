@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"strconv"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// IndexerData data stored in the commit indexer
+type IndexerData struct {
+	RepoID   int64  `json:"repo_id"`
+	CommitID string `json:"commit_id"`
+
+	// Fields used for keyword searching
+	Message        string   `json:"message"`
+	AuthorName     string   `json:"author_name"`
+	AuthorEmail    string   `json:"author_email"`
+	CommitterName  string   `json:"committer_name"`
+	CommitterEmail string   `json:"committer_email"`
+	Paths          []string `json:"paths"` // the files touched by the commit, empty for merge commits
+
+	// CommittedUnix is used for filtering and sorting
+	CommittedUnix timeutil.TimeStamp `json:"committed_unix"`
+}
+
+// DocID returns the ID of the document of the commit, which is unique across repositories
+func (d *IndexerData) DocID() string {
+	return DocID(d.RepoID, d.CommitID)
+}
+
+// DocID returns the ID of the document of a commit
+func DocID(repoID int64, commitID string) string {
+	return strconv.FormatInt(repoID, 36) + "_" + commitID
+}
+
+// SearchOptions represents search options
+type SearchOptions struct {
+	Keyword string // matched against the message, author, committer and paths
+
+	SearchMode indexer.SearchModeType
+
+	RepoIDs []int64 // repositories the commits belong to, empty means all repositories
+
+	Author string // name or email of the author
+	Path   string // prefix of a path touched by the commits
+
+	CommittedAfterUnix  optional.Option[int64]
+	CommittedBeforeUnix optional.Option[int64]
+
+	Paginator *db.ListOptions
+}
+
+// SearchResult represents search results, the hits are sorted by commit date, the newest first
+type SearchResult struct {
+	Total int64
+	Hits  []*IndexerData
+}
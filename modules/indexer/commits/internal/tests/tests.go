@@ -0,0 +1,150 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// This package contains tests for commit indexer modules.
+// All the code in this package is only used for testing.
+// Do not put any production code in this package to avoid it being included in the final binary.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/commits/internal"
+	"code.gitea.io/gitea/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexer(t *testing.T, idx internal.Indexer) {
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Ping(t.Context()))
+
+	require.NoError(t, idx.Index(t.Context(), data...))
+	require.NoError(t, waitData(idx, int64(len(data))))
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			result, err := idx.Search(t.Context(), c.SearchOptions)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(result.Hits))
+			for _, hit := range result.Hits {
+				ids = append(ids, hit.CommitID)
+			}
+			assert.Equal(t, c.ExpectedIDs, ids)
+			assert.EqualValues(t, len(c.ExpectedIDs), result.Total)
+		})
+	}
+
+	t.Run("Hit", func(t *testing.T) {
+		result, err := idx.Search(t.Context(), &internal.SearchOptions{Keyword: "crash", SearchMode: indexer.SearchModeWords})
+		require.NoError(t, err)
+		require.Len(t, result.Hits, 1)
+
+		hit := result.Hits[0]
+		// the emails are matched case insensitively, the backends may store them lowercased
+		assert.True(t, strings.EqualFold(data[0].AuthorEmail, hit.AuthorEmail))
+		hit.AuthorEmail = data[0].AuthorEmail
+		assert.Equal(t, data[0], hit)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, idx.Delete(t.Context(), 1))
+		require.NoError(t, waitData(idx, 1))
+
+		result, err := idx.Search(t.Context(), &internal.SearchOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.Hits, 1)
+		assert.Equal(t, "c3", result.Hits[0].CommitID)
+	})
+}
+
+var data = []*internal.IndexerData{
+	{RepoID: 1, CommitID: "c1", Message: "Fix ABC-123 crash", AuthorName: "Alice Smith", AuthorEmail: "Alice@example.com", Paths: []string{"cmd/serv.go"}, CommittedUnix: 100},
+	{RepoID: 1, CommitID: "c2", Message: "Update README", AuthorName: "Bob", AuthorEmail: "bob@example.com", Paths: []string{"README.md"}, CommittedUnix: 200},
+	{RepoID: 2, CommitID: "c3", Message: "Refs ABC-123", AuthorName: "Bob", AuthorEmail: "bob@example.com", Paths: []string{"cmd/web.go", "go.mod"}, CommittedUnix: 300},
+}
+
+type testIndexerCase struct {
+	Name          string
+	SearchOptions *internal.SearchOptions
+	ExpectedIDs   []string
+}
+
+var cases = []*testIndexerCase{
+	{
+		Name:          "default",
+		SearchOptions: &internal.SearchOptions{},
+		ExpectedIDs:   []string{"c3", "c2", "c1"},
+	},
+	{
+		Name:          "empty",
+		SearchOptions: &internal.SearchOptions{Keyword: "f1dfac73-fda6-4a6b-b8a4-2408fcb8ef69", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []string{},
+	},
+	{
+		Name:          "Keyword",
+		SearchOptions: &internal.SearchOptions{Keyword: "ABC-123", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []string{"c3", "c1"},
+	},
+	{
+		Name:          "RepoIDs",
+		SearchOptions: &internal.SearchOptions{Keyword: "ABC-123", SearchMode: indexer.SearchModeExact, RepoIDs: []int64{1}},
+		ExpectedIDs:   []string{"c1"},
+	},
+	{
+		Name:          "KeywordPath",
+		SearchOptions: &internal.SearchOptions{Keyword: "README.md"},
+		ExpectedIDs:   []string{"c2"},
+	},
+	{
+		Name:          "AuthorEmail",
+		SearchOptions: &internal.SearchOptions{Author: "alice@example.com"},
+		ExpectedIDs:   []string{"c1"},
+	},
+	{
+		Name:          "AuthorName",
+		SearchOptions: &internal.SearchOptions{Author: "bob"},
+		ExpectedIDs:   []string{"c3", "c2"},
+	},
+	{
+		Name:          "Path",
+		SearchOptions: &internal.SearchOptions{Path: "cmd/"},
+		ExpectedIDs:   []string{"c3", "c1"},
+	},
+	{
+		Name:          "Committed",
+		SearchOptions: &internal.SearchOptions{CommittedAfterUnix: optional.Some[int64](150), CommittedBeforeUnix: optional.Some[int64](250)},
+		ExpectedIDs:   []string{"c2"},
+	},
+}
+
+func waitData(idx internal.Indexer, total int64) error {
+	var actual int64
+	for i := 0; i < 100; i++ {
+		result, err := idx.Search(context.Background(), &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 0,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		actual = result.Total
+		if actual == total {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("waitData: expected %d, actual %d", total, actual)
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import "sync"
+
+// GenerationRefs counts the users of each generation of an index, a generation is identified by the pointer it's stored with.
+// A generation which was replaced is only destroyed after the searches and indexing using it completed,
+// while the users of the other generations aren't blocked meanwhile.
+// The zero value is ready to use.
+type GenerationRefs[T any] struct {
+	mu   sync.Mutex
+	refs map[*T]*generationRef
+}
+
+type generationRef struct {
+	count    int
+	released chan struct{} // closed when the generation isn't used anymore, it's only created when someone waits for it
+}
+
+// Use loads the generations and marks them as used, release must be called when they aren't used anymore.
+// The generations are loaded again if one of them was replaced meanwhile, so a replaced generation is never returned
+// once WaitReleased returned for it. The loaded generations may be nil.
+func (r *GenerationRefs[T]) Use(loads ...func() *T) (gens []*T, release func()) {
+	for {
+		gens = make([]*T, len(loads))
+		for i, load := range loads {
+			gens[i] = load()
+		}
+		r.acquire(gens)
+
+		replaced := false
+		for i, load := range loads {
+			if load() != gens[i] {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			return gens, func() { r.release(gens) }
+		}
+		r.release(gens)
+	}
+}
+
+// WaitReleased waits until the generation isn't used anymore, it must have been replaced before so no new user gets it
+func (r *GenerationRefs[T]) WaitReleased(gen *T) {
+	r.mu.Lock()
+	ref := r.refs[gen]
+	if ref == nil {
+		r.mu.Unlock()
+		return
+	}
+	if ref.released == nil {
+		ref.released = make(chan struct{})
+	}
+	released := ref.released
+	r.mu.Unlock()
+
+	<-released
+}
+
+func (r *GenerationRefs[T]) acquire(gens []*T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.refs == nil {
+		r.refs = make(map[*T]*generationRef)
+	}
+	for _, gen := range gens {
+		if gen == nil {
+			continue
+		}
+		ref := r.refs[gen]
+		if ref == nil {
+			ref = &generationRef{}
+			r.refs[gen] = ref
+		}
+		ref.count++
+	}
+}
+
+func (r *GenerationRefs[T]) release(gens []*T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, gen := range gens {
+		if gen == nil {
+			continue
+		}
+		ref := r.refs[gen]
+		ref.count--
+		if ref.count == 0 {
+			if ref.released != nil {
+				close(ref.released)
+			}
+			delete(r.refs, gen)
+		}
+	}
+}
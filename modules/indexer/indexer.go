@@ -6,10 +6,12 @@ package indexer
 type SearchModeType string
 
 const (
-	SearchModeExact  SearchModeType = "exact"
-	SearchModeWords  SearchModeType = "words"
-	SearchModeFuzzy  SearchModeType = "fuzzy"
-	SearchModeRegexp SearchModeType = "regexp"
+	SearchModeExact     SearchModeType = "exact"
+	SearchModeExactCase SearchModeType = "exact_case"
+	SearchModeWords     SearchModeType = "words"
+	SearchModeFuzzy     SearchModeType = "fuzzy"
+	SearchModeRegexp    SearchModeType = "regexp"
+	SearchModeSymbol    SearchModeType = "symbol"
 )
 
 type SearchMode struct {
@@ -52,3 +54,21 @@ func GitGrepSupportedSearchModes() []SearchMode {
 		},
 	}...)
 }
+
+func TrigramSupportedSearchModes() []SearchMode {
+	return append(GitGrepSupportedSearchModes(), []SearchMode{
+		{
+			ModeValue:    SearchModeExactCase,
+			TooltipTrKey: "search.exact_case_tooltip",
+			TitleTrKey:   "search.exact_case",
+		},
+	}...)
+}
+
+func SymbolSearchMode() SearchMode {
+	return SearchMode{
+		ModeValue:    SearchModeSymbol,
+		TooltipTrKey: "search.symbol_tooltip",
+		TitleTrKey:   "search.symbol",
+	}
+}
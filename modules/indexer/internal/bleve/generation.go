@@ -0,0 +1,139 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/ethantkoenig/rupture"
+)
+
+// generationSuffix is followed by the generation number in the directory names of the generations built by NewGeneration,
+// the generation 0 is the index directory itself
+const generationSuffix = ".gen"
+
+func generationPath(indexDir string, generation int) string {
+	if generation == 0 {
+		return indexDir
+	}
+	return indexDir + generationSuffix + strconv.Itoa(generation)
+}
+
+// listGenerations returns the generations of the index existing on disk, the latest one first
+func listGenerations(indexDir string) ([]int, error) {
+	entries, err := os.ReadDir(filepath.Dir(indexDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var generations []int
+	prefix := filepath.Base(indexDir)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if entry.Name() == prefix {
+			generations = append(generations, 0)
+		} else if suffix, ok := strings.CutPrefix(entry.Name(), prefix+generationSuffix); ok {
+			if generation, err := strconv.Atoi(suffix); err == nil && generation > 0 {
+				generations = append(generations, generation)
+			}
+		}
+	}
+	slices.SortFunc(generations, func(a, b int) int { return cmp.Compare(b, a) })
+	return generations, nil
+}
+
+// openLatestGeneration opens the latest complete generation of the index and removes the other ones,
+// which were either replaced by it or being built when Gitea stopped.
+// It returns the version of the latest generation if it had to be removed because it's outdated.
+func (i *Indexer) openLatestGeneration() (int, error) {
+	generations, err := listGenerations(i.indexDir)
+	if err != nil {
+		return 0, err
+	}
+
+	var oldVersion int
+	for _, generation := range generations {
+		path := generationPath(i.indexDir, generation)
+		if i.Indexer == nil {
+			_, err := rupture.ReadIndexMetadata(path)
+			if err == nil {
+				index, version, err := openIndexer(path, i.version)
+				if err != nil {
+					return 0, err
+				}
+				if index != nil {
+					i.Indexer, i.path, i.generation = index, path, generation
+				} else {
+					oldVersion = cmp.Or(oldVersion, version)
+				}
+				continue
+			} else if !os.IsNotExist(err) {
+				return 0, err
+			}
+		}
+		log.Info("Removing the replaced or incomplete bleve index in %q", path)
+		if err := util.RemoveAll(path); err != nil {
+			return 0, err
+		}
+	}
+	return oldVersion, nil
+}
+
+// NewGeneration creates an empty generation of the index beside the one in use, so the index can be rebuilt without degrading the search.
+// The new generation replaces the old ones when Init opens the index after it has been completed by CompleteGeneration.
+func (i *Indexer) NewGeneration(_ context.Context) (*Indexer, error) {
+	if i.Indexer == nil {
+		return nil, fmt.Errorf("indexer is not initialized")
+	}
+
+	next := &Indexer{
+		indexDir:      i.indexDir,
+		version:       i.version,
+		mappingGetter: i.mappingGetter,
+		generation:    i.generation + 1,
+	}
+	next.path = generationPath(next.indexDir, next.generation)
+
+	// the directory may be left by a rebuild which was interrupted
+	if err := util.RemoveAll(next.path); err != nil {
+		return nil, err
+	}
+	indexMapping, err := i.mappingGetter()
+	if err != nil {
+		return nil, err
+	}
+	next.Indexer, err = bleve.New(next.path, indexMapping)
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// CompleteGeneration marks a generation created by NewGeneration as complete by writing its metadata
+func (i *Indexer) CompleteGeneration(_ context.Context) error {
+	return rupture.WriteIndexMetadata(i.path, &rupture.IndexMetadata{
+		Version: i.version,
+	})
+}
+
+// Destroy closes the indexer and removes its generation of the index
+func (i *Indexer) Destroy(_ context.Context) error {
+	i.Close()
+	return util.RemoveAll(i.path)
+}
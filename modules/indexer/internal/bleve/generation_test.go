@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerations(t *testing.T) {
+	indexDir := filepath.Join(t.TempDir(), "test.bleve")
+	newIndexer := func() *Indexer {
+		return NewIndexer(indexDir, 1, func() (mapping.IndexMapping, error) {
+			return bleve.NewIndexMapping(), nil
+		})
+	}
+
+	live := newIndexer()
+	existed, err := live.Init(t.Context())
+	require.NoError(t, err)
+	assert.False(t, existed)
+	require.NoError(t, live.Indexer.Index("old", map[string]string{"text": "old"}))
+
+	// the complete generation replaces the live one
+	next, err := live.NewGeneration(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, indexDir+".gen1", next.path)
+	require.NoError(t, next.Indexer.Index("new", map[string]string{"text": "new"}))
+	require.NoError(t, next.CompleteGeneration(t.Context()))
+	require.NoError(t, live.Destroy(t.Context()))
+	assert.NoDirExists(t, indexDir)
+
+	// the incomplete generation is removed when the index is opened
+	incomplete, err := next.NewGeneration(t.Context())
+	require.NoError(t, err)
+	incomplete.Close()
+	next.Close()
+
+	reopened := newIndexer()
+	existed, err = reopened.Init(t.Context())
+	require.NoError(t, err)
+	defer reopened.Close()
+	assert.True(t, existed)
+	assert.Equal(t, 1, reopened.generation)
+	assert.NoDirExists(t, indexDir+".gen2")
+
+	doc, err := reopened.Indexer.Document("new")
+	require.NoError(t, err)
+	assert.NotNil(t, doc)
+}
@@ -24,6 +24,10 @@ type Indexer struct {
 	indexDir      string
 	version       int
 	mappingGetter MappingGetter
+
+	// path is the directory of the generation of the index in use, see NewGeneration
+	path       string
+	generation int
 }
 
 type MappingGetter func() (mapping.IndexMapping, error)
@@ -33,6 +37,7 @@ func NewIndexer(indexDir string, version int, mappingGetter func() (mapping.Inde
 		indexDir:      indexDir,
 		version:       version,
 		mappingGetter: mappingGetter,
+		path:          indexDir,
 	}
 }
 
@@ -46,12 +51,11 @@ func (i *Indexer) Init(_ context.Context) (bool, error) {
 		return false, fmt.Errorf("indexer is already initialized")
 	}
 
-	indexer, version, err := openIndexer(i.indexDir, i.version)
+	version, err := i.openLatestGeneration()
 	if err != nil {
 		return false, err
 	}
-	if indexer != nil {
-		i.Indexer = indexer
+	if i.Indexer != nil {
 		return true, nil
 	}
 
@@ -64,12 +68,12 @@ func (i *Indexer) Init(_ context.Context) (bool, error) {
 		return false, err
 	}
 
-	indexer, err = bleve.New(i.indexDir, indexMapping)
+	indexer, err := bleve.New(i.path, indexMapping)
 	if err != nil {
 		return false, err
 	}
 
-	if err = rupture.WriteIndexMetadata(i.indexDir, &rupture.IndexMetadata{
+	if err = rupture.WriteIndexMetadata(i.path, &rupture.IndexMetadata{
 		Version: i.version,
 	}); err != nil {
 		return false, err
@@ -97,7 +101,7 @@ func (i *Indexer) Close() {
 	}
 
 	if err := i.Indexer.Close(); err != nil {
-		log.Error("Failed to close bleve indexer in %q: %v", i.indexDir, err)
+		log.Error("Failed to close bleve indexer in %q: %v", i.path, err)
 	}
 	i.Indexer = nil
 }
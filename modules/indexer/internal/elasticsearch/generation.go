@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// generationSeparator separates the versioned index name and the creation time in the index names of the generations
+const generationSeparator = "-"
+
+// NewGeneration creates an empty generation of the index beside the one in use, so the index can be rebuilt without degrading the search.
+// CompleteGeneration turns the versioned index name into an alias of the new generation.
+func (i *Indexer) NewGeneration(ctx context.Context) (*Indexer, error) {
+	if i.Client == nil {
+		return nil, fmt.Errorf("indexer is not initialized")
+	}
+
+	alias := versionedIndexName(i.indexName, i.version)
+	if err := i.deleteIncompleteGenerations(ctx, alias); err != nil {
+		return nil, err
+	}
+
+	next := &Indexer{
+		Client:          i.Client,
+		url:             i.url,
+		indexName:       i.indexName,
+		version:         i.version,
+		mapping:         i.mapping,
+		generationIndex: fmt.Sprintf("%s%s%d", alias, generationSeparator, time.Now().UnixNano()),
+	}
+	if err := next.createIndex(ctx); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// deleteIncompleteGenerations deletes the generations left by the rebuilds which were interrupted
+func (i *Indexer) deleteIncompleteGenerations(ctx context.Context, alias string) error {
+	generations, err := i.Client.IndexGet(alias + generationSeparator + "*").Do(ctx)
+	if err != nil {
+		return err
+	}
+	for name, generation := range generations {
+		if _, ok := generation.Aliases[alias]; ok {
+			continue
+		}
+		log.Info("Deleting the incomplete elasticsearch index %q", name)
+		if _, err := i.Client.DeleteIndex(name).Do(ctx); err != nil && !elastic.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// CompleteGeneration points the versioned index name to a generation created by NewGeneration,
+// and deletes the indexes it pointed to before in the same atomic operation
+func (i *Indexer) CompleteGeneration(ctx context.Context) error {
+	alias := versionedIndexName(i.indexName, i.version)
+	actions := []elastic.AliasAction{elastic.NewAliasAddAction(alias).Index(i.generationIndex)}
+
+	// the versioned index name is either the index created before any generation or an alias of the current generation
+	replaced, err := i.Client.IndexGet(alias).Do(ctx)
+	if err != nil && !elastic.IsNotFound(err) {
+		return err
+	}
+	for name := range replaced {
+		actions = append(actions, elastic.NewAliasRemoveIndexAction(name))
+	}
+
+	result, err := i.Client.Alias().Action(actions...).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if !result.Acknowledged {
+		return fmt.Errorf("point alias %s to index %s failed", alias, i.generationIndex)
+	}
+	return nil
+}
+
+// Destroy closes the indexer and deletes its generation of the index,
+// the index used before the generation is deleted by CompleteGeneration
+func (i *Indexer) Destroy(ctx context.Context) error {
+	defer i.Close()
+	if i.Client == nil || i.generationIndex == "" {
+		return nil
+	}
+	if _, err := i.Client.DeleteIndex(i.generationIndex).Do(ctx); err != nil && !elastic.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -22,6 +22,9 @@ type Indexer struct {
 	indexName string
 	version   int
 	mapping   string
+
+	// generationIndex is the name of the index of a generation created by NewGeneration
+	generationIndex string
 }
 
 func NewIndexer(url, indexName string, version int, mapping string) *Indexer {
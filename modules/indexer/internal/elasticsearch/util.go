@@ -13,8 +13,12 @@ import (
 	"github.com/olivere/elastic/v7"
 )
 
-// VersionedIndexName returns the full index name with version
+// VersionedIndexName returns the full index name with version,
+// or the name of the index of the generation if the indexer was created by NewGeneration
 func (i *Indexer) VersionedIndexName() string {
+	if i.generationIndex != "" {
+		return i.generationIndex
+	}
 	return versionedIndexName(i.indexName, i.version)
 }
 
@@ -39,6 +39,9 @@ func addUnicodeNormalizeTokenFilter(m *mapping.IndexMappingImpl) error {
 
 const maxBatchSize = 16
 
+// similarTitleBoost makes the issues whose title contains the terms of a similar issue rank higher
+const similarTitleBoost = 2.0
+
 // IndexerData an update to the issue indexer
 type IndexerData internal.IndexerData
 
@@ -114,7 +117,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	return mapping, nil
 }
 
-var _ internal.Indexer = &Indexer{}
+var _ internal.GenerationIndexer = &Indexer{}
 
 // Indexer implements Indexer interface
 type Indexer struct {
@@ -135,6 +138,28 @@ func NewIndexer(indexDir string) *Indexer {
 	}
 }
 
+// NewGeneration creates an empty generation of the index beside the one in use
+func (b *Indexer) NewGeneration(ctx context.Context) (internal.GenerationIndexer, error) {
+	inner, err := b.inner.NewGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}, nil
+}
+
+// CompleteGeneration makes the generation replace the one in use
+func (b *Indexer) CompleteGeneration(ctx context.Context) error {
+	return b.inner.CompleteGeneration(ctx)
+}
+
+// Destroy closes the indexer and removes its generation of the index
+func (b *Indexer) Destroy(ctx context.Context) error {
+	return b.inner.Destroy(ctx)
+}
+
 // Index will save the index data
 func (b *Indexer) Index(_ context.Context, issues ...*internal.IndexerData) error {
 	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
@@ -162,7 +187,19 @@ func (b *Indexer) Delete(_ context.Context, ids ...int64) error {
 func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
 	var queries []query.Query
 
-	if options.Keyword != "" {
+	if options.IsSimilaritySearch() {
+		// bleve doesn't support "more like this" queries, so the issues containing enough of the significant terms are searched
+		terms := internal.SimilarityTerms(options.SimilarTitle, options.SimilarContent)
+		termQueries := make([]query.Query, 0, len(terms))
+		for _, term := range terms {
+			titleQuery := inner_bleve.MatchAndQuery(term, "title", issueIndexerAnalyzer, 0)
+			titleQuery.SetBoost(similarTitleBoost)
+			termQueries = append(termQueries, bleve.NewDisjunctionQuery(titleQuery, inner_bleve.MatchAndQuery(term, "content", issueIndexerAnalyzer, 0)))
+		}
+		q := bleve.NewDisjunctionQuery(termQueries...)
+		q.SetMin(float64(internal.MinSimilarityTermsMatch(terms)))
+		queries = append(queries, q)
+	} else if options.Keyword != "" {
 		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
 		if searchMode == indexer.SearchModeWords || searchMode == indexer.SearchModeFuzzy {
 			fuzziness := 0
@@ -275,6 +312,12 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 			options.UpdatedBeforeUnix,
 			"updated_unix"))
 	}
+	if options.CreatedAfterUnix.Has() || options.CreatedBeforeUnix.Has() {
+		queries = append(queries, inner_bleve.NumericRangeInclusiveQuery(
+			options.CreatedAfterUnix,
+			options.CreatedBeforeUnix,
+			"created_unix"))
+	}
 
 	var indexerQuery query.Query = bleve.NewConjunctionQuery(queries...)
 	if len(queries) == 0 {
@@ -284,12 +327,15 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
 	search := bleve.NewSearchRequestOptions(indexerQuery, limit, skip, false)
 
-	if options.SortBy == "" {
-		options.SortBy = internal.SortByCreatedAsc
+	if options.IsSimilaritySearch() {
+		search.SortBy([]string{"-_score", "-_id"})
+	} else {
+		if options.SortBy == "" {
+			options.SortBy = internal.SortByCreatedAsc
+		}
+		search.SortBy([]string{string(options.SortBy), "-_id"})
 	}
 
-	search.SortBy([]string{string(options.SortBy), "-_id"})
-
 	result, err := b.inner.Indexer.SearchInContext(ctx, search)
 	if err != nil {
 		return nil, err
@@ -305,7 +351,8 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 			return nil, err
 		}
 		ret.Hits = append(ret.Hits, internal.Match{
-			ID: id,
+			ID:    id,
+			Score: hit.Score,
 		})
 	}
 	return ret, nil
@@ -77,6 +77,10 @@ func (i *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	//        So that's the root problem:
 	//        The notification is defined in modules, but it's using lots of things should be in services.
 
+	if options.IsSimilaritySearch() {
+		return i.searchSimilar(ctx, options)
+	}
+
 	cond := builder.NewCond()
 
 	if options.Keyword != "" {
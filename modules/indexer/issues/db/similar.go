@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package db
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issue_model "code.gitea.io/gitea/models/issues"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+
+	"xorm.io/builder"
+)
+
+const (
+	// maxSimilarQueryTerms is the number of the most significant terms searched in the database,
+	// it's less than internal.MaxSimilarityTerms to keep the query cheap
+	maxSimilarQueryTerms = 8
+	// maxSimilarCandidates is the number of the recently updated issues containing any of the terms which are compared
+	maxSimilarCandidates = 200
+)
+
+// searchSimilar finds the issues similar to options.SimilarTitle and options.SimilarContent.
+// The database can't rank the issues by relevance, so the recently updated issues containing any of
+// the significant terms are loaded and scored by the terms they contain.
+func (i *Indexer) searchSimilar(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	terms := internal.SimilarityTerms(options.SimilarTitle, options.SimilarContent)
+	if len(terms) == 0 {
+		return &internal.SearchResult{}, nil
+	}
+
+	cond := builder.NewCond()
+	for _, term := range terms[:min(len(terms), maxSimilarQueryTerms)] {
+		cond = cond.Or(db.BuildCaseInsensitiveLike("issue.name", term), db.BuildCaseInsensitiveLike("issue.content", term))
+	}
+
+	opt, err := ToDBOptions(ctx, options.Copy(func(o *internal.SearchOptions) {
+		o.SortBy = internal.SortByUpdatedDesc
+		o.Paginator = &db.ListOptions{PageSize: maxSimilarCandidates}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	ids, _, err := issue_model.IssueIDs(ctx, opt, cond)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := issue_model.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	minMatch := internal.MinSimilarityTermsMatch(terms)
+	hits := make([]internal.Match, 0, len(issues))
+	for _, issue := range issues {
+		title, content := strings.ToLower(issue.Title), strings.ToLower(issue.Content)
+		matched, score := 0, 0.0
+		for _, term := range terms {
+			if strings.Contains(title, term) {
+				matched++
+				score += 2
+			} else if strings.Contains(content, term) {
+				matched++
+				score++
+			}
+		}
+		if matched >= minMatch {
+			hits = append(hits, internal.Match{ID: issue.ID, Score: score / float64(len(terms))})
+		}
+	}
+	slices.SortFunc(hits, func(a, b internal.Match) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.ID, a.ID))
+	})
+
+	total := int64(len(hits))
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
+	hits = hits[min(skip, len(hits)):]
+	hits = hits[:min(limit, len(hits))]
+	return &internal.SearchResult{Total: total, Hits: hits}, nil
+}
@@ -75,6 +75,12 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 	if opts.UpdatedBeforeUnix > 0 {
 		searchOpt.UpdatedBeforeUnix = optional.Some(opts.UpdatedBeforeUnix)
 	}
+	if opts.CreatedAfterUnix > 0 {
+		searchOpt.CreatedAfterUnix = optional.Some(opts.CreatedAfterUnix)
+	}
+	if opts.CreatedBeforeUnix > 0 {
+		searchOpt.CreatedBeforeUnix = optional.Some(opts.CreatedBeforeUnix)
+	}
 
 	searchOpt.Paginator = opts.Paginator
 
@@ -25,9 +25,12 @@ const (
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
 	esMultiMatchTypePhrasePrefix = "phrase_prefix"
+	// similarMinimumShouldMatch is how many of the significant terms of an issue a similar issue has to contain:
+	// all of them if there are up to 2, otherwise 40% of them
+	similarMinimumShouldMatch = "2<40%"
 )
 
-var _ internal.Indexer = &Indexer{}
+var _ internal.GenerationIndexer = &Indexer{}
 
 // Indexer implements Indexer interface
 type Indexer struct {
@@ -50,6 +53,28 @@ func NewIndexer(url, indexerName string) *Indexer {
 	return indexer
 }
 
+// NewGeneration creates an empty generation of the index beside the one in use
+func (b *Indexer) NewGeneration(ctx context.Context) (internal.GenerationIndexer, error) {
+	inner, err := b.inner.NewGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}, nil
+}
+
+// CompleteGeneration makes the generation replace the one in use
+func (b *Indexer) CompleteGeneration(ctx context.Context) error {
+	return b.inner.CompleteGeneration(ctx)
+}
+
+// Destroy closes the indexer and removes its generation of the index
+func (b *Indexer) Destroy(ctx context.Context) error {
+	return b.inner.Destroy(ctx)
+}
+
 const (
 	defaultMapping = `
 {
@@ -152,7 +177,15 @@ func (b *Indexer) Delete(ctx context.Context, ids ...int64) error {
 func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
 	query := elastic.NewBoolQuery()
 
-	if options.Keyword != "" {
+	if options.IsSimilaritySearch() {
+		query.Must(elastic.NewMoreLikeThisQuery().
+			LikeText(options.SimilarTitle, options.SimilarContent).
+			Field("title", "content").
+			MinTermFreq(1).
+			MinDocFreq(1).
+			MaxQueryTerms(internal.MaxSimilarityTerms).
+			MinimumShouldMatch(similarMinimumShouldMatch))
+	} else if options.Keyword != "" {
 		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
 		if searchMode == indexer.SearchModeExact {
 			query.Must(elastic.NewMultiMatchQuery(options.Keyword, "title", "content", "comments").Type(esMultiMatchTypePhrasePrefix))
@@ -245,13 +278,31 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}
 		query.Must(q)
 	}
-
-	if options.SortBy == "" {
-		options.SortBy = internal.SortByCreatedAsc
+	if options.CreatedAfterUnix.Has() || options.CreatedBeforeUnix.Has() {
+		q := elastic.NewRangeQuery("created_unix")
+		if options.CreatedAfterUnix.Has() {
+			q.Gte(options.CreatedAfterUnix.Value())
+		}
+		if options.CreatedBeforeUnix.Has() {
+			q.Lte(options.CreatedBeforeUnix.Value())
+		}
+		query.Must(q)
 	}
-	sortBy := []elastic.Sorter{
-		parseSortBy(options.SortBy),
-		elastic.NewFieldSort("id").Desc(),
+
+	var sortBy []elastic.Sorter
+	if options.IsSimilaritySearch() {
+		sortBy = []elastic.Sorter{
+			elastic.NewScoreSort(),
+			elastic.NewFieldSort("id").Desc(),
+		}
+	} else {
+		if options.SortBy == "" {
+			options.SortBy = internal.SortByCreatedAsc
+		}
+		sortBy = []elastic.Sorter{
+			parseSortBy(options.SortBy),
+			elastic.NewFieldSort("id").Desc(),
+		}
 	}
 
 	// See https://stackoverflow.com/questions/35206409/elasticsearch-2-1-result-window-is-too-large-index-max-result-window/35221900
@@ -272,8 +323,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	hits := make([]internal.Match, 0, limit)
 	for _, hit := range searchResult.Hits.Hits {
 		id, _ := strconv.ParseInt(hit.Id, 10, 64)
+		var score float64
+		if hit.Score != nil {
+			score = *hit.Score
+		}
 		hits = append(hits, internal.Match{
-			ID: id,
+			ID:    id,
+			Score: score,
 		})
 	}
 
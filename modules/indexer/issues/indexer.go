@@ -166,7 +166,8 @@ func getIssueIndexerQueueHandler(ctx context.Context) func(items ...*IndexerMeta
 	return func(items ...*IndexerMetadata) []*IndexerMetadata {
 		var unhandled []*IndexerMetadata
 
-		indexer := *globalIndexer.Load()
+		indexer, release := activeIndexers()
+		defer release()
 		for _, item := range items {
 			log.Trace("IndexerMetadata Process: %d %v %t", item.ID, item.IDs, item.IsDelete)
 			if item.IsDelete {
@@ -263,7 +264,9 @@ func DeleteRepoIssueIndexer(ctx context.Context, repoID int64) {
 
 // IsAvailable checks if issue indexer is available
 func IsAvailable(ctx context.Context) bool {
-	return (*globalIndexer.Load()).Ping(ctx) == nil
+	ix, release := useGlobalIndexer()
+	defer release()
+	return ix.Ping(ctx) == nil
 }
 
 // SearchOptions indicates the options for searching issues
@@ -282,7 +285,8 @@ const (
 
 // SearchIssues search issues by options.
 func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, error) {
-	ix := *globalIndexer.Load()
+	ix, release := useGlobalIndexer()
+	defer release()
 
 	if opts.Keyword == "" || opts.IsKeywordNumeric() {
 		// This is a conservative shortcut.
@@ -306,6 +310,25 @@ func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, err
 	return ret, result.Total, nil
 }
 
+// Match is an issue found by the issue indexer with its relevance
+type Match = internal.Match
+
+// SearchSimilarIssues searches the issues similar to opts.SimilarTitle and opts.SimilarContent, the most similar ones first.
+// The scores of the matches can only be compared with the other matches of the same search.
+func SearchSimilarIssues(ctx context.Context, opts *SearchOptions) ([]Match, error) {
+	if len(internal.SimilarityTerms(opts.SimilarTitle, opts.SimilarContent)) == 0 {
+		return nil, nil // nothing significant to compare
+	}
+
+	ix, release := useGlobalIndexer()
+	defer release()
+	result, err := ix.Search(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result.Hits, nil
+}
+
 // CountIssues counts issues by options. It is a shortcut of SearchIssues(ctx, opts) but only returns the total count.
 func CountIssues(ctx context.Context, opts *SearchOptions) (int64, error) {
 	opts = opts.Copy(func(options *SearchOptions) { options.Paginator = &db_model.ListOptions{PageSize: 0} })
@@ -4,11 +4,14 @@
 package issues
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/indexer/issues/bleve"
 	"code.gitea.io/gitea/modules/indexer/issues/internal"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
@@ -44,6 +47,45 @@ func TestDBSearchIssues(t *testing.T) {
 	t.Run("search issues with order", searchIssueWithOrder)
 	t.Run("search issues in project", searchIssueInProject)
 	t.Run("search issues with paginator", searchIssueWithPaginator)
+	t.Run("search similar issues", searchSimilarIssues)
+}
+
+func TestRebuildIssueIndexer(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	indexDir := filepath.Join(t.TempDir(), "issues.bleve")
+	var live internal.Indexer = bleve.NewIndexer(indexDir)
+	_, err := live.Init(t.Context())
+	require.NoError(t, err)
+	oldGlobalIndexer := globalIndexer.Load()
+	globalIndexer.Store(&live)
+	defer func() {
+		(*globalIndexer.Load()).Close()
+		globalIndexer.Store(oldGlobalIndexer)
+	}()
+
+	// a search which loaded the replaced generation keeps it until it completes
+	inUse, release := useGlobalIndexer()
+	rebuilt := make(chan error, 1)
+	go func() { rebuilt <- RebuildIssueIndexer(t.Context()) }()
+	require.Eventually(t, func() bool { return *globalIndexer.Load() != live }, 10*time.Second, 10*time.Millisecond)
+	// the searches with the new generation aren't blocked meanwhile
+	issueIDs, _, err := SearchIssues(t.Context(), &SearchOptions{Keyword: "issue2", RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, issueIDs)
+	_, err = inUse.Search(t.Context(), &SearchOptions{Keyword: "issue2", RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	assert.DirExists(t, indexDir)
+	release()
+
+	require.NoError(t, <-rebuilt)
+	assert.Nil(t, RebuildProgress())
+	assert.NoDirExists(t, indexDir)
+	assert.DirExists(t, indexDir+".gen1")
+
+	issueIDs, _, err = SearchIssues(t.Context(), &SearchOptions{Keyword: "issue2", RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, issueIDs)
 }
 
 func searchIssueWithKeyword(t *testing.T) {
@@ -462,3 +504,23 @@ func searchIssueWithPaginator(t *testing.T) {
 		assert.Equal(t, test.expectedTotal, total)
 	}
 }
+
+func searchSimilarIssues(t *testing.T) {
+	matches, err := SearchSimilarIssues(t.Context(), &SearchOptions{
+		SimilarTitle: "Content of the fifth issue",
+		RepoIDs:      []int64{1},
+		IsPull:       optional.Some(false),
+	})
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	assert.Equal(t, []int64{5, 1}, ids)
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+
+	// nothing significant to compare
+	matches, err = SearchSimilarIssues(t.Context(), &SearchOptions{SimilarTitle: "It is not a bug", RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
@@ -20,6 +20,18 @@ type Indexer interface {
 	SupportedSearchModes() []indexer.SearchMode
 }
 
+// GenerationIndexer is an indexer which can build a new generation of its index beside the one in use,
+// so the index can be rebuilt without degrading the search
+type GenerationIndexer interface {
+	Indexer
+	// NewGeneration creates an empty generation of the index, which isn't used until it's completed
+	NewGeneration(ctx context.Context) (GenerationIndexer, error)
+	// CompleteGeneration makes the generation replace the one in use
+	CompleteGeneration(ctx context.Context) error
+	// Destroy closes the indexer and removes its generation of the index
+	Destroy(ctx context.Context) error
+}
+
 // NewDummyIndexer returns a dummy indexer
 func NewDummyIndexer() Indexer {
 	return &dummyIndexer{
@@ -80,6 +80,12 @@ type SearchOptions struct {
 
 	SearchMode indexer.SearchModeType
 
+	// SimilarTitle and SimilarContent are the title and content of an issue to find the similar issues of.
+	// If any of them is set, the issues sharing the most significant terms with them are returned ordered by relevance,
+	// and Keyword, SearchMode and SortBy are ignored.
+	SimilarTitle   string
+	SimilarContent string
+
 	RepoIDs   []int64 // repository IDs which the issues belong to
 	AllPublic bool    // if include all public repositories
 
@@ -111,6 +117,9 @@ type SearchOptions struct {
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
+	CreatedAfterUnix  optional.Option[int64]
+	CreatedBeforeUnix optional.Option[int64]
+
 	Paginator *db.ListOptions
 
 	SortBy SortBy // sort by field
@@ -129,6 +138,11 @@ func (o *SearchOptions) Copy(edit ...func(options *SearchOptions)) *SearchOption
 	return &v
 }
 
+// IsSimilaritySearch returns whether the issues similar to SimilarTitle and SimilarContent are searched
+func (o *SearchOptions) IsSimilaritySearch() bool {
+	return o.SimilarTitle != "" || o.SimilarContent != ""
+}
+
 // used for optimized issue index based search
 func (o *SearchOptions) IsKeywordNumeric() bool {
 	_, err := strconv.Atoi(o.Keyword)
@@ -0,0 +1,67 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"code.gitea.io/gitea/modules/container"
+)
+
+// MaxSimilarityTerms is the maximum number of terms of a text used to find the issues similar to it
+const MaxSimilarityTerms = 20
+
+// minSimilarityTermLength is the minimum number of characters of a term used to find similar issues,
+// shorter words are too common to tell whether two issues are similar
+const minSimilarityTermLength = 3
+
+// similarityStopWords are common English words which don't tell whether two issues are similar,
+// including the words frequently used in the issue templates
+var similarityStopWords = container.SetOf(
+	"about", "after", "again", "all", "also", "and", "any", "are", "because", "been", "before", "being", "but",
+	"can", "cannot", "could", "did", "does", "doing", "don", "down", "each", "for", "from", "get", "got",
+	"had", "has", "have", "having", "her", "here", "him", "his", "how", "into", "its", "just", "more", "most",
+	"not", "now", "off", "once", "only", "other", "our", "out", "over", "own", "same", "she", "should", "some",
+	"such", "than", "that", "the", "their", "them", "then", "there", "these", "they", "this", "those", "through",
+	"too", "under", "until", "very", "was", "were", "what", "when", "where", "which", "while", "who", "why",
+	"will", "with", "would", "you", "your",
+	"bug", "issue", "expected", "behavior", "behaviour", "actual", "steps", "reproduce", "description",
+	"version", "please", "thanks", "https", "http", "www", "com",
+)
+
+// SimilarityTerms returns the significant terms of a text to find the issues similar to it,
+// the most frequent ones first. The terms of the title are counted more than the ones of the content.
+func SimilarityTerms(title, content string) []string {
+	counts := map[string]int{}
+	var terms []string
+	addTerms := func(text string, weight int) {
+		for _, term := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}) {
+			if len([]rune(term)) < minSimilarityTermLength || similarityStopWords.Contains(term) {
+				continue
+			}
+			if _, ok := counts[term]; !ok {
+				terms = append(terms, term)
+			}
+			counts[term] += weight
+		}
+	}
+	addTerms(title, 3)
+	addTerms(content, 1)
+
+	// the stable sort keeps the terms which occur first when the counts are equal
+	slices.SortStableFunc(terms, func(a, b string) int { return counts[b] - counts[a] })
+	if len(terms) > MaxSimilarityTerms {
+		terms = terms[:MaxSimilarityTerms]
+	}
+	return terms
+}
+
+// MinSimilarityTermsMatch returns how many of the terms a similar issue has to contain
+func MinSimilarityTermsMatch(terms []string) int {
+	return max(1, (len(terms)+2)/3)
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarityTerms(t *testing.T) {
+	assert.Empty(t, SimilarityTerms("", ""))
+	assert.Empty(t, SimilarityTerms("It is not a bug", "Please, can you do it?"))
+
+	assert.Equal(t, []string{"images", "large", "avatar", "uploaded", "error"},
+		SimilarityTerms("Large avatar images can't be uploaded", "I get an error with my images"))
+
+	// a term of the title counts as much as three of the content
+	assert.Equal(t, []string{"webhook", "timeout", "timeouts"},
+		SimilarityTerms("Webhook timeout", "timeouts timeouts"))
+	assert.Equal(t, []string{"timeouts", "webhook", "timeout"},
+		SimilarityTerms("Webhook timeout", "timeouts timeouts timeouts timeouts"))
+
+	terms := SimilarityTerms(strings.Repeat("word ", 10), strings.Repeat("a1b2c3 ", 5)+"term1 term2 term3 term4 term5 term6 term7 term8 term9 term10 term11 term12 term13 term14 term15 term16 term17 term18 term19 term20")
+	assert.Len(t, terms, MaxSimilarityTerms)
+	assert.Equal(t, []string{"word", "a1b2c3", "term1"}, terms[:3])
+}
+
+func TestMinSimilarityTermsMatch(t *testing.T) {
+	assert.Equal(t, 1, MinSimilarityTermsMatch(nil))
+	assert.Equal(t, 1, MinSimilarityTermsMatch([]string{"a"}))
+	assert.Equal(t, 2, MinSimilarityTermsMatch([]string{"a", "b", "c", "d"}))
+	assert.Equal(t, 7, MinSimilarityTermsMatch(make([]string, MaxSimilarityTerms)))
+}
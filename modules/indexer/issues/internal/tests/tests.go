@@ -130,6 +130,26 @@ var cases = []*testIndexerCase{
 		ExpectedIDs:   []int64{1002, 1001, 1000},
 		ExpectedTotal: 3,
 	},
+	{
+		Name: "SimilarTitle",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1001, Title: "Avatar upload fails for large images"},
+			{ID: 1002, Title: "Error 500", Content: "The server returns an error when the avatar images are too large"},
+			{ID: 1003, Title: "Dark theme colors", Content: "The colors of the avatar border are wrong"},
+		},
+		SearchOptions: &internal.SearchOptions{
+			SimilarTitle:   "Large avatar images can't be uploaded",
+			SimilarContent: "I get an error with my images",
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			ids := make([]int64, 0, len(result.Hits))
+			for _, hit := range result.Hits {
+				ids = append(ids, hit.ID)
+			}
+			assert.ElementsMatch(t, []int64{1001, 1002}, ids)
+			assert.EqualValues(t, 2, result.Total)
+		},
+	},
 	{
 		Name: "RepoIDs",
 		ExtraData: []*internal.IndexerData{
@@ -519,6 +539,26 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "created",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			CreatedAfterUnix:  optional.Some(int64(20)),
+			CreatedBeforeUnix: optional.Some(int64(30)),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.GreaterOrEqual(t, data[v.ID].CreatedUnix, int64(20))
+				assert.LessOrEqual(t, data[v.ID].CreatedUnix, int64(30))
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return data[v.ID].CreatedUnix >= 20 && data[v.ID].CreatedUnix <= 30
+			}), result.Total)
+		},
+	},
 	{
 		Name: "SortByCreatedDesc",
 		SearchOptions: &internal.SearchOptions{
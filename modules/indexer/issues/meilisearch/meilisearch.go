@@ -19,10 +19,19 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 4
+	issueIndexerLatestVersion = 5
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
+
+	// maxQueryWords is the number of words of a query meilisearch searches, the next ones are ignored
+	// See https://www.meilisearch.com/docs/learn/resources/known_limitations#maximum-number-of-query-words
+	maxQueryWords = 10
+
+	// similarRankingScoreThreshold excludes the issues which only contain a few of the significant terms of a similar issue,
+	// since the number of the matched terms is the most important ranking rule, a third of them scores about 0.3
+	// See https://www.meilisearch.com/docs/learn/relevancy/ranking_score
+	similarRankingScoreThreshold = 0.3
 )
 
 // ErrMalformedResponse is never expected as we initialize the indexer ourself and so define the types.
@@ -79,6 +88,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"review_requested_ids",
 			"subscriber_ids",
 			"updated_unix",
+			"created_unix",
 		},
 		SortableAttributes: []string{
 			"updated_unix",
@@ -216,13 +226,22 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	if options.UpdatedBeforeUnix.Has() {
 		query.And(inner_meilisearch.NewFilterLte("updated_unix", options.UpdatedBeforeUnix.Value()))
 	}
-
-	if options.SortBy == "" {
-		options.SortBy = internal.SortByCreatedAsc
+	if options.CreatedAfterUnix.Has() {
+		query.And(inner_meilisearch.NewFilterGte("created_unix", options.CreatedAfterUnix.Value()))
+	}
+	if options.CreatedBeforeUnix.Has() {
+		query.And(inner_meilisearch.NewFilterLte("created_unix", options.CreatedBeforeUnix.Value()))
 	}
-	sortBy := []string{
-		parseSortBy(options.SortBy),
-		"id:desc",
+
+	var sortBy []string
+	if !options.IsSimilaritySearch() { // the similar issues are sorted by relevance
+		if options.SortBy == "" {
+			options.SortBy = internal.SortByCreatedAsc
+		}
+		sortBy = []string{
+			parseSortBy(options.SortBy),
+			"id:desc",
+		}
 	}
 
 	skip, limit := indexer_internal.ParsePaginator(options.Paginator, maxTotalHits)
@@ -236,18 +255,30 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	}
 
 	keyword := options.Keyword // default to match "words"
-	if options.SearchMode == indexer.SearchModeExact {
+	matchingStrategy := meilisearch.All
+	if options.IsSimilaritySearch() {
+		// meilisearch doesn't support "more like this" queries, so the significant terms are searched,
+		// and the least significant ones are ignored until there are results
+		terms := internal.SimilarityTerms(options.SimilarTitle, options.SimilarContent)
+		keyword = strings.Join(terms[:min(len(terms), maxQueryWords)], " ")
+		matchingStrategy = meilisearch.Last
+	} else if options.SearchMode == indexer.SearchModeExact {
 		// https://www.meilisearch.com/docs/reference/api/search#phrase-search
 		keyword = doubleQuoteKeyword(keyword)
 	}
 
-	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).Search(keyword, &meilisearch.SearchRequest{
+	req := &meilisearch.SearchRequest{
 		Filter:           query.Statement(),
 		Limit:            int64(limit),
 		Offset:           int64(skip),
 		Sort:             sortBy,
-		MatchingStrategy: "all",
-	})
+		MatchingStrategy: matchingStrategy,
+		ShowRankingScore: options.IsSimilaritySearch(),
+	}
+	if options.IsSimilaritySearch() {
+		req.RankingScoreThreshold = similarRankingScoreThreshold
+	}
+	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).Search(keyword, req)
 	if err != nil {
 		return nil, err
 	}
@@ -301,8 +332,10 @@ func convertHits(searchRes *meilisearch.SearchResponse) ([]internal.Match, error
 			return nil, ErrMalformedResponse
 		}
 
+		score, _ := hit["_rankingScore"].(float64) // only returned for the similarity searches
 		hits = append(hits, internal.Match{
-			ID: int64(issueID),
+			ID:    int64(issueID),
+			Score: score,
 		})
 	}
 	return hits, nil
@@ -0,0 +1,500 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrInvalidQuery represents a term of an issue search query which can't be parsed or resolved
+type ErrInvalidQuery struct {
+	Term string
+	Msg  string
+}
+
+// IsErrInvalidQuery checks if an error is an ErrInvalidQuery
+func IsErrInvalidQuery(err error) bool {
+	_, ok := err.(ErrInvalidQuery)
+	return ok
+}
+
+func (err ErrInvalidQuery) Error() string {
+	return fmt.Sprintf("invalid search query term %q: %s", err.Term, err.Msg)
+}
+
+func (err ErrInvalidQuery) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// Query represents an issue search query written in a GitHub-like syntax, for example:
+//
+//	is:open is:pr label:bug -label:wontfix author:alice assignee:@me milestone:"v2" created:>2025-01-01 sort:updated-desc crash
+//
+// The terms which are not qualifiers form the keyword.
+// ParseQuery only checks the syntax, Resolve looks up the labels, milestones and users referenced by the query,
+// and Apply compiles the query into SearchOptions, so all indexers see exactly the same filters.
+type Query struct {
+	Keyword string
+
+	IsClosed optional.Option[bool]
+	IsPull   optional.Option[bool]
+
+	Labels         []string
+	ExcludedLabels []string
+	NoLabel        bool
+
+	Milestones  []string
+	NoMilestone bool
+
+	Author          string
+	Assignee        string
+	NoAssignee      bool
+	Mentions        string
+	ReviewRequested string
+	ReviewedBy      string
+
+	CreatedAfterUnix  optional.Option[int64]
+	CreatedBeforeUnix optional.Option[int64]
+	UpdatedAfterUnix  optional.Option[int64]
+	UpdatedBeforeUnix optional.Option[int64]
+
+	SortBy internal.SortBy
+
+	// filled by Resolve
+	includedLabelIDs    []int64
+	includedAnyLabelIDs []int64
+	excludedLabelIDs    []int64
+	milestoneIDs        []int64
+	posterID            optional.Option[int64]
+	assigneeID          optional.Option[int64]
+	mentionID           optional.Option[int64]
+	reviewRequestedID   optional.Option[int64]
+	reviewedID          optional.Option[int64]
+}
+
+const queryUserSelf = "@me"
+
+var querySortTypes = map[string]internal.SortBy{
+	"created":       internal.SortByCreatedDesc,
+	"created-desc":  internal.SortByCreatedDesc,
+	"created-asc":   internal.SortByCreatedAsc,
+	"updated":       internal.SortByUpdatedDesc,
+	"updated-desc":  internal.SortByUpdatedDesc,
+	"updated-asc":   internal.SortByUpdatedAsc,
+	"comments":      internal.SortByCommentsDesc,
+	"comments-desc": internal.SortByCommentsDesc,
+	"comments-asc":  internal.SortByCommentsAsc,
+	"deadline":      internal.SortByDeadlineAsc,
+	"deadline-asc":  internal.SortByDeadlineAsc,
+	"deadline-desc": internal.SortByDeadlineDesc,
+}
+
+// queryTerm is a whitespace separated part of a query, quotes are kept in raw
+type queryTerm struct {
+	raw     string
+	negated bool
+	key     string
+	value   string
+}
+
+// splitQueryTerms splits a query by whitespaces which are not quoted
+func splitQueryTerms(s string) []string {
+	var terms []string
+	start, inQuote := -1, false
+	for i, c := range s {
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case !inQuote && (c == ' ' || c == '\t' || c == '\n' || c == '\r'):
+			if start >= 0 {
+				terms = append(terms, s[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		terms = append(terms, s[start:])
+	}
+	return terms
+}
+
+func isQueryQualifier(key string) bool {
+	switch key {
+	case "is", "no", "label", "milestone", "author", "assignee", "mentions", "review-requested", "reviewed-by", "created", "updated", "sort":
+		return true
+	}
+	return false
+}
+
+// parseQueryTerm returns nil if the term is not a qualifier but a part of the keyword
+func parseQueryTerm(raw string) (*queryTerm, error) {
+	term := &queryTerm{raw: raw}
+	s := raw
+	if len(s) > 1 && s[0] == '-' {
+		term.negated = true
+		s = s[1:]
+	}
+	key, value, ok := strings.Cut(s, ":")
+	if !ok || strings.Contains(key, `"`) {
+		return nil, nil
+	}
+	term.key = strings.ToLower(key)
+	if !isQueryQualifier(term.key) {
+		return nil, nil
+	}
+	if strings.Count(value, `"`)%2 != 0 {
+		return nil, ErrInvalidQuery{Term: raw, Msg: "unterminated quote"}
+	}
+	term.value = strings.ReplaceAll(value, `"`, "")
+	if term.value == "" {
+		return nil, ErrInvalidQuery{Term: raw, Msg: "missing value"}
+	}
+	if term.negated && term.key != "is" && term.key != "label" {
+		return nil, ErrInvalidQuery{Term: raw, Msg: fmt.Sprintf("%s: can't be negated", term.key)}
+	}
+	return term, nil
+}
+
+// ParseQuery parses an issue search query, it returns ErrInvalidQuery if the query has syntax errors.
+// A query without any qualifier is returned as a plain keyword.
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{}
+	var keywords []string
+	hasQualifiers := false
+	for _, raw := range splitQueryTerms(s) {
+		term, err := parseQueryTerm(raw)
+		if err != nil {
+			return nil, err
+		}
+		if term == nil {
+			keywords = append(keywords, raw)
+			continue
+		}
+		hasQualifiers = true
+		if err := q.addTerm(term); err != nil {
+			return nil, err
+		}
+	}
+	if hasQualifiers {
+		q.Keyword = strings.Join(keywords, " ")
+	} else {
+		q.Keyword = strings.TrimSpace(s)
+	}
+	return q, nil
+}
+
+func setQueryOption[T comparable](term *queryTerm, opt *optional.Option[T], v T) error {
+	if opt.Has() && opt.Value() != v {
+		return ErrInvalidQuery{Term: term.raw, Msg: fmt.Sprintf("conflicts with another %s: qualifier", term.key)}
+	}
+	*opt = optional.Some(v)
+	return nil
+}
+
+func setQueryUser(term *queryTerm, name *string) error {
+	if *name != "" && !strings.EqualFold(*name, term.value) {
+		return ErrInvalidQuery{Term: term.raw, Msg: fmt.Sprintf("only one %s: qualifier is allowed", term.key)}
+	}
+	*name = term.value
+	return nil
+}
+
+func (q *Query) addTerm(term *queryTerm) error {
+	switch term.key {
+	case "is":
+		switch strings.ToLower(term.value) {
+		case "open":
+			return setQueryOption(term, &q.IsClosed, term.negated)
+		case "closed":
+			return setQueryOption(term, &q.IsClosed, !term.negated)
+		case "issue":
+			return setQueryOption(term, &q.IsPull, term.negated)
+		case "pr", "pull":
+			return setQueryOption(term, &q.IsPull, !term.negated)
+		}
+		return ErrInvalidQuery{Term: term.raw, Msg: "expected one of open, closed, issue, pr"}
+	case "no":
+		switch strings.ToLower(term.value) {
+		case "label":
+			q.NoLabel = true
+		case "milestone":
+			q.NoMilestone = true
+		case "assignee":
+			if q.Assignee != "" {
+				return ErrInvalidQuery{Term: term.raw, Msg: "conflicts with the assignee: qualifier"}
+			}
+			q.NoAssignee = true
+		default:
+			return ErrInvalidQuery{Term: term.raw, Msg: "expected one of label, milestone, assignee"}
+		}
+	case "label":
+		if term.negated {
+			q.ExcludedLabels = append(q.ExcludedLabels, term.value)
+		} else {
+			q.Labels = append(q.Labels, term.value)
+		}
+	case "milestone":
+		q.Milestones = append(q.Milestones, term.value)
+	case "author":
+		return setQueryUser(term, &q.Author)
+	case "assignee":
+		if q.NoAssignee {
+			return ErrInvalidQuery{Term: term.raw, Msg: "conflicts with the no:assignee qualifier"}
+		}
+		return setQueryUser(term, &q.Assignee)
+	case "mentions":
+		return setQueryUser(term, &q.Mentions)
+	case "review-requested":
+		return setQueryUser(term, &q.ReviewRequested)
+	case "reviewed-by":
+		return setQueryUser(term, &q.ReviewedBy)
+	case "created":
+		return parseQueryTimeRange(term, &q.CreatedAfterUnix, &q.CreatedBeforeUnix)
+	case "updated":
+		return parseQueryTimeRange(term, &q.UpdatedAfterUnix, &q.UpdatedBeforeUnix)
+	case "sort":
+		sortBy, ok := querySortTypes[strings.ToLower(term.value)]
+		if !ok {
+			return ErrInvalidQuery{Term: term.raw, Msg: "expected one of created, updated, comments, deadline with an optional -asc or -desc suffix"}
+		}
+		if q.SortBy != "" && q.SortBy != sortBy {
+			return ErrInvalidQuery{Term: term.raw, Msg: "only one sort: qualifier is allowed"}
+		}
+		q.SortBy = sortBy
+	}
+	return nil
+}
+
+// parseQueryTime parses a date or a time, it returns the first second of it and the first second after it
+func parseQueryTime(s string) (start, next int64, ok bool) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, setting.DefaultUILocation); err == nil {
+		return t.Unix(), t.AddDate(0, 0, 1).Unix(), true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Unix(), t.Unix() + 1, true
+	}
+	return 0, 0, false
+}
+
+// parseQueryTimeRange parses ">D", ">=D", "<D", "<=D", "D", "D1..D2", "D1..*" and "*..D2",
+// the results are inclusive bounds in unix seconds.
+func parseQueryTimeRange(term *queryTerm, after, before *optional.Option[int64]) error {
+	invalid := ErrInvalidQuery{Term: term.raw, Msg: "expected a date (YYYY-MM-DD) or RFC 3339 time, optionally prefixed by >, >=, < or <=, or a range like D1..D2"}
+	if after.Has() || before.Has() {
+		return ErrInvalidQuery{Term: term.raw, Msg: fmt.Sprintf("only one %s: qualifier is allowed", term.key)}
+	}
+
+	value := term.value
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		if from != "*" {
+			start, _, ok := parseQueryTime(from)
+			if !ok {
+				return invalid
+			}
+			*after = optional.Some(start)
+		}
+		if to != "*" {
+			_, next, ok := parseQueryTime(to)
+			if !ok {
+				return invalid
+			}
+			*before = optional.Some(next - 1)
+		}
+		if from == "*" && to == "*" {
+			return invalid
+		}
+		if after.Has() && before.Has() && after.Value() > before.Value() {
+			return ErrInvalidQuery{Term: term.raw, Msg: "the start of the range is after its end"}
+		}
+		return nil
+	}
+
+	var op string
+	for _, prefix := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, prefix) {
+			op, value = prefix, value[len(prefix):]
+			break
+		}
+	}
+	start, next, ok := parseQueryTime(value)
+	if !ok {
+		return invalid
+	}
+	switch op {
+	case ">=":
+		*after = optional.Some(start)
+	case ">":
+		*after = optional.Some(next)
+	case "<=":
+		*before = optional.Some(next - 1)
+	case "<":
+		*before = optional.Some(start - 1)
+	default:
+		*after, *before = optional.Some(start), optional.Some(next-1)
+	}
+	return nil
+}
+
+func resolveQueryUser(ctx context.Context, doer *user_model.User, key, name string) (optional.Option[int64], error) {
+	if name == "" {
+		return optional.None[int64](), nil
+	}
+	if name == queryUserSelf {
+		if doer == nil {
+			return nil, ErrInvalidQuery{Term: key + ":" + name, Msg: "requires signing in"}
+		}
+		return optional.Some(doer.ID), nil
+	}
+	u, err := user_model.GetUserByName(ctx, name)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil, ErrInvalidQuery{Term: key + ":" + name, Msg: "user does not exist"}
+		}
+		return nil, err
+	}
+	return optional.Some(u.ID), nil
+}
+
+// Resolve looks up the labels, milestones and users referenced by the query.
+// Labels are looked up in the given repositories and their owner organizations, milestones in the given repositories,
+// or everywhere if repoIDs is empty.
+// "@me" refers to the doer, which could be nil for anonymous users.
+func (q *Query) Resolve(ctx context.Context, doer *user_model.User, repoIDs []int64) (err error) {
+	if q.posterID, err = resolveQueryUser(ctx, doer, "author", q.Author); err != nil {
+		return err
+	}
+	if q.assigneeID, err = resolveQueryUser(ctx, doer, "assignee", q.Assignee); err != nil {
+		return err
+	}
+	if q.mentionID, err = resolveQueryUser(ctx, doer, "mentions", q.Mentions); err != nil {
+		return err
+	}
+	if q.reviewRequestedID, err = resolveQueryUser(ctx, doer, "review-requested", q.ReviewRequested); err != nil {
+		return err
+	}
+	if q.reviewedID, err = resolveQueryUser(ctx, doer, "reviewed-by", q.ReviewedBy); err != nil {
+		return err
+	}
+
+	if len(q.Labels) > 0 || len(q.ExcludedLabels) > 0 {
+		labels, err := issues_model.GetLabelsByNamesInRepos(ctx, repoIDs, append(slices.Clone(q.Labels), q.ExcludedLabels...))
+		if err != nil {
+			return err
+		}
+		labelIDsByName := make(map[string][]int64, len(labels))
+		for _, label := range labels {
+			labelIDsByName[label.Name] = append(labelIDsByName[label.Name], label.ID)
+		}
+
+		q.includedLabelIDs, q.includedAnyLabelIDs, q.excludedLabelIDs = nil, nil, nil
+		names := slices.Compact(slices.Sorted(slices.Values(q.Labels)))
+		for _, name := range names {
+			ids := labelIDsByName[name]
+			switch {
+			case len(ids) == 0:
+				return ErrInvalidQuery{Term: "label:" + name, Msg: "label does not exist"}
+			case len(ids) == 1:
+				q.includedLabelIDs = append(q.includedLabelIDs, ids[0])
+			case len(names) == 1:
+				// the same label name in different repositories, any of them matches
+				q.includedAnyLabelIDs = ids
+			default:
+				return ErrInvalidQuery{Term: "label:" + name, Msg: "label exists in several repositories and can't be combined with other label: qualifiers"}
+			}
+		}
+		for _, name := range q.ExcludedLabels {
+			q.excludedLabelIDs = append(q.excludedLabelIDs, labelIDsByName[name]...)
+		}
+	}
+
+	q.milestoneIDs = nil
+	for _, name := range q.Milestones {
+		ids, err := issues_model.GetMilestoneIDsByNamesInRepos(ctx, repoIDs, []string{name})
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return ErrInvalidQuery{Term: "milestone:" + name, Msg: "milestone does not exist"}
+		}
+		q.milestoneIDs = append(q.milestoneIDs, ids...)
+	}
+	return nil
+}
+
+// Apply overrides the search options with the filters of the query, Resolve must have been called before.
+func (q *Query) Apply(opts *SearchOptions) {
+	opts.Keyword = q.Keyword
+
+	if q.IsClosed.Has() {
+		opts.IsClosed = q.IsClosed
+	}
+	if q.IsPull.Has() {
+		opts.IsPull = q.IsPull
+	}
+
+	if q.NoLabel {
+		opts.NoLabelOnly = true
+	}
+	opts.IncludedLabelIDs = append(opts.IncludedLabelIDs, q.includedLabelIDs...)
+	if len(q.includedAnyLabelIDs) > 0 {
+		opts.IncludedAnyLabelIDs = q.includedAnyLabelIDs
+	}
+	opts.ExcludedLabelIDs = append(opts.ExcludedLabelIDs, q.excludedLabelIDs...)
+
+	if q.NoMilestone {
+		opts.MilestoneIDs = []int64{0}
+	} else if len(q.milestoneIDs) > 0 {
+		opts.MilestoneIDs = q.milestoneIDs
+	}
+
+	if q.posterID.Has() {
+		opts.PosterID = q.posterID
+	}
+	if q.NoAssignee {
+		opts.AssigneeID = optional.Some[int64](0)
+	} else if q.assigneeID.Has() {
+		opts.AssigneeID = q.assigneeID
+	}
+	if q.mentionID.Has() {
+		opts.MentionID = q.mentionID
+	}
+	if q.reviewRequestedID.Has() {
+		opts.ReviewRequestedID = q.reviewRequestedID
+	}
+	if q.reviewedID.Has() {
+		opts.ReviewedID = q.reviewedID
+	}
+
+	if q.CreatedAfterUnix.Has() {
+		opts.CreatedAfterUnix = q.CreatedAfterUnix
+	}
+	if q.CreatedBeforeUnix.Has() {
+		opts.CreatedBeforeUnix = q.CreatedBeforeUnix
+	}
+	if q.UpdatedAfterUnix.Has() {
+		opts.UpdatedAfterUnix = q.UpdatedAfterUnix
+	}
+	if q.UpdatedBeforeUnix.Has() {
+		opts.UpdatedBeforeUnix = q.UpdatedBeforeUnix
+	}
+
+	if q.SortBy != "" {
+		opts.SortBy = q.SortBy
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	defer test.MockVariableValue(&setting.DefaultUILocation, time.UTC)()
+
+	jan1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	jan2 := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC).Unix()
+	feb1 := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	cases := []struct {
+		query    string
+		expected *Query
+	}{
+		{
+			query:    "  crash on   start ",
+			expected: &Query{Keyword: "crash on   start"},
+		},
+		{
+			query:    "http://example.com -foo",
+			expected: &Query{Keyword: "http://example.com -foo"},
+		},
+		{
+			query: `is:open is:pr label:bug -label:wontfix label:"needs review" author:alice assignee:@me milestone:"v2" crash`,
+			expected: &Query{
+				Keyword:        "crash",
+				IsClosed:       optional.Some(false),
+				IsPull:         optional.Some(true),
+				Labels:         []string{"bug", "needs review"},
+				ExcludedLabels: []string{"wontfix"},
+				Milestones:     []string{"v2"},
+				Author:         "alice",
+				Assignee:       "@me",
+			},
+		},
+		{
+			query:    "-is:open -is:pr no:label no:milestone no:assignee",
+			expected: &Query{IsClosed: optional.Some(true), IsPull: optional.Some(false), NoLabel: true, NoMilestone: true, NoAssignee: true},
+		},
+		{
+			query:    "mentions:bob review-requested:carol reviewed-by:dave sort:updated-desc",
+			expected: &Query{Mentions: "bob", ReviewRequested: "carol", ReviewedBy: "dave", SortBy: SortByUpdatedDesc},
+		},
+		{
+			query:    "created:>2025-01-01 updated:<=2025-01-01",
+			expected: &Query{CreatedAfterUnix: optional.Some(jan2), UpdatedBeforeUnix: optional.Some(jan2 - 1)},
+		},
+		{
+			query:    "created:>=2025-01-01 updated:<2025-01-01",
+			expected: &Query{CreatedAfterUnix: optional.Some(jan1), UpdatedBeforeUnix: optional.Some(jan1 - 1)},
+		},
+		{
+			query:    "created:2025-01-01 updated:2025-01-01..2025-01-31",
+			expected: &Query{CreatedAfterUnix: optional.Some(jan1), CreatedBeforeUnix: optional.Some(jan2 - 1), UpdatedAfterUnix: optional.Some(jan1), UpdatedBeforeUnix: optional.Some(feb1 - 1)},
+		},
+		{
+			query:    "created:*..2025-01-01 updated:>2025-01-01T00:00:00Z",
+			expected: &Query{CreatedBeforeUnix: optional.Some(jan2 - 1), UpdatedAfterUnix: optional.Some(jan1 + 1)},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			q, err := ParseQuery(c.query)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, q)
+		})
+	}
+
+	invalid := []struct {
+		query string
+		term  string
+	}{
+		{query: "is:merged", term: "is:merged"},
+		{query: "is:open is:closed", term: "is:closed"},
+		{query: "label:", term: "label:"},
+		{query: `label:"bug`, term: `label:"bug`},
+		{query: "-author:alice", term: "-author:alice"},
+		{query: "author:alice author:bob", term: "author:bob"},
+		{query: "assignee:alice no:assignee", term: "no:assignee"},
+		{query: "no:project", term: "no:project"},
+		{query: "created:yesterday", term: "created:yesterday"},
+		{query: "created:2025-02-01..2025-01-01", term: "created:2025-02-01..2025-01-01"},
+		{query: "created:>2025-01-01 created:<2025-02-01", term: "created:<2025-02-01"},
+		{query: "sort:priority", term: "sort:priority"},
+	}
+	for _, c := range invalid {
+		t.Run(c.query, func(t *testing.T) {
+			_, err := ParseQuery(c.query)
+			require.Error(t, err)
+			assert.True(t, IsErrInvalidQuery(err))
+			assert.Equal(t, c.term, err.(ErrInvalidQuery).Term)
+		})
+	}
+}
+
+func TestQueryResolveAndApply(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	q, err := ParseQuery("label:label1 -label:label2 -label:unknown milestone:milestone1 author:user1 assignee:@me sort:comments-asc bug")
+	require.NoError(t, err)
+	require.NoError(t, q.Resolve(t.Context(), doer, []int64{1}))
+
+	opts := &SearchOptions{RepoIDs: []int64{1}, Keyword: "ignored", SortBy: SortByCreatedDesc}
+	q.Apply(opts)
+	assert.Equal(t, &SearchOptions{
+		Keyword:          "bug",
+		RepoIDs:          []int64{1},
+		IncludedLabelIDs: []int64{1},
+		ExcludedLabelIDs: []int64{2},
+		MilestoneIDs:     []int64{1},
+		PosterID:         optional.Some[int64](1),
+		AssigneeID:       optional.Some[int64](2),
+		SortBy:           SortByCommentsAsc,
+	}, opts)
+
+	for _, query := range []string{"label:unknown", "milestone:unknown", `milestone:"milestone of repo42"`, "author:unknown-user"} {
+		q, err := ParseQuery(query)
+		require.NoError(t, err)
+		err = q.Resolve(t.Context(), doer, []int64{1})
+		assert.True(t, IsErrInvalidQuery(err), query)
+	}
+
+	q, err = ParseQuery("author:@me")
+	require.NoError(t, err)
+	assert.True(t, IsErrInvalidQuery(q.Resolve(t.Context(), nil, []int64{1})))
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	db_model "code.gitea.io/gitea/models/db"
+	issue_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+)
+
+// rebuildBatchSize is the number of issues indexed at once while rebuilding
+const rebuildBatchSize = 100
+
+// ErrRebuildInProgress is returned when rebuilding the issue indexer while it's already being rebuilt
+var ErrRebuildInProgress = errors.New("the issue indexer is already being rebuilt")
+
+var (
+	// rebuildProgress is the progress of the running rebuild, it's nil if the indexer isn't being rebuilt
+	rebuildProgress atomic.Pointer[indexer.Progress]
+	// rebuildingIndexer is the generation of the index built by the running rebuild,
+	// the changed issues are indexed into it besides the global indexer
+	rebuildingIndexer atomic.Pointer[internal.Indexer]
+	// generationRefs counts the users of the generations loaded from globalIndexer and rebuildingIndexer,
+	// so a generation which was replaced is only destroyed after the searches and indexing using it completed
+	generationRefs indexer.GenerationRefs[internal.Indexer]
+)
+
+// RebuildProgress returns the progress of the running rebuild of the issue indexer, or nil if it isn't being rebuilt
+func RebuildProgress() *indexer.Progress {
+	return rebuildProgress.Load()
+}
+
+// RebuildIssueIndexer reindexes all the issues. If the indexer supports it, a new generation of the index is built
+// beside the one in use and replaces it when it's complete, so the search isn't degraded while rebuilding.
+// Otherwise, the issues are reindexed in place.
+func RebuildIssueIndexer(ctx context.Context) error {
+	total, err := db_model.CountByBean(ctx, &issue_model.Issue{})
+	if err != nil {
+		return err
+	}
+	progress := indexer.NewProgress(total)
+	if !rebuildProgress.CompareAndSwap(nil, progress) {
+		return ErrRebuildInProgress
+	}
+	defer rebuildProgress.Store(nil)
+
+	livePtr := globalIndexer.Load()
+	live := *livePtr
+	generationIndexer, ok := live.(internal.GenerationIndexer)
+	if !ok {
+		return populateIndexer(ctx, live, progress)
+	}
+
+	next, err := generationIndexer.NewGeneration(ctx)
+	if err != nil {
+		return fmt.Errorf("create a new generation of the issue index: %w", err)
+	}
+	nextIndexer := internal.Indexer(next)
+	rebuildingIndexer.Store(&nextIndexer)
+
+	err = populateIndexer(ctx, next, progress)
+	if err == nil {
+		err = next.CompleteGeneration(ctx)
+	}
+	if err != nil {
+		rebuildingIndexer.Store(nil)
+		generationRefs.WaitReleased(&nextIndexer)
+		if err := next.Destroy(ctx); err != nil {
+			log.Error("Unable to remove the incomplete generation of the issue index: %v", err)
+		}
+		return err
+	}
+
+	// the new generation is used before it stops being the rebuilding one, so no changed issue misses it
+	globalIndexer.Store(&nextIndexer)
+	rebuildingIndexer.Store(nil)
+	generationRefs.WaitReleased(livePtr)
+	if err := generationIndexer.Destroy(ctx); err != nil {
+		log.Error("Unable to remove the replaced generation of the issue index: %v", err)
+	}
+
+	log.Info("Issue indexer rebuilt with %d issues in %v", progress.Done(), time.Since(progress.Started))
+	return nil
+}
+
+// useGlobalIndexer returns the global indexer, release must be called when it isn't used anymore
+func useGlobalIndexer() (ix internal.Indexer, release func()) {
+	gens, release := generationRefs.Use(globalIndexer.Load)
+	return *gens[0], release
+}
+
+// activeIndexers returns the indexers which the changed issues are indexed into:
+// the global indexer and the generation being rebuilt if there is one.
+// release must be called when they aren't used anymore.
+func activeIndexers() (ixs indexers, release func()) {
+	// the rebuilding generation is loaded first, because it becomes the global indexer before it stops being the rebuilding one
+	gens, release := generationRefs.Use(rebuildingIndexer.Load, globalIndexer.Load)
+	rebuilding, global := gens[0], gens[1]
+	ixs = indexers{*global}
+	if rebuilding != nil && *rebuilding != ixs[0] {
+		ixs = append(ixs, *rebuilding)
+	}
+	return ixs, release
+}
+
+type indexers []internal.Indexer
+
+func (ixs indexers) Index(ctx context.Context, issues ...*internal.IndexerData) error {
+	for _, ix := range ixs {
+		if err := ix.Index(ctx, issues...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ixs indexers) Delete(ctx context.Context, ids ...int64) error {
+	for _, ix := range ixs {
+		if err := ix.Delete(ctx, ids...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// populateIndexer indexes the issues of all the repositories into the indexer directly instead of pushing them to the queue
+func populateIndexer(ctx context.Context, ix internal.Indexer, progress *indexer.Progress) error {
+	for page := 1; ; page++ {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("shutdown before completion: %w", ctx.Err())
+		default:
+		}
+		repos, _, err := repo_model.SearchRepositoryByName(ctx, &repo_model.SearchRepoOptions{
+			ListOptions: db_model.ListOptions{Page: page, PageSize: repo_model.RepositoryListDefaultPageSize},
+			OrderBy:     db_model.SearchOrderByID,
+			Private:     true,
+			Collaborate: optional.Some(false),
+		})
+		if err != nil {
+			return fmt.Errorf("SearchRepositoryByName: %w", err)
+		}
+		if len(repos) == 0 {
+			return nil
+		}
+
+		for _, repo := range repos {
+			ids, err := issue_model.GetIssueIDsByRepoID(ctx, repo.ID)
+			if err != nil {
+				return fmt.Errorf("issue_model.GetIssueIDsByRepoID: %w", err)
+			}
+			for batch := range slices.Chunk(ids, rebuildBatchSize) {
+				issues := make([]*internal.IndexerData, 0, len(batch))
+				for _, id := range batch {
+					data, existed, err := getIssueIndexerData(ctx, id)
+					if err != nil {
+						return fmt.Errorf("get issue data of %d: %w", id, err)
+					}
+					if existed {
+						issues = append(issues, data)
+					}
+				}
+				if err := ix.Index(ctx, issues...); err != nil {
+					return fmt.Errorf("index issues of repo %d: %w", repo.ID, err)
+				}
+				progress.Add(int64(len(batch)))
+			}
+		}
+	}
+}
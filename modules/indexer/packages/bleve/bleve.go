@@ -0,0 +1,205 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
+	"code.gitea.io/gitea/modules/indexer/packages/internal"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	analyzer_keyword "github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/token/camelcase"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	packageIndexerAnalyzer      = "packageIndexer"
+	packageIndexerDocType       = "packageIndexerDocType"
+	packageIndexerLatestVersion = 1
+)
+
+const unicodeNormalizeName = "unicodeNormalize"
+
+const maxBatchSize = 16
+
+// fieldBoosts make the packages whose name or keywords match the keyword rank higher
+var fieldBoosts = map[string]float64{
+	"name":        3.0,
+	"keywords":    2.0,
+	"description": 1.0,
+	"properties":  1.0,
+}
+
+// IndexerData an update to the package indexer
+type IndexerData internal.IndexerData
+
+// Type returns the document type, for bleve's mapping.Classifier interface.
+func (i *IndexerData) Type() string {
+	return packageIndexerDocType
+}
+
+// generatePackageIndexMapping generates the bleve index mapping for packages
+func generatePackageIndexMapping() (mapping.IndexMapping, error) {
+	mapping := bleve.NewIndexMapping()
+	docMapping := bleve.NewDocumentMapping()
+
+	numericFieldMapping := bleve.NewNumericFieldMapping()
+	numericFieldMapping.Store = false
+	numericFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("owner_id", numericFieldMapping)
+
+	termFieldMapping := bleve.NewTextFieldMapping()
+	termFieldMapping.Store = false
+	termFieldMapping.IncludeInAll = false
+	termFieldMapping.Analyzer = analyzer_keyword.Name
+	docMapping.AddFieldMappingsAt("package_type", termFieldMapping)
+
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.Store = false
+	textFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("name", textFieldMapping)
+	docMapping.AddFieldMappingsAt("description", textFieldMapping)
+	docMapping.AddFieldMappingsAt("keywords", textFieldMapping)
+	docMapping.AddFieldMappingsAt("properties", textFieldMapping)
+
+	if err := mapping.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
+		"type": unicodenorm.Name,
+		"form": unicodenorm.NFC,
+	}); err != nil {
+		return nil, err
+	} else if err = mapping.AddCustomAnalyzer(packageIndexerAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{unicodeNormalizeName, camelcase.Name, lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
+	mapping.DefaultAnalyzer = packageIndexerAnalyzer
+	mapping.AddDocumentMapping(packageIndexerDocType, docMapping)
+	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
+	mapping.DefaultMapping = bleve.NewDocumentDisabledMapping() // disable default mapping, avoid indexing unexpected structs
+
+	return mapping, nil
+}
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_bleve.Indexer
+	indexer_internal.Indexer // do not composite inner_bleve.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWordsFuzzy()
+}
+
+// NewIndexer creates a new bleve local indexer
+func NewIndexer(indexDir string) *Indexer {
+	inner := inner_bleve.NewIndexer(indexDir, packageIndexerLatestVersion, generatePackageIndexMapping)
+	return &Indexer{
+		Indexer: inner,
+		inner:   inner,
+	}
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, packages ...*internal.IndexerData) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, pkg := range packages {
+		if err := batch.Index(indexer_internal.Base36(pkg.ID), (*IndexerData)(pkg)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Delete deletes packages by ids
+func (b *Indexer) Delete(_ context.Context, ids ...int64) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, id := range ids {
+		if err := batch.Delete(indexer_internal.Base36(id)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Search searches for packages by given conditions.
+// Returns the matching package IDs
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	var queries []query.Query
+
+	if options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		fuzziness := 0
+		if searchMode == indexer.SearchModeFuzzy {
+			fuzziness = inner_bleve.GuessFuzzinessByKeyword(options.Keyword)
+		}
+		fieldQueries := make([]query.Query, 0, len(fieldBoosts))
+		for _, field := range []string{"name", "keywords", "description", "properties"} {
+			if searchMode == indexer.SearchModeExact {
+				q := inner_bleve.MatchPhraseQuery(options.Keyword, field, packageIndexerAnalyzer, 0)
+				q.SetBoost(fieldBoosts[field])
+				fieldQueries = append(fieldQueries, q)
+			} else /* words and fuzzy */ {
+				q := inner_bleve.MatchAndQuery(options.Keyword, field, packageIndexerAnalyzer, fuzziness)
+				q.SetBoost(fieldBoosts[field])
+				fieldQueries = append(fieldQueries, q)
+			}
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(fieldQueries...))
+	}
+
+	if options.OwnerID != 0 {
+		queries = append(queries, inner_bleve.NumericEqualityQuery(options.OwnerID, "owner_id"))
+	}
+	if options.Type != "" {
+		typeQuery := bleve.NewTermQuery(options.Type)
+		typeQuery.SetField("package_type")
+		queries = append(queries, typeQuery)
+	}
+
+	var indexerQuery query.Query = bleve.NewConjunctionQuery(queries...)
+	if len(queries) == 0 {
+		indexerQuery = bleve.NewMatchAllQuery()
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
+	search := bleve.NewSearchRequestOptions(indexerQuery, limit, skip, false)
+	search.SortBy([]string{"-_score", "-_id"})
+
+	result, err := b.inner.Indexer.SearchInContext(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &internal.SearchResult{
+		Total: int64(result.Total),
+		Hits:  make([]internal.Match, 0, len(result.Hits)),
+	}
+	for _, hit := range result.Hits {
+		id, err := indexer_internal.ParseBase36(hit.ID)
+		if err != nil {
+			return nil, err
+		}
+		ret.Hits = append(ret.Hits, internal.Match{
+			ID:    id,
+			Score: hit.Score,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/packages/bleve"
+	"code.gitea.io/gitea/modules/indexer/packages/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/packages/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+
+	"xorm.io/builder"
+)
+
+// populateBatchSize is the number of packages pushed to the queue at once while populating the indexer
+const populateBatchSize = 50
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[int64]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+func index(ctx context.Context, indexer internal.Indexer, packageID int64) error {
+	p, err := packages_model.GetPackageByID(ctx, packageID)
+	if errors.Is(err, packages_model.ErrPackageNotExist) {
+		return indexer.Delete(ctx, packageID)
+	}
+	if err != nil {
+		return err
+	}
+	if p.IsInternal {
+		return indexer.Delete(ctx, p.ID)
+	}
+
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		PackageID:  p.ID,
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return err
+	}
+	if len(pvs) == 0 {
+		// all the versions have been deleted
+		return indexer.Delete(ctx, p.ID)
+	}
+
+	data, err := getIndexerData(ctx, p, pvs[0])
+	if err != nil {
+		return err
+	}
+	return indexer.Index(ctx, data)
+}
+
+// Init initialize the package indexer
+func Init() {
+	if !setting.Indexer.PackageIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: PackageIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing package indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Package Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.PackageType {
+	case "bleve", "elasticsearch":
+		handler := func(items ...int64) (unhandled []int64) {
+			indexer := *globalIndexer.Load()
+			for _, packageID := range items {
+				log.Trace("Package Indexer Process Package: %d", packageID)
+				if err := index(ctx, indexer, packageID); err != nil {
+					if !setting.IsInTesting {
+						log.Error("Package indexer handler: index error for package %v: %v", packageID, err)
+					}
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken package will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "package_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create package indexer queue")
+		}
+	default:
+		log.Fatal("Unknown package indexer type; %s", setting.Indexer.PackageType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			pIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.PackageType {
+		case "bleve":
+			log.Info("PID: %d Initializing Package Indexer at: %s", os.Getpid(), setting.Indexer.PackagePath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing package indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.PackagePath)
+				}
+			}()
+
+			pIndexer = bleve.NewIndexer(setting.Indexer.PackagePath)
+			existed, err = pIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the bleve Package Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.PackagePath, err)
+			}
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Package Indexer at: %s", os.Getpid(), setting.Indexer.PackageConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing package indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.PackageConnStr)
+				}
+			}()
+
+			pIndexer = elasticsearch.NewIndexer(setting.Indexer.PackageConnStr, setting.Indexer.PackageIndexerName)
+			existed, err = pIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the elasticsearch Package Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.PackageConnStr, err)
+			}
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.PackageType)
+		}
+
+		globalIndexer.Store(&pIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populatePackageIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Package Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Package Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Package Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Package Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+// UpdatePackageIndexer adds, updates or removes a package in the indexer according to its current state
+func UpdatePackageIndexer(packageID int64) {
+	if indexerQueue == nil {
+		return
+	}
+	if err := indexerQueue.Push(packageID); err != nil && err != queue.ErrAlreadyInQueue {
+		log.Error("Update package index of package %d failed: %v", packageID, err)
+	}
+}
+
+// IsAvailable checks if package indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// populatePackageIndexer populate the package indexer with the existing packages,
+// it should only be run when the indexer is created for the first time.
+func populatePackageIndexer(ctx context.Context) {
+	log.Info("Populating the package indexer with existing packages")
+
+	exist, err := db.IsTableNotEmpty("package")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	maxPackageID, err := db.GetMaxID("package")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing package ID and work backwards, so that we
+	// don't include packages that are created after gitea starts; such packages will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxPackageID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Package Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids := make([]int64, 0, populateBatchSize)
+		if err := db.GetEngine(ctx).Table("package").
+			Where(builder.Lte{"id": maxPackageID}.And(builder.Eq{"is_internal": false})).
+			Cols("id").
+			Desc("id").
+			Limit(populateBatchSize).
+			Find(&ids); err != nil {
+			log.Error("populatePackageIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			if err := indexerQueue.Push(id); err != nil && err != queue.ErrAlreadyInQueue {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxPackageID = id - 1
+		}
+	}
+	log.Info("Done populating the package indexer with existing packages")
+}
+
+// SupportedSearchModes returns the search modes of the package indexer
+func SupportedSearchModes() []indexer.SearchMode {
+	return (*globalIndexer.Load()).SupportedSearchModes()
+}
+
+type (
+	IndexerData   = internal.IndexerData
+	SearchOptions = internal.SearchOptions
+)
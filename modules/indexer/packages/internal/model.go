@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+)
+
+// IndexerData data stored in the package indexer, the metadata is the one of the latest version
+type IndexerData struct {
+	ID          int64  `json:"id"`
+	OwnerID     int64  `json:"owner_id"`
+	PackageType string `json:"package_type"`
+
+	// Fields used for keyword searching
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Keywords    []string `json:"keywords"`
+	Properties  []string `json:"properties"` // the values of the package and version properties, like module names
+}
+
+// SearchOptions represents search options
+type SearchOptions struct {
+	Keyword string // matched against the name, description, keywords and properties
+
+	SearchMode indexer.SearchModeType
+
+	OwnerID int64  // the owner of the packages, 0 means all owners
+	Type    string // the type of the packages, empty means all types
+
+	Paginator *db.ListOptions
+}
+
+// Match represents a matched package
+type Match struct {
+	ID    int64   `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// SearchResult represents search results, the hits are sorted by relevance
+type SearchResult struct {
+	Total int64
+	Hits  []Match
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// This package contains tests for package indexer modules.
+// All the code in this package is only used for testing.
+// Do not put any production code in this package to avoid it being included in the final binary.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/packages/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexer(t *testing.T, idx internal.Indexer) {
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Ping(t.Context()))
+
+	require.NoError(t, idx.Index(t.Context(), data...))
+	require.NoError(t, waitData(idx, int64(len(data))))
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.SearchOptions.SearchMode != "" && !slices.ContainsFunc(idx.SupportedSearchModes(), func(mode indexer.SearchMode) bool {
+				return mode.ModeValue == c.SearchOptions.SearchMode
+			}) {
+				t.Skipf("search mode %s is not supported", c.SearchOptions.SearchMode)
+			}
+
+			assert.Equal(t, c.ExpectedIDs, search(t, idx, c.SearchOptions))
+		})
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, idx.Delete(t.Context(), 1))
+		require.NoError(t, waitData(idx, 2))
+
+		assert.Equal(t, []int64{3}, search(t, idx, &internal.SearchOptions{Keyword: "padding", SearchMode: indexer.SearchModeWords}))
+	})
+}
+
+var data = []*internal.IndexerData{
+	{ID: 1, OwnerID: 1, PackageType: "npm", Name: "left-pad", Description: "String left pad", Keywords: []string{"string", "padding"}},
+	{ID: 2, OwnerID: 1, PackageType: "swift", Name: "mona.LisaKit", Description: "A library to draw portraits", Properties: []string{"LisaKit"}},
+	{ID: 3, OwnerID: 2, PackageType: "npm", Name: "text-utils", Description: "Utilities for padding strings and more", Keywords: []string{"text"}},
+}
+
+type testIndexerCase struct {
+	Name          string
+	SearchOptions *internal.SearchOptions
+	ExpectedIDs   []int64
+}
+
+var cases = []*testIndexerCase{
+	{
+		Name:          "empty",
+		SearchOptions: &internal.SearchOptions{Keyword: "f1dfac73-fda6-4a6b-b8a4-2408fcb8ef69", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []int64{},
+	},
+	{
+		// the match in the keywords ranks higher than the match in the description
+		Name:          "KeywordsRankHigher",
+		SearchOptions: &internal.SearchOptions{Keyword: "padding", SearchMode: indexer.SearchModeWords},
+		ExpectedIDs:   []int64{1, 3},
+	},
+	{
+		Name:          "OwnerID",
+		SearchOptions: &internal.SearchOptions{Keyword: "padding", OwnerID: 2},
+		ExpectedIDs:   []int64{3},
+	},
+	{
+		Name:          "Type",
+		SearchOptions: &internal.SearchOptions{Keyword: "left", Type: "npm"},
+		ExpectedIDs:   []int64{1},
+	},
+	{
+		Name:          "Properties",
+		SearchOptions: &internal.SearchOptions{Keyword: "LisaKit", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []int64{2},
+	},
+	{
+		Name:          "Fuzzy",
+		SearchOptions: &internal.SearchOptions{Keyword: "portrait", SearchMode: indexer.SearchModeFuzzy},
+		ExpectedIDs:   []int64{2},
+	},
+	{
+		Name:          "TypeMismatch",
+		SearchOptions: &internal.SearchOptions{Keyword: "portraits", Type: "npm"},
+		ExpectedIDs:   []int64{},
+	},
+	{
+		Name:          "AllOfOwner",
+		SearchOptions: &internal.SearchOptions{OwnerID: 1},
+		ExpectedIDs:   []int64{2, 1},
+	},
+}
+
+func search(t *testing.T, idx internal.Indexer, opts *internal.SearchOptions) []int64 {
+	result, err := idx.Search(t.Context(), opts)
+	require.NoError(t, err)
+
+	ids := make([]int64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+	assert.EqualValues(t, len(ids), result.Total)
+	return ids
+}
+
+func waitData(idx internal.Indexer, total int64) error {
+	var actual int64
+	for i := 0; i < 100; i++ {
+		result, err := idx.Search(context.Background(), &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 0,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		actual = result.Total
+		if actual == total {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("waitData: expected %d, actual %d", total, actual)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/indexer/packages/internal"
+	"code.gitea.io/gitea/modules/json"
+)
+
+// maxPropertyValueLength is the maximum length of the indexed property values,
+// the longer values are rather contents like manifests than names
+const maxPropertyValueLength = 255
+
+var (
+	// descriptionKeys are the keys of the descriptions in the metadata of the different package types
+	descriptionKeys = []string{"description", "summary", "long_description"}
+	// keywordsKeys are the keys of the keywords in the metadata of the different package types,
+	// their values are either lists or strings separated by spaces or commas
+	keywordsKeys = []string{"keywords", "tags"}
+)
+
+func getIndexerData(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) (*internal.IndexerData, error) {
+	description, keywords := parseMetadata(pv.MetadataJSON)
+
+	var properties []string
+	for _, ref := range []struct {
+		typ packages_model.PropertyType
+		id  int64
+	}{{packages_model.PropertyTypePackage, p.ID}, {packages_model.PropertyTypeVersion, pv.ID}} {
+		pps, err := packages_model.GetProperties(ctx, ref.typ, ref.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, pp := range pps {
+			properties = append(properties, pp.Value)
+		}
+	}
+
+	return &internal.IndexerData{
+		ID:          p.ID,
+		OwnerID:     p.OwnerID,
+		PackageType: string(p.Type),
+		Name:        p.Name,
+		Description: description,
+		Keywords:    keywords,
+		Properties:  filterPropertyValues(properties),
+	}, nil
+}
+
+// parseMetadata returns the description and the keywords found in the metadata of a package version
+func parseMetadata(metadataJSON string) (description string, keywords []string) {
+	var metadata map[string]any
+	if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+		// the metadata of some package types isn't an object
+		return "", nil
+	}
+
+	descriptions := make([]string, 0, len(descriptionKeys))
+	for _, key := range descriptionKeys {
+		if value, ok := metadata[key].(string); ok && strings.TrimSpace(value) != "" {
+			descriptions = append(descriptions, value)
+		}
+	}
+
+	seen := make(container.Set[string])
+	for _, key := range keywordsKeys {
+		var values []string
+		switch value := metadata[key].(type) {
+		case string:
+			values = strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+		case []any:
+			for _, v := range value {
+				if s, ok := v.(string); ok {
+					values = append(values, s)
+				}
+			}
+		}
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" && seen.Add(v) {
+				keywords = append(keywords, v)
+			}
+		}
+	}
+
+	return strings.Join(descriptions, "\n"), keywords
+}
+
+// filterPropertyValues removes the empty, duplicated and too long property values
+func filterPropertyValues(values []string) []string {
+	seen := make(container.Set[string])
+	filtered := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || len(value) > maxPropertyValueLength || !seen.Add(value) {
+			continue
+		}
+		filtered = append(filtered, value)
+	}
+	return filtered
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetadata(t *testing.T) {
+	description, keywords := parseMetadata(`{"description":"A package","summary":"Short","keywords":["a","b"],"tags":"b, c d"}`)
+	assert.Equal(t, "A package\nShort", description)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, keywords)
+
+	description, keywords = parseMetadata(`{"long_description":"Long","tags":["x",1]}`)
+	assert.Equal(t, "Long", description)
+	assert.Equal(t, []string{"x"}, keywords)
+
+	description, keywords = parseMetadata(`null`)
+	assert.Empty(t, description)
+	assert.Empty(t, keywords)
+
+	description, keywords = parseMetadata(`["not", "an", "object"]`)
+	assert.Empty(t, description)
+	assert.Empty(t, keywords)
+}
+
+func TestFilterPropertyValues(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, filterPropertyValues([]string{"a", "", "b", "a", strings.Repeat("x", maxPropertyValueLength+1)}))
+}
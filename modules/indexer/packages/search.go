@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+)
+
+// maxSearchCandidates is the maximum number of the most relevant packages checked against the visibility filter,
+// the less relevant ones aren't returned
+const maxSearchCandidates = 1000
+
+// SearchPackages searches the packages by the given options and returns the latest versions of the matched packages
+// of the requested page sorted by relevance and the total count.
+// The index doesn't know who can see the packages, so only the packages whose latest versions are found by the filter,
+// together with the access scope of the context, are returned.
+func SearchPackages(ctx context.Context, opts *SearchOptions, filter *packages_model.PackageSearchOptions) ([]*packages_model.PackageVersion, int64, error) {
+	candidatesOpts := *opts
+	candidatesOpts.Paginator = &db.ListOptions{Page: 1, PageSize: maxSearchCandidates}
+	res, err := (*globalIndexer.Load()).Search(ctx, &candidatesOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(res.Hits) == 0 {
+		return nil, 0, nil
+	}
+
+	ids := make([]int64, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ID)
+	}
+	filterOpts := *filter
+	filterOpts.PackageIDs = ids
+	filterOpts.Paginator = nil
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, &filterOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	byPackageID := make(map[int64]*packages_model.PackageVersion, len(pvs))
+	for _, pv := range pvs {
+		byPackageID[pv.PackageID] = pv
+	}
+	matched := make([]*packages_model.PackageVersion, 0, len(pvs))
+	for _, id := range ids {
+		if pv, ok := byPackageID[id]; ok {
+			matched = append(matched, pv)
+		}
+	}
+
+	total := int64(len(matched))
+	if opts.Paginator != nil && !opts.Paginator.IsListAll() {
+		skip, limit := opts.Paginator.GetSkipTake()
+		matched = matched[min(skip, len(matched)):min(skip+limit, len(matched))]
+	}
+	return matched, total, nil
+}
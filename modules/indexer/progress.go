@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Progress is the progress of rebuilding an index, it's safe to be updated and read concurrently
+type Progress struct {
+	Started time.Time
+
+	total atomic.Int64
+	done  atomic.Int64
+}
+
+// NewProgress starts the progress of rebuilding an index with the given number of items
+func NewProgress(total int64) *Progress {
+	p := &Progress{Started: time.Now()}
+	p.total.Store(total)
+	return p
+}
+
+// Add records that n more items have been indexed
+func (p *Progress) Add(n int64) {
+	p.done.Add(n)
+}
+
+// Total returns the number of items to index, it's an estimation because items may be added while rebuilding
+func (p *Progress) Total() int64 {
+	return max(p.total.Load(), p.done.Load())
+}
+
+// Done returns the number of items already indexed
+func (p *Progress) Done() int64 {
+	return p.done.Load()
+}
+
+// ETA returns the estimated duration until all the items are indexed, or 0 if it's unknown yet
+func (p *Progress) ETA() time.Duration {
+	done, total := p.Done(), p.Total()
+	if done == 0 {
+		return 0
+	}
+	elapsed := time.Since(p.Started)
+	return time.Duration(float64(elapsed) * float64(total-done) / float64(done)).Truncate(time.Second)
+}
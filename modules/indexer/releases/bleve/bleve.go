@@ -0,0 +1,220 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"context"
+	"math"
+
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
+	"code.gitea.io/gitea/modules/indexer/releases/internal"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/camelcase"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	releaseIndexerAnalyzer      = "releaseIndexer"
+	releaseIndexerDocType       = "releaseIndexerDocType"
+	releaseIndexerLatestVersion = 1
+)
+
+const unicodeNormalizeName = "unicodeNormalize"
+
+const maxBatchSize = 16
+
+// fieldBoosts make the releases whose tag name or title matches the keyword rank higher
+var fieldBoosts = map[string]float64{
+	"tag_name":    2.0,
+	"title":       2.0,
+	"note":        1.0,
+	"attachments": 1.0,
+}
+
+// IndexerData an update to the release indexer
+type IndexerData internal.IndexerData
+
+// Type returns the document type, for bleve's mapping.Classifier interface.
+func (i *IndexerData) Type() string {
+	return releaseIndexerDocType
+}
+
+// generateReleaseIndexMapping generates the bleve index mapping for releases
+func generateReleaseIndexMapping() (mapping.IndexMapping, error) {
+	mapping := bleve.NewIndexMapping()
+	docMapping := bleve.NewDocumentMapping()
+
+	numericFieldMapping := bleve.NewNumericFieldMapping()
+	numericFieldMapping.Store = false
+	numericFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("repo_id", numericFieldMapping)
+	docMapping.AddFieldMappingsAt("created_unix", numericFieldMapping)
+
+	boolFieldMapping := bleve.NewBooleanFieldMapping()
+	boolFieldMapping.Store = false
+	boolFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("is_prerelease", boolFieldMapping)
+
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.Store = false
+	textFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("tag_name", textFieldMapping)
+	docMapping.AddFieldMappingsAt("title", textFieldMapping)
+	docMapping.AddFieldMappingsAt("note", textFieldMapping)
+	docMapping.AddFieldMappingsAt("attachments", textFieldMapping)
+
+	if err := mapping.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
+		"type": unicodenorm.Name,
+		"form": unicodenorm.NFC,
+	}); err != nil {
+		return nil, err
+	} else if err = mapping.AddCustomAnalyzer(releaseIndexerAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{unicodeNormalizeName, camelcase.Name, lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
+	mapping.DefaultAnalyzer = releaseIndexerAnalyzer
+	mapping.AddDocumentMapping(releaseIndexerDocType, docMapping)
+	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
+	mapping.DefaultMapping = bleve.NewDocumentDisabledMapping() // disable default mapping, avoid indexing unexpected structs
+
+	return mapping, nil
+}
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_bleve.Indexer
+	indexer_internal.Indexer // do not composite inner_bleve.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWordsFuzzy()
+}
+
+// NewIndexer creates a new bleve local indexer
+func NewIndexer(indexDir string) *Indexer {
+	inner := inner_bleve.NewIndexer(indexDir, releaseIndexerLatestVersion, generateReleaseIndexMapping)
+	return &Indexer{
+		Indexer: inner,
+		inner:   inner,
+	}
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, releases ...*internal.IndexerData) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, release := range releases {
+		if err := batch.Index(indexer_internal.Base36(release.ID), (*IndexerData)(release)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Delete deletes releases by ids
+func (b *Indexer) Delete(_ context.Context, ids ...int64) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, id := range ids {
+		if err := batch.Delete(indexer_internal.Base36(id)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// DeleteRepo deletes the releases of a repository
+func (b *Indexer) DeleteRepo(ctx context.Context, repoID int64) error {
+	searchRequest := bleve.NewSearchRequestOptions(inner_bleve.NumericEqualityQuery(repoID, "repo_id"), math.MaxInt32, 0, false)
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return err
+	}
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, hit := range result.Hits {
+		if err := batch.Delete(hit.ID); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Search searches for releases by given conditions.
+// Returns the matching release IDs
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	var queries []query.Query
+
+	if options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		fuzziness := 0
+		if searchMode == indexer.SearchModeFuzzy {
+			fuzziness = inner_bleve.GuessFuzzinessByKeyword(options.Keyword)
+		}
+		fieldQueries := make([]query.Query, 0, len(fieldBoosts))
+		for _, field := range []string{"tag_name", "title", "note", "attachments"} {
+			if searchMode == indexer.SearchModeExact {
+				q := inner_bleve.MatchPhraseQuery(options.Keyword, field, releaseIndexerAnalyzer, 0)
+				q.SetBoost(fieldBoosts[field])
+				fieldQueries = append(fieldQueries, q)
+			} else /* words and fuzzy */ {
+				q := inner_bleve.MatchAndQuery(options.Keyword, field, releaseIndexerAnalyzer, fuzziness)
+				q.SetBoost(fieldBoosts[field])
+				fieldQueries = append(fieldQueries, q)
+			}
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(fieldQueries...))
+	}
+
+	if len(options.RepoIDs) > 0 {
+		repoQueries := make([]query.Query, 0, len(options.RepoIDs))
+		for _, repoID := range options.RepoIDs {
+			repoQueries = append(repoQueries, inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(repoQueries...))
+	}
+
+	var indexerQuery query.Query = bleve.NewConjunctionQuery(queries...)
+	if len(queries) == 0 {
+		indexerQuery = bleve.NewMatchAllQuery()
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
+	search := bleve.NewSearchRequestOptions(indexerQuery, limit, skip, false)
+	search.SortBy([]string{"-_score", "-created_unix", "-_id"})
+
+	result, err := b.inner.Indexer.SearchInContext(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &internal.SearchResult{
+		Total: int64(result.Total),
+		Hits:  make([]internal.Match, 0, len(result.Hits)),
+	}
+	for _, hit := range result.Hits {
+		id, err := indexer_internal.ParseBase36(hit.ID)
+		if err != nil {
+			return nil, err
+		}
+		ret.Hits = append(ret.Hits, internal.Match{
+			ID:    id,
+			Score: hit.Score,
+		})
+	}
+	return ret, nil
+}
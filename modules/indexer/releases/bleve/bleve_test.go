@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer/releases/internal/tests"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+)
+
+func TestBleveIndexer(t *testing.T) {
+	defer test.MockVariableValue(&setting.Indexer.TypeBleveMaxFuzzniess, 2)()
+
+	indexer := NewIndexer(t.TempDir())
+	defer indexer.Close()
+
+	tests.TestIndexer(t, indexer)
+}
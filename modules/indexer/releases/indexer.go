@@ -0,0 +1,335 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package releases
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/releases/bleve"
+	"code.gitea.io/gitea/modules/indexer/releases/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/releases/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+
+	"xorm.io/builder"
+)
+
+// populateBatchSize is the number of releases pushed to the queue at once while populating the indexer
+const populateBatchSize = 50
+
+// IndexerMetadata is the item of the indexer queue, it's either a release to reindex or a deleted repository
+type IndexerMetadata struct {
+	ID            int64 `json:"id"`
+	DeletedRepoID int64 `json:"deleted_repo_id"`
+}
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[*IndexerMetadata]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+func index(ctx context.Context, indexer internal.Indexer, item *IndexerMetadata) error {
+	if item.DeletedRepoID > 0 {
+		return indexer.DeleteRepo(ctx, item.DeletedRepoID)
+	}
+
+	rel, err := repo_model.GetReleaseByID(ctx, item.ID)
+	if repo_model.IsErrReleaseNotExist(err) {
+		return indexer.Delete(ctx, item.ID)
+	}
+	if err != nil {
+		return err
+	}
+	// drafts are only visible to the writers of the repository and tags have no release notes
+	if rel.IsDraft || rel.IsTag {
+		return indexer.Delete(ctx, rel.ID)
+	}
+
+	if err := repo_model.GetReleaseAttachments(ctx, rel); err != nil {
+		return err
+	}
+	attachments := make([]string, 0, len(rel.Attachments))
+	for _, attachment := range rel.Attachments {
+		attachments = append(attachments, attachment.Name)
+	}
+
+	return indexer.Index(ctx, &internal.IndexerData{
+		ID:           rel.ID,
+		RepoID:       rel.RepoID,
+		TagName:      rel.TagName,
+		Title:        rel.Title,
+		Note:         rel.Note,
+		Attachments:  attachments,
+		IsPrerelease: rel.IsPrerelease,
+		CreatedUnix:  rel.CreatedUnix,
+	})
+}
+
+// Init initialize the release indexer
+func Init() {
+	if !setting.Indexer.ReleaseIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: ReleaseIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing release indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Release Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.ReleaseType {
+	case "bleve", "elasticsearch":
+		handler := func(items ...*IndexerMetadata) (unhandled []*IndexerMetadata) {
+			indexer := *globalIndexer.Load()
+			for _, item := range items {
+				log.Trace("Release Indexer Process: %+v", item)
+				if err := index(ctx, indexer, item); err != nil {
+					if !setting.IsInTesting {
+						log.Error("Release indexer handler: index error for %+v: %v", item, err)
+					}
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken release will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "release_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create release indexer queue")
+		}
+	default:
+		log.Fatal("Unknown release indexer type; %s", setting.Indexer.ReleaseType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			rIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.ReleaseType {
+		case "bleve":
+			log.Info("PID: %d Initializing Release Indexer at: %s", os.Getpid(), setting.Indexer.ReleasePath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing release indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.ReleasePath)
+				}
+			}()
+
+			rIndexer = bleve.NewIndexer(setting.Indexer.ReleasePath)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the bleve Release Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.ReleasePath, err)
+			}
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Release Indexer at: %s", os.Getpid(), setting.Indexer.ReleaseConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing release indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.ReleaseConnStr)
+				}
+			}()
+
+			rIndexer = elasticsearch.NewIndexer(setting.Indexer.ReleaseConnStr, setting.Indexer.ReleaseIndexerName)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the elasticsearch Release Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.ReleaseConnStr, err)
+			}
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.ReleaseType)
+		}
+
+		globalIndexer.Store(&rIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populateReleaseIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Release Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Release Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Release Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Release Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+func pushToQueue(item *IndexerMetadata) {
+	if indexerQueue == nil {
+		return
+	}
+	if err := indexerQueue.Push(item); err != nil && err != queue.ErrAlreadyInQueue {
+		log.Error("Push %+v to the release indexer queue failed: %v", item, err)
+	}
+}
+
+// UpdateReleaseIndexer adds, updates or removes a release in the indexer according to its current state
+func UpdateReleaseIndexer(releaseID int64) {
+	pushToQueue(&IndexerMetadata{ID: releaseID})
+}
+
+// DeleteRepoReleaseIndexer removes the releases of a deleted repository from the indexer
+func DeleteRepoReleaseIndexer(repoID int64) {
+	pushToQueue(&IndexerMetadata{DeletedRepoID: repoID})
+}
+
+// UpdateRepoIndexer reindexes all the releases of a repository,
+// the drafts are included because the releases whose tags are deleted become drafts
+func UpdateRepoIndexer(ctx context.Context, repoID int64) error {
+	if indexerQueue == nil {
+		return nil
+	}
+	ids, err := getReleaseIDs(ctx, builder.Eq{"repo_id": repoID, "is_tag": false}, 0)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		UpdateReleaseIndexer(id)
+	}
+	return nil
+}
+
+// IsAvailable checks if release indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// getReleaseIDs returns the IDs of the releases matching the condition in descending order
+func getReleaseIDs(ctx context.Context, cond builder.Cond, limit int) ([]int64, error) {
+	sess := db.GetEngine(ctx).Table("release").
+		Where(cond).
+		Cols("id").
+		Desc("id")
+	if limit > 0 {
+		sess = sess.Limit(limit)
+	}
+	ids := make([]int64, 0, max(limit, 10))
+	return ids, sess.Find(&ids)
+}
+
+// populateReleaseIndexer populate the release indexer with the existing releases,
+// it should only be run when the indexer is created for the first time.
+func populateReleaseIndexer(ctx context.Context) {
+	log.Info("Populating the release indexer with existing releases")
+
+	exist, err := db.IsTableNotEmpty("release")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	maxReleaseID, err := db.GetMaxID("release")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing release ID and work backwards, so that we
+	// don't include releases that are created after gitea starts; such releases will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxReleaseID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Release Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := getReleaseIDs(ctx, builder.Lte{"id": maxReleaseID}.And(builder.Eq{"is_draft": false, "is_tag": false}), populateBatchSize)
+		if err != nil {
+			log.Error("populateReleaseIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			if err := indexerQueue.Push(&IndexerMetadata{ID: id}); err != nil && err != queue.ErrAlreadyInQueue {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxReleaseID = id - 1
+		}
+	}
+	log.Info("Done populating the release indexer with existing releases")
+}
+
+// SupportedSearchModes returns the search modes of the release indexer
+func SupportedSearchModes() []indexer.SearchMode {
+	return (*globalIndexer.Load()).SupportedSearchModes()
+}
+
+type (
+	IndexerData   = internal.IndexerData
+	SearchOptions = internal.SearchOptions
+)
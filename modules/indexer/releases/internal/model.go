@@ -0,0 +1,48 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// IndexerData data stored in the release indexer
+type IndexerData struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"repo_id"`
+
+	// Fields used for keyword searching
+	TagName     string   `json:"tag_name"`
+	Title       string   `json:"title"`
+	Note        string   `json:"note"`
+	Attachments []string `json:"attachments"` // the names of the attached files
+
+	IsPrerelease bool               `json:"is_prerelease"`
+	CreatedUnix  timeutil.TimeStamp `json:"created_unix"`
+}
+
+// SearchOptions represents search options
+type SearchOptions struct {
+	Keyword string // matched against the tag name, title, note and attachment names
+
+	SearchMode indexer.SearchModeType
+
+	RepoIDs []int64 // repositories the releases belong to, empty means all repositories
+
+	Paginator *db.ListOptions
+}
+
+// Match represents a matched release
+type Match struct {
+	ID    int64   `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// SearchResult represents search results, the hits are sorted by relevance
+type SearchResult struct {
+	Total int64
+	Hits  []Match
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// This package contains tests for release indexer modules.
+// All the code in this package is only used for testing.
+// Do not put any production code in this package to avoid it being included in the final binary.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/releases/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexer(t *testing.T, idx internal.Indexer) {
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Ping(t.Context()))
+
+	require.NoError(t, idx.Index(t.Context(), data...))
+	require.NoError(t, waitData(idx, int64(len(data))))
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.SearchOptions.SearchMode != "" && !slices.ContainsFunc(idx.SupportedSearchModes(), func(mode indexer.SearchMode) bool {
+				return mode.ModeValue == c.SearchOptions.SearchMode
+			}) {
+				t.Skipf("search mode %s is not supported", c.SearchOptions.SearchMode)
+			}
+
+			assert.Equal(t, c.ExpectedIDs, search(t, idx, c.SearchOptions))
+		})
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, idx.Delete(t.Context(), 2))
+		require.NoError(t, waitData(idx, 2))
+
+		assert.Equal(t, []int64{3}, search(t, idx, &internal.SearchOptions{Keyword: "crash", SearchMode: indexer.SearchModeWords}))
+	})
+
+	t.Run("DeleteRepo", func(t *testing.T) {
+		require.NoError(t, idx.DeleteRepo(t.Context(), 1))
+		require.NoError(t, waitData(idx, 1))
+
+		assert.Equal(t, []int64{3}, search(t, idx, &internal.SearchOptions{}))
+	})
+}
+
+var data = []*internal.IndexerData{
+	{ID: 1, RepoID: 1, TagName: "v1.0.0", Title: "First release", Note: "Initial version", Attachments: []string{"app-linux-amd64.tar.gz"}, CreatedUnix: 100},
+	{ID: 2, RepoID: 1, TagName: "v1.0.1", Title: "Bugfix release", Note: "Fixed the crash when the config file is missing", CreatedUnix: 200},
+	{ID: 3, RepoID: 2, TagName: "v2.0.0", Title: "Crash fixes", Note: "Nothing else", Attachments: []string{"checksums.txt"}, CreatedUnix: 300},
+}
+
+type testIndexerCase struct {
+	Name          string
+	SearchOptions *internal.SearchOptions
+	ExpectedIDs   []int64
+}
+
+var cases = []*testIndexerCase{
+	{
+		// the newest releases come first when no keyword is given
+		Name:          "default",
+		SearchOptions: &internal.SearchOptions{},
+		ExpectedIDs:   []int64{3, 2, 1},
+	},
+	{
+		Name:          "empty",
+		SearchOptions: &internal.SearchOptions{Keyword: "f1dfac73-fda6-4a6b-b8a4-2408fcb8ef69", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []int64{},
+	},
+	{
+		// the match in the title ranks higher than the match in the note
+		Name:          "TitleRanksHigher",
+		SearchOptions: &internal.SearchOptions{Keyword: "crash", SearchMode: indexer.SearchModeWords},
+		ExpectedIDs:   []int64{3, 2},
+	},
+	{
+		Name:          "RepoIDs",
+		SearchOptions: &internal.SearchOptions{Keyword: "crash", RepoIDs: []int64{1}},
+		ExpectedIDs:   []int64{2},
+	},
+	{
+		Name:          "AttachmentWords",
+		SearchOptions: &internal.SearchOptions{Keyword: "linux", SearchMode: indexer.SearchModeWords},
+		ExpectedIDs:   []int64{1},
+	},
+	{
+		Name:          "AttachmentExact",
+		SearchOptions: &internal.SearchOptions{Keyword: "checksums.txt", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []int64{3},
+	},
+	{
+		Name:          "Phrase",
+		SearchOptions: &internal.SearchOptions{Keyword: "config file", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []int64{2},
+	},
+	{
+		Name:          "TagName",
+		SearchOptions: &internal.SearchOptions{Keyword: "v1.0.1", SearchMode: indexer.SearchModeExact},
+		ExpectedIDs:   []int64{2},
+	},
+	{
+		Name:          "Fuzzy",
+		SearchOptions: &internal.SearchOptions{Keyword: "bugfux", SearchMode: indexer.SearchModeFuzzy},
+		ExpectedIDs:   []int64{2},
+	},
+}
+
+func search(t *testing.T, idx internal.Indexer, opts *internal.SearchOptions) []int64 {
+	result, err := idx.Search(t.Context(), opts)
+	require.NoError(t, err)
+
+	ids := make([]int64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+	assert.EqualValues(t, len(ids), result.Total)
+	return ids
+}
+
+func waitData(idx internal.Indexer, total int64) error {
+	var actual int64
+	for i := 0; i < 100; i++ {
+		result, err := idx.Search(context.Background(), &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 0,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		actual = result.Total
+		if actual == total {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("waitData: expected %d, actual %d", total, actual)
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package releases
+
+import (
+	"context"
+)
+
+// SearchReleases searches the published releases by the given options,
+// it returns the IDs of the matched releases of the requested page sorted by relevance and the total count
+func SearchReleases(ctx context.Context, opts *SearchOptions) ([]int64, int64, error) {
+	res, err := (*globalIndexer.Load()).Search(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int64, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids, res.Total, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"context"
+	"math"
+
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	analyzer_keyword "github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/token/camelcase"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	wikiIndexerAnalyzer      = "wikiIndexer"
+	wikiIndexerDocType       = "wikiIndexerDocType"
+	wikiIndexerLatestVersion = 1
+)
+
+const unicodeNormalizeName = "unicodeNormalize"
+
+const maxBatchSize = 16
+
+// titleBoost makes the pages whose title matches the keyword rank higher
+const titleBoost = 2.0
+
+// IndexerData an update to the wiki indexer
+type IndexerData internal.IndexerData
+
+// Type returns the document type, for bleve's mapping.Classifier interface.
+func (i *IndexerData) Type() string {
+	return wikiIndexerDocType
+}
+
+// generateWikiIndexMapping generates the bleve index mapping for wiki pages
+func generateWikiIndexMapping() (mapping.IndexMapping, error) {
+	mapping := bleve.NewIndexMapping()
+	docMapping := bleve.NewDocumentMapping()
+
+	numericFieldMapping := bleve.NewNumericFieldMapping()
+	numericFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("repo_id", numericFieldMapping)
+
+	termFieldMapping := bleve.NewTextFieldMapping()
+	termFieldMapping.IncludeInAll = false
+	termFieldMapping.Analyzer = analyzer_keyword.Name
+	docMapping.AddFieldMappingsAt("path", termFieldMapping)
+
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("title", textFieldMapping)
+	docMapping.AddFieldMappingsAt("content", textFieldMapping)
+
+	if err := mapping.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
+		"type": unicodenorm.Name,
+		"form": unicodenorm.NFC,
+	}); err != nil {
+		return nil, err
+	} else if err = mapping.AddCustomAnalyzer(wikiIndexerAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{unicodeNormalizeName, camelcase.Name, lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
+	mapping.DefaultAnalyzer = wikiIndexerAnalyzer
+	mapping.AddDocumentMapping(wikiIndexerDocType, docMapping)
+	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
+	mapping.DefaultMapping = bleve.NewDocumentDisabledMapping() // disable default mapping, avoid indexing unexpected structs
+
+	return mapping, nil
+}
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_bleve.Indexer
+	indexer_internal.Indexer // do not composite inner_bleve.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWordsFuzzy()
+}
+
+// NewIndexer creates a new bleve local indexer
+func NewIndexer(indexDir string) *Indexer {
+	inner := inner_bleve.NewIndexer(indexDir, wikiIndexerLatestVersion, generateWikiIndexMapping)
+	return &Indexer{
+		Indexer: inner,
+		inner:   inner,
+	}
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, repoID int64, changes *internal.RepoChanges) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, page := range changes.Updates {
+		if err := batch.Index(page.DocID(), (*IndexerData)(page)); err != nil {
+			return err
+		}
+	}
+	for _, path := range changes.RemovedPaths {
+		if err := batch.Delete(internal.DocID(repoID, path)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Delete deletes the pages of a repository
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	searchRequest := bleve.NewSearchRequestOptions(inner_bleve.NumericEqualityQuery(repoID, "repo_id"), math.MaxInt32, 0, false)
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return err
+	}
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, hit := range result.Hits {
+		if err := batch.Delete(hit.ID); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Search searches for wiki pages by given conditions.
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	var queries []query.Query
+
+	if options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		var titleQuery, contentQuery query.Query
+		if searchMode == indexer.SearchModeWords || searchMode == indexer.SearchModeFuzzy {
+			fuzziness := 0
+			if searchMode == indexer.SearchModeFuzzy {
+				fuzziness = inner_bleve.GuessFuzzinessByKeyword(options.Keyword)
+			}
+			q := inner_bleve.MatchAndQuery(options.Keyword, "title", wikiIndexerAnalyzer, fuzziness)
+			q.SetBoost(titleBoost)
+			titleQuery = q
+			contentQuery = inner_bleve.MatchAndQuery(options.Keyword, "content", wikiIndexerAnalyzer, fuzziness)
+		} else /* exact */ {
+			q := inner_bleve.MatchPhraseQuery(options.Keyword, "title", wikiIndexerAnalyzer, 0)
+			q.SetBoost(titleBoost)
+			titleQuery = q
+			contentQuery = inner_bleve.MatchPhraseQuery(options.Keyword, "content", wikiIndexerAnalyzer, 0)
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(titleQuery, contentQuery))
+	}
+
+	if len(options.RepoIDs) > 0 {
+		repoQueries := make([]query.Query, 0, len(options.RepoIDs))
+		for _, repoID := range options.RepoIDs {
+			repoQueries = append(repoQueries, inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(repoQueries...))
+	}
+
+	var indexerQuery query.Query = bleve.NewConjunctionQuery(queries...)
+	if len(queries) == 0 {
+		indexerQuery = bleve.NewMatchAllQuery()
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
+	search := bleve.NewSearchRequestOptions(indexerQuery, limit, skip, false)
+	search.Fields = []string{"repo_id", "path", "title", "content"}
+	search.SortBy([]string{"-_score", "_id"})
+
+	result, err := b.inner.Indexer.SearchInContext(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &internal.SearchResult{
+		Total: int64(result.Total),
+		Hits:  make([]*internal.IndexerData, 0, len(result.Hits)),
+	}
+	for _, hit := range result.Hits {
+		repoID, _ := hit.Fields["repo_id"].(float64)
+		path, _ := hit.Fields["path"].(string)
+		title, _ := hit.Fields["title"].(string)
+		content, _ := hit.Fields["content"].(string)
+		ret.Hits = append(ret.Hits, &internal.IndexerData{
+			RepoID:  int64(repoID),
+			Path:    path,
+			Title:   title,
+			Content: content,
+		})
+	}
+	return ret, nil
+}
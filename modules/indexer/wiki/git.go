@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/jaytaylor/html2text"
+)
+
+// isWikiPage returns whether the file of the wiki repository is a page listed in the wiki,
+// the pages are the markdown files in the root directory except the sidebar and the footer
+func isWikiPage(path string) bool {
+	return strings.HasSuffix(path, ".md") && !strings.Contains(path, "/") &&
+		path != "_Sidebar.md" && path != "_Footer.md"
+}
+
+// pageTitle returns the title of the page displayed to users, it must be kept in sync with
+// GitPathToWebPath and WebPathToUserTitle of services/wiki
+func pageTitle(path string) string {
+	name := strings.TrimSuffix(path, ".md")
+	if strings.HasSuffix(name, ".-") {
+		name = strings.TrimSuffix(name, ".-")
+	} else {
+		name = strings.ReplaceAll(name, "-", " ")
+	}
+	if unescaped, err := url.QueryUnescape(name); err == nil {
+		return unescaped
+	}
+	return name
+}
+
+// renderPageText renders the markdown of a page and returns its text, so the markup doesn't pollute the index
+func renderPageText(ctx context.Context, content string) (string, error) {
+	html, err := markdown.RenderRawString(markup.NewRenderContext(ctx), content)
+	if err != nil {
+		return "", err
+	}
+	return html2text.FromString(html, html2text.Options{TextOnly: true})
+}
+
+// getWikiChanges returns the changes of the pages of the wiki since the last update of the indexer.
+// needReset is true if the indexed history was rewritten, and the pages of the repository have to be
+// removed before indexing the changes.
+func getWikiChanges(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, revision string) (changes *internal.RepoChanges, needReset bool, err error) {
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki)
+	if err != nil {
+		return nil, false, err
+	}
+	if status.CommitSha == revision {
+		return nil, false, nil
+	}
+
+	commit, err := gitRepo.GetCommit(revision)
+	if err != nil {
+		return nil, false, err
+	}
+
+	needReset = len(status.CommitSha) == 0
+	if !needReset {
+		isAncestorCmd := git.NewCommand("merge-base", "--is-ancestor").AddDynamicArguments(status.CommitSha, revision)
+		_, _, err := isAncestorCmd.RunStdString(ctx, &git.RunOpts{Dir: repo.WikiPath()})
+		needReset = err != nil
+	}
+
+	var paths []string
+	changes = &internal.RepoChanges{}
+	if needReset {
+		entries, err := commit.ListEntries()
+		if err != nil {
+			return nil, false, err
+		}
+		for _, entry := range entries {
+			paths = append(paths, entry.Name())
+		}
+	} else {
+		stdout, _, err := git.NewCommand("diff", "--name-only", "--no-renames", "-z").
+			AddDynamicArguments(status.CommitSha, revision).
+			RunStdString(ctx, &git.RunOpts{Dir: repo.WikiPath()})
+		if err != nil {
+			return nil, false, err
+		}
+		paths = strings.Split(strings.TrimSuffix(stdout, "\x00"), "\x00")
+	}
+
+	for _, path := range paths {
+		if !isWikiPage(path) {
+			continue
+		}
+		entry, err := commit.GetTreeEntryByPath(path)
+		if git.IsErrNotExist(err) {
+			changes.RemovedPaths = append(changes.RemovedPaths, path)
+			continue
+		} else if err != nil {
+			return nil, false, err
+		}
+		if !entry.IsRegular() {
+			changes.RemovedPaths = append(changes.RemovedPaths, path)
+			continue
+		}
+
+		content, err := entry.Blob().GetBlobContent(setting.Indexer.MaxIndexerFileSize)
+		if err != nil {
+			return nil, false, err
+		}
+		text, err := renderPageText(ctx, content)
+		if err != nil {
+			// index the page by its title and the source at least
+			log.Warn("Unable to render the wiki page %q of %s: %v", path, repo.FullName(), err)
+			text = content
+		}
+		changes.Updates = append(changes.Updates, &internal.IndexerData{
+			RepoID:  repo.ID,
+			Path:    path,
+			Title:   pageTitle(path),
+			Content: text,
+		})
+	}
+	return changes, needReset, nil
+}
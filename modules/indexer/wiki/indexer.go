@@ -0,0 +1,328 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/wiki/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/indexer/wiki/meilisearch"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[int64]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if repo_model.IsErrRepoNotExist(err) {
+		return indexer.Delete(ctx, repoID)
+	}
+	if err != nil {
+		return err
+	}
+	if !repo.HasWiki() {
+		return clearWiki(ctx, indexer, repo)
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, repo.WikiPath())
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	sha, err := gitRepo.GetBranchCommitID(repo.DefaultWikiBranch)
+	if git.IsErrNotExist(err) || git.IsErrBranchNotExist(err) {
+		// the wiki has no page yet
+		return clearWiki(ctx, indexer, repo)
+	} else if err != nil {
+		return err
+	}
+
+	changes, needReset, err := getWikiChanges(ctx, repo, gitRepo, sha)
+	if err != nil {
+		return err
+	}
+	if changes == nil {
+		return nil
+	}
+	if needReset {
+		if err := indexer.Delete(ctx, repo.ID); err != nil {
+			return err
+		}
+	}
+	if err := indexer.Index(ctx, repo.ID, changes); err != nil {
+		return err
+	}
+
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki, sha)
+}
+
+// clearWiki removes the pages of a repository whose wiki has been deleted,
+// the status is reset so that the pages are indexed from scratch if the wiki is created again
+func clearWiki(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository) error {
+	if err := indexer.Delete(ctx, repo.ID); err != nil {
+		return err
+	}
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki, "")
+}
+
+// Init initialize the wiki indexer
+func Init() {
+	if !setting.Indexer.WikiIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: WikiIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing wiki indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Wiki Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.WikiType {
+	case "bleve", "elasticsearch", "meilisearch":
+		handler := func(items ...int64) (unhandled []int64) {
+			indexer := *globalIndexer.Load()
+			for _, repoID := range items {
+				log.Trace("Wiki Indexer Process Repo: %d", repoID)
+				if err := index(ctx, indexer, repoID); err != nil {
+					if !setting.IsInTesting {
+						log.Error("Wiki indexer handler: index error for repo %v: %v", repoID, err)
+					}
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken repo will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "wiki_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create wiki indexer queue")
+		}
+	default:
+		log.Fatal("Unknown wiki indexer type; %s", setting.Indexer.WikiType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			wIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.WikiType {
+		case "bleve":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.WikiPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.WikiPath)
+				}
+			}()
+
+			wIndexer = bleve.NewIndexer(setting.Indexer.WikiPath)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the bleve Wiki Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.WikiPath, err)
+			}
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.WikiConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.WikiConnStr)
+				}
+			}()
+
+			wIndexer = elasticsearch.NewIndexer(setting.Indexer.WikiConnStr, setting.Indexer.WikiIndexerName)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the elasticsearch Wiki Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.WikiConnStr, err)
+			}
+		case "meilisearch":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.WikiConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.WikiIndexerName)
+				}
+			}()
+
+			wIndexer = meilisearch.NewIndexer(setting.Indexer.WikiConnStr, setting.Indexer.WikiConnAuth, setting.Indexer.WikiIndexerName)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the meilisearch Wiki Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.WikiConnStr, err)
+			}
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.WikiType)
+		}
+
+		globalIndexer.Store(&wIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populateWikiIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Wiki Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Wiki Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Wiki Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Wiki Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+// UpdateRepoIndexer update the pages of a repository's wiki in the indexer
+func UpdateRepoIndexer(repo *repo_model.Repository) {
+	if indexerQueue == nil {
+		return
+	}
+	if err := indexerQueue.Push(repo.ID); err != nil && err != queue.ErrAlreadyInQueue {
+		log.Error("Update wiki index of repo %d failed: %v", repo.ID, err)
+	}
+}
+
+// IsAvailable checks if wiki indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// populateWikiIndexer populate the wiki indexer with the wikis of the existing repositories,
+// it should only be run when the indexer is created for the first time.
+func populateWikiIndexer(ctx context.Context) {
+	log.Info("Populating the wiki indexer with existing repositories")
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	// the statuses are stale since the index has been created afresh
+	if _, err := db.GetEngine(ctx).Where("indexer_type = ?", repo_model.RepoIndexerTypeWiki).Delete(&repo_model.RepoIndexerStatus{}); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	maxRepoID, err := db.GetMaxID("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, so that we
+	// don't include repos that are created after gitea starts; such repos will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxRepoID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Wiki Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeWiki, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateWikiIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			if err := indexerQueue.Push(id); err != nil && err != queue.ErrAlreadyInQueue {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done populating the wiki indexer with existing repositories")
+}
+
+// SupportedSearchModes returns the search modes of the wiki indexer
+func SupportedSearchModes() []indexer.SearchMode {
+	return (*globalIndexer.Load()).SupportedSearchModes()
+}
+
+type (
+	IndexerData   = internal.IndexerData
+	SearchOptions = internal.SearchOptions
+)
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+)
+
+// IndexerData data stored in the wiki indexer
+type IndexerData struct {
+	RepoID int64 `json:"repo_id"`
+	// Path is the path of the page file in the wiki repository, like "Home-Page.md"
+	Path string `json:"path"`
+
+	// Fields used for keyword searching
+	Title   string `json:"title"`
+	Content string `json:"content"` // the text of the rendered page
+}
+
+// DocID returns the ID of the document of the page, which is unique across repositories
+func (d *IndexerData) DocID() string {
+	return DocID(d.RepoID, d.Path)
+}
+
+// DocID returns the ID of the document of a page.
+// The path is hashed because some engines like meilisearch only accept alphanumeric characters, "-" and "_" in IDs.
+func DocID(repoID int64, path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return strconv.FormatInt(repoID, 36) + "_" + hex.EncodeToString(sum[:])
+}
+
+// RepoChanges changes of the pages of a wiki since the last update of the indexer
+type RepoChanges struct {
+	Updates      []*IndexerData
+	RemovedPaths []string
+}
+
+// SearchOptions represents search options
+type SearchOptions struct {
+	Keyword string // matched against the title and content
+
+	SearchMode indexer.SearchModeType
+
+	RepoIDs []int64 // repositories the wikis belong to, empty means all repositories
+
+	Paginator *db.ListOptions
+}
+
+// SearchResult represents search results, the hits are sorted by relevance
+type SearchResult struct {
+	Total int64
+	Hits  []*IndexerData
+}
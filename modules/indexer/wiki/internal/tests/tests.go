@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// This package contains tests for wiki indexer modules.
+// All the code in this package is only used for testing.
+// Do not put any production code in this package to avoid it being included in the final binary.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexer(t *testing.T, idx internal.Indexer) {
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Ping(t.Context()))
+
+	require.NoError(t, idx.Index(t.Context(), 1, &internal.RepoChanges{Updates: []*internal.IndexerData{home1, guide1}}))
+	require.NoError(t, idx.Index(t.Context(), 2, &internal.RepoChanges{Updates: []*internal.IndexerData{home2}}))
+	require.NoError(t, waitData(idx, 3))
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.SearchOptions.SearchMode != "" && !slices.ContainsFunc(idx.SupportedSearchModes(), func(mode indexer.SearchMode) bool {
+				return mode.ModeValue == c.SearchOptions.SearchMode
+			}) {
+				t.Skipf("search mode %s is not supported", c.SearchOptions.SearchMode)
+			}
+
+			result, err := idx.Search(t.Context(), c.SearchOptions)
+			require.NoError(t, err)
+
+			paths := make([]string, 0, len(result.Hits))
+			for _, hit := range result.Hits {
+				paths = append(paths, fmt.Sprintf("%d/%s", hit.RepoID, hit.Path))
+			}
+			assert.Equal(t, c.ExpectedPaths, paths)
+			assert.EqualValues(t, len(c.ExpectedPaths), result.Total)
+		})
+	}
+
+	t.Run("Hit", func(t *testing.T) {
+		result, err := idx.Search(t.Context(), &internal.SearchOptions{Keyword: "download", SearchMode: indexer.SearchModeWords})
+		require.NoError(t, err)
+		require.Len(t, result.Hits, 1)
+		assert.Equal(t, guide1, result.Hits[0])
+	})
+
+	t.Run("RemovedPaths", func(t *testing.T) {
+		require.NoError(t, idx.Index(t.Context(), 1, &internal.RepoChanges{RemovedPaths: []string{guide1.Path}}))
+		require.NoError(t, waitData(idx, 2))
+
+		result, err := idx.Search(t.Context(), &internal.SearchOptions{Keyword: "download", SearchMode: indexer.SearchModeWords})
+		require.NoError(t, err)
+		assert.Empty(t, result.Hits)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, idx.Delete(t.Context(), 1))
+		require.NoError(t, waitData(idx, 1))
+
+		result, err := idx.Search(t.Context(), &internal.SearchOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.Hits, 1)
+		assert.Equal(t, home2, result.Hits[0])
+	})
+}
+
+var (
+	home1  = &internal.IndexerData{RepoID: 1, Path: "Home.md", Title: "Home", Content: "Welcome to the project, see the installation guide"}
+	guide1 = &internal.IndexerData{RepoID: 1, Path: "Installation-Guide.md", Title: "Installation Guide", Content: "Download the binary and run it"}
+	home2  = &internal.IndexerData{RepoID: 2, Path: "Home.md", Title: "Home", Content: "Nothing about installation here"}
+)
+
+type testIndexerCase struct {
+	Name          string
+	SearchOptions *internal.SearchOptions
+	ExpectedPaths []string // the repository ID and the path of the pages
+}
+
+var cases = []*testIndexerCase{
+	{
+		Name:          "empty",
+		SearchOptions: &internal.SearchOptions{Keyword: "f1dfac73-fda6-4a6b-b8a4-2408fcb8ef69", SearchMode: indexer.SearchModeExact},
+		ExpectedPaths: []string{},
+	},
+	{
+		// the match in the title ranks higher than the match in the content
+		Name:          "TitleRanksHigher",
+		SearchOptions: &internal.SearchOptions{Keyword: "installation guide", SearchMode: indexer.SearchModeWords, RepoIDs: []int64{1}},
+		ExpectedPaths: []string{"1/Installation-Guide.md", "1/Home.md"},
+	},
+	{
+		Name:          "RepoIDs",
+		SearchOptions: &internal.SearchOptions{Keyword: "installation", SearchMode: indexer.SearchModeExact, RepoIDs: []int64{2}},
+		ExpectedPaths: []string{"2/Home.md"},
+	},
+	{
+		Name:          "Fuzzy",
+		SearchOptions: &internal.SearchOptions{Keyword: "binery", SearchMode: indexer.SearchModeFuzzy},
+		ExpectedPaths: []string{"1/Installation-Guide.md"},
+	},
+}
+
+func waitData(idx internal.Indexer, total int64) error {
+	var actual int64
+	for i := 0; i < 100; i++ {
+		result, err := idx.Search(context.Background(), &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 0,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		actual = result.Total
+		if actual == total {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("waitData: expected %d, actual %d", total, actual)
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_meilisearch "code.gitea.io/gitea/modules/indexer/internal/meilisearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	wikiIndexerLatestVersion = 1
+
+	// TODO: make this configurable if necessary
+	maxTotalHits = 10000
+)
+
+// ErrMalformedResponse is never expected as we initialize the indexer ourself and so define the types.
+var ErrMalformedResponse = errors.New("meilisearch returned unexpected malformed content")
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_meilisearch.Indexer
+	indexer_internal.Indexer // do not composite inner_meilisearch.Indexer directly to avoid exposing too much
+}
+
+// document is the document stored in meilisearch, which requires the primary key in the documents
+type document struct {
+	ID string `json:"id"`
+	*internal.IndexerData
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new meilisearch indexer
+func NewIndexer(url, apiKey, indexerName string) *Indexer {
+	settings := &meilisearch.Settings{
+		// the title is listed first, so the "attribute" ranking rule prefers the pages whose title matches
+		SearchableAttributes: []string{
+			"title",
+			"content",
+		},
+		DisplayedAttributes: []string{
+			"id",
+			"repo_id",
+			"path",
+			"title",
+			"content",
+		},
+		FilterableAttributes: []string{
+			"repo_id",
+		},
+		Pagination: &meilisearch.Pagination{
+			MaxTotalHits: maxTotalHits,
+		},
+	}
+
+	inner := inner_meilisearch.NewIndexer(url, apiKey, indexerName, wikiIndexerLatestVersion, settings)
+	indexer := &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+	return indexer
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, repoID int64, changes *internal.RepoChanges) error {
+	if len(changes.Updates) > 0 {
+		docs := make([]*document, 0, len(changes.Updates))
+		for _, page := range changes.Updates {
+			docs = append(docs, &document{ID: page.DocID(), IndexerData: page})
+		}
+		if _, err := b.inner.Client.Index(b.inner.VersionedIndexName()).AddDocuments(docs); err != nil {
+			return err
+		}
+	}
+	if len(changes.RemovedPaths) > 0 {
+		ids := make([]string, 0, len(changes.RemovedPaths))
+		for _, path := range changes.RemovedPaths {
+			ids = append(ids, internal.DocID(repoID, path))
+		}
+		if _, err := b.inner.Client.Index(b.inner.VersionedIndexName()).DeleteDocuments(ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete deletes the pages of a repository
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	_, err := b.inner.Client.Index(b.inner.VersionedIndexName()).DeleteDocumentsByFilter(inner_meilisearch.NewFilterEq("repo_id", repoID).Statement())
+	return err
+}
+
+// Search searches for wiki pages by given conditions.
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	query := inner_meilisearch.FilterAnd{}
+	if len(options.RepoIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("repo_id", options.RepoIDs...))
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator, maxTotalHits)
+
+	counting := limit == 0
+	if counting {
+		// If set limit to 0, it will be 20 by default, and -1 is not allowed.
+		// See https://www.meilisearch.com/docs/reference/api/search#limit
+		// So set limit to 1 to make the cost as low as possible, then clear the result before returning.
+		limit = 1
+	}
+
+	keyword := options.Keyword // default to match "words"
+	if options.SearchMode == indexer.SearchModeExact {
+		// https://www.meilisearch.com/docs/reference/api/search#phrase-search
+		keyword = doubleQuoteKeyword(keyword)
+	}
+
+	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).Search(keyword, &meilisearch.SearchRequest{
+		Filter:           query.Statement(),
+		Limit:            int64(limit),
+		Offset:           int64(skip),
+		MatchingStrategy: "all",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if counting {
+		searchRes.Hits = nil
+	}
+
+	hits, err := convertHits(searchRes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &internal.SearchResult{
+		Total: searchRes.EstimatedTotalHits,
+		Hits:  hits,
+	}, nil
+}
+
+func doubleQuoteKeyword(k string) string {
+	kp := strings.Split(k, " ")
+	parts := 0
+	for i := range kp {
+		part := strings.Trim(kp[i], "\"")
+		if part != "" {
+			kp[parts] = fmt.Sprintf(`"%s"`, part)
+			parts++
+		}
+	}
+	return strings.Join(kp[:parts], " ")
+}
+
+func convertHits(searchRes *meilisearch.SearchResponse) ([]*internal.IndexerData, error) {
+	hits := make([]*internal.IndexerData, 0, len(searchRes.Hits))
+	for _, hit := range searchRes.Hits {
+		hit, ok := hit.(map[string]any)
+		if !ok {
+			return nil, ErrMalformedResponse
+		}
+
+		repoID, ok := hit["repo_id"].(float64)
+		if !ok {
+			return nil, ErrMalformedResponse
+		}
+		path, _ := hit["path"].(string)
+		title, _ := hit["title"].(string)
+		content, _ := hit["content"].(string)
+
+		hits = append(hits, &internal.IndexerData{
+			RepoID:  int64(repoID),
+			Path:    path,
+			Title:   title,
+			Content: content,
+		})
+	}
+	return hits, nil
+}
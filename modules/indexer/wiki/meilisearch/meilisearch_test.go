@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/indexer/wiki/internal/tests"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeilisearchIndexer(t *testing.T) {
+	// The meilisearch instance started by pull-db-tests.yml > test-unit > services > meilisearch
+	url := "http://meilisearch:7700"
+	key := "" // auth has been disabled in test environment
+
+	if os.Getenv("CI") == "" {
+		// Make it possible to run tests against a local meilisearch instance
+		url = os.Getenv("TEST_MEILISEARCH_URL")
+		if url == "" {
+			t.Skip("TEST_MEILISEARCH_URL not set and not running in CI")
+			return
+		}
+		key = os.Getenv("TEST_MEILISEARCH_KEY")
+	}
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url)
+		return err == nil && resp.StatusCode == http.StatusOK
+	}, time.Minute, time.Second, "Expected meilisearch to be up")
+
+	indexer := NewIndexer(url, key, fmt.Sprintf("test_meilisearch_wiki_indexer_%d", time.Now().Unix()))
+	defer indexer.Close()
+
+	tests.TestIndexer(t, indexer)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// snippetLength is the number of characters of the content displayed around the matched keyword
+const snippetLength = 200
+
+// Result a search result to display
+type Result struct {
+	RepoID  int64
+	Path    string
+	Title   string
+	Snippet string
+}
+
+// SearchWiki searches the pages of the wikis by the given options,
+// it returns the total count of the matched pages and the results of the requested page
+func SearchWiki(ctx context.Context, opts *SearchOptions) (int64, []*Result, error) {
+	res, err := (*globalIndexer.Load()).Search(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	results := make([]*Result, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		results = append(results, &Result{
+			RepoID:  hit.RepoID,
+			Path:    hit.Path,
+			Title:   hit.Title,
+			Snippet: makeSnippet(hit.Content, opts.Keyword),
+		})
+	}
+	return res.Total, results, nil
+}
+
+// makeSnippet returns a part of the content around the first occurrence of the keyword,
+// or of its first word if the keyword itself doesn't occur
+func makeSnippet(content, keyword string) string {
+	content = strings.Join(strings.Fields(content), " ")
+	if utf8.RuneCountInString(content) <= snippetLength {
+		return content
+	}
+
+	lowerContent := strings.ToLower(content)
+	pos := -1
+	if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+		pos = strings.Index(lowerContent, keyword)
+		if fields := strings.Fields(keyword); pos < 0 && len(fields) > 0 {
+			pos = strings.Index(lowerContent, fields[0])
+		}
+	}
+	if len(lowerContent) != len(content) {
+		// the lower case changed the byte length, so the position can't be used in the content
+		pos = -1
+	}
+
+	runes := []rune(content)
+	start := 0
+	if pos > 0 {
+		// start a quarter of the snippet before the keyword
+		start = max(utf8.RuneCountInString(content[:pos])-snippetLength/4, 0)
+	}
+	start = min(start, len(runes)-snippetLength)
+	end := start + snippetLength
+
+	snippet := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWikiPage(t *testing.T) {
+	assert.True(t, isWikiPage("Home.md"))
+	assert.True(t, isWikiPage("Installation-Guide.md"))
+	assert.False(t, isWikiPage("_Sidebar.md"))
+	assert.False(t, isWikiPage("_Footer.md"))
+	assert.False(t, isWikiPage("images/logo.png"))
+	assert.False(t, isWikiPage("docs/Home.md"))
+}
+
+func TestPageTitle(t *testing.T) {
+	assert.Equal(t, "Home", pageTitle("Home.md"))
+	assert.Equal(t, "Installation Guide", pageTitle("Installation-Guide.md"))
+	assert.Equal(t, "2000-01-02 meeting", pageTitle("2000-01-02+meeting.-.md"))
+	assert.Equal(t, "100% Free", pageTitle("100%25+Free.md"))
+}
+
+func TestMakeSnippet(t *testing.T) {
+	assert.Equal(t, "short content", makeSnippet("short\n\ncontent", "content"))
+
+	long := strings.Repeat("lorem ipsum ", 50) + "the keyword is here " + strings.Repeat("dolor sit amet ", 50)
+	snippet := makeSnippet(long, "KEYWORD")
+	assert.Contains(t, snippet, "the keyword is here")
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+
+	// the first word is looked for if the whole keyword doesn't occur
+	assert.Contains(t, makeSnippet(long, "keyword missing"), "the keyword is here")
+
+	snippet = makeSnippet(long, "not found")
+	assert.True(t, strings.HasPrefix(snippet, "lorem ipsum"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrInvalidArtifact      = util.NewInvalidArgumentErrorf("collection artifact is invalid")
+	ErrMissingManifestFile  = util.NewInvalidArgumentErrorf("MANIFEST.json file is missing")
+	ErrMissingFilesFile     = util.NewInvalidArgumentErrorf("FILES.json file is missing")
+	ErrInvalidManifestFile  = util.NewInvalidArgumentErrorf("MANIFEST.json file is invalid")
+	ErrInvalidFilesFile     = util.NewInvalidArgumentErrorf("FILES.json file is invalid")
+	ErrManifestFileTooLarge = util.NewInvalidArgumentErrorf("MANIFEST.json or FILES.json file is too large")
+	ErrInvalidNamespace     = util.NewInvalidArgumentErrorf("collection namespace is invalid")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("collection name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("collection version is invalid")
+	ErrInvalidDependency    = util.NewInvalidArgumentErrorf("collection dependency is invalid")
+	ErrChecksumMismatch     = util.NewInvalidArgumentErrorf("file checksum does not match")
+)
+
+// https://docs.ansible.com/ansible/latest/dev_guide/collections_galaxy_meta.html
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+const (
+	manifestFilename = "MANIFEST.json"
+	filesFilename    = "FILES.json"
+
+	maxManifestFileSize = 10 * 1024 * 1024
+	maxReadmeSize       = 1 << 20
+)
+
+// Package represents an Ansible collection
+type Package struct {
+	Namespace string
+	Name      string
+	Version   string
+	Metadata  *Metadata
+}
+
+// FullName returns the fully qualified collection name which is used as package name
+func (p *Package) FullName() string {
+	return PackageName(p.Namespace, p.Name)
+}
+
+// PackageName returns the package name of a collection
+func PackageName(namespace, name string) string {
+	return namespace + "." + name
+}
+
+// Filename returns the name of the collection artifact
+func Filename(namespace, name, version string) string {
+	return namespace + "-" + name + "-" + version + ".tar.gz"
+}
+
+// Metadata represents the metadata of an Ansible collection
+type Metadata struct {
+	Namespace        string            `json:"namespace"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
+	Authors          []string          `json:"authors,omitempty"`
+	License          []string          `json:"license,omitempty"`
+	LicenseFile      string            `json:"license_file,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+	Dependencies     map[string]string `json:"dependencies,omitempty"`
+	RepositoryURL    string            `json:"repository_url,omitempty"`
+	DocumentationURL string            `json:"documentation_url,omitempty"`
+	HomepageURL      string            `json:"homepage_url,omitempty"`
+	IssuesURL        string            `json:"issues_url,omitempty"`
+	Readme           string            `json:"readme,omitempty"`
+}
+
+type collectionInfo struct {
+	Namespace     string            `json:"namespace"`
+	Name          string            `json:"name"`
+	Version       string            `json:"version"`
+	Authors       []string          `json:"authors"`
+	Readme        string            `json:"readme"`
+	Tags          []string          `json:"tags"`
+	Description   string            `json:"description"`
+	License       []string          `json:"license"`
+	LicenseFile   string            `json:"license_file"`
+	Dependencies  map[string]string `json:"dependencies"`
+	Repository    string            `json:"repository"`
+	Documentation string            `json:"documentation"`
+	Homepage      string            `json:"homepage"`
+	Issues        string            `json:"issues"`
+}
+
+type fileEntry struct {
+	Name         string `json:"name"`
+	FileType     string `json:"ftype"`
+	ChecksumType string `json:"chksum_type"`
+	ChecksumSHA2 string `json:"chksum_sha256"`
+}
+
+type manifestFile struct {
+	CollectionInfo   collectionInfo `json:"collection_info"`
+	FileManifestFile fileEntry      `json:"file_manifest_file"`
+	Format           int            `json:"format"`
+}
+
+type filesFile struct {
+	Files  []fileEntry `json:"files"`
+	Format int         `json:"format"`
+}
+
+// ParsePackage parses the collection artifact and verifies the checksums listed in MANIFEST.json and FILES.json
+func ParsePackage(r io.Reader) (*Package, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidArtifact
+	}
+	defer gzr.Close()
+
+	var manifestData, filesData []byte
+	readmes := make(map[string]string)
+	checksums := make(map[string]string)
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidArtifact
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(hd.Name, "./")
+
+		switch name {
+		case manifestFilename, filesFilename:
+			if hd.Size > maxManifestFileSize {
+				return nil, ErrManifestFileTooLarge
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			if name == manifestFilename {
+				manifestData = data
+			} else {
+				filesData = data
+				sum := sha256.Sum256(data)
+				checksums[name] = hex.EncodeToString(sum[:])
+			}
+			continue
+		}
+
+		h := sha256.New()
+		var w io.Writer = h
+		var readme strings.Builder
+		// The name of the readme file is only known after MANIFEST.json was read
+		if !strings.Contains(name, "/") && strings.HasPrefix(strings.ToLower(name), "readme") && hd.Size <= maxReadmeSize {
+			w = io.MultiWriter(h, &readme)
+		}
+		if _, err := io.Copy(w, tr); err != nil {
+			return nil, err
+		}
+		checksums[name] = hex.EncodeToString(h.Sum(nil))
+		if readme.Len() > 0 {
+			readmes[name] = readme.String()
+		}
+	}
+
+	if manifestData == nil {
+		return nil, ErrMissingManifestFile
+	}
+	if filesData == nil {
+		return nil, ErrMissingFilesFile
+	}
+
+	var manifest manifestFile
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, ErrInvalidManifestFile
+	}
+
+	if manifest.FileManifestFile.Name != filesFilename || !strings.EqualFold(manifest.FileManifestFile.ChecksumSHA2, checksums[filesFilename]) {
+		return nil, ErrChecksumMismatch
+	}
+
+	var files filesFile
+	if err := json.Unmarshal(filesData, &files); err != nil {
+		return nil, ErrInvalidFilesFile
+	}
+	for _, f := range files.Files {
+		if f.FileType != "file" {
+			continue
+		}
+		if checksum, ok := checksums[f.Name]; !ok || !strings.EqualFold(checksum, f.ChecksumSHA2) {
+			return nil, ErrChecksumMismatch
+		}
+	}
+
+	p, err := parseCollectionInfo(&manifest.CollectionInfo)
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.Readme = readmes[manifest.CollectionInfo.Readme]
+
+	return p, nil
+}
+
+func isValidName(name string) bool {
+	return namePattern.MatchString(name) && !strings.Contains(name, "__")
+}
+
+func parseCollectionInfo(ci *collectionInfo) (*Package, error) {
+	if !isValidName(ci.Namespace) {
+		return nil, ErrInvalidNamespace
+	}
+	if !isValidName(ci.Name) {
+		return nil, ErrInvalidName
+	}
+
+	if _, err := version.NewSemver(ci.Version); err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	for fqcn := range ci.Dependencies {
+		namespace, name, ok := strings.Cut(fqcn, ".")
+		if !ok || !isValidName(namespace) || !isValidName(name) {
+			return nil, ErrInvalidDependency
+		}
+	}
+
+	if !validation.IsValidURL(ci.Repository) {
+		ci.Repository = ""
+	}
+	if !validation.IsValidURL(ci.Documentation) {
+		ci.Documentation = ""
+	}
+	if !validation.IsValidURL(ci.Homepage) {
+		ci.Homepage = ""
+	}
+	if !validation.IsValidURL(ci.Issues) {
+		ci.Issues = ""
+	}
+
+	return &Package{
+		Namespace: ci.Namespace,
+		Name:      ci.Name,
+		Version:   ci.Version,
+		Metadata: &Metadata{
+			Namespace:        ci.Namespace,
+			Name:             ci.Name,
+			Description:      ci.Description,
+			Authors:          ci.Authors,
+			License:          ci.License,
+			LicenseFile:      ci.LicenseFile,
+			Tags:             ci.Tags,
+			Dependencies:     ci.Dependencies,
+			RepositoryURL:    ci.Repository,
+			DocumentationURL: ci.Documentation,
+			HomepageURL:      ci.Homepage,
+			IssuesURL:        ci.Issues,
+		},
+	}, nil
+}
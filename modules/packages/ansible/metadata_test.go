@@ -0,0 +1,117 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	namespace      = "gitea"
+	name           = "test_collection"
+	packageVersion = "1.0.0-beta.1"
+	description    = "Collection Description"
+	readme         = "# Test Collection"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func createArchive(files [][2]string) io.Reader {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, file := range files {
+		tw.WriteHeader(&tar.Header{
+			Name: file[0],
+			Mode: 0o600,
+			Size: int64(len(file[1])),
+		})
+		tw.Write([]byte(file[1]))
+	}
+	tw.Close()
+	zw.Close()
+	return &buf
+}
+
+func createCollection(namespace, name, version, readmeChecksum string) io.Reader {
+	filesJSON := fmt.Sprintf(`{"files":[{"name":".","ftype":"dir","chksum_type":null,"chksum_sha256":null,"format":1},{"name":"README.md","ftype":"file","chksum_type":"sha256","chksum_sha256":"%s","format":1}],"format":1}`, readmeChecksum)
+	manifestJSON := fmt.Sprintf(`{"collection_info":{"namespace":"%s","name":"%s","version":"%s","authors":["Gitea Authors"],"readme":"README.md","tags":["gitea","test"],"description":"%s","license":["MIT"],"license_file":null,"dependencies":{"community.general":">=1.0.0"},"repository":"https://gitea.com/gitea/test","documentation":"not a url","homepage":null,"issues":null},"file_manifest_file":{"name":"FILES.json","ftype":"file","chksum_type":"sha256","chksum_sha256":"%s","format":1},"format":1}`,
+		namespace, name, version, description, sha256Hex(filesJSON))
+
+	return createArchive([][2]string{
+		{"MANIFEST.json", manifestJSON},
+		{"FILES.json", filesJSON},
+		{"README.md", readme},
+	})
+}
+
+func TestParsePackage(t *testing.T) {
+	t.Run("InvalidArtifact", func(t *testing.T) {
+		p, err := ParsePackage(bytes.NewReader([]byte{1, 2, 3}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidArtifact)
+	})
+
+	t.Run("MissingManifestFile", func(t *testing.T) {
+		p, err := ParsePackage(createArchive([][2]string{{"FILES.json", "{}"}}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingManifestFile)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, invalid := range []string{"", "Test", "1test", "te__st", "te-st"} {
+			p, err := ParsePackage(createCollection(namespace, invalid, packageVersion, sha256Hex(readme)))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+
+			p, err = ParsePackage(createCollection(invalid, name, packageVersion, sha256Hex(readme)))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidNamespace)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParsePackage(createCollection(namespace, name, "1.a", sha256Hex(readme)))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		p, err := ParsePackage(createCollection(namespace, name, packageVersion, sha256Hex("other")))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(createCollection(namespace, name, packageVersion, sha256Hex(readme)))
+		require.NoError(t, err)
+		assert.NotNil(t, p)
+
+		assert.Equal(t, namespace, p.Namespace)
+		assert.Equal(t, name, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, "gitea.test_collection", p.FullName())
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, []string{"Gitea Authors"}, p.Metadata.Authors)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.License)
+		assert.Equal(t, []string{"gitea", "test"}, p.Metadata.Tags)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, p.Metadata.Dependencies)
+		assert.Equal(t, "https://gitea.com/gitea/test", p.Metadata.RepositoryURL)
+		assert.Empty(t, p.Metadata.DocumentationURL)
+		assert.Equal(t, readme, p.Metadata.Readme)
+	})
+}
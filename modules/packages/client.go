@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"strings"
+)
+
+const (
+	// ClientBrowser is the client name of web browsers
+	ClientBrowser = "browser"
+	// ClientOther is the client name of unknown clients
+	ClientOther = "other"
+)
+
+// knownClients maps lower case user agent prefixes to client names.
+// The list is fixed to keep the number of distinct clients in the download statistics small.
+var knownClients = []struct {
+	Prefix string
+	Name   string
+}{
+	{"ansible-galaxy", "ansible-galaxy"},
+	{"apache-maven", "maven"},
+	{"apk", "apk"},
+	{"bun", "bun"},
+	{"bundler", "bundler"},
+	{"buildkit", "buildkit"},
+	{"cargo", "cargo"},
+	{"composer", "composer"},
+	{"conan", "conan"},
+	{"conda", "conda"},
+	{"containerd", "containerd"},
+	{"crane", "crane"},
+	{"curl", "curl"},
+	{"dart pub", "pub"},
+	{"debian apt-http", "apt"},
+	{"docker", "docker"},
+	{"dotnet", "nuget"},
+	{"gradle", "gradle"},
+	{"go-http-client", "go"},
+	{"helm", "helm"},
+	{"hex", "hex"},
+	{"libdnf", "dnf"},
+	{"librpm", "dnf"},
+	{"libpod", "podman"},
+	{"mozilla", ClientBrowser},
+	{"npm", "npm"},
+	{"nuget", "nuget"},
+	{"opentofu", "opentofu"},
+	{"pacman", "pacman"},
+	{"pdm", "pdm"},
+	{"pip", "pip"},
+	{"pnpm", "pnpm"},
+	{"podman", "podman"},
+	{"poetry", "poetry"},
+	{"python-requests", "pip"},
+	{"r (", "r"},
+	{"rubygems", "rubygems"},
+	{"skopeo", "skopeo"},
+	{"swiftpackagemanager", "swift"},
+	{"terraform", "terraform"},
+	{"twine", "twine"},
+	{"uv", "uv"},
+	{"vagrant", "vagrant"},
+	{"wget", "wget"},
+	{"yarn", "yarn"},
+}
+
+// ClientFromUserAgent returns the name of the package client which sent the user agent
+func ClientFromUserAgent(userAgent string) string {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+
+	for _, c := range knownClients {
+		if strings.HasPrefix(userAgent, c.Prefix) && isProductNameEnd(userAgent, len(c.Prefix)) {
+			return c.Name
+		}
+	}
+	return ClientOther
+}
+
+// isProductNameEnd checks if the product name of the user agent ends at the position
+func isProductNameEnd(userAgent string, pos int) bool {
+	if pos == len(userAgent) || userAgent[pos-1] == '(' {
+		return true
+	}
+	switch userAgent[pos] {
+	case '/', ' ', ';', '(':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientFromUserAgent(t *testing.T) {
+	cases := map[string]string{
+		"npm/10.2.4 node/v20.11.0 linux x64 workspaces/false": "npm",
+		"pnpm/8.15.1 npm/? node/v20.11.0 linux x64":           "pnpm",
+		"pip/24.0 {\"ci\":null}":                              "pip",
+		"pipenv/2023.12.1":                                    ClientOther,
+		"docker/25.0.3 go/go1.21.6 git-commit/f417435":        "docker",
+		"Apache-Maven/3.9.6 (Java 17.0.10; Linux 6.5.0)":      "maven",
+		"NuGet Command Line/6.8.0 (Microsoft Windows 10)":     "nuget",
+		"Go-http-client/1.1":                                  "go",
+		"Debian APT-HTTP/1.3 (2.7.14)":                        "apt",
+		"R (4.3.2 x86_64-pc-linux-gnu x86_64 linux-gnu)":      "r",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:122.0)":           ClientBrowser,
+		"curl":               "curl",
+		"":                   ClientOther,
+		"MyCustomClient/1.0": ClientOther,
+	}
+
+	for userAgent, expected := range cases {
+		assert.Equal(t, expected, ClientFromUserAgent(userAgent), userAgent)
+	}
+}
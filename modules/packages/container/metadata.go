@@ -21,6 +21,7 @@ const (
 	PropertyMediaType         = "container.mediatype"
 	PropertyManifestTagged    = "container.manifest.tagged"
 	PropertyManifestReference = "container.manifest.reference"
+	PropertyManifestSubject   = "container.manifest.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -63,6 +64,9 @@ type Metadata struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
+	Subject          string            `json:"subject,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
 }
 
 type Manifest struct {
@@ -71,6 +75,30 @@ type Manifest struct {
 	Size     int64  `json:"size"`
 }
 
+type ArtifactKind string
+
+const (
+	ArtifactKindSignature   ArtifactKind = "signature"
+	ArtifactKindSBOM        ArtifactKind = "sbom"
+	ArtifactKindAttestation ArtifactKind = "attestation"
+	ArtifactKindOther       ArtifactKind = "other"
+)
+
+// GetArtifactKind classifies the artifact type of a manifest referring to an image
+func GetArtifactKind(artifactType string) ArtifactKind {
+	at := strings.ToLower(artifactType)
+	switch {
+	case strings.Contains(at, "spdx"), strings.Contains(at, "cyclonedx"), strings.Contains(at, "syft"), strings.Contains(at, "sbom"):
+		return ArtifactKindSBOM
+	case strings.Contains(at, "in-toto"), strings.Contains(at, "intoto"), strings.Contains(at, "dsse"), strings.Contains(at, "attestation"), strings.Contains(at, "provenance"):
+		return ArtifactKindAttestation
+	case strings.Contains(at, "signature"), strings.Contains(at, ".sig."), strings.Contains(at, "sigstore"):
+		return ArtifactKindSignature
+	default:
+		return ArtifactKindOther
+	}
+}
+
 // ParseImageConfig parses the metadata of an image config
 func ParseImageConfig(mt string, r io.Reader) (*Metadata, error) {
 	if strings.EqualFold(mt, helm.ConfigMediaType) {
@@ -27,9 +27,6 @@ const (
 	PropertyControl                    = "debian.control"
 	PropertyRepositoryIncludeInRelease = "debian.repository.include_in_release"
 
-	SettingKeyPrivate = "debian.key.private"
-	SettingKeyPublic  = "debian.key.public"
-
 	RepositoryPackage = "_debian"
 	RepositoryVersion = "_repository"
 
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// ContentTypeErlang is the content type of API responses in the Erlang External Term Format
+const ContentTypeErlang = "application/vnd.hex+erlang"
+
+// EncodeTerm encodes the map with binary keys and values in the Erlang External Term Format
+// The Hex client decodes the API responses with binary_to_term.
+func EncodeTerm(m map[string]string) []byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := []byte{131, 116} // VERSION_MAGIC, MAP_EXT
+	b = binary.BigEndian.AppendUint32(b, uint32(len(keys)))
+	for _, k := range keys {
+		b = appendBinaryTerm(b, k)
+		b = appendBinaryTerm(b, m[k])
+	}
+	return b
+}
+
+func appendBinaryTerm(b []byte, s string) []byte {
+	b = append(b, 109) // BINARY_EXT
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
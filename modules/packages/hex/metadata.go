@@ -0,0 +1,286 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrInvalidTarball       = util.NewInvalidArgumentErrorf("tarball is invalid")
+	ErrMissingVersionFile   = util.NewInvalidArgumentErrorf("VERSION file is missing")
+	ErrUnsupportedVersion   = util.NewInvalidArgumentErrorf("tarball version is not supported")
+	ErrMissingMetadataFile  = util.NewInvalidArgumentErrorf("metadata.config file is missing")
+	ErrMissingContentsFile  = util.NewInvalidArgumentErrorf("contents.tar.gz file is missing")
+	ErrInvalidMetadataFile  = util.NewInvalidArgumentErrorf("metadata.config file is invalid")
+	ErrInvalidChecksum      = util.NewInvalidArgumentErrorf("CHECKSUM does not match the tarball content")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidRequirement   = util.NewInvalidArgumentErrorf("package requirement is invalid")
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("metadata.config file is too large")
+)
+
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+const (
+	SettingKeyPrivate = "hex.key.private"
+	SettingKeyPublic  = "hex.key.public"
+
+	tarballVersion      = "3"
+	maxMetadataFileSize = 128 * 1024
+)
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App           string            `json:"app,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Licenses      []string          `json:"licenses,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+	BuildTools    []string          `json:"build_tools,omitempty"`
+	Elixir        string            `json:"elixir,omitempty"`
+	Requirements  []*Requirement    `json:"requirements,omitempty"`
+	InnerChecksum string            `json:"inner_checksum"`
+}
+
+// Requirement represents a dependency of a Hex package
+type Requirement struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// ParsePackage parses the Hex release tarball
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+func ParsePackage(r io.Reader) (*Package, error) {
+	var versionFile, checksumFile, metadataFile []byte
+	inner := sha256.New()
+	hasContents := false
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTarball
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case "VERSION":
+			if versionFile, err = io.ReadAll(io.LimitReader(tr, 10)); err != nil {
+				return nil, err
+			}
+		case "CHECKSUM":
+			if checksumFile, err = io.ReadAll(io.LimitReader(tr, 100)); err != nil {
+				return nil, err
+			}
+		case "metadata.config":
+			if hd.Size > maxMetadataFileSize {
+				return nil, ErrMetadataFileTooLarge
+			}
+			if metadataFile, err = io.ReadAll(tr); err != nil {
+				return nil, err
+			}
+		case "contents.tar.gz":
+			// The inner checksum covers the files in the order VERSION, metadata.config, contents.tar.gz
+			if versionFile == nil || metadataFile == nil {
+				return nil, ErrInvalidMetadataFile
+			}
+			inner.Write(versionFile)
+			inner.Write(metadataFile)
+			if _, err := io.Copy(inner, tr); err != nil {
+				return nil, err
+			}
+			hasContents = true
+		}
+	}
+
+	if versionFile == nil {
+		return nil, ErrMissingVersionFile
+	}
+	if strings.TrimSpace(string(versionFile)) != tarballVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	if metadataFile == nil {
+		return nil, ErrMissingMetadataFile
+	}
+	if !hasContents {
+		return nil, ErrMissingContentsFile
+	}
+
+	innerChecksum := hex.EncodeToString(inner.Sum(nil))
+	if checksumFile != nil && !strings.EqualFold(strings.TrimSpace(string(checksumFile)), innerChecksum) {
+		return nil, ErrInvalidChecksum
+	}
+
+	p, err := ParseMetadataConfig(bytes.NewReader(metadataFile))
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.InnerChecksum = innerChecksum
+
+	return p, nil
+}
+
+// ParseMetadataConfig parses the metadata.config file of a Hex package
+func ParseMetadataConfig(r io.Reader) (*Package, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxMetadataFileSize))
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := parseTerms(string(data))
+	if err != nil {
+		return nil, ErrInvalidMetadataFile
+	}
+
+	config := make(map[string]any, len(terms))
+	for _, term := range terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple) != 2 {
+			return nil, ErrInvalidMetadataFile
+		}
+		key, ok := termToString(tuple[0])
+		if !ok {
+			return nil, ErrInvalidMetadataFile
+		}
+		config[key] = tuple[1]
+	}
+
+	name, _ := termToString(config["name"])
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	v, _ := termToString(config["version"])
+	if _, err := version.NewSemver(v); err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		Licenses:   termToStrings(config["licenses"]),
+		BuildTools: termToStrings(config["build_tools"]),
+	}
+	m.App, _ = termToString(config["app"])
+	m.Description, _ = termToString(config["description"])
+	m.Elixir, _ = termToString(config["elixir"])
+
+	if links, ok := termToMap(config["links"]); ok {
+		m.Links = make(map[string]string, len(links))
+		for k, v := range links {
+			if s, ok := termToString(v); ok && validation.IsValidURL(s) {
+				m.Links[k] = s
+			}
+		}
+	}
+
+	if m.Requirements, err = parseRequirements(config["requirements"]); err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		Name:     name,
+		Version:  v,
+		Metadata: m,
+	}, nil
+}
+
+// parseRequirements supports the list of maps written by current clients and the map keyed by name of older clients
+func parseRequirements(v any) ([]*Requirement, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	entries := make([]map[string]any, 0, 10)
+	if list, ok := v.([]any); ok && (len(list) == 0 || !isProplist(list)) {
+		for _, e := range list {
+			m, ok := termToMap(e)
+			if !ok {
+				return nil, ErrInvalidRequirement
+			}
+			entries = append(entries, m)
+		}
+	} else if byName, ok := termToMap(v); ok {
+		for name, e := range byName {
+			m, ok := termToMap(e)
+			if !ok {
+				return nil, ErrInvalidRequirement
+			}
+			m["name"] = name
+			entries = append(entries, m)
+		}
+	} else {
+		return nil, ErrInvalidRequirement
+	}
+
+	requirements := make([]*Requirement, 0, len(entries))
+	for _, e := range entries {
+		r := &Requirement{}
+		r.Name, _ = termToString(e["name"])
+		if !namePattern.MatchString(r.Name) {
+			return nil, ErrInvalidRequirement
+		}
+		r.App, _ = termToString(e["app"])
+		r.Requirement, _ = termToString(e["requirement"])
+		r.Optional, _ = e["optional"].(bool)
+		r.Repository, _ = termToString(e["repository"])
+		requirements = append(requirements, r)
+	}
+
+	sort.Slice(requirements, func(i, j int) bool {
+		return requirements[i].Name < requirements[j].Name
+	})
+
+	return requirements, nil
+}
+
+// isProplist checks if the list consists of {Name, Properties} tuples
+func isProplist(list []any) bool {
+	for _, e := range list {
+		if t, ok := e.(Tuple); !ok || len(t) != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+func termToStrings(v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, e := range list {
+		if s, ok := termToString(e); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
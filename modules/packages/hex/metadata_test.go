@@ -0,0 +1,176 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "gitea_test"
+	packageVersion = "1.0.1-rc.1"
+	description    = "Package Description"
+)
+
+const metadataConfig = `{<<"app">>,<<"gitea_test">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"` + description + `">>}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"files">>,[<<"lib">>,<<"lib/gitea_test.ex">>,<<"mix.exs">>]}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://gitea.com/gitea/gitea">>},{<<"Invalid">>,<<"no url">>}]}.
+{<<"name">>,<<"` + packageName + `">>}.
+{<<"requirements">>,
+ [#{<<"app">> => <<"jason">>,<<"name">> => <<"jason">>,
+    <<"optional">> => false,<<"repository">> => <<"hexpm">>,
+    <<"requirement">> => <<"~> 1.4">>},
+  #{<<"app">> => <<"plug">>,<<"name">> => <<"plug">>,
+    <<"optional">> => true,<<"repository">> => <<"hexpm">>,
+    <<"requirement">> => <<">= 1.0.0">>}]}.
+{<<"version">>,<<"` + packageVersion + `">>}.
+`
+
+func createTarball(files ...[2]string) io.Reader {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range files {
+		tw.WriteHeader(&tar.Header{
+			Name: file[0],
+			Mode: 0o600,
+			Size: int64(len(file[1])),
+		})
+		tw.Write([]byte(file[1]))
+	}
+	tw.Close()
+	return &buf
+}
+
+func TestParsePackage(t *testing.T) {
+	contents := "dummy contents"
+	checksum := sha256.Sum256([]byte("3" + metadataConfig + contents))
+
+	t.Run("MissingVersionFile", func(t *testing.T) {
+		p, err := ParsePackage(createTarball([2]string{"metadata.config", metadataConfig}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingVersionFile)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		p, err := ParsePackage(createTarball([2]string{"VERSION", "2"}, [2]string{"metadata.config", metadataConfig}, [2]string{"contents.tar.gz", contents}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("MissingContentsFile", func(t *testing.T) {
+		p, err := ParsePackage(createTarball([2]string{"VERSION", "3"}, [2]string{"metadata.config", metadataConfig}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingContentsFile)
+	})
+
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		p, err := ParsePackage(createTarball([2]string{"VERSION", "3"}, [2]string{"CHECKSUM", strings.Repeat("A", 64)}, [2]string{"metadata.config", metadataConfig}, [2]string{"contents.tar.gz", contents}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidChecksum)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(createTarball(
+			[2]string{"VERSION", "3"},
+			[2]string{"CHECKSUM", strings.ToUpper(hex.EncodeToString(checksum[:]))},
+			[2]string{"metadata.config", metadataConfig},
+			[2]string{"contents.tar.gz", contents},
+		))
+		require.NoError(t, err)
+		assert.NotNil(t, p)
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, hex.EncodeToString(checksum[:]), p.Metadata.InnerChecksum)
+	})
+}
+
+func TestParseMetadataConfig(t *testing.T) {
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Gitea", "1gitea", "gi-tea"} {
+			p, err := ParseMetadataConfig(strings.NewReader(strings.Replace(metadataConfig, `{<<"name">>,<<"`+packageName, `{<<"name">>,<<"`+name, 1)))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(strings.Replace(metadataConfig, packageVersion, "1.a", 1)))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("InvalidSyntax", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(`{<<"name">>,<<"test">>`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidMetadataFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(metadataConfig))
+		require.NoError(t, err)
+		assert.NotNil(t, p)
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, "gitea_test", p.Metadata.App)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "~> 1.15", p.Metadata.Elixir)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, map[string]string{"GitHub": "https://gitea.com/gitea/gitea"}, p.Metadata.Links)
+		assert.Equal(t, []*Requirement{
+			{Name: "jason", App: "jason", Requirement: "~> 1.4", Repository: "hexpm"},
+			{Name: "plug", App: "plug", Requirement: ">= 1.0.0", Optional: true, Repository: "hexpm"},
+		}, p.Metadata.Requirements)
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		content := `{<<"name">>,<<"test">>}.
+{<<"version">>,<<"0.1.0">>}.
+{<<"requirements">>,[{<<"plug">>,[{<<"app">>,<<"plug">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.0">>}]}]}.
+`
+		p, err := ParseMetadataConfig(strings.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, []*Requirement{
+			{Name: "plug", App: "plug", Requirement: "~> 1.0"},
+		}, p.Metadata.Requirements)
+	})
+
+	t.Run("Escapes", func(t *testing.T) {
+		content := `% comment
+{<<"name">>,<<"test">>}.
+{<<"version">>,<<"0.1.0">>}.
+{<<"description">>,<<"Caf\303\251 \"quoted\"\n"/utf8>>}.
+`
+		p, err := ParseMetadataConfig(strings.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, "CafÃ© \"quoted\"\n", p.Metadata.Description)
+
+		content = strings.Replace(content, `<<"Caf\303\251 \"quoted\"\n"/utf8>>`, `<<67,97,102,195,169>>`, 1)
+		p, err = ParseMetadataConfig(strings.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, "Café", p.Metadata.Description)
+	})
+}
+
+func TestEncodeTerm(t *testing.T) {
+	assert.Equal(t,
+		[]byte{131, 116, 0, 0, 0, 1, 109, 0, 0, 0, 1, 'a', 109, 0, 0, 0, 2, 'b', 'c'},
+		EncodeTerm(map[string]string{"a": "bc"}),
+	)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages wrapped in a signed message and gzipped
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// NamesEntry is a package of the /names resource
+type NamesEntry struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+// VersionsEntry is a package of the /versions resource
+type VersionsEntry struct {
+	Name     string
+	Versions []string
+	// Retired contains the indexes of the retired versions
+	Retired []int
+}
+
+// Release is a release of the /packages/<name> resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Requirement
+}
+
+// BuildNames builds the Names message
+func BuildNames(repository string, entries []*NamesEntry) []byte {
+	var b []byte
+	for _, e := range entries {
+		var pkg []byte
+		pkg = appendString(pkg, 1, e.Name)
+		if !e.UpdatedAt.IsZero() {
+			var ts []byte
+			ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+			ts = protowire.AppendVarint(ts, uint64(e.UpdatedAt.Unix()))
+			if nanos := e.UpdatedAt.Nanosecond(); nanos != 0 {
+				ts = protowire.AppendTag(ts, 2, protowire.VarintType)
+				ts = protowire.AppendVarint(ts, uint64(nanos))
+			}
+			pkg = appendBytes(pkg, 2, ts)
+		}
+		b = appendBytes(b, 1, pkg)
+	}
+	return appendString(b, 2, repository)
+}
+
+// BuildVersions builds the Versions message
+func BuildVersions(repository string, entries []*VersionsEntry) []byte {
+	var b []byte
+	for _, e := range entries {
+		var pkg []byte
+		pkg = appendString(pkg, 1, e.Name)
+		for _, v := range e.Versions {
+			pkg = appendString(pkg, 2, v)
+		}
+		if len(e.Retired) > 0 {
+			var packed []byte
+			for _, i := range e.Retired {
+				packed = protowire.AppendVarint(packed, uint64(i))
+			}
+			pkg = appendBytes(pkg, 3, packed)
+		}
+		b = appendBytes(b, 1, pkg)
+	}
+	return appendString(b, 2, repository)
+}
+
+// BuildPackage builds the Package message
+func BuildPackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, r := range releases {
+		var rel []byte
+		rel = appendString(rel, 1, r.Version)
+		rel = appendBytes(rel, 2, r.InnerChecksum)
+		for _, d := range r.Dependencies {
+			var dep []byte
+			dep = appendString(dep, 1, d.Name)
+			dep = appendString(dep, 2, d.Requirement)
+			if d.Optional {
+				dep = protowire.AppendTag(dep, 3, protowire.VarintType)
+				dep = protowire.AppendVarint(dep, 1)
+			}
+			if d.App != "" && d.App != d.Name {
+				dep = appendString(dep, 4, d.App)
+			}
+			if d.Repository != "" {
+				dep = appendString(dep, 5, d.Repository)
+			}
+			rel = appendBytes(rel, 3, dep)
+		}
+		if len(r.OuterChecksum) > 0 {
+			rel = appendBytes(rel, 5, r.OuterChecksum)
+		}
+		b = appendBytes(b, 1, rel)
+	}
+	b = appendString(b, 2, name)
+	return appendString(b, 3, repository)
+}
+
+// BuildSigned wraps the payload in a Signed message and compresses it
+func BuildSigned(payload, signature []byte) ([]byte, error) {
+	var b []byte
+	b = appendBytes(b, 1, payload)
+	b = appendBytes(b, 2, signature)
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
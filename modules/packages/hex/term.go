@@ -0,0 +1,405 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Atom is an Erlang atom
+type Atom string
+
+// Tuple is an Erlang tuple
+type Tuple []any
+
+var errUnexpectedEnd = errors.New("unexpected end of input")
+
+// termParser parses the subset of the Erlang term syntax which is written by io_lib:format("~p") and read by file:consult
+// Binaries and strings are returned as string, lists as []any, maps as map[string]any and integers as int64.
+type termParser struct {
+	data string
+	pos  int
+}
+
+// parseTerms parses all terms of a file in the format read by file:consult
+func parseTerms(data string) ([]any, error) {
+	p := &termParser{data: data}
+
+	terms := make([]any, 0, 10)
+	for {
+		p.skipWhitespace()
+		if p.pos >= len(p.data) {
+			return terms, nil
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("."); err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+}
+
+func (p *termParser) skipWhitespace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) peek(s string) bool {
+	p.skipWhitespace()
+	return strings.HasPrefix(p.data[p.pos:], s)
+}
+
+func (p *termParser) expect(s string) error {
+	if !p.peek(s) {
+		if p.pos >= len(p.data) {
+			return errUnexpectedEnd
+		}
+		return fmt.Errorf("expected %q at position %d", s, p.pos)
+	}
+	p.pos += len(s)
+	return nil
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipWhitespace()
+	if p.pos >= len(p.data) {
+		return nil, errUnexpectedEnd
+	}
+
+	switch c := p.data[p.pos]; {
+	case strings.HasPrefix(p.data[p.pos:], "<<"):
+		return p.parseBinary()
+	case strings.HasPrefix(p.data[p.pos:], "#{"):
+		return p.parseMap()
+	case c == '{':
+		p.pos++
+		elements, err := p.parseSequence("}")
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elements), nil
+	case c == '[':
+		p.pos++
+		return p.parseSequence("]")
+	case c == '"':
+		return p.parseQuoted('"')
+	case c == '\'':
+		s, err := p.parseQuoted('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(s), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for p.pos < len(p.data) && isAtomChar(p.data[p.pos]) {
+			p.pos++
+		}
+		switch atom := p.data[start:p.pos]; atom {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		default:
+			return Atom(atom), nil
+		}
+	default:
+		return nil, fmt.Errorf("unexpected character %q at position %d", c, p.pos)
+	}
+}
+
+func isAtomChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '@'
+}
+
+func (p *termParser) parseSequence(end string) ([]any, error) {
+	elements := make([]any, 0, 4)
+	if p.peek(end) {
+		p.pos += len(end)
+		return elements, nil
+	}
+	for {
+		element, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		if p.peek(",") {
+			p.pos++
+			continue
+		}
+		return elements, p.expect(end)
+	}
+}
+
+func (p *termParser) parseMap() (any, error) {
+	p.pos += 2
+
+	m := make(map[string]any)
+	if p.peek("}") {
+		p.pos++
+		return m, nil
+	}
+	for {
+		key, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("=>"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		k, ok := termToString(key)
+		if !ok {
+			return nil, fmt.Errorf("unsupported map key at position %d", p.pos)
+		}
+		m[k] = value
+
+		if p.peek(",") {
+			p.pos++
+			continue
+		}
+		return m, p.expect("}")
+	}
+}
+
+// parseBinary parses <<"text">>, <<"text"/utf8>> and <<1,2,3>>
+func (p *termParser) parseBinary() (any, error) {
+	p.pos += 2
+
+	var sb strings.Builder
+	if p.peek(">>") {
+		p.pos += 2
+		return "", nil
+	}
+	for {
+		p.skipWhitespace()
+		if p.pos >= len(p.data) {
+			return nil, errUnexpectedEnd
+		}
+
+		if p.data[p.pos] == '"' {
+			s, err := p.parseQuoted('"')
+			if err != nil {
+				return nil, err
+			}
+			if p.peek("/utf8") {
+				p.pos += 5
+				sb.WriteString(s)
+			} else {
+				// Without the utf8 modifier every character is a single byte
+				for _, r := range s {
+					sb.WriteByte(byte(r))
+				}
+			}
+		} else {
+			n, err := p.parseNumber()
+			if err != nil {
+				return nil, err
+			}
+			i, ok := n.(int64)
+			if !ok || i < 0 || i > 255 {
+				return nil, fmt.Errorf("invalid byte at position %d", p.pos)
+			}
+			sb.WriteByte(byte(i))
+		}
+
+		if p.peek(",") {
+			p.pos++
+			continue
+		}
+		if err := p.expect(">>"); err != nil {
+			return nil, err
+		}
+
+		s := sb.String()
+		if !utf8.ValidString(s) {
+			return nil, fmt.Errorf("binary is not valid UTF-8 at position %d", p.pos)
+		}
+		return s, nil
+	}
+}
+
+func (p *termParser) parseQuoted(quote byte) (string, error) {
+	p.pos++
+
+	var sb strings.Builder
+	for {
+		if p.pos >= len(p.data) {
+			return "", errUnexpectedEnd
+		}
+
+		c := p.data[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			p.pos++
+			if p.pos >= len(p.data) {
+				return "", errUnexpectedEnd
+			}
+			r, err := p.parseEscape()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+		default:
+			r, size := utf8.DecodeRuneInString(p.data[p.pos:])
+			sb.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+func (p *termParser) parseEscape() (rune, error) {
+	c := p.data[p.pos]
+	p.pos++
+
+	switch c {
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'v':
+		return '\v', nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'e':
+		return 0x1b, nil
+	case 's':
+		return ' ', nil
+	case 'd':
+		return 0x7f, nil
+	case 'x':
+		if strings.HasPrefix(p.data[p.pos:], "{") {
+			end := strings.IndexByte(p.data[p.pos:], '}')
+			if end == -1 {
+				return 0, errUnexpectedEnd
+			}
+			v, err := strconv.ParseUint(p.data[p.pos+1:p.pos+end], 16, 32)
+			if err != nil {
+				return 0, err
+			}
+			p.pos += end + 1
+			return rune(v), nil
+		}
+		if p.pos+2 > len(p.data) {
+			return 0, errUnexpectedEnd
+		}
+		v, err := strconv.ParseUint(p.data[p.pos:p.pos+2], 16, 8)
+		if err != nil {
+			return 0, err
+		}
+		p.pos += 2
+		return rune(v), nil
+	}
+
+	if c >= '0' && c <= '7' {
+		start := p.pos - 1
+		for p.pos < len(p.data) && p.pos-start < 3 && p.data[p.pos] >= '0' && p.data[p.pos] <= '7' {
+			p.pos++
+		}
+		v, err := strconv.ParseUint(p.data[start:p.pos], 8, 16)
+		if err != nil {
+			return 0, err
+		}
+		return rune(v), nil
+	}
+
+	return rune(c), nil
+}
+
+func (p *termParser) parseNumber() (any, error) {
+	p.skipWhitespace()
+
+	start := p.pos
+	if p.pos < len(p.data) && p.data[p.pos] == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c >= '0' && c <= '9' {
+			p.pos++
+		} else if c == '.' && p.pos+1 < len(p.data) && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' {
+			// a dot which is not followed by a digit terminates the term
+			isFloat = true
+			p.pos++
+		} else if isFloat && (c == 'e' || c == 'E' || ((c == '-' || c == '+') && (p.data[p.pos-1] == 'e' || p.data[p.pos-1] == 'E'))) {
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	s := p.data[start:p.pos]
+	if isFloat {
+		return strconv.ParseFloat(s, 64)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func termToString(v any) (string, bool) {
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case Atom:
+		return string(t), true
+	default:
+		return "", false
+	}
+}
+
+// termToMap converts a map or a property list of 2-tuples
+func termToMap(v any) (map[string]any, bool) {
+	switch t := v.(type) {
+	case map[string]any:
+		return t, true
+	case []any:
+		m := make(map[string]any, len(t))
+		for _, e := range t {
+			tuple, ok := e.(Tuple)
+			if !ok || len(tuple) != 2 {
+				return nil, false
+			}
+			k, ok := termToString(tuple[0])
+			if !ok {
+				return nil, false
+			}
+			m[k] = tuple[1]
+		}
+		return m, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package provenance
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	// StatementTypeV01 is the type of in-toto v0.1 statements
+	StatementTypeV01 = "https://in-toto.io/Statement/v0.1"
+	// StatementTypeV1 is the type of in-toto v1 statements
+	StatementTypeV1 = "https://in-toto.io/Statement/v1"
+	// PayloadType is the DSSE payload type of in-toto statements
+	PayloadType = "application/vnd.in-toto+json"
+
+	// PredicateTypeSLSAv02 is the predicate type of SLSA v0.2 provenance
+	PredicateTypeSLSAv02 = "https://slsa.dev/provenance/v0.2"
+	// PredicateTypeSLSAv1 is the predicate type of SLSA v1 provenance
+	PredicateTypeSLSAv1 = "https://slsa.dev/provenance/v1"
+
+	// MaxSize is the maximum size of a provenance document
+	MaxSize = 1 << 20
+)
+
+var (
+	ErrInvalidDocument  = util.NewInvalidArgumentErrorf("document is neither an in-toto statement nor a DSSE envelope")
+	ErrInvalidStatement = util.NewInvalidArgumentErrorf("invalid in-toto statement")
+	ErrDocumentTooLarge = util.NewInvalidArgumentErrorf("document is too large")
+)
+
+// Subject is an artifact the statement is about
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Statement is an in-toto attestation statement
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []*Subject `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+}
+
+// envelope is a DSSE envelope
+type envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// Source is the source the artifacts were built from
+type Source struct {
+	URI    string
+	Digest string
+}
+
+// Document is a parsed provenance document
+type Document struct {
+	Statement *Statement
+	// HasSignatures is set if the statement was wrapped in a DSSE envelope with signatures, they are not verified
+	HasSignatures bool
+	Source        *Source
+}
+
+// ParseDocument parses an in-toto statement which may be wrapped in a DSSE envelope
+func ParseDocument(data []byte) (*Document, error) {
+	if len(data) > MaxSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	var probe struct {
+		Type        string `json:"_type"`
+		PayloadType string `json:"payloadType"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, ErrInvalidDocument
+	}
+
+	doc := &Document{}
+	switch {
+	case probe.PayloadType != "":
+		var env envelope
+		if err := json.Unmarshal(data, &env); err != nil {
+			return nil, ErrInvalidDocument
+		}
+		if env.PayloadType != PayloadType {
+			return nil, util.NewInvalidArgumentErrorf("unsupported payload type: %s", env.PayloadType)
+		}
+		payload, err := base64.StdEncoding.DecodeString(env.Payload)
+		if err != nil {
+			return nil, ErrInvalidStatement
+		}
+		data = payload
+		doc.HasSignatures = len(env.Signatures) > 0
+	case probe.Type == "":
+		return nil, ErrInvalidDocument
+	}
+
+	if err := json.Unmarshal(data, &doc.Statement); err != nil || doc.Statement == nil {
+		return nil, ErrInvalidStatement
+	}
+	if err := doc.Statement.validate(); err != nil {
+		return nil, err
+	}
+
+	doc.Source = extractSource(doc.Statement.PredicateType, data)
+
+	return doc, nil
+}
+
+func (s *Statement) validate() error {
+	if s.Type != StatementTypeV01 && s.Type != StatementTypeV1 {
+		return util.NewInvalidArgumentErrorf("unsupported statement type: %s", s.Type)
+	}
+	if s.PredicateType == "" {
+		return util.NewInvalidArgumentErrorf("invalid in-toto statement: predicate type is missing")
+	}
+	if len(s.Subject) == 0 {
+		return util.NewInvalidArgumentErrorf("invalid in-toto statement: subject is missing")
+	}
+	for _, subject := range s.Subject {
+		if subject == nil || len(subject.Digest) == 0 {
+			return util.NewInvalidArgumentErrorf("invalid in-toto statement: subject digest is missing")
+		}
+	}
+	return nil
+}
+
+// extractSource extracts the source repository and commit of SLSA provenance predicates
+func extractSource(predicateType string, data []byte) *Source {
+	switch predicateType {
+	case PredicateTypeSLSAv1:
+		var statement struct {
+			Predicate struct {
+				BuildDefinition struct {
+					ResolvedDependencies []struct {
+						URI    string            `json:"uri"`
+						Digest map[string]string `json:"digest"`
+					} `json:"resolvedDependencies"`
+				} `json:"buildDefinition"`
+			} `json:"predicate"`
+		}
+		if err := json.Unmarshal(data, &statement); err != nil {
+			return nil
+		}
+		for _, dep := range statement.Predicate.BuildDefinition.ResolvedDependencies {
+			if commit := gitCommit(dep.Digest); commit != "" {
+				return &Source{URI: dep.URI, Digest: commit}
+			}
+		}
+	case PredicateTypeSLSAv02:
+		var statement struct {
+			Predicate struct {
+				Invocation struct {
+					ConfigSource struct {
+						URI    string            `json:"uri"`
+						Digest map[string]string `json:"digest"`
+					} `json:"configSource"`
+				} `json:"invocation"`
+				Materials []struct {
+					URI    string            `json:"uri"`
+					Digest map[string]string `json:"digest"`
+				} `json:"materials"`
+			} `json:"predicate"`
+		}
+		if err := json.Unmarshal(data, &statement); err != nil {
+			return nil
+		}
+		if commit := gitCommit(statement.Predicate.Invocation.ConfigSource.Digest); commit != "" {
+			return &Source{URI: statement.Predicate.Invocation.ConfigSource.URI, Digest: commit}
+		}
+		for _, material := range statement.Predicate.Materials {
+			if commit := gitCommit(material.Digest); commit != "" {
+				return &Source{URI: material.URI, Digest: commit}
+			}
+		}
+	}
+	return nil
+}
+
+func gitCommit(digest map[string]string) string {
+	if commit := digest["gitCommit"]; commit != "" {
+		return strings.ToLower(commit)
+	}
+	return strings.ToLower(digest["sha1"])
+}
+
+// SubjectDigests returns the sha256 digests of all subjects. Subjects without sha256 digest are returned as empty string.
+func (s *Statement) SubjectDigests() []string {
+	digests := make([]string, 0, len(s.Subject))
+	for _, subject := range s.Subject {
+		digests = append(digests, strings.ToLower(subject.Digest["sha256"]))
+	}
+	return digests
+}
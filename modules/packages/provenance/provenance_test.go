@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package provenance
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	subjectDigest = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	commitSHA     = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+)
+
+const slsaV1Statement = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"subject": [{"name": "package.tgz", "digest": {"sha256": "` + subjectDigest + `"}}],
+	"predicateType": "https://slsa.dev/provenance/v1",
+	"predicate": {
+		"buildDefinition": {
+			"buildType": "https://gitea.com/actions",
+			"resolvedDependencies": [{"uri": "git+https://gitea.com/user/repo@refs/heads/main", "digest": {"gitCommit": "` + commitSHA + `"}}]
+		}
+	}
+}`
+
+func TestParseDocument(t *testing.T) {
+	t.Run("Statement", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(slsaV1Statement))
+		assert.NoError(t, err)
+		assert.False(t, doc.HasSignatures)
+		assert.Equal(t, PredicateTypeSLSAv1, doc.Statement.PredicateType)
+		assert.Equal(t, []string{subjectDigest}, doc.Statement.SubjectDigests())
+		assert.NotNil(t, doc.Source)
+		assert.Equal(t, "git+https://gitea.com/user/repo@refs/heads/main", doc.Source.URI)
+		assert.Equal(t, commitSHA, doc.Source.Digest)
+	})
+
+	t.Run("Envelope", func(t *testing.T) {
+		envelope := `{"payloadType": "application/vnd.in-toto+json", "payload": "` + base64.StdEncoding.EncodeToString([]byte(slsaV1Statement)) + `", "signatures": [{"keyid": "", "sig": "c2ln"}]}`
+
+		doc, err := ParseDocument([]byte(envelope))
+		assert.NoError(t, err)
+		assert.True(t, doc.HasSignatures)
+		assert.Equal(t, commitSHA, doc.Source.Digest)
+	})
+
+	t.Run("SLSAv02", func(t *testing.T) {
+		statement := `{
+			"_type": "https://in-toto.io/Statement/v0.1",
+			"subject": [{"name": "package.tgz", "digest": {"sha256": "` + subjectDigest + `"}}],
+			"predicateType": "https://slsa.dev/provenance/v0.2",
+			"predicate": {"invocation": {"configSource": {"uri": "git+https://gitea.com/user/repo", "digest": {"sha1": "` + strings.ToUpper(commitSHA) + `"}}}}
+		}`
+
+		doc, err := ParseDocument([]byte(statement))
+		assert.NoError(t, err)
+		assert.Equal(t, "git+https://gitea.com/user/repo", doc.Source.URI)
+		assert.Equal(t, commitSHA, doc.Source.Digest)
+	})
+
+	t.Run("OtherPredicate", func(t *testing.T) {
+		statement := `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "a", "digest": {"sha512": "abc"}}], "predicateType": "https://spdx.dev/Document", "predicate": {}}`
+
+		doc, err := ParseDocument([]byte(statement))
+		assert.NoError(t, err)
+		assert.Nil(t, doc.Source)
+		assert.Equal(t, []string{""}, doc.Statement.SubjectDigests())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{
+			``,
+			`[]`,
+			`{"name": "no statement"}`,
+			`{"_type": "https://example.com/Statement", "subject": [{"digest": {"sha256": "abc"}}], "predicateType": "x"}`,
+			`{"_type": "https://in-toto.io/Statement/v1", "subject": [], "predicateType": "x"}`,
+			`{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "a"}], "predicateType": "x"}`,
+			`{"_type": "https://in-toto.io/Statement/v1", "subject": [{"digest": {"sha256": "abc"}}]}`,
+			`{"payloadType": "text/plain", "payload": ""}`,
+			`{"payloadType": "application/vnd.in-toto+json", "payload": "%%%"}`,
+		} {
+			_, err := ParseDocument([]byte(content))
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, content)
+		}
+
+		_, err := ParseDocument([]byte(`{"_type": "` + strings.Repeat("a", MaxSize) + `"}`))
+		assert.ErrorIs(t, err, ErrDocumentTooLarge)
+	})
+}
@@ -19,9 +19,6 @@ const (
 	PropertyGroup        = "rpm.group"
 	PropertyArchitecture = "rpm.architecture"
 
-	SettingKeyPrivate = "rpm.key.private"
-	SettingKeyPublic  = "rpm.key.public"
-
 	RepositoryPackage = "_rpm"
 	RepositoryVersion = "_repository"
 )
@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	PropertyOS   = "terraform.os"
+	PropertyArch = "terraform.arch"
+
+	// DefaultProtocol is the plugin protocol of providers uploaded without a manifest
+	DefaultProtocol = "5.0"
+
+	maxReadmeSize = 1 << 20
+)
+
+var (
+	ErrInvalidName      = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidArchive   = util.NewInvalidArgumentErrorf("module archive is invalid")
+	ErrMissingTerraform = util.NewInvalidArgumentErrorf("module archive contains no Terraform configuration files")
+	ErrInvalidFilename  = util.NewInvalidArgumentErrorf("provider filename is invalid")
+	ErrInvalidManifest  = util.NewInvalidArgumentErrorf("provider manifest is invalid")
+)
+
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#module-addresses
+var (
+	moduleNamePattern   = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z_-]{0,62}[0-9A-Za-z])?\z`)
+	moduleSystemPattern = regexp.MustCompile(`\A[0-9a-z]{1,64}\z`)
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	platformPattern     = regexp.MustCompile(`\A[0-9a-z]+\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider version
+type Metadata struct {
+	Kind      string   `json:"kind"`
+	Readme    string   `json:"readme,omitempty"`
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// IsValidModuleName checks the name and the target system of a module
+func IsValidModuleName(name, system string) bool {
+	return moduleNamePattern.MatchString(name) && moduleSystemPattern.MatchString(system)
+}
+
+// IsValidProviderType checks the type of a provider
+func IsValidProviderType(providerType string) bool {
+	return providerTypePattern.MatchString(providerType)
+}
+
+// ModulePackageName returns the name of the package which stores the module
+func ModulePackageName(name, system string) string {
+	return name + "/" + system
+}
+
+// ModuleFilename returns the name of the archive of a module version
+func ModuleFilename(name, system, version string) string {
+	return fmt.Sprintf("%s-%s-%s.tar.gz", name, system, version)
+}
+
+// ParseModuleArchive checks that the gzipped tarball contains a module and extracts its readme
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	defer gzr.Close()
+
+	m := &Metadata{
+		Kind: KindModule,
+	}
+	hasConfiguration := false
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(hd.Name, "./"))
+		if strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
+			hasConfiguration = true
+		}
+		if strings.EqualFold(name, "README.md") {
+			readme, err := io.ReadAll(io.LimitReader(tr, maxReadmeSize))
+			if err != nil {
+				return nil, ErrInvalidArchive
+			}
+			m.Readme = string(readme)
+		}
+	}
+
+	if !hasConfiguration {
+		return nil, ErrMissingTerraform
+	}
+	return m, nil
+}
+
+// ProviderFile is a file of a provider version
+type ProviderFile struct {
+	Type       string
+	Version    string
+	OS         string
+	Arch       string
+	IsManifest bool
+}
+
+// ParseProviderFilename parses the name of a provider archive or manifest as created by the
+// release tooling: terraform-provider-{type}_{version}_{os}_{arch}.zip or terraform-provider-{type}_{version}_manifest.json
+func ParseProviderFilename(filename string) (*ProviderFile, error) {
+	name, ok := strings.CutPrefix(filename, "terraform-provider-")
+	if !ok {
+		return nil, ErrInvalidFilename
+	}
+
+	if name, ok := strings.CutSuffix(name, "_manifest.json"); ok {
+		parts := strings.Split(name, "_")
+		if len(parts) != 2 {
+			return nil, ErrInvalidFilename
+		}
+		return &ProviderFile{Type: parts[0], Version: parts[1], IsManifest: true}, nil
+	}
+
+	name, ok = strings.CutSuffix(name, ".zip")
+	if !ok {
+		return nil, ErrInvalidFilename
+	}
+	parts := strings.Split(name, "_")
+	if len(parts) != 4 || !platformPattern.MatchString(parts[2]) || !platformPattern.MatchString(parts[3]) {
+		return nil, ErrInvalidFilename
+	}
+	return &ProviderFile{Type: parts[0], Version: parts[1], OS: parts[2], Arch: parts[3]}, nil
+}
+
+// ProviderSHA256SumsFilename returns the name of the checksum file of a provider version
+func ProviderSHA256SumsFilename(providerType, version string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_SHA256SUMS", providerType, version)
+}
+
+// ParseProviderManifest returns the plugin protocols listed in a terraform-registry-manifest.json file
+// https://developer.hashicorp.com/terraform/registry/providers/publishing#terraform-registry-manifest-file
+func ParseProviderManifest(r io.Reader) ([]string, error) {
+	var manifest struct {
+		Version  int `json:"version"`
+		Metadata struct {
+			ProtocolVersions []string `json:"protocol_versions"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r, 1<<16)).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	if manifest.Version != 1 || len(manifest.Metadata.ProtocolVersions) == 0 {
+		return nil, ErrInvalidManifest
+	}
+	return manifest.Metadata.ProtocolVersions, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseModuleArchive(t *testing.T) {
+	createArchive := func(files map[string]string) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			hdr := &tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			}
+			tw.WriteHeader(hdr)
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(strings.NewReader("dummy"))
+		assert.Nil(t, metadata)
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("MissingConfiguration", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{"README.md": "readme"}))
+		assert.Nil(t, metadata)
+		assert.ErrorIs(t, err, ErrMissingTerraform)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{
+			"./main.tf":       `variable "name" {}`,
+			"./README.md":     "# Module",
+			"docs/README.md":  "ignored",
+			"examples/a.tf":   "",
+			"outputs.tf.json": "{}",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Equal(t, "# Module", metadata.Readme)
+	})
+}
+
+func TestParseProviderFilename(t *testing.T) {
+	for _, filename := range []string{
+		"provider_1.0.0_linux_amd64.zip",
+		"terraform-provider-test_1.0.0_linux.zip",
+		"terraform-provider-test_1.0.0_linux_amd64.tar.gz",
+		"terraform-provider-test_1.0.0_Linux_amd64.zip",
+		"terraform-provider-test_manifest.json",
+	} {
+		_, err := ParseProviderFilename(filename)
+		assert.ErrorIs(t, err, ErrInvalidFilename, "filename: %s", filename)
+	}
+
+	file, err := ParseProviderFilename("terraform-provider-test_1.0.0-rc1_linux_amd64.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderFile{Type: "test", Version: "1.0.0-rc1", OS: "linux", Arch: "amd64"}, file)
+
+	file, err = ParseProviderFilename("terraform-provider-test_1.0.0_manifest.json")
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderFile{Type: "test", Version: "1.0.0", IsManifest: true}, file)
+}
+
+func TestParseProviderManifest(t *testing.T) {
+	protocols, err := ParseProviderManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"6.0"}, protocols)
+
+	_, err = ParseProviderManifest(strings.NewReader(`{"version":2,"metadata":{"protocol_versions":["6.0"]}}`))
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+
+	_, err = ParseProviderManifest(strings.NewReader(`dummy`))
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestIsValidName(t *testing.T) {
+	assert.True(t, IsValidModuleName("consul", "aws"))
+	assert.True(t, IsValidModuleName("my_module-1", "azurerm"))
+	assert.False(t, IsValidModuleName("-module", "aws"))
+	assert.False(t, IsValidModuleName("module", "AWS"))
+	assert.False(t, IsValidModuleName("module/sub", "aws"))
+
+	assert.True(t, IsValidProviderType("my-provider"))
+	assert.False(t, IsValidProviderType("my_provider"))
+	assert.False(t, IsValidProviderType("provider-"))
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/nosql"
+)
+
+// EventExporter represents configuration of the event exporter which publishes webhook payloads to Redis Streams
+var EventExporter = struct {
+	Enabled      bool
+	ConnStr      string
+	StreamPrefix string
+	Events       container.Set[string] // empty means all events are exported
+	Streams      map[string]string     // event type -> stream name, overrides StreamPrefix
+	MaxLength    int64                 // approximate maximum number of entries kept in a stream, 0 means no trimming
+}{
+	Enabled:      false,
+	StreamPrefix: "gitea:events:",
+	Streams:      map[string]string{},
+	MaxLength:    100000,
+}
+
+func loadEventExporterFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("event_exporter")
+	EventExporter.Enabled = sec.Key("ENABLED").MustBool(false)
+	if !EventExporter.Enabled {
+		return
+	}
+
+	EventExporter.ConnStr = sec.Key("CONN_STR").MustString("redis://127.0.0.1:6379/0")
+	if nosql.ToRedisURI(EventExporter.ConnStr) == nil {
+		log.Fatal("[event_exporter] CONN_STR %s is not a valid redis connection string", EventExporter.ConnStr)
+	}
+	EventExporter.StreamPrefix = sec.Key("STREAM_PREFIX").MustString("gitea:events:")
+	EventExporter.Events = container.SetOf(sec.Key("EVENTS").Strings(",")...)
+	EventExporter.MaxLength = sec.Key("MAX_LENGTH").MustInt64(100000)
+	if EventExporter.MaxLength < 0 {
+		EventExporter.MaxLength = 0
+	}
+
+	EventExporter.Streams = map[string]string{}
+	for _, key := range rootCfg.Section("event_exporter.streams").Keys() {
+		EventExporter.Streams[key.Name()] = key.String()
+	}
+}
+
+// EventExporterStreamName returns the name of the stream which the given event type is published to
+func EventExporterStreamName(event string) string {
+	if stream, ok := EventExporter.Streams[event]; ok && stream != "" {
+		return stream
+	}
+	return EventExporter.StreamPrefix + event
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEventExporterConfig(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		cfg, err := NewConfigProviderFromData(``)
+		assert.NoError(t, err)
+
+		loadEventExporterFrom(cfg)
+		assert.False(t, EventExporter.Enabled)
+	})
+
+	t.Run("Streams", func(t *testing.T) {
+		iniStr := `
+[event_exporter]
+ENABLED = true
+CONN_STR = redis://127.0.0.1:6379/1
+EVENTS = push, issues
+MAX_LENGTH = 500
+
+[event_exporter.streams]
+push = analytics:pushes
+`
+		cfg, err := NewConfigProviderFromData(iniStr)
+		assert.NoError(t, err)
+
+		loadEventExporterFrom(cfg)
+		assert.True(t, EventExporter.Enabled)
+		assert.Equal(t, "redis://127.0.0.1:6379/1", EventExporter.ConnStr)
+		assert.EqualValues(t, 500, EventExporter.MaxLength)
+		assert.True(t, EventExporter.Events.Contains("push", "issues"))
+		assert.False(t, EventExporter.Events.Contains("release"))
+		assert.Equal(t, "analytics:pushes", EventExporterStreamName("push"))
+		assert.Equal(t, "gitea:events:issues", EventExporterStreamName("issues"))
+	})
+}
@@ -28,10 +28,37 @@ var Indexer = struct {
 	RepoConnStr          string
 	RepoIndexerName      string
 	MaxIndexerFileSize   int64
+	MaxIndexerRefs       int
 	IncludePatterns      []*GlobMatcher
 	ExcludePatterns      []*GlobMatcher
 	ExcludeVendored      bool
 
+	CommitIndexerEnabled  bool
+	CommitType            string
+	CommitPath            string
+	CommitConnStr         string
+	CommitIndexerName     string
+	CommitIndexerMaxDepth int
+
+	WikiIndexerEnabled bool
+	WikiType           string
+	WikiPath           string
+	WikiConnStr        string
+	WikiConnAuth       string
+	WikiIndexerName    string
+
+	ReleaseIndexerEnabled bool
+	ReleaseType           string
+	ReleasePath           string
+	ReleaseConnStr        string
+	ReleaseIndexerName    string
+
+	PackageIndexerEnabled bool
+	PackageType           string
+	PackagePath           string
+	PackageConnStr        string
+	PackageIndexerName    string
+
 	TypeBleveMaxFuzzniess int
 }{
 	IssueType:        "bleve",
@@ -47,7 +74,34 @@ var Indexer = struct {
 	RepoConnStr:          "",
 	RepoIndexerName:      "gitea_codes",
 	MaxIndexerFileSize:   1024 * 1024,
+	MaxIndexerRefs:       10,
 	ExcludeVendored:      true,
+
+	CommitIndexerEnabled:  false,
+	CommitType:            "bleve",
+	CommitPath:            "indexers/commits.bleve",
+	CommitConnStr:         "",
+	CommitIndexerName:     "gitea_commits",
+	CommitIndexerMaxDepth: 1000,
+
+	WikiIndexerEnabled: false,
+	WikiType:           "bleve",
+	WikiPath:           "indexers/wiki.bleve",
+	WikiConnStr:        "",
+	WikiConnAuth:       "",
+	WikiIndexerName:    "gitea_wiki",
+
+	ReleaseIndexerEnabled: false,
+	ReleaseType:           "bleve",
+	ReleasePath:           "indexers/releases.bleve",
+	ReleaseConnStr:        "",
+	ReleaseIndexerName:    "gitea_releases",
+
+	PackageIndexerEnabled: false,
+	PackageType:           "bleve",
+	PackagePath:           "indexers/packages.bleve",
+	PackageConnStr:        "",
+	PackageIndexerName:    "gitea_packages",
 }
 
 func loadIndexerFrom(rootCfg ConfigProvider) {
@@ -78,7 +132,11 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.RepoIndexerEnabled = sec.Key("REPO_INDEXER_ENABLED").MustBool(false)
 	Indexer.RepoIndexerRepoTypes = strings.Split(sec.Key("REPO_INDEXER_REPO_TYPES").MustString("sources,forks,mirrors,templates"), ",")
 	Indexer.RepoType = sec.Key("REPO_INDEXER_TYPE").MustString("bleve")
-	Indexer.RepoPath = filepath.ToSlash(sec.Key("REPO_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/repos.bleve"))))
+	defaultRepoPath := "indexers/repos.bleve"
+	if Indexer.RepoType == "trigram" {
+		defaultRepoPath = "indexers/repos.trigram"
+	}
+	Indexer.RepoPath = filepath.ToSlash(sec.Key("REPO_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, defaultRepoPath))))
 	if !filepath.IsAbs(Indexer.RepoPath) {
 		Indexer.RepoPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.RepoPath))
 	}
@@ -89,7 +147,63 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.ExcludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_EXCLUDE").MustString(""))
 	Indexer.ExcludeVendored = sec.Key("REPO_INDEXER_EXCLUDE_VENDORED").MustBool(true)
 	Indexer.MaxIndexerFileSize = sec.Key("MAX_FILE_SIZE").MustInt64(1024 * 1024)
+	Indexer.MaxIndexerRefs = sec.Key("REPO_INDEXER_MAX_REFS").MustInt(10)
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
+
+	Indexer.CommitIndexerEnabled = sec.Key("COMMIT_INDEXER_ENABLED").MustBool(false)
+	Indexer.CommitType = sec.Key("COMMIT_INDEXER_TYPE").MustString("bleve")
+	Indexer.CommitPath = filepath.ToSlash(sec.Key("COMMIT_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/commits.bleve"))))
+	if !filepath.IsAbs(Indexer.CommitPath) {
+		Indexer.CommitPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.CommitPath))
+	}
+	Indexer.CommitConnStr = sec.Key("COMMIT_INDEXER_CONN_STR").MustString("")
+	Indexer.CommitIndexerName = sec.Key("COMMIT_INDEXER_NAME").MustString("gitea_commits")
+	Indexer.CommitIndexerMaxDepth = sec.Key("COMMIT_INDEXER_MAX_DEPTH").MustInt(1000)
+	if Indexer.CommitIndexerMaxDepth <= 0 {
+		log.Warn("COMMIT_INDEXER_MAX_DEPTH must be positive, using 1000")
+		Indexer.CommitIndexerMaxDepth = 1000
+	}
+
+	Indexer.WikiIndexerEnabled = sec.Key("WIKI_INDEXER_ENABLED").MustBool(false)
+	Indexer.WikiType = sec.Key("WIKI_INDEXER_TYPE").MustString("bleve")
+	if Indexer.WikiType == "bleve" {
+		Indexer.WikiPath = filepath.ToSlash(sec.Key("WIKI_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/wiki.bleve"))))
+		if !filepath.IsAbs(Indexer.WikiPath) {
+			Indexer.WikiPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.WikiPath))
+		}
+	} else {
+		Indexer.WikiConnStr = sec.Key("WIKI_INDEXER_CONN_STR").MustString("")
+		if Indexer.WikiType == "meilisearch" {
+			u, err := url.Parse(Indexer.WikiConnStr)
+			if err != nil {
+				log.Warn("Failed to parse WIKI_INDEXER_CONN_STR: %v", err)
+				u = &url.URL{}
+			}
+			Indexer.WikiConnAuth, _ = u.User.Password()
+			u.User = nil
+			Indexer.WikiConnStr = u.String()
+		}
+	}
+	Indexer.WikiIndexerName = sec.Key("WIKI_INDEXER_NAME").MustString("gitea_wiki")
+
+	Indexer.ReleaseIndexerEnabled = sec.Key("RELEASE_INDEXER_ENABLED").MustBool(false)
+	Indexer.ReleaseType = sec.Key("RELEASE_INDEXER_TYPE").MustString("bleve")
+	Indexer.ReleasePath = filepath.ToSlash(sec.Key("RELEASE_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/releases.bleve"))))
+	if !filepath.IsAbs(Indexer.ReleasePath) {
+		Indexer.ReleasePath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.ReleasePath))
+	}
+	Indexer.ReleaseConnStr = sec.Key("RELEASE_INDEXER_CONN_STR").MustString("")
+	Indexer.ReleaseIndexerName = sec.Key("RELEASE_INDEXER_NAME").MustString("gitea_releases")
+
+	Indexer.PackageIndexerEnabled = sec.Key("PACKAGE_INDEXER_ENABLED").MustBool(false)
+	Indexer.PackageType = sec.Key("PACKAGE_INDEXER_TYPE").MustString("bleve")
+	Indexer.PackagePath = filepath.ToSlash(sec.Key("PACKAGE_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/packages.bleve"))))
+	if !filepath.IsAbs(Indexer.PackagePath) {
+		Indexer.PackagePath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.PackagePath))
+	}
+	Indexer.PackageConnStr = sec.Key("PACKAGE_INDEXER_CONN_STR").MustString("")
+	Indexer.PackageIndexerName = sec.Key("PACKAGE_INDEXER_NAME").MustString("gitea_packages")
+
 	Indexer.TypeBleveMaxFuzzniess = sec.Key("TYPE_BLEVE_MAX_FUZZINESS").MustInt(0)
 }
 
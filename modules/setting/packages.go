@@ -8,6 +8,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dustin/go-humanize"
 )
@@ -22,6 +23,7 @@ var (
 		LimitTotalOwnerCount int64
 		LimitTotalOwnerSize  int64
 		LimitSizeAlpine      int64
+		LimitSizeAnsible     int64
 		LimitSizeArch        int64
 		LimitSizeCargo       int64
 		LimitSizeChef        int64
@@ -34,6 +36,7 @@ var (
 		LimitSizeGeneric     int64
 		LimitSizeGo          int64
 		LimitSizeHelm        int64
+		LimitSizeHex         int64
 		LimitSizeMaven       int64
 		LimitSizeNpm         int64
 		LimitSizeNuGet       int64
@@ -42,12 +45,26 @@ var (
 		LimitSizeRpm         int64
 		LimitSizeRubyGems    int64
 		LimitSizeSwift       int64
+		LimitSizeTerraform   int64
 		LimitSizeVagrant     int64
 
 		DefaultRPMSignEnabled bool
+
+		ProxyAllowedHostList string
+		ProxyTimeout         time.Duration
+
+		DownloadStatsEnabled bool
+
+		SigningKeyRotationInterval time.Duration
+		SigningKeyRotationOverlap  time.Duration
 	}{
 		Enabled:              true,
 		LimitTotalOwnerCount: -1,
+		ProxyAllowedHostList: "external",
+		ProxyTimeout:         30 * time.Second,
+		DownloadStatsEnabled: true,
+
+		SigningKeyRotationOverlap: 30 * 24 * time.Hour,
 	}
 )
 
@@ -80,6 +97,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 
 	Packages.LimitTotalOwnerSize = mustBytes(sec, "LIMIT_TOTAL_OWNER_SIZE")
 	Packages.LimitSizeAlpine = mustBytes(sec, "LIMIT_SIZE_ALPINE")
+	Packages.LimitSizeAnsible = mustBytes(sec, "LIMIT_SIZE_ANSIBLE")
 	Packages.LimitSizeArch = mustBytes(sec, "LIMIT_SIZE_ARCH")
 	Packages.LimitSizeCargo = mustBytes(sec, "LIMIT_SIZE_CARGO")
 	Packages.LimitSizeChef = mustBytes(sec, "LIMIT_SIZE_CHEF")
@@ -92,6 +110,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHex = mustBytes(sec, "LIMIT_SIZE_HEX")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
@@ -100,6 +119,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeRpm = mustBytes(sec, "LIMIT_SIZE_RPM")
 	Packages.LimitSizeRubyGems = mustBytes(sec, "LIMIT_SIZE_RUBYGEMS")
 	Packages.LimitSizeSwift = mustBytes(sec, "LIMIT_SIZE_SWIFT")
+	Packages.LimitSizeTerraform = mustBytes(sec, "LIMIT_SIZE_TERRAFORM")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
 	return nil
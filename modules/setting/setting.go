@@ -211,6 +211,7 @@ func LoadSettings() {
 	loadMailsFrom(CfgProvider)
 	loadProxyFrom(CfgProvider)
 	loadWebhookFrom(CfgProvider)
+	loadEventExporterFrom(CfgProvider)
 	loadMigrationsFrom(CfgProvider)
 	loadIndexerFrom(CfgProvider)
 	loadTaskFrom(CfgProvider)
@@ -5,6 +5,7 @@ package setting
 
 import (
 	"net/url"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 )
@@ -20,6 +21,10 @@ var Webhook = struct {
 	ProxyURL        string
 	ProxyURLFixed   *url.URL
 	ProxyHosts      []string
+
+	DeliveryLease       time.Duration
+	DeliveryMaxAttempts int
+	OutboxPollInterval  time.Duration
 }{
 	QueueLength:    1000,
 	DeliverTimeout: 5,
@@ -27,6 +32,10 @@ var Webhook = struct {
 	PagingNum:      10,
 	ProxyURL:       "",
 	ProxyHosts:     []string{},
+
+	DeliveryLease:       time.Minute,
+	DeliveryMaxAttempts: 5,
+	OutboxPollInterval:  10 * time.Second,
 }
 
 func loadWebhookFrom(rootCfg ConfigProvider) {
@@ -35,7 +44,7 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 	Webhook.DeliverTimeout = sec.Key("DELIVER_TIMEOUT").MustInt(5)
 	Webhook.SkipTLSVerify = sec.Key("SKIP_TLS_VERIFY").MustBool()
 	Webhook.AllowedHostList = sec.Key("ALLOWED_HOST_LIST").MustString("")
-	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist"}
+	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist", "googlechat", "ntfy"}
 	Webhook.PagingNum = sec.Key("PAGING_NUM").MustInt(10)
 	Webhook.ProxyURL = sec.Key("PROXY_URL").MustString("")
 	if Webhook.ProxyURL != "" {
@@ -47,4 +56,13 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 		}
 	}
 	Webhook.ProxyHosts = sec.Key("PROXY_HOSTS").Strings(",")
+
+	// the lease must outlive a delivery, otherwise another node could deliver the task again
+	minLease := time.Duration(Webhook.DeliverTimeout)*time.Second + 10*time.Second
+	Webhook.DeliveryLease = max(sec.Key("DELIVERY_LEASE").MustDuration(time.Minute), minLease)
+	Webhook.DeliveryMaxAttempts = max(sec.Key("DELIVERY_MAX_ATTEMPTS").MustInt(5), 1)
+	Webhook.OutboxPollInterval = sec.Key("OUTBOX_POLL_INTERVAL").MustDuration(10 * time.Second)
+	if Webhook.OutboxPollInterval <= 0 {
+		Webhook.OutboxPollInterval = 10 * time.Second
+	}
 }
@@ -24,7 +24,12 @@ type Hook struct {
 	Config              map[string]string `json:"config"`
 	Events              []string          `json:"events"`
 	AuthorizationHeader string            `json:"authorization_header"`
-	Active              bool              `json:"active"`
+	// PEM encoded client certificate chain presented to the receiver
+	ClientCertificate string `json:"client_certificate"`
+	// PEM encoded CA bundle to verify the receiver
+	CACertificate string `json:"ca_certificate"`
+	TLSServerName string `json:"tls_server_name"`
+	Active        bool   `json:"active"`
 	// swagger:strfmt date-time
 	Updated time.Time `json:"updated_at"`
 	// swagger:strfmt date-time
@@ -41,13 +46,23 @@ type CreateHookOptionConfig map[string]string
 // CreateHookOption options when create a hook
 type CreateHookOption struct {
 	// required: true
-	// enum: dingtalk,discord,gitea,gogs,msteams,slack,telegram,feishu,wechatwork,packagist
+	// enum: dingtalk,discord,gitea,gogs,msteams,slack,telegram,feishu,wechatwork,packagist,googlechat,ntfy
 	Type string `json:"type" binding:"Required"`
 	// required: true
 	Config              CreateHookOptionConfig `json:"config" binding:"Required"`
 	Events              []string               `json:"events"`
 	BranchFilter        string                 `json:"branch_filter" binding:"GlobPattern"`
 	AuthorizationHeader string                 `json:"authorization_header"`
+	// PEM encoded client certificate chain for mutual TLS
+	ClientCertificate string `json:"client_certificate"`
+	// PEM encoded private key of the client certificate
+	ClientKey string `json:"client_key"`
+	// PEM encoded CA bundle to verify the receiver instead of the system certificates
+	CACertificate string `json:"ca_certificate"`
+	// server name to send with SNI and to verify the certificate of the receiver against
+	TLSServerName string `json:"tls_server_name"`
+	// proxy to use instead of the instance webhook proxy, it must be an allowed host of the webhooks
+	ProxyURL string `json:"proxy_url"`
 	// default: false
 	Active bool `json:"active"`
 }
@@ -58,6 +73,11 @@ type EditHookOption struct {
 	Events              []string          `json:"events"`
 	BranchFilter        string            `json:"branch_filter" binding:"GlobPattern"`
 	AuthorizationHeader string            `json:"authorization_header"`
+	ClientCertificate   *string           `json:"client_certificate"`
+	ClientKey           *string           `json:"client_key"`
+	CACertificate       *string           `json:"ca_certificate"`
+	TLSServerName       *string           `json:"tls_server_name"`
+	ProxyURL            *string           `json:"proxy_url"`
 	Active              *bool             `json:"active"`
 }
 
@@ -114,6 +134,13 @@ var (
 	_ Payloader = &RepositoryPayload{}
 	_ Payloader = &ReleasePayload{}
 	_ Payloader = &PackagePayload{}
+	_ Payloader = &OrganizationPayload{}
+	_ Payloader = &MembershipPayload{}
+	_ Payloader = &TeamPayload{}
+	_ Payloader = &MemberPayload{}
+	_ Payloader = &BranchProtectionRulePayload{}
+	_ Payloader = &StarPayload{}
+	_ Payloader = &UserPayload{}
 )
 
 // CreatePayload represents a payload information of create event.
@@ -417,6 +444,8 @@ const (
 	HookPackageCreated HookPackageAction = "created"
 	// HookPackageDeleted deleted
 	HookPackageDeleted HookPackageAction = "deleted"
+	// HookPackageAccessUpdated visibility or access grants changed
+	HookPackageAccessUpdated HookPackageAction = "access_updated"
 )
 
 // PackagePayload represents a package payload
@@ -424,6 +453,7 @@ type PackagePayload struct {
 	Action       HookPackageAction `json:"action"`
 	Repository   *Repository       `json:"repository"`
 	Package      *Package          `json:"package"`
+	Access       *PackageAccess    `json:"access,omitempty"`
 	Organization *Organization     `json:"organization"`
 	Sender       *User             `json:"sender"`
 }
@@ -484,3 +514,175 @@ type WorkflowJobPayload struct {
 func (p *WorkflowJobPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
+
+// HookOrganizationAction an action that happens to the members of an organization
+type HookOrganizationAction string
+
+const (
+	// HookOrganizationMemberAdded member added
+	HookOrganizationMemberAdded HookOrganizationAction = "member_added"
+	// HookOrganizationMemberRemoved member removed
+	HookOrganizationMemberRemoved HookOrganizationAction = "member_removed"
+)
+
+// OrganizationPayload represents a payload information of organization membership event.
+type OrganizationPayload struct {
+	Action       HookOrganizationAction `json:"action"`
+	Member       *User                  `json:"member"`
+	Organization *Organization          `json:"organization"`
+	Sender       *User                  `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *OrganizationPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookMembershipAction an action that happens to the members of a team
+type HookMembershipAction string
+
+const (
+	// HookMembershipAdded member added
+	HookMembershipAdded HookMembershipAction = "added"
+	// HookMembershipRemoved member removed
+	HookMembershipRemoved HookMembershipAction = "removed"
+)
+
+// MembershipPayload represents a payload information of team membership event.
+type MembershipPayload struct {
+	Action       HookMembershipAction `json:"action"`
+	Member       *User                `json:"member"`
+	Team         *Team                `json:"team"`
+	Organization *Organization        `json:"organization"`
+	Sender       *User                `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MembershipPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookTeamAction an action that happens to the repository access of a team
+type HookTeamAction string
+
+const (
+	// HookTeamAddedToRepository team got access to a repository
+	HookTeamAddedToRepository HookTeamAction = "added_to_repository"
+	// HookTeamRemovedFromRepository team lost access to a repository
+	HookTeamRemovedFromRepository HookTeamAction = "removed_from_repository"
+)
+
+// TeamPayload represents a payload information of team repository access event.
+type TeamPayload struct {
+	Action       HookTeamAction `json:"action"`
+	Team         *Team          `json:"team"`
+	Repository   *Repository    `json:"repository"`
+	Organization *Organization  `json:"organization"`
+	Sender       *User          `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *TeamPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookMemberAction an action that happens to the collaborators of a repository
+type HookMemberAction string
+
+const (
+	// HookMemberAdded collaborator added
+	HookMemberAdded HookMemberAction = "added"
+	// HookMemberEdited collaborator permission changed
+	HookMemberEdited HookMemberAction = "edited"
+	// HookMemberRemoved collaborator removed
+	HookMemberRemoved HookMemberAction = "removed"
+)
+
+// MemberPayload represents a payload information of repository collaborator event.
+type MemberPayload struct {
+	Action HookMemberAction `json:"action"`
+	Member *User            `json:"member"`
+	// enum: read,write,admin
+	Permission string      `json:"permission,omitempty"`
+	Repository *Repository `json:"repository"`
+	Sender     *User       `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MemberPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookBranchProtectionRuleAction an action that happens to a branch protection rule
+type HookBranchProtectionRuleAction string
+
+const (
+	// HookBranchProtectionRuleCreated created
+	HookBranchProtectionRuleCreated HookBranchProtectionRuleAction = "created"
+	// HookBranchProtectionRuleEdited edited
+	HookBranchProtectionRuleEdited HookBranchProtectionRuleAction = "edited"
+	// HookBranchProtectionRuleDeleted deleted
+	HookBranchProtectionRuleDeleted HookBranchProtectionRuleAction = "deleted"
+)
+
+// BranchProtectionRulePayload represents a payload information of branch protection rule event.
+type BranchProtectionRulePayload struct {
+	Action     HookBranchProtectionRuleAction `json:"action"`
+	Rule       *BranchProtection              `json:"rule"`
+	Repository *Repository                    `json:"repository"`
+	Sender     *User                          `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *BranchProtectionRulePayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookStarAction an action that happens to the stars of a repository
+type HookStarAction string
+
+const (
+	// HookStarCreated repository starred
+	HookStarCreated HookStarAction = "created"
+	// HookStarDeleted repository unstarred
+	HookStarDeleted HookStarAction = "deleted"
+)
+
+// StarPayload represents a payload information of star event.
+type StarPayload struct {
+	Action HookStarAction `json:"action"`
+	// swagger:strfmt date-time
+	StarredAt  *time.Time  `json:"starred_at"`
+	Repository *Repository `json:"repository"`
+	Sender     *User       `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *StarPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookUserAction an action that happens to a user account
+type HookUserAction string
+
+const (
+	// HookUserCreated account created
+	HookUserCreated HookUserAction = "created"
+	// HookUserSuspended account prohibited from signing in
+	HookUserSuspended HookUserAction = "suspended"
+	// HookUserUnsuspended account allowed to sign in again
+	HookUserUnsuspended HookUserAction = "unsuspended"
+)
+
+// UserPayload represents a payload information of user account event.
+// It is only delivered to system webhooks.
+type UserPayload struct {
+	Action HookUserAction `json:"action"`
+	User   *User          `json:"user"`
+	Sender *User          `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *UserPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// IndexerStatus represents the health of an indexer
+type IndexerStatus struct {
+	Name string `json:"name"`
+	// the backend of the indexer, like bleve or elasticsearch
+	Type      string `json:"type"`
+	Enabled   bool   `json:"enabled"`
+	Available bool   `json:"available"`
+	// whether the whole index can be rebuilt
+	CanRebuild bool `json:"can_rebuild"`
+	// whether the contents of a repository can be reindexed
+	CanReindexRepo bool `json:"can_reindex_repo"`
+	// the progress of rebuilding the index, it's null if the index isn't being rebuilt
+	Rebuild *IndexerRebuildProgress `json:"rebuild"`
+	// whether the indexer indexes the contents of the repositories, then indexed_repos of total_repos repositories have been indexed
+	IsRepoIndexer bool  `json:"is_repo_indexer"`
+	IndexedRepos  int64 `json:"indexed_repos"`
+	TotalRepos    int64 `json:"total_repos"`
+}
+
+// IndexerRebuildProgress represents the progress of rebuilding an index
+type IndexerRebuildProgress struct {
+	// swagger:strfmt date-time
+	Started time.Time `json:"started"`
+	// the number of items indexed
+	Done int64 `json:"done"`
+	// the estimated number of items to index
+	Total int64 `json:"total"`
+	// the estimated number of seconds until the rebuild completes, it's 0 if it's unknown yet
+	ETASeconds int64 `json:"eta_seconds"`
+}
@@ -19,6 +19,37 @@ type Package struct {
 	HTMLURL    string      `json:"html_url"`
 	// swagger:strfmt date-time
 	CreatedAt time.Time `json:"created_at"`
+	// the Actions workflow run the package version was built by
+	Source *PackageSource `json:"source,omitempty"`
+}
+
+// PackageSource represents the Actions workflow run a package version was built by
+type PackageSource struct {
+	Repository *Repository `json:"repository"`
+	CommitSHA  string      `json:"commit_sha"`
+	// the file name of the workflow
+	Workflow string `json:"workflow"`
+	RunID    int64  `json:"run_id"`
+	// the web url of the workflow run
+	RunURL string `json:"run_url"`
+}
+
+// PackageProvenance represents an in-toto provenance attestation attached to a package version
+type PackageProvenance struct {
+	ID            int64  `json:"id"`
+	PredicateType string `json:"predicate_type"`
+	// the source repository stated by the attestation
+	SourceURI string `json:"source_uri,omitempty"`
+	// the source commit stated by the attestation
+	SourceDigest string `json:"source_digest,omitempty"`
+	// the attestation was wrapped in a DSSE envelope with signatures, the signatures are not verified
+	HasSignatures bool `json:"has_signatures"`
+	// all subjects of the attestation are files of the package version
+	MatchesFiles bool `json:"matches_files"`
+	// the source commit stated by the attestation is the recorded build source of the package version
+	MatchesBuildSource bool `json:"matches_build_source"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // PackageFile represents a package file
@@ -31,3 +62,70 @@ type PackageFile struct {
 	HashSHA256 string `json:"sha256"`
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageDownloadStats represents the number of downloads of a package in a period
+type PackageDownloadStats struct {
+	// swagger:strfmt date-time
+	Date time.Time `json:"date"`
+	// the length of the period, old daily statistics are rolled up into months
+	// enum: day,month
+	Period  string `json:"period"`
+	Version string `json:"version,omitempty"`
+	Client  string `json:"client,omitempty"`
+	Count   int64  `json:"count"`
+}
+
+// PackageAccess represents the visibility and the access grants of a package
+type PackageAccess struct {
+	// the visibility of the package, "inherit" follows the owner
+	// enum: inherit,public,private
+	Visibility    string                       `json:"visibility"`
+	Teams         []*PackageTeamAccess         `json:"teams"`
+	Collaborators []*PackageCollaboratorAccess `json:"collaborators"`
+}
+
+// PackageTeamAccess represents the access of a team to a package
+type PackageTeamAccess struct {
+	Team *Team `json:"team"`
+	// enum: read,write
+	Permission string `json:"permission"`
+}
+
+// PackageCollaboratorAccess represents the access of a user to a package
+type PackageCollaboratorAccess struct {
+	User *User `json:"user"`
+	// enum: read,write
+	Permission string `json:"permission"`
+}
+
+// EditPackageAccessOption options for changing the visibility of a package
+type EditPackageAccessOption struct {
+	// required: true
+	// enum: inherit,public,private
+	Visibility string `json:"visibility" binding:"Required;In(inherit,public,private)"`
+}
+
+// PackageAccessGrantOption options for granting a team or user access to a package
+type PackageAccessGrantOption struct {
+	// required: true
+	// enum: read,write
+	Permission string `json:"permission" binding:"Required;In(read,write)"`
+}
+
+// PackageSigningKey represents a PGP key which signs the repository metadata of a package type
+type PackageSigningKey struct {
+	ID          int64  `json:"id"`
+	Type        string `json:"type"`
+	Fingerprint string `json:"fingerprint"`
+	PublicKey   string `json:"public_key"`
+	// active keys sign the repository metadata, retired keys are not published anymore and revoked keys are published with their revocation signature
+	// enum: active,retired,revoked
+	Status string `json:"status"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// the end of the overlap window after the key was replaced by a newer key
+	// swagger:strfmt date-time
+	RetireAt *time.Time `json:"retire_at,omitempty"`
+	// swagger:strfmt date-time
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// CodeSearchResult is a file of a repository matching a code search
+type CodeSearchResult struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	// the commit of the searched branch or tag the file was indexed from
+	CommitID string `json:"commit_id"`
+	HTMLURL  string `json:"html_url"`
+	// the matching lines with some lines around them
+	Lines []*CodeSearchResultLine `json:"lines"`
+}
+
+// CodeSearchResultLine is a line of a code search result
+type CodeSearchResultLine struct {
+	Num     int    `json:"num"`
+	Content string `json:"content"`
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// CodeSymbol is a definition of a function, method, type or constant in the default branch of a repository
+type CodeSymbol struct {
+	Name string `json:"name"`
+	// enum: function,method,type,constant
+	Kind string `json:"kind"`
+	// the type or class the symbol is defined in
+	Container string `json:"container"`
+	Language  string `json:"language"`
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	HTMLURL   string `json:"html_url"`
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// CommitSearchResult is a commit matching a commit search
+type CommitSearchResult struct {
+	// the full name of the repository of the commit, like "owner/repo"
+	Repository string `json:"repository"`
+	SHA        string `json:"sha"`
+	Message    string `json:"message"`
+	// swagger:strfmt date-time
+	Committed time.Time `json:"committed"`
+	Author    *Identity `json:"author"`
+	Committer *Identity `json:"committer"`
+	// the files touched by the commit, empty for merge commits
+	Files   []string `json:"files"`
+	HTMLURL string   `json:"html_url"`
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// WikiSearchResult is a wiki page matching a wiki search
+type WikiSearchResult struct {
+	// the full name of the repository of the wiki, like "owner/repo"
+	Repository string `json:"repository"`
+	Title      string `json:"title"`
+	HTMLURL    string `json:"html_url"`
+	SubURL     string `json:"sub_url"`
+	// a part of the text of the page around the matched keyword
+	Snippet string `json:"snippet"`
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// SearchResponse is a page of the results of a search across all types
+type SearchResponse struct {
+	// the total number of matches of each searched type
+	Counts map[string]int64 `json:"counts"`
+	// the results of all searched types ordered by score
+	Results []*SearchResult `json:"results"`
+	// the cursor to pass to get the next page, empty if there are no more results
+	NextCursor string `json:"next_cursor"`
+	// the searched types which are temporarily not searchable, like when their indexer is not available
+	Unavailable []string `json:"unavailable"`
+}
+
+// SearchResult is an item matching a search across all types
+type SearchResult struct {
+	// the type of the item, one of "repository", "code", "issue", "user" or "package"
+	Type string `json:"type"`
+	// the relevance of the result, higher is more relevant
+	Score      float64            `json:"score"`
+	Title      string             `json:"title"`
+	HTMLURL    string             `json:"html_url"`
+	Highlights []*SearchHighlight `json:"highlights"`
+	// the repository of the found item, set for the types "repository", "code" and "issue"
+	Repository *Repository       `json:"repository,omitempty"`
+	Code       *CodeSearchResult `json:"code,omitempty"`
+	Issue      *Issue            `json:"issue,omitempty"`
+	User       *User             `json:"user,omitempty"`
+	Package    *Package          `json:"package,omitempty"`
+}
+
+// SearchHighlight is a part of a field of a search result which contains the searched terms
+type SearchHighlight struct {
+	// the name of the field, like "name", "description", "path", "content", "title" or "body"
+	Field    string `json:"field"`
+	Fragment string `json:"fragment"`
+	// the [start, end) positions of the matched terms in the fragment, counted in characters
+	Matches [][2]int `json:"matches"`
+}
@@ -32,6 +32,13 @@ const (
 	HookEventRelease                   HookEventType = "release"
 	HookEventPackage                   HookEventType = "package"
 	HookEventStatus                    HookEventType = "status"
+	HookEventOrganization              HookEventType = "organization"
+	HookEventMembership                HookEventType = "membership"
+	HookEventTeam                      HookEventType = "team"
+	HookEventMember                    HookEventType = "member"
+	HookEventBranchProtectionRule      HookEventType = "branch_protection_rule"
+	HookEventStar                      HookEventType = "star"
+	HookEventUser                      HookEventType = "user"
 	// once a new event added here, please also added to AllEvents() function
 
 	// FIXME: This event should be a group of pull_request_review_xxx events
@@ -67,6 +74,13 @@ func AllEvents() []HookEventType {
 		HookEventRelease,
 		HookEventPackage,
 		HookEventStatus,
+		HookEventOrganization,
+		HookEventMembership,
+		HookEventTeam,
+		HookEventMember,
+		HookEventBranchProtectionRule,
+		HookEventStar,
+		HookEventUser,
 		HookEventWorkflowJob,
 	}
 }
@@ -112,6 +126,8 @@ const (
 	MATRIX     HookType = "matrix"
 	WECHATWORK HookType = "wechatwork"
 	PACKAGIST  HookType = "packagist"
+	GOOGLECHAT HookType = "googlechat"
+	NTFY       HookType = "ntfy"
 )
 
 // HookStatus is the status of a web hook
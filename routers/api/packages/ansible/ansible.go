@@ -0,0 +1,566 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	ansible_module "code.gitea.io/gitea/modules/packages/ansible"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+const maxPageSize = 1000
+
+func apiError(ctx *context.Context, status int, obj any) {
+	type Error struct {
+		Status string `json:"status"`
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		ctx.JSON(status, map[string]any{
+			"errors": []Error{
+				{
+					Status: fmt.Sprint(status),
+					Code:   strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+					Title:  http.StatusText(status),
+					Detail: message,
+				},
+			},
+		})
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return fmt.Sprintf("%sapi/packages/%s/ansible", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name))
+}
+
+func apiURL(ctx *context.Context) string {
+	return baseURL(ctx) + "/api/v3"
+}
+
+func collectionURL(ctx *context.Context, namespace, name string) string {
+	return fmt.Sprintf("%s/collections/%s/%s/", apiURL(ctx), namespace, name)
+}
+
+func versionURL(ctx *context.Context, namespace, name, version string) string {
+	return fmt.Sprintf("%sversions/%s/", collectionURL(ctx, namespace, name), url.PathEscape(version))
+}
+
+// Discovery returns the available API versions
+// ansible-galaxy appends /api/ to the configured server URL if that URL does not answer
+func Discovery(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, map[string]any{
+		"description":     "Gitea Ansible Galaxy registry",
+		"current_version": "v3",
+		"available_versions": map[string]string{
+			"v3": "v3/",
+		},
+	})
+}
+
+type paginationMeta struct {
+	Count int64 `json:"count"`
+}
+
+type paginationLinks struct {
+	First    string  `json:"first"`
+	Previous *string `json:"previous"`
+	Next     *string `json:"next"`
+	Last     string  `json:"last"`
+}
+
+type paginatedResponse struct {
+	Meta  paginationMeta  `json:"meta"`
+	Links paginationLinks `json:"links"`
+	Data  any             `json:"data"`
+}
+
+func getPagination(ctx *context.Context) (int, int) {
+	offset := max(ctx.FormInt("offset"), 0)
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = 100
+	}
+	return offset, min(limit, maxPageSize)
+}
+
+func newPaginatedResponse(link string, offset, limit int, total int64, data any) *paginatedResponse {
+	pageLink := func(offset int) string {
+		return fmt.Sprintf("%s?limit=%d&offset=%d", link, limit, offset)
+	}
+
+	links := paginationLinks{
+		First: pageLink(0),
+		Last:  pageLink(max(int((total-1)/int64(limit))*limit, 0)),
+	}
+	if offset > 0 {
+		previous := pageLink(max(offset-limit, 0))
+		links.Previous = &previous
+	}
+	if int64(offset+limit) < total {
+		next := pageLink(offset + limit)
+		links.Next = &next
+	}
+
+	return &paginatedResponse{
+		Meta:  paginationMeta{Count: total},
+		Links: links,
+		Data:  data,
+	}
+}
+
+type versionSummary struct {
+	Version   string    `json:"version"`
+	Href      string    `json:"href"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type collectionResponse struct {
+	Href           string          `json:"href"`
+	Namespace      string          `json:"namespace"`
+	Name           string          `json:"name"`
+	Deprecated     bool            `json:"deprecated"`
+	VersionsURL    string          `json:"versions_url"`
+	HighestVersion *versionSummary `json:"highest_version"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func getSortedPackageDescriptors(ctx *context.Context, namespace, name string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, ansible_module.PackageName(namespace, name))
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.GreaterThan(pds[j].SemVer)
+	})
+
+	return pds, nil
+}
+
+func toCollectionResponse(ctx *context.Context, pds []*packages_model.PackageDescriptor) *collectionResponse {
+	metadata := pds[0].Metadata.(*ansible_module.Metadata)
+
+	createdAt := pds[0].Version.CreatedUnix.AsLocalTime()
+	updatedAt := createdAt
+	for _, pd := range pds {
+		t := pd.Version.CreatedUnix.AsLocalTime()
+		if t.Before(createdAt) {
+			createdAt = t
+		}
+		if t.After(updatedAt) {
+			updatedAt = t
+		}
+	}
+
+	return &collectionResponse{
+		Href:        collectionURL(ctx, metadata.Namespace, metadata.Name),
+		Namespace:   metadata.Namespace,
+		Name:        metadata.Name,
+		VersionsURL: collectionURL(ctx, metadata.Namespace, metadata.Name) + "versions/",
+		HighestVersion: &versionSummary{
+			Version:   pds[0].Version.Version,
+			Href:      versionURL(ctx, metadata.Namespace, metadata.Name, pds[0].Version.Version),
+			CreatedAt: pds[0].Version.CreatedUnix.AsLocalTime(),
+			UpdatedAt: pds[0].Version.CreatedUnix.AsLocalTime(),
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// EnumerateCollections lists all collections of the owner
+func EnumerateCollections(ctx *context.Context) {
+	offset, limit := getPagination(ctx)
+
+	pvs, total, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       packages_model.TypeAnsible,
+		IsInternal: optional.Some(false),
+		Sort:       packages_model.SortNameAsc,
+		Paginator:  db.NewAbsoluteListOptions(offset, limit),
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	collections := make([]*collectionResponse, 0, len(pvs))
+	for _, pv := range pvs {
+		p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		namespace, name, _ := strings.Cut(p.Name, ".")
+		pds, err := getSortedPackageDescriptors(ctx, namespace, name)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		collections = append(collections, toCollectionResponse(ctx, pds))
+	}
+
+	ctx.JSON(http.StatusOK, newPaginatedResponse(apiURL(ctx)+"/collections/", offset, limit, total, collections))
+}
+
+// CollectionMetadata returns the collection with its highest version
+func CollectionMetadata(ctx *context.Context) {
+	pds, err := getSortedPackageDescriptors(ctx, ctx.PathParam("namespace"), ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCollectionResponse(ctx, pds))
+}
+
+// EnumerateCollectionVersions lists the versions of a collection, the highest version first
+func EnumerateCollectionVersions(ctx *context.Context) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	pds, err := getSortedPackageDescriptors(ctx, namespace, name)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	offset, limit := getPagination(ctx)
+
+	versions := make([]*versionSummary, 0, limit)
+	for i := offset; i < len(pds) && i < offset+limit; i++ {
+		pd := pds[i]
+		versions = append(versions, &versionSummary{
+			Version:   pd.Version.Version,
+			Href:      versionURL(ctx, namespace, name, pd.Version.Version),
+			CreatedAt: pd.Version.CreatedUnix.AsLocalTime(),
+			UpdatedAt: pd.Version.CreatedUnix.AsLocalTime(),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, newPaginatedResponse(collectionURL(ctx, namespace, name)+"versions/", offset, limit, int64(len(pds)), versions))
+}
+
+type namespaceReference struct {
+	Name string `json:"name"`
+}
+
+type collectionReference struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+type artifact struct {
+	Filename string `json:"filename"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+}
+
+type versionMetadata struct {
+	Authors       []string          `json:"authors"`
+	Dependencies  map[string]string `json:"dependencies"`
+	Description   string            `json:"description"`
+	Documentation string            `json:"documentation"`
+	Homepage      string            `json:"homepage"`
+	Issues        string            `json:"issues"`
+	License       []string          `json:"license"`
+	Repository    string            `json:"repository"`
+	Tags          []string          `json:"tags"`
+}
+
+type versionResponse struct {
+	Version     string              `json:"version"`
+	Href        string              `json:"href"`
+	DownloadURL string              `json:"download_url"`
+	Namespace   namespaceReference  `json:"namespace"`
+	Collection  collectionReference `json:"collection"`
+	Name        string              `json:"name"`
+	Artifact    artifact            `json:"artifact"`
+	Metadata    versionMetadata     `json:"metadata"`
+	Signatures  []any               `json:"signatures"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// CollectionVersionMetadata returns a version of a collection with its dependencies and artifact
+func CollectionVersionMetadata(ctx *context.Context) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, ansible_module.PackageName(namespace, name), ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	metadata := pd.Metadata.(*ansible_module.Metadata)
+	pf := pd.Files[0]
+
+	dependencies := metadata.Dependencies
+	if dependencies == nil {
+		dependencies = map[string]string{}
+	}
+
+	ctx.JSON(http.StatusOK, &versionResponse{
+		Version:     pd.Version.Version,
+		Href:        versionURL(ctx, metadata.Namespace, metadata.Name, pd.Version.Version),
+		DownloadURL: fmt.Sprintf("%s/download/%s", baseURL(ctx), url.PathEscape(pf.File.Name)),
+		Namespace:   namespaceReference{Name: metadata.Namespace},
+		Collection: collectionReference{
+			ID:   pd.Package.ID,
+			Name: metadata.Name,
+			Href: collectionURL(ctx, metadata.Namespace, metadata.Name),
+		},
+		Name: metadata.Name,
+		Artifact: artifact{
+			Filename: pf.File.Name,
+			SHA256:   pf.Blob.HashSHA256,
+			Size:     pf.Blob.Size,
+		},
+		Metadata: versionMetadata{
+			Authors:       metadata.Authors,
+			Dependencies:  dependencies,
+			Description:   metadata.Description,
+			Documentation: metadata.DocumentationURL,
+			Homepage:      metadata.HomepageURL,
+			Issues:        metadata.IssuesURL,
+			License:       metadata.License,
+			Repository:    metadata.RepositoryURL,
+			Tags:          metadata.Tags,
+		},
+		Signatures: []any{},
+		CreatedAt:  pd.Version.CreatedUnix.AsLocalTime(),
+		UpdatedAt:  pd.Version.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// DownloadCollectionFile serves the artifact of a collection version
+func DownloadCollectionFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	parts := strings.SplitN(strings.TrimSuffix(filename, ".tar.gz"), "-", 3)
+	if len(parts) != 3 || !strings.HasSuffix(filename, ".tar.gz") {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeAnsible,
+			Name:        ansible_module.PackageName(parts[0], parts[1]),
+			Version:     parts[2],
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadCollection imports a collection artifact
+// The import happens synchronously, the returned task is already completed.
+// https://docs.ansible.com/ansible/latest/galaxy/dev_guide.html#publishing-your-collection
+func UploadCollection(ctx *context.Context) {
+	file, _, err := ctx.Req.FormFile("file")
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(file)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if expected := ctx.Req.FormValue("sha256"); expected != "" {
+		_, _, hashSHA256, _ := buf.Sums()
+		if !strings.EqualFold(expected, hex.EncodeToString(hashSHA256)) {
+			apiError(ctx, http.StatusBadRequest, ansible_module.ErrChecksumMismatch)
+			return
+		}
+	}
+
+	pck, err := ansible_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeAnsible,
+				Name:        pck.FullName(),
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: ansible_module.Filename(pck.Namespace, pck.Name, pck.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, map[string]string{
+		"task": fmt.Sprintf("%s/imports/collections/%d/", apiURL(ctx), pv.ID),
+	})
+}
+
+// ImportTask returns the state of an import. Imports are synchronous, so every existing task is completed.
+func ImportTask(ctx *context.Context) {
+	pv, err := packages_model.GetVersionByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if p.OwnerID != ctx.Package.Owner.ID || p.Type != packages_model.TypeAnsible || pv.IsInternal {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	namespace, name, _ := strings.Cut(p.Name, ".")
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"id":          fmt.Sprint(pv.ID),
+		"state":       "completed",
+		"created_at":  pv.CreatedUnix.AsLocalTime(),
+		"finished_at": pv.CreatedUnix.AsLocalTime(),
+		"namespace":   namespace,
+		"name":        name,
+		"version":     pv.Version,
+		"messages":    []any{},
+		"error":       nil,
+	})
+}
+
+// DeleteCollectionVersion deletes a version of a collection
+func DeleteCollectionVersion(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeAnsible,
+			Name:        ansible_module.PackageName(ctx.PathParam("namespace"), ctx.PathParam("name")),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/packages/alpine"
+	"code.gitea.io/gitea/routers/api/packages/ansible"
 	"code.gitea.io/gitea/routers/api/packages/arch"
 	"code.gitea.io/gitea/routers/api/packages/cargo"
 	"code.gitea.io/gitea/routers/api/packages/chef"
@@ -26,6 +27,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/generic"
 	"code.gitea.io/gitea/routers/api/packages/goproxy"
 	"code.gitea.io/gitea/routers/api/packages/helm"
+	"code.gitea.io/gitea/routers/api/packages/hex"
 	"code.gitea.io/gitea/routers/api/packages/maven"
 	"code.gitea.io/gitea/routers/api/packages/npm"
 	"code.gitea.io/gitea/routers/api/packages/nuget"
@@ -34,6 +36,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/rpm"
 	"code.gitea.io/gitea/routers/api/packages/rubygems"
 	"code.gitea.io/gitea/routers/api/packages/swift"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/api/packages/vagrant"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
@@ -73,7 +76,7 @@ func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
 				}
 
 				if publicOnly {
-					if ctx.Package != nil && ctx.Package.Owner.Visibility.IsPrivate() {
+					if ctx.Package != nil && !ctx.Package.RestrictToPublic() {
 						ctx.HTTPError(http.StatusForbidden, "reqToken", "token scope is limited to public packages")
 						return
 					}
@@ -120,6 +123,19 @@ func CommonRoutes() *web.Router {
 		&nuget.Auth{},
 		&conan.Auth{},
 		&chef.Auth{},
+		&hex.Auth{},
+	})
+
+	// The Terraform registry protocols address modules and providers by namespace, which is the name of the owner
+	r.Group("/-/terraform", func() {
+		r.Group("/modules/v1/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Get("/{version}/download", terraform.DownloadModuleVersion)
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+		r.Group("/providers/v1/{username}/{type}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Get("/{version}/download/{os}/{arch}", terraform.DownloadProviderPackage)
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
 	})
 
 	r.Group("/{username}", func() {
@@ -136,6 +152,23 @@ func CommonRoutes() *web.Router {
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/ansible", func() {
+			r.Get("/api", ansible.Discovery)
+			r.Group("/api/v3", func() {
+				r.Group("/collections", func() {
+					r.Get("", ansible.EnumerateCollections)
+					r.Group("/{namespace}/{name}", func() {
+						r.Get("", ansible.CollectionMetadata)
+						r.Get("/versions", ansible.EnumerateCollectionVersions)
+						r.Get("/versions/{version}", ansible.CollectionVersionMetadata)
+						r.Delete("/versions/{version}", reqPackageAccess(perm.AccessModeWrite), ansible.DeleteCollectionVersion)
+					})
+				})
+				r.Post("/artifacts/collections", reqPackageAccess(perm.AccessModeWrite), ansible.UploadCollection)
+				r.Get("/imports/collections/{id}", ansible.ImportTask)
+			})
+			r.Get("/download/{filename}", ansible.DownloadCollectionFile)
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/arch", func() {
 			r.Methods("HEAD,GET", "/repository.key", arch.GetRepositoryKey)
 			r.Methods("PUT", "" /* no repository */, reqPackageAccess(perm.AccessModeWrite), arch.UploadPackageFile)
@@ -430,6 +463,17 @@ func CommonRoutes() *web.Router {
 			r.Get("/{filename}", helm.DownloadPackageFile)
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{name}", hex.PackageReleases)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Get("/public_key", hex.GetPublicKey)
+			r.Group("/api", func() {
+				r.Post("/publish", hex.UploadPackage)
+				r.Delete("/packages/{name}/releases/{version}", hex.DeletePackage)
+			}, reqPackageAccess(perm.AccessModeWrite))
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
@@ -661,6 +705,21 @@ func CommonRoutes() *web.Router {
 				r.Get("/identifiers", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.LookupPackageIdentifiers)
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/terraform", func() {
+			r.Get("/repository.key", terraform.GetRepositoryKey)
+			r.Group("/modules/{name}/{system}/{version}", func() {
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadModule)
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteModule)
+				r.Get("/{filename}", terraform.DownloadModuleFile)
+			})
+			r.Group("/providers/{type}/{version}", func() {
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteProvider)
+				r.Group("/{filename}", func() {
+					r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadProviderFile)
+					r.Get("", terraform.DownloadProviderFile)
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/vagrant", func() {
 			r.Group("/authenticate", func() {
 				r.Get("", vagrant.CheckAuthenticate)
@@ -721,6 +780,8 @@ func ContainerRoutes() *web.Router {
 			g.MatchPath("GET", `/<image:*>/manifests/<reference>`, container.VerifyImageName, container.GetManifest)
 			g.MatchPath("PUT", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.UploadManifest)
 			g.MatchPath("DELETE", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.DeleteManifest)
+
+			g.MatchPath("GET", `/<image:*>/referrers/<digest>`, container.VerifyImageName, container.GetReferrers)
 		})
 	}, container.ReqContainerAccess, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
 
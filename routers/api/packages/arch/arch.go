@@ -150,7 +150,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion, packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -291,6 +291,8 @@ func DeletePackageVersion(ctx *context.Context) {
 	if err := packages_service.RemovePackageFileAndVersionIfUnreferenced(ctx, ctx.Doer, pfs[0]); err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -312,7 +312,7 @@ func UploadPackage(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -370,6 +370,8 @@ func DeletePackageVersion(ctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -394,7 +396,11 @@ func DeletePackage(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
+			if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
 			return
 		}
 	}
@@ -32,15 +32,19 @@ func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataS
 		return nil, nil
 	}
 
-	u, err := user_model.GetUserByID(req.Context(), packageMeta.UserID)
+	u, err := user_model.GetPossibleUserByID(req.Context(), packageMeta.UserID)
 	if err != nil {
-		log.Error("GetUserByID:  %v", err)
+		log.Error("GetPossibleUserByID:  %v", err)
 		return nil, err
 	}
 	if packageMeta.Scope != "" {
 		store.GetData()["IsApiToken"] = true
 		store.GetData()["ApiTokenScope"] = packageMeta.Scope
 	}
+	if packageMeta.ActionsTaskID != 0 {
+		store.GetData()["IsActionsToken"] = true
+		store.GetData()["ActionsTaskID"] = packageMeta.ActionsTaskID
+	}
 
 	return u, nil
 }
@@ -133,7 +133,8 @@ func Authenticate(ctx *context.Context) {
 		return
 	}
 
-	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, packageScope)
+	actionsTaskID, _ := ctx.Data["ActionsTaskID"].(int64)
+	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, packageScope, actionsTaskID)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -446,7 +447,7 @@ func uploadFile(ctx *context.Context, fileFilter container.Set[string], fileKey
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -512,6 +513,8 @@ func DeleteRecipeV1(ctx *context.Context) {
 	if err := deleteRecipeOrPackage(ctx, rref, true, nil, false); err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, conan_model.ErrPackageReferenceNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -527,6 +530,8 @@ func DeleteRecipeV2(ctx *context.Context) {
 	if err := deleteRecipeOrPackage(ctx, rref, rref.Revision == "", nil, false); err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, conan_model.ErrPackageReferenceNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -574,6 +579,8 @@ func DeletePackageV1(ctx *context.Context) {
 			if err := deleteRecipeOrPackage(ctx, currentRref, true, pref, true); err != nil {
 				if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, conan_model.ErrPackageReferenceNotExist) {
 					apiError(ctx, http.StatusNotFound, err)
+				} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+					apiError(ctx, http.StatusForbidden, err)
 				} else {
 					apiError(ctx, http.StatusInternalServerError, err)
 				}
@@ -593,6 +600,8 @@ func DeletePackageV2(ctx *context.Context) {
 		if err := deleteRecipeOrPackage(ctx, rref, false, pref, pref.Revision == ""); err != nil {
 			if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, conan_model.ErrPackageReferenceNotExist) {
 				apiError(ctx, http.StatusNotFound, err)
+			} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
 			} else {
 				apiError(ctx, http.StatusInternalServerError, err)
 			}
@@ -618,6 +627,8 @@ func DeletePackageV2(ctx *context.Context) {
 		if err := deleteRecipeOrPackage(ctx, rref, false, pref, true); err != nil {
 			if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, conan_model.ErrPackageReferenceNotExist) {
 				apiError(ctx, http.StatusNotFound, err)
+			} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
 			} else {
 				apiError(ctx, http.StatusInternalServerError, err)
 			}
@@ -32,6 +32,8 @@ import (
 	container_service "code.gitea.io/gitea/services/packages/container"
 
 	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -172,7 +174,8 @@ func Authenticate(ctx *context.Context) {
 		}
 	}
 
-	token, err := packages_service.CreateAuthorizationToken(u, packageScope)
+	actionsTaskID, _ := ctx.Data["ActionsTaskID"].(int64)
+	token, err := packages_service.CreateAuthorizationToken(u, packageScope, actionsTaskID)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -249,7 +252,11 @@ func InitiateUploadBlob(ctx *context.Context) {
 
 			if accessible {
 				if err := mountBlob(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: image}, blob.Blob); err != nil {
-					apiError(ctx, http.StatusInternalServerError, err)
+					if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+						apiError(ctx, http.StatusForbidden, err)
+					} else {
+						apiError(ctx, http.StatusInternalServerError, err)
+					}
 					return
 				}
 
@@ -288,7 +295,7 @@ func InitiateUploadBlob(ctx *context.Context) {
 			},
 		); err != nil {
 			switch err {
-			case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 				apiError(ctx, http.StatusForbidden, err)
 			default:
 				apiError(ctx, http.StatusInternalServerError, err)
@@ -433,7 +440,7 @@ func EndUploadBlob(ctx *context.Context) {
 		},
 	); err != nil {
 		switch err {
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -590,7 +597,7 @@ func UploadManifest(ctx *context.Context) {
 			apiErrorDefined(ctx, errBlobUnknown)
 		} else {
 			switch err {
-			case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 				apiError(ctx, http.StatusForbidden, err)
 			default:
 				apiError(ctx, http.StatusInternalServerError, err)
@@ -599,6 +606,10 @@ func UploadManifest(ctx *context.Context) {
 		return
 	}
 
+	if mci.Subject != "" {
+		ctx.Resp.Header().Set("OCI-Subject", mci.Subject)
+	}
+
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
@@ -692,7 +703,11 @@ func DeleteManifest(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
+			if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
 			return
 		}
 	}
@@ -780,6 +795,67 @@ func GetTagList(ctx *context.Context) {
 	})
 }
 
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	d := ctx.PathParam("digest")
+
+	if digest.Digest(d).Validate() != nil {
+		apiErrorDefined(ctx, errDigestInvalid)
+		return
+	}
+
+	index := &oci.Index{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: []oci.Descriptor{},
+	}
+
+	// An unknown image or subject results in an empty list instead of an error
+	p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeContainer, ctx.PathParam("image"))
+	if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	artifactType := ctx.FormTrim("artifactType")
+
+	if p != nil {
+		referrers, err := container_service.GetReferrers(ctx, p, d)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		for _, r := range referrers {
+			if artifactType != "" && r.ArtifactType != artifactType {
+				continue
+			}
+
+			index.Manifests = append(index.Manifests, oci.Descriptor{
+				MediaType:    r.MediaType,
+				Digest:       digest.Digest(r.Digest),
+				Size:         r.Size,
+				ArtifactType: r.ArtifactType,
+				Annotations:  r.Annotations,
+			})
+		}
+	}
+
+	if artifactType != "" {
+		ctx.Resp.Header().Set("OCI-Filters-Applied", "artifactType")
+	}
+
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		ContentType: oci.MediaTypeImageIndex,
+		Status:      http.StatusOK,
+	})
+	if err := json.NewEncoder(ctx.Resp).Encode(index); err != nil {
+		log.Error("JSON encode: %v", err)
+	}
+}
+
 // FIXME: Workaround to be removed in v1.20
 // https://github.com/go-gitea/gitea/issues/19586
 func workaroundGetContainerBlob(ctx *context.Context, opts *container_model.BlobSearchOptions) (*packages_model.PackageFileDescriptor, error) {
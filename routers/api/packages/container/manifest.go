@@ -11,9 +11,9 @@ import (
 	"os"
 	"strings"
 
-	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/models/perm"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
@@ -48,6 +48,7 @@ type manifestCreationInfo struct {
 	Reference  string
 	IsTagged   bool
 	Properties map[string]string
+	Subject    string
 }
 
 func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
@@ -92,7 +93,7 @@ func processImageManifest(ctx context.Context, mci *manifestCreationInfo, buf *p
 			return err
 		}
 
-		ctx, committer, err := db.TxContext(ctx)
+		ctx, committer, err := packages_model.TxContext(ctx)
 		if err != nil {
 			return err
 		}
@@ -107,15 +108,31 @@ func processImageManifest(ctx context.Context, mci *manifestCreationInfo, buf *p
 			return err
 		}
 
-		configReader, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(configDescriptor.Blob.HashSHA256))
-		if err != nil {
-			return err
+		var metadata *container_module.Metadata
+		if isArtifactManifest(&manifest) {
+			// The config of an artifact is not an image config and may contain anything
+			metadata = &container_module.Metadata{
+				Type: container_module.TypeOCI,
+			}
+		} else {
+			configReader, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(configDescriptor.Blob.HashSHA256))
+			if err != nil {
+				return err
+			}
+			defer configReader.Close()
+
+			metadata, err = container_module.ParseImageConfig(manifest.Config.MediaType, configReader)
+			if err != nil {
+				return err
+			}
 		}
-		defer configReader.Close()
 
-		metadata, err := container_module.ParseImageConfig(manifest.Config.MediaType, configReader)
-		if err != nil {
-			return err
+		if manifest.Subject != nil {
+			artifactType := manifest.ArtifactType
+			if artifactType == "" {
+				artifactType = manifest.Config.MediaType
+			}
+			setSubject(mci, metadata, manifest.Subject, artifactType, manifest.Annotations)
 		}
 
 		blobReferences := make([]*blobReference, 0, 1+len(manifest.Layers))
@@ -209,7 +226,7 @@ func processImageManifestIndex(ctx context.Context, mci *manifestCreationInfo, b
 			return err
 		}
 
-		ctx, committer, err := db.TxContext(ctx)
+		ctx, committer, err := packages_model.TxContext(ctx)
 		if err != nil {
 			return err
 		}
@@ -220,6 +237,10 @@ func processImageManifestIndex(ctx context.Context, mci *manifestCreationInfo, b
 			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
 		}
 
+		if index.Subject != nil {
+			setSubject(mci, metadata, index.Subject, index.ArtifactType, index.Annotations)
+		}
+
 		for _, manifest := range index.Manifests {
 			if !isImageManifestMediaType(manifest.MediaType) {
 				return errManifestInvalid
@@ -300,6 +321,21 @@ func processImageManifestIndex(ctx context.Context, mci *manifestCreationInfo, b
 	return manifestDigest, nil
 }
 
+// isArtifactManifest checks if the manifest describes an artifact (signature, SBOM, ...) instead of an image
+// https://github.com/opencontainers/image-spec/blob/main/manifest.md#guidelines-for-artifact-usage
+func isArtifactManifest(manifest *oci.Manifest) bool {
+	return manifest.ArtifactType != "" || strings.EqualFold(manifest.Config.MediaType, oci.MediaTypeEmptyJSON)
+}
+
+// setSubject stores the relationship of a manifest to the manifest it refers to
+func setSubject(mci *manifestCreationInfo, metadata *container_module.Metadata, subject *oci.Descriptor, artifactType string, annotations map[string]string) {
+	mci.Subject = string(subject.Digest)
+
+	metadata.Subject = string(subject.Digest)
+	metadata.ArtifactType = artifactType
+	metadata.Annotations = annotations
+}
+
 func notifyPackageCreate(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion) error {
 	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
 	if err != nil {
@@ -328,6 +364,10 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		created = false
 	}
 
+	if err := packages_service.CheckPackageAccess(ctx, p, perm.AccessModeWrite); err != nil {
+		return nil, err
+	}
+
 	if created {
 		if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypePackage, p.ID, container_module.PropertyRepository, strings.ToLower(mci.Owner.LowerName+"/"+mci.Image)); err != nil {
 			log.Error("Error setting package property: %v", err)
@@ -362,6 +402,7 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 
 		// keep download count on overwrite
 		_pv.DownloadCount = pv.DownloadCount
+		oldVersionID := pv.ID
 
 		if pv, err = packages_model.GetOrInsertVersion(ctx, _pv); err != nil {
 			if !errors.Is(err, packages_model.ErrDuplicatePackageVersion) {
@@ -369,6 +410,11 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 				return nil, err
 			}
 		}
+
+		if err := packages_model.TransferDownloadStats(ctx, oldVersionID, pv.ID); err != nil {
+			log.Error("Error transferring download statistics: %v", err)
+			return nil, err
+		}
 	}
 
 	if err := packages_service.CheckCountQuotaExceeded(ctx, mci.Creator, mci.Owner); err != nil {
@@ -387,6 +433,12 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 			return nil, err
 		}
 	}
+	if metadata.Subject != "" {
+		if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, metadata.Subject); err != nil {
+			log.Error("Error setting package version property: %v", err)
+			return nil, err
+		}
+	}
 
 	return pv, nil
 }
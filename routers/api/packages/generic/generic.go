@@ -131,7 +131,7 @@ func UploadPackage(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -159,6 +159,10 @@ func DeletePackage(ctx *context.Context) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
+		if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -198,12 +202,20 @@ func DeletePackageFile(ctx *context.Context) {
 
 	if len(pfs) == 1 {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
+			if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
 			return
 		}
 	} else {
 		if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
+			if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
 			return
 		}
 	}
@@ -4,6 +4,7 @@
 package helper
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,7 +13,10 @@ import (
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
 )
 
 // LogAndProcessError logs an error and calls a custom callback with the processed error message.
@@ -61,3 +65,17 @@ func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf
 
 	ctx.ServeContent(s, opts)
 }
+
+// ProxyErrorStatus returns the response status for an error of pulling a package from the upstream registry of a proxy
+func ProxyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, packages_proxy.ErrUpstreamUnavailable), errors.Is(err, packages_proxy.ErrChecksumMismatch):
+		return http.StatusBadGateway
+	case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize), errors.Is(err, packages_service.ErrPackageAccessDenied):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"net/http"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/auth"
+)
+
+var _ auth.Method = &Auth{}
+
+type Auth struct{}
+
+func (a *Auth) Name() string {
+	return "hex"
+}
+
+// The Hex client sends the key as plain Authorization header without a scheme
+// https://github.com/hexpm/hex_core/blob/main/src/hex_api.erl
+func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
+	key := req.Header.Get("Authorization")
+	if key == "" || strings.Contains(key, " ") {
+		return nil, nil
+	}
+
+	token, err := auth_model.GetAccessTokenBySHA(req.Context(), key)
+	if err != nil {
+		if !(auth_model.IsErrAccessTokenNotExist(err) || auth_model.IsErrAccessTokenEmpty(err)) {
+			log.Error("GetAccessTokenBySHA: %v", err)
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	u, err := user_model.GetUserByID(req.Context(), token.UID)
+	if err != nil {
+		log.Error("GetUserByID:  %v", err)
+		return nil, err
+	}
+
+	token.UpdatedUnix = timeutil.TimeStampNow()
+	if err := auth_model.UpdateAccessToken(req.Context(), token); err != nil {
+		log.Error("UpdateAccessToken:  %v", err)
+	}
+
+	store.GetData()["IsApiToken"] = true
+	store.GetData()["ApiToken"] = token
+	store.GetData()["ApiTokenScope"] = token.Scope
+
+	return u, nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	hex_service "code.gitea.io/gitea/services/packages/hex"
+)
+
+// apiError writes the error in the Erlang term format the Hex client expects from the API
+func apiError(ctx *context.Context, status int, obj any) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		termResponse(ctx, status, map[string]string{
+			"status":  fmt.Sprint(status),
+			"message": message,
+		})
+	})
+}
+
+func termResponse(ctx *context.Context, status int, m map[string]string) {
+	ctx.Resp.Header().Set("Content-Type", hex_module.ContentTypeErlang)
+	ctx.Resp.WriteHeader(status)
+	_, _ = ctx.Resp.Write(hex_module.EncodeTerm(m))
+}
+
+func serveResource(ctx *context.Context, data []byte, err error) {
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(data), &context.ServeHeaderOptions{
+		ContentType: "application/octet-stream",
+	})
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#names
+func EnumeratePackageNames(ctx *context.Context) {
+	data, err := hex_service.BuildNames(ctx, ctx.Package.Owner)
+	serveResource(ctx, data, err)
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#versions
+func EnumeratePackageVersions(ctx *context.Context) {
+	data, err := hex_service.BuildVersions(ctx, ctx.Package.Owner)
+	serveResource(ctx, data, err)
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#package
+func PackageReleases(ctx *context.Context) {
+	data, err := hex_service.BuildPackage(ctx, ctx.Package.Owner, ctx.PathParam("name"))
+	serveResource(ctx, data, err)
+}
+
+// GetPublicKey returns the public key used to sign the registry resources
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := hex_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pub), &context.ServeHeaderOptions{
+		ContentType: "application/x-pem-file",
+		Filename:    "public_key",
+	})
+}
+
+// DownloadPackageFile serves the release tarball named <name>-<version>.tar
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	name, version, ok := strings.Cut(strings.TrimSuffix(filename, ".tar"), "-")
+	if !ok || !strings.HasSuffix(filename, ".tar") {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        name,
+			Version:     version,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadPackage publishes a release tarball. An existing release is only replaced if the replace parameter is set.
+// https://github.com/hexpm/hex_core/blob/main/src/hex_api_release.erl
+func UploadPackage(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeHex,
+		Name:        pck.Name,
+		Version:     pck.Version,
+	}
+
+	if ctx.FormBool("replace") {
+		if err := packages_service.RemovePackageVersionByNameAndVersion(ctx, ctx.Doer, pi); err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo:      *pi,
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: fmt.Sprintf("%s-%s.tar", pck.Name, pck.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	_, _, hashSHA256, _ := buf.Sums()
+
+	termResponse(ctx, http.StatusCreated, map[string]string{
+		"name":     pck.Name,
+		"version":  pck.Version,
+		"checksum": fmt.Sprintf("%x", hashSHA256),
+		"url":      fmt.Sprintf("%sapi/packages/%s/hex/tarballs/%s-%s.tar", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), pck.Name, url.PathEscape(pck.Version)),
+		"html_url": fmt.Sprintf("%s/-/packages/hex/%s/%s", ctx.Package.Owner.HTMLURL(), pck.Name, url.PathEscape(pck.Version)),
+	})
+}
+
+// DeletePackage deletes a release
+func DeletePackage(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        ctx.PathParam("name"),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
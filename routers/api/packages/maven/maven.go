@@ -29,6 +29,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
 )
 
 const (
@@ -98,34 +99,61 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	}
 	pvs = append(pvsLegacy, pvs...)
 
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
-		return
+	var metadata *MetadataResponse
+	if len(pvs) > 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		sort.Slice(pds, func(i, j int) bool {
+			// Maven and Gradle order packages by their creation timestamp and not by their version string
+			return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
+		})
+
+		metadata = createMetadataResponse(pds, params.GroupID, params.ArtifactID)
+
+		latest := pds[len(pds)-1]
+		// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
+		lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
+		ctx.Resp.Header().Set("Last-Modified", lastModified)
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	// the versions of the upstream repository are listed too if the registry is a proxy
+	pp, err := getPackageProxy(ctx, params)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	if pp != nil {
+		upstream, err := fetchUpstreamMetadata(ctx, pp, params)
+		if err != nil {
+			if !errors.Is(err, util.ErrNotExist) && !errors.Is(err, packages_proxy.ErrUpstreamUnavailable) {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+			if metadata == nil {
+				apiError(ctx, helper.ProxyErrorStatus(err), err)
+				return
+			}
+		} else {
+			metadata = mergeMetadataResponse(upstream, metadata)
+		}
+	}
 
-	sort.Slice(pds, func(i, j int) bool {
-		// Maven and Gradle order packages by their creation timestamp and not by their version string
-		return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
-	})
+	if metadata == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
 
-	xmlMetadata, err := xml.Marshal(createMetadataResponse(pds, params.GroupID, params.ArtifactID))
+	xmlMetadata, err := xml.Marshal(metadata)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	xmlMetadataWithHeader := append([]byte(xml.Header), xmlMetadata...)
 
-	latest := pds[len(pds)-1]
-	// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
-	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
-	ctx.Resp.Header().Set("Last-Modified", lastModified)
-
 	ext := strings.ToLower(path.Ext(params.Filename))
 	if isChecksumExtension(ext) {
 		var hash []byte
@@ -153,20 +181,19 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	_, _ = ctx.Resp.Write(xmlMetadataWithHeader)
 }
 
-func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
 	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
 	if errors.Is(err, util.ErrNotExist) {
 		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
 	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
+		return nil, err
 	}
 
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
+
+func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -174,9 +201,19 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, params, filename)
+	if errors.Is(err, util.ErrNotExist) {
+		pulled, pullErr := pullPackageFile(ctx, params, filename)
+		if pullErr != nil {
+			apiError(ctx, helper.ProxyErrorStatus(pullErr), pullErr)
+			return
+		}
+		if pulled {
+			pf, err = getPackageFile(ctx, params, filename)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -399,7 +436,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,197 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package maven
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
+)
+
+func getPackageProxy(ctx *context.Context, params parameters) (*packages_model.PackageProxy, error) {
+	// snapshots change without a new version, so they are never pulled
+	if strings.HasSuffix(params.Version, "-SNAPSHOT") {
+		return nil, nil
+	}
+	pp, err := packages_proxy.GetOwnerProxy(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven)
+	if err != nil || pp == nil || !pp.IsAllowed(params.toInternalPackageName()) {
+		return nil, err
+	}
+	return pp, nil
+}
+
+// upstreamPath returns the path of a file of the package in the upstream repository
+func (p *parameters) upstreamPath(filename string) string {
+	parts := []string{strings.ReplaceAll(p.GroupID, ".", "/"), p.ArtifactID}
+	if p.Version != "" {
+		parts = append(parts, p.Version)
+	}
+	return path.Join(append(parts, filename)...)
+}
+
+// fetchUpstreamMetadata returns the versions of the package in the upstream repository
+func fetchUpstreamMetadata(ctx *context.Context, pp *packages_model.PackageProxy, params parameters) (*MetadataResponse, error) {
+	content, err := packages_proxy.FetchMetadata(ctx, pp, params.upstreamPath(mavenMetadataFile), "")
+	if err != nil {
+		return nil, err
+	}
+	var metadata MetadataResponse
+	if err := xml.Unmarshal(content, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid upstream metadata of %s: %w", params.toInternalPackageName(), err)
+	}
+	return &metadata, nil
+}
+
+// mergeMetadataResponse adds the local versions which are unknown upstream to the upstream metadata
+func mergeMetadataResponse(upstream, local *MetadataResponse) *MetadataResponse {
+	if local == nil {
+		return upstream
+	}
+
+	known := make(map[string]bool, len(upstream.Version))
+	for _, v := range upstream.Version {
+		known[v] = true
+	}
+	for _, v := range local.Version {
+		if !known[v] {
+			upstream.Version = append(upstream.Version, v)
+		}
+	}
+	if upstream.Latest == "" {
+		upstream.Latest = local.Latest
+	}
+	if upstream.Release == "" {
+		upstream.Release = local.Release
+	}
+	return upstream
+}
+
+// fetchUpstreamChecksum returns the SHA1 checksum published with the file, or an empty string if there is none
+func fetchUpstreamChecksum(ctx *context.Context, pp *packages_model.PackageProxy, filePath string) (string, error) {
+	buf, err := packages_proxy.FetchFile(ctx, pp, filePath+extensionSHA1)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer buf.Close()
+
+	content, err := io.ReadAll(io.LimitReader(buf, 1024))
+	if err != nil {
+		return "", err
+	}
+	// some repositories append the filename to the checksum
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], nil
+}
+
+// pullPackageFile pulls a file which is missing locally from the upstream repository and stores it,
+// it returns false if the registry of the owner is not a proxy for the package.
+func pullPackageFile(ctx *context.Context, params parameters, filename string) (bool, error) {
+	if params.IsMeta {
+		return false, nil
+	}
+	pp, err := getPackageProxy(ctx, params)
+	if err != nil || pp == nil {
+		return false, err
+	}
+
+	packageName := params.toInternalPackageName()
+
+	// for the same package, only one upload or pull at a time
+	releaser, err := globallock.Lock(ctx, mavenPkgNameKey(packageName))
+	if err != nil {
+		return false, err
+	}
+	defer releaser()
+
+	// another request may have pulled the file while waiting for the lock
+	if _, err := getPackageFile(ctx, params, filename); err == nil {
+		return true, nil
+	}
+
+	filePath := params.upstreamPath(filename)
+	checksum, err := fetchUpstreamChecksum(ctx, pp, filePath)
+	if err != nil {
+		return true, err
+	}
+
+	buf, err := packages_proxy.FetchFile(ctx, pp, filePath)
+	if err != nil {
+		return true, err
+	}
+	defer buf.Close()
+
+	if checksum != "" {
+		if err := packages_proxy.VerifyChecksum(buf, "sha1", checksum); err != nil {
+			return true, err
+		}
+	}
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeMaven,
+			Name:        packageName,
+			Version:     params.Version,
+		},
+		SemverCompatible: false,
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Data: buf,
+	}
+
+	if strings.ToLower(path.Ext(filename)) == extensionPom {
+		pfci.IsLead = true
+
+		pvci.Metadata, err = maven_module.ParsePackageMetaData(buf)
+		if err != nil {
+			return true, fmt.Errorf("%w: %v", packages_proxy.ErrUpstreamUnavailable, err)
+		}
+
+		// the version may have been created by a file pulled before the pom
+		if pvci.Metadata != nil {
+			pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name, pvci.Version)
+			if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+				return true, err
+			}
+			if pv != nil {
+				raw, err := json.Marshal(pvci.Metadata)
+				if err != nil {
+					return true, err
+				}
+				pv.MetadataJSON = string(raw)
+				if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+					return true, err
+				}
+			}
+		}
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return true, err
+		}
+	}
+
+	_, _, err = packages_proxy.CreatePackageFromUpstream(ctx, pp, pvci, pfci)
+	return true, err
+}
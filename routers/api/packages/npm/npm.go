@@ -60,6 +60,17 @@ func PackageMetadata(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+
+	pp, err := getPackageProxy(ctx, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if pp != nil {
+		serveProxyPackageMetadata(ctx, pp, packageName, pvs)
+		return
+	}
+
 	if len(pvs) == 0 {
 		apiError(ctx, http.StatusNotFound, err)
 		return
@@ -85,18 +96,27 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-	)
+	pvi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeNpm,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(ctx, pvi, pfi)
+	if errors.Is(err, packages_model.ErrPackageNotExist) {
+		pulled, pullErr := pullPackageFile(ctx, packageName, packageVersion, filename)
+		if pullErr != nil {
+			apiError(ctx, helper.ProxyErrorStatus(pullErr), pullErr)
+			return
+		}
+		if pulled {
+			s, u, pf, err = packages_service.GetFileStreamByPackageNameAndVersion(ctx, pvi, pfi)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -216,7 +236,7 @@ func UploadPackage(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -271,6 +291,10 @@ func DeletePackageVersion(ctx *context.Context) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
+		if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -295,7 +319,11 @@ func DeletePackage(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
+			if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
 			return
 		}
 	}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
+)
+
+// abbreviated metadata contains everything needed to install a package
+const upstreamMetadataAccept = "application/vnd.npm.install-v1+json; q=1.0, application/json; q=0.8"
+
+// upstreamVersion contains the fields of an upstream version object which are stored with a pulled package
+type upstreamVersion struct {
+	Description          string            `json:"description"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	PeerDependenciesMeta map[string]any    `json:"peerDependenciesMeta"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	Bin                  map[string]string `json:"bin"`
+}
+
+// upstreamPackageMetadata is the upstream metadata document, the version objects are kept as they are
+type upstreamPackageMetadata struct {
+	DistTags map[string]string         `json:"dist-tags"`
+	Versions map[string]map[string]any `json:"versions"`
+}
+
+func getPackageProxy(ctx *context.Context, packageName string) (*packages_model.PackageProxy, error) {
+	pp, err := packages_proxy.GetOwnerProxy(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm)
+	if err != nil || pp == nil || !pp.IsAllowed(packageName) {
+		return nil, err
+	}
+	return pp, nil
+}
+
+func fetchUpstreamPackageMetadata(ctx *context.Context, pp *packages_model.PackageProxy, packageName string) (*upstreamPackageMetadata, error) {
+	content, err := packages_proxy.FetchMetadata(ctx, pp, url.PathEscape(packageName), upstreamMetadataAccept)
+	if err != nil {
+		return nil, err
+	}
+	var metadata upstreamPackageMetadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid upstream metadata of %s: %w", packageName, err)
+	}
+	return &metadata, nil
+}
+
+func upstreamTarball(version map[string]any) (tarball, integrity, shasum string) {
+	dist, _ := version["dist"].(map[string]any)
+	tarball, _ = dist["tarball"].(string)
+	integrity, _ = dist["integrity"].(string)
+	shasum, _ = dist["shasum"].(string)
+	return tarball, integrity, shasum
+}
+
+// serveProxyPackageMetadata serves the upstream metadata merged with the local versions, which take precedence.
+// The local versions are served alone if the upstream registry doesn't know the package or is unavailable.
+func serveProxyPackageMetadata(ctx *context.Context, pp *packages_model.PackageProxy, packageName string, pvs []*packages_model.PackageVersion) {
+	upstream, err := fetchUpstreamPackageMetadata(ctx, pp, packageName)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) && !errors.Is(err, packages_proxy.ErrUpstreamUnavailable) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if len(pvs) == 0 {
+			if errors.Is(err, packages_proxy.ErrUpstreamUnavailable) {
+				apiError(ctx, http.StatusBadGateway, err)
+			} else {
+				apiError(ctx, http.StatusNotFound, err)
+			}
+			return
+		}
+		upstream = nil
+	}
+
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
+
+	var local *npm_module.PackageMetadata
+	if len(pvs) > 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		local = createPackageMetadataResponse(registryURL, pds)
+	}
+	if upstream == nil {
+		ctx.JSON(http.StatusOK, local)
+		return
+	}
+
+	versions := make(map[string]any, len(upstream.Versions))
+	for v, version := range upstream.Versions {
+		tarball, _, _ := upstreamTarball(version)
+		if tarball == "" {
+			continue
+		}
+		version["dist"].(map[string]any)["tarball"] = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(v), url.PathEscape(path.Base(tarball)))
+		versions[v] = version
+	}
+	distTags := upstream.DistTags
+	if distTags == nil {
+		distTags = make(map[string]string)
+	}
+	if local != nil {
+		for v, version := range local.Versions {
+			versions[v] = version
+		}
+		for tag, v := range local.DistTags {
+			distTags[tag] = v
+		}
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"_id":       packageName,
+		"name":      packageName,
+		"dist-tags": distTags,
+		"versions":  versions,
+	})
+}
+
+// pullPackageFile pulls a tarball which is missing locally from the upstream registry and stores it,
+// it returns false if the registry of the owner is not a proxy for the package.
+func pullPackageFile(ctx *context.Context, packageName, packageVersion, filename string) (bool, error) {
+	pp, err := getPackageProxy(ctx, packageName)
+	if err != nil || pp == nil {
+		return false, err
+	}
+
+	releaser, err := globallock.Lock(ctx, packages_proxy.PullLockKey(pp, packageName, packageVersion))
+	if err != nil {
+		return false, err
+	}
+	defer releaser()
+
+	// another request may have pulled the file while waiting for the lock
+	if _, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName, packageVersion); err == nil {
+		return true, nil
+	}
+
+	return true, pullUpstreamVersion(ctx, pp, packageName, packageVersion, filename)
+}
+
+func pullUpstreamVersion(ctx *context.Context, pp *packages_model.PackageProxy, packageName, packageVersion, filename string) error {
+	upstream, err := fetchUpstreamPackageMetadata(ctx, pp, packageName)
+	if err != nil {
+		return err
+	}
+	version, ok := upstream.Versions[packageVersion]
+	if !ok {
+		return packages_proxy.ErrUpstreamNotExist
+	}
+	tarball, integrity, shasum := upstreamTarball(version)
+	if tarball == "" || !strings.EqualFold(path.Base(tarball), filename) {
+		return packages_proxy.ErrUpstreamNotExist
+	}
+
+	var metadata upstreamVersion
+	if content, err := json.Marshal(version); err == nil {
+		// the fields are optional, a version with unexpected values is stored without them
+		_ = json.Unmarshal(content, &metadata)
+	}
+
+	buf, err := packages_proxy.FetchFile(ctx, pp, tarball)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if sha512, ok := strings.CutPrefix(integrity, "sha512-"); ok {
+		sum, err := base64.StdEncoding.DecodeString(sha512)
+		if err != nil {
+			return packages_proxy.ErrChecksumMismatch
+		}
+		err = packages_proxy.VerifyChecksum(buf, "sha512", hex.EncodeToString(sum))
+		if err != nil {
+			return err
+		}
+	} else if shasum != "" {
+		if err := packages_proxy.VerifyChecksum(buf, "sha1", shasum); err != nil {
+			return err
+		}
+	}
+
+	scope, name := "", packageName
+	if strings.HasPrefix(packageName, "@") {
+		scope, name, _ = strings.Cut(packageName[1:], "/")
+	}
+
+	_, _, err = packages_proxy.CreatePackageFromUpstream(
+		ctx,
+		pp,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Metadata: &npm_module.Metadata{
+				Scope:                   scope,
+				Name:                    name,
+				Description:             metadata.Description,
+				Dependencies:            metadata.Dependencies,
+				DevelopmentDependencies: metadata.DevDependencies,
+				PeerDependencies:        metadata.PeerDependencies,
+				PeerDependenciesMeta:    metadata.PeerDependenciesMeta,
+				OptionalDependencies:    metadata.OptionalDependencies,
+				Bin:                     metadata.Bin,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: strings.ToLower(filename),
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return err
+}
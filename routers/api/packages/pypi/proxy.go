@@ -0,0 +1,174 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
+)
+
+// PEP 691 – JSON-based Simple API for Python Package Indexes: https://peps.python.org/pep-0691/
+const upstreamMetadataAccept = "application/vnd.pypi.simple.v1+json"
+
+// upstreamFile is a file of the project page of the upstream index
+type upstreamFile struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	Hashes         map[string]string `json:"hashes"`
+	RequiresPython string            `json:"requires-python"`
+	Version        string            `json:"-"`
+}
+
+type upstreamProject struct {
+	Name  string          `json:"name"`
+	Files []*upstreamFile `json:"files"`
+}
+
+// versionFromFilename extracts the version from the name of a wheel or source distribution
+// https://packaging.python.org/en/latest/specifications/binary-distribution-format/#file-name-convention
+// https://packaging.python.org/en/latest/specifications/source-distribution-format/#source-distribution-file-name
+func versionFromFilename(filename string) string {
+	if name, ok := strings.CutSuffix(filename, ".whl"); ok {
+		parts := strings.Split(name, "-")
+		if len(parts) < 5 {
+			return ""
+		}
+		return parts[1]
+	}
+
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		if name, ok := strings.CutSuffix(filename, ext); ok {
+			if pos := strings.LastIndexByte(name, '-'); pos != -1 {
+				return name[pos+1:]
+			}
+		}
+	}
+	return ""
+}
+
+func getPackageProxy(ctx *context.Context, packageName string) (*packages_model.PackageProxy, error) {
+	pp, err := packages_proxy.GetOwnerProxy(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI)
+	if err != nil || pp == nil || !pp.IsAllowed(packageName) {
+		return nil, err
+	}
+	return pp, nil
+}
+
+// fetchUpstreamFiles returns the files of the package in the upstream index which have a valid version and a SHA256 hash
+func fetchUpstreamFiles(ctx *context.Context, pp *packages_model.PackageProxy, packageName string) ([]*upstreamFile, error) {
+	projectPath := strings.ToLower(packageName) + "/"
+	content, err := packages_proxy.FetchMetadata(ctx, pp, projectPath, upstreamMetadataAccept)
+	if err != nil {
+		return nil, err
+	}
+	projectURL, err := packages_proxy.ResolveURL(pp, projectPath)
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(projectURL)
+	if err != nil {
+		return nil, err
+	}
+	var project upstreamProject
+	if err := json.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("invalid upstream project page of %s: %w", packageName, err)
+	}
+
+	files := make([]*upstreamFile, 0, len(project.Files))
+	for _, file := range project.Files {
+		file.Version = versionFromFilename(file.Filename)
+		if file.URL == "" || file.Hashes["sha256"] == "" || !isValidNameAndVersion(packageName, file.Version) {
+			continue
+		}
+		// the URLs may be relative to the project page
+		u, err := base.Parse(file.URL)
+		if err != nil {
+			continue
+		}
+		file.URL = u.String()
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// pullPackageFile pulls a file which is missing locally from the upstream index and stores it,
+// it returns false if the registry of the owner is not a proxy for the package.
+func pullPackageFile(ctx *context.Context, packageName, packageVersion, filename string) (bool, error) {
+	pp, err := getPackageProxy(ctx, packageName)
+	if err != nil || pp == nil {
+		return false, err
+	}
+
+	releaser, err := globallock.Lock(ctx, packages_proxy.PullLockKey(pp, packageName, packageVersion))
+	if err != nil {
+		return false, err
+	}
+	defer releaser()
+
+	// another request may have pulled the file while waiting for the lock
+	if pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI, packageName, packageVersion); err == nil {
+		if _, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey); err == nil {
+			return true, nil
+		}
+	}
+
+	files, err := fetchUpstreamFiles(ctx, pp, packageName)
+	if err != nil {
+		return true, err
+	}
+	var file *upstreamFile
+	for _, f := range files {
+		if f.Version == packageVersion && strings.EqualFold(f.Filename, filename) {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return true, packages_proxy.ErrUpstreamNotExist
+	}
+
+	buf, err := packages_proxy.FetchFile(ctx, pp, file.URL)
+	if err != nil {
+		return true, err
+	}
+	defer buf.Close()
+
+	if err := packages_proxy.VerifyChecksum(buf, "sha256", file.Hashes["sha256"]); err != nil {
+		return true, err
+	}
+
+	_, _, err = packages_proxy.CreatePackageFromUpstream(
+		ctx,
+		pp,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: false,
+			Metadata: &pypi_module.Metadata{
+				RequiresPython: file.RequiresPython,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: file.Filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return true, err
+}
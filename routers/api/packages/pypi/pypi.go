@@ -14,13 +14,16 @@ import (
 	"unicode"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/validation"
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -54,16 +57,50 @@ func PackageMetadata(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	// the files of the upstream index which are not pulled yet are listed too if the registry is a proxy
+	var upstreamFiles []*upstreamFile
+	pp, err := getPackageProxy(ctx, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	if pp != nil {
+		files, err := fetchUpstreamFiles(ctx, pp, packageName)
+		if err != nil {
+			if !errors.Is(err, util.ErrNotExist) && !errors.Is(err, packages_proxy.ErrUpstreamUnavailable) {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+			if len(pds) == 0 {
+				apiError(ctx, helper.ProxyErrorStatus(err), err)
+				return
+			}
+		}
+
+		localFiles := make(container.Set[string])
+		for _, pd := range pds {
+			for _, pf := range pd.Files {
+				localFiles.Add(pf.File.LowerName)
+			}
+		}
+		for _, file := range files {
+			if !localFiles.Contains(strings.ToLower(file.Filename)) {
+				upstreamFiles = append(upstreamFiles, file)
+			}
+		}
+	}
+
+	if len(pds) == 0 && len(upstreamFiles) == 0 {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
 
 	// sort package descriptors by version to mimic PyPI format
 	sort.Slice(pds, func(i, j int) bool {
@@ -71,8 +108,13 @@ func PackageMetadata(ctx *context.Context) {
 	})
 
 	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
+	ctx.Data["PackageName"] = packageName
+	ctx.Data["PackageLowerName"] = strings.ToLower(packageName)
+	if len(pds) > 0 {
+		ctx.Data["PackageName"] = pds[0].Package.Name
+	}
 	ctx.Data["PackageDescriptors"] = pds
+	ctx.Data["UpstreamFiles"] = upstreamFiles
 	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
 }
 
@@ -82,18 +124,27 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypePyPI,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-	)
+	pvi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypePyPI,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(ctx, pvi, pfi)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		pulled, pullErr := pullPackageFile(ctx, packageName, packageVersion, filename)
+		if pullErr != nil {
+			apiError(ctx, helper.ProxyErrorStatus(pullErr), pullErr)
+			return
+		}
+		if pulled {
+			s, u, pf, err = packages_service.GetFileStreamByPackageNameAndVersion(ctx, pvi, pfi)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -201,7 +252,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -50,9 +50,9 @@ gpgcheck=1
 gpgkey=`+url+`/repository.key`)
 }
 
-// Gets or creates the PGP public key used to sign repository metadata files
+// Gets the PGP public keys used to sign repository metadata files, including the revoked ones
 func GetRepositoryKey(ctx *context.Context) {
-	_, pub, err := rpm_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	pub, err := rpm_service.GetPublicKeys(ctx, ctx.Package.Owner.ID)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -134,12 +134,13 @@ func UploadPackageFile(ctx *context.Context) {
 	defer buf.Close()
 
 	if setting.Packages.DefaultRPMSignEnabled || ctx.FormBool("sign") {
-		priv, _, err := rpm_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+		keys, err := rpm_service.GetOrCreateSigningKeys(ctx, ctx.Package.Owner.ID)
 		if err != nil {
 			apiError(ctx, http.StatusInternalServerError, err)
 			return
 		}
-		signedBuf, err := rpm_service.SignPackage(buf, priv)
+		// a package can carry a single signature only, use the newest key
+		signedBuf, err := rpm_service.SignPackage(buf, keys[0].PrivateKey)
 		if err != nil {
 			apiError(ctx, http.StatusBadRequest, err)
 			return
@@ -200,7 +201,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion, packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -298,6 +299,8 @@ func DeletePackageFile(webctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(webctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(webctx, http.StatusForbidden, err)
 		} else {
 			apiError(webctx, http.StatusInternalServerError, err)
 		}
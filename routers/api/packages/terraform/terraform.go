@@ -0,0 +1,635 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	terraform_service "code.gitea.io/gitea/services/packages/terraform"
+
+	"github.com/hashicorp/go-version"
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		ctx.JSON(status, struct {
+			Errors []string `json:"errors"`
+		}{
+			Errors: []string{
+				message,
+			},
+		})
+	})
+}
+
+// ServiceDiscovery returns the locations of the module and provider registries.
+// The namespace of a module or provider is the name of its owner.
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func ServiceDiscovery(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, map[string]string{
+		"modules.v1":   setting.AppURL + "api/packages/-/terraform/modules/v1/",
+		"providers.v1": setting.AppURL + "api/packages/-/terraform/providers/v1/",
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return fmt.Sprintf("%sapi/packages/%s/terraform", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name))
+}
+
+// GetRepositoryKey returns the public keys used to sign the checksums of the providers
+func GetRepositoryKey(ctx *context.Context) {
+	pub, err := packages_service.GetPublicSigningKeys(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pub), &context.ServeHeaderOptions{
+		ContentType: "application/pgp-keys",
+		Filename:    "repository.key",
+	})
+}
+
+func getSortedPackageDescriptors(ctx *context.Context, packageName string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+	return pds, nil
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+type moduleVersions struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+// EnumerateModuleVersions lists the versions of a module
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+func EnumerateModuleVersions(ctx *context.Context) {
+	pds, err := getSortedPackageDescriptors(ctx, terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system")))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pds))
+	for _, pd := range pds {
+		versions = append(versions, &moduleVersion{Version: pd.Version.Version})
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"modules": []*moduleVersions{
+			{Versions: versions},
+		},
+	})
+}
+
+// DownloadModuleVersion points Terraform to the archive of a module version
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+func DownloadModuleVersion(ctx *context.Context) {
+	name, system, moduleVersion := ctx.PathParam("name"), ctx.PathParam("system"), ctx.PathParam("version")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(name, system), moduleVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", fmt.Sprintf("%s/modules/%s/%s/%s/%s", baseURL(ctx), url.PathEscape(name), url.PathEscape(system), url.PathEscape(pv.Version), url.PathEscape(terraform_module.ModuleFilename(name, system, pv.Version))))
+	ctx.Status(http.StatusNoContent)
+}
+
+// UploadModule publishes a module version from a gzipped tarball
+func UploadModule(ctx *context.Context) {
+	name, system, moduleVersion := ctx.PathParam("name"), ctx.PathParam("system"), ctx.PathParam("version")
+	if !terraform_module.IsValidModuleName(name, system) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	if _, err := version.NewSemver(moduleVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        terraform_module.ModulePackageName(name, system),
+				Version:     moduleVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: terraform_module.ModuleFilename(name, system, moduleVersion),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadModuleFile serves the archive of a module version
+func DownloadModuleFile(ctx *context.Context) {
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system")),
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.PathParam("filename"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// DeleteModule deletes a module version
+func DeleteModule(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system")),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+func providerProtocols(pd *packages_model.PackageDescriptor) []string {
+	if protocols := pd.Metadata.(*terraform_module.Metadata).Protocols; len(protocols) > 0 {
+		return protocols
+	}
+	return []string{terraform_module.DefaultProtocol}
+}
+
+// EnumerateProviderVersions lists the versions of a provider and their platforms
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func EnumerateProviderVersions(ctx *context.Context) {
+	pds, err := getSortedPackageDescriptors(ctx, ctx.PathParam("type"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		if pd.Metadata.(*terraform_module.Metadata).Kind != terraform_module.KindProvider {
+			continue
+		}
+
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pfd := range pd.Files {
+			if os := pfd.Properties.GetByName(terraform_module.PropertyOS); os != "" {
+				platforms = append(platforms, &providerPlatform{
+					OS:   os,
+					Arch: pfd.Properties.GetByName(terraform_module.PropertyArch),
+				})
+			}
+		}
+		// a version is only installable once it has an archive
+		if len(platforms) == 0 {
+			continue
+		}
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: providerProtocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"versions": versions,
+	})
+}
+
+type gpgPublicKey struct {
+	KeyID          string `json:"key_id"`
+	ASCIIArmor     string `json:"ascii_armor"`
+	TrustSignature string `json:"trust_signature"`
+	Source         string `json:"source"`
+	SourceURL      string `json:"source_url"`
+}
+
+type providerPackage struct {
+	Protocols           []string `json:"protocols"`
+	OS                  string   `json:"os"`
+	Arch                string   `json:"arch"`
+	Filename            string   `json:"filename"`
+	DownloadURL         string   `json:"download_url"`
+	SHASumsURL          string   `json:"shasums_url"`
+	SHASumsSignatureURL string   `json:"shasums_signature_url"`
+	SHASum              string   `json:"shasum"`
+	SigningKeys         struct {
+		GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+	} `json:"signing_keys"`
+}
+
+func getProviderDescriptor(ctx *context.Context) (*packages_model.PackageDescriptor, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, ctx.PathParam("type"), ctx.PathParam("version"))
+	if err != nil {
+		return nil, err
+	}
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return nil, err
+	}
+	if pd.Metadata.(*terraform_module.Metadata).Kind != terraform_module.KindProvider {
+		return nil, packages_model.ErrPackageNotExist
+	}
+	return pd, nil
+}
+
+// DownloadProviderPackage returns the download location and the signed checksums of a provider archive
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func DownloadProviderPackage(ctx *context.Context) {
+	pd, err := getProviderDescriptor(ctx)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	os, arch := ctx.PathParam("os"), ctx.PathParam("arch")
+
+	var archive *packages_model.PackageFileDescriptor
+	for _, pfd := range pd.Files {
+		if pfd.Properties.GetByName(terraform_module.PropertyOS) == os && pfd.Properties.GetByName(terraform_module.PropertyArch) == arch {
+			archive = pfd
+			break
+		}
+	}
+	if archive == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	pub, keyID, err := terraform_service.GetPublicKey(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := fmt.Sprintf("%s/providers/%s/%s/", baseURL(ctx), url.PathEscape(pd.Package.Name), url.PathEscape(pd.Version.Version))
+	sumsURL := versionURL + url.PathEscape(terraform_module.ProviderSHA256SumsFilename(pd.Package.Name, pd.Version.Version))
+
+	resp := &providerPackage{
+		Protocols:           providerProtocols(pd),
+		OS:                  os,
+		Arch:                arch,
+		Filename:            archive.File.Name,
+		DownloadURL:         versionURL + url.PathEscape(archive.File.Name),
+		SHASumsURL:          sumsURL,
+		SHASumsSignatureURL: sumsURL + ".sig",
+		SHASum:              archive.Blob.HashSHA256,
+	}
+	resp.SigningKeys.GPGPublicKeys = []*gpgPublicKey{
+		{
+			KeyID:      keyID,
+			ASCIIArmor: pub,
+			Source:     "Gitea",
+			SourceURL:  baseURL(ctx) + "/repository.key",
+		},
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UploadProviderFile publishes a platform archive or the registry manifest of a provider version
+func UploadProviderFile(ctx *context.Context) {
+	providerType, providerVersion, filename := ctx.PathParam("type"), ctx.PathParam("version"), ctx.PathParam("filename")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	if _, err := version.NewSemver(providerVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	file, err := terraform_module.ParseProviderFilename(filename)
+	if err == nil && (file.Type != providerType || file.Version != providerVersion) {
+		err = terraform_module.ErrInvalidFilename
+	}
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata := &terraform_module.Metadata{
+		Kind: terraform_module.KindProvider,
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator: ctx.Doer,
+		Data:    buf,
+	}
+
+	if file.IsManifest {
+		metadata.Protocols, err = terraform_module.ParseProviderManifest(buf)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		magic := make([]byte, 4)
+		if _, err := io.ReadFull(buf, magic); err != nil || !bytes.Equal(magic, []byte("PK\x03\x04")) {
+			apiError(ctx, http.StatusBadRequest, "the provider archive must be a zip file")
+			return
+		}
+		pfci.IsLead = true
+		pfci.Properties = map[string]string{
+			terraform_module.PropertyOS:   file.OS,
+			terraform_module.PropertyArch: file.Arch,
+		}
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        providerType,
+				Version:     providerVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		pfci,
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrPackageAccessDenied:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	// the manifest may be uploaded after the archives which created the version
+	if file.IsManifest {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if pv.MetadataJSON != string(raw) {
+			pv.MetadataJSON = string(raw)
+			if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadProviderFile serves an uploaded file of a provider version or the signed checksums of its archives
+func DownloadProviderFile(ctx *context.Context) {
+	providerType, providerVersion, filename := ctx.PathParam("type"), ctx.PathParam("version"), ctx.PathParam("filename")
+
+	sumsFilename := terraform_module.ProviderSHA256SumsFilename(providerType, providerVersion)
+	if filename == sumsFilename || filename == sumsFilename+".sig" {
+		pd, err := getProviderDescriptor(ctx)
+		if err != nil {
+			if errors.Is(err, packages_model.ErrPackageNotExist) {
+				apiError(ctx, http.StatusNotFound, err)
+				return
+			}
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		content := terraform_service.BuildSHA256Sums(pd)
+		contentType := "text/plain; charset=utf-8"
+		if filename != sumsFilename {
+			content, err = terraform_service.SignData(ctx, ctx.Package.Owner.ID, bytes.NewReader(content))
+			if err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+			contentType = "application/octet-stream"
+		}
+
+		ctx.ServeContent(bytes.NewReader(content), &context.ServeHeaderOptions{
+			ContentType: contentType,
+			Filename:    filename,
+		})
+		return
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        providerType,
+			Version:     providerVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// DeleteProvider deletes a provider version with all its files
+func DeleteProvider(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        ctx.PathParam("type"),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	indexer_service "code.gitea.io/gitea/services/indexer"
+)
+
+// ListIndexers api for getting the health of the indexers
+func ListIndexers(ctx *context.APIContext) {
+	// swagger:operation GET /admin/indexers admin adminListIndexers
+	// ---
+	// summary: List the indexers with their health and rebuild progress
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IndexerStatusList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	statuses, err := indexer_service.GetStatuses(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.IndexerStatus, 0, len(statuses))
+	for _, status := range statuses {
+		apiStatus := &api.IndexerStatus{
+			Name:           status.Name,
+			Type:           status.Type,
+			Enabled:        status.Enabled,
+			Available:      status.Available,
+			CanRebuild:     status.CanRebuild,
+			CanReindexRepo: status.CanReindexRepo,
+			IsRepoIndexer:  status.IsRepoIndexer,
+			IndexedRepos:   status.IndexedRepos,
+			TotalRepos:     status.TotalRepos,
+		}
+		if status.Rebuild != nil {
+			apiStatus.Rebuild = &api.IndexerRebuildProgress{
+				Started:    status.Rebuild.Started,
+				Done:       status.Rebuild.Done(),
+				Total:      status.Rebuild.Total(),
+				ETASeconds: int64(status.Rebuild.ETA().Seconds()),
+			}
+		}
+		res = append(res, apiStatus)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RebuildIndexer api for rebuilding the whole index of an indexer
+func RebuildIndexer(ctx *context.APIContext) {
+	// swagger:operation POST /admin/indexers/{indexer}/rebuild admin adminRebuildIndexer
+	// ---
+	// summary: Rebuild the whole index of an indexer in the background
+	// description: A new index is built beside the one in use if the backend supports it, so the search isn't degraded while rebuilding.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: indexer
+	//   in: path
+	//   description: name of the indexer
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	name := ctx.PathParam("indexer")
+	if err := indexer_service.Rebuild(name); err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.APIErrorNotFound(err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	log.Trace("Rebuilding indexer %s started by admin(%s)", name, ctx.Doer.Name)
+
+	ctx.Status(http.StatusAccepted)
+}
+
+// ReindexRepo api for reindexing a repository with an indexer from scratch
+func ReindexRepo(ctx *context.APIContext) {
+	// swagger:operation POST /admin/indexers/{indexer}/repos/{owner}/{repo} admin adminReindexRepo
+	// ---
+	// summary: Reindex all the contents of a repository with an indexer from scratch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: indexer
+	//   in: path
+	//   description: name of the indexer
+	//   type: string
+	//   required: true
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ctx.PathParam("owner"), ctx.PathParam("repo"))
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	name := ctx.PathParam("indexer")
+	if err := indexer_service.ReindexRepo(ctx, name, repo); err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.APIErrorNotFound(err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	log.Trace("Reindexing repository %s with indexer %s requested by admin(%s)", repo.FullName(), name, ctx.Doer.Name)
+
+	ctx.Status(http.StatusAccepted)
+}
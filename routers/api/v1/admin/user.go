@@ -29,6 +29,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	"code.gitea.io/gitea/services/mailer"
+	notify_service "code.gitea.io/gitea/services/notify"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -155,6 +156,7 @@ func CreateUser(ctx *context.APIContext) {
 	}
 
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
+	notify_service.CreateUser(ctx, ctx.Doer, u)
 
 	// Send email notification.
 	if form.SendNotify {
@@ -201,6 +203,7 @@ func EditUser(ctx *context.APIContext) {
 		MustChangePassword: optional.FromPtr(form.MustChangePassword),
 		ProhibitLogin:      optional.FromPtr(form.ProhibitLogin),
 	}
+	wasProhibitLogin := ctx.ContextUser.ProhibitLogin
 	if err := user_service.UpdateAuth(ctx, ctx.ContextUser, authOpts); err != nil {
 		switch {
 		case errors.Is(err, password.ErrMinLength):
@@ -214,6 +217,9 @@ func EditUser(ctx *context.APIContext) {
 		}
 		return
 	}
+	if ctx.ContextUser.ProhibitLogin != wasProhibitLogin {
+		notify_service.SuspendUser(ctx, ctx.Doer, ctx.ContextUser, ctx.ContextUser.ProhibitLogin)
+	}
 
 	if form.Email != nil {
 		if err := user_service.AdminAddOrSetPrimaryEmailAddress(ctx, ctx.ContextUser, *form.Email); err != nil {
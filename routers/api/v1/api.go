@@ -282,7 +282,7 @@ func checkTokenPublicOnly() func(ctx *context.APIContext) {
 				return
 			}
 		case auth_model.ContainsCategory(requiredScopeCategories, auth_model.AccessTokenScopeCategoryPackage):
-			if ctx.Package != nil && ctx.Package.Owner.Visibility.IsPrivate() {
+			if ctx.Package != nil && !ctx.Package.RestrictToPublic() {
 				ctx.APIError(http.StatusForbidden, "token scope is limited to public packages")
 				return
 			}
@@ -944,6 +944,7 @@ func Routes() *web.Router {
 		m.Group("", func() {
 			m.Get("/version", misc.Version)
 			m.Get("/signing-key.gpg", misc.SigningKey)
+			m.Get("/search", reqExploreSignIn(), misc.Search)
 			m.Post("/markup", reqToken(), bind(api.MarkupOption{}), misc.Markup)
 			m.Post("/markdown", reqToken(), bind(api.MarkdownOption{}), misc.Markdown)
 			m.Post("/markdown/raw", reqToken(), misc.MarkdownRaw)
@@ -1141,6 +1142,8 @@ func Routes() *web.Router {
 		// Repos (requires repo scope)
 		m.Group("/repos", func() {
 			m.Get("/search", repo.Search)
+			m.Get("/commits/search", repo.SearchAllIndexedCommits)
+			m.Get("/wikis/search", repo.SearchAllWikis)
 
 			// (repo scope)
 			m.Post("/migrate", reqToken(), bind(api.MigrateRepoOptions{}), repo.Migrate)
@@ -1269,6 +1272,7 @@ func Routes() *web.Router {
 					m.Get("/revisions/{pageName}", repo.ListPageRevisions)
 					m.Post("/new", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeWiki), bind(api.CreateWikiPageOptions{}), repo.NewWikiPage)
 					m.Get("/pages", repo.ListWikiPages)
+					m.Get("/search", repo.SearchWiki)
 				}, mustEnableWiki)
 				m.Post("/markup", reqToken(), bind(api.MarkupOption{}), misc.Markup)
 				m.Post("/markdown", reqToken(), bind(api.MarkdownOption{}), misc.Markdown)
@@ -1284,6 +1288,7 @@ func Routes() *web.Router {
 					m.Combo("").Get(repo.ListReleases).
 						Post(reqToken(), reqRepoWriter(unit.TypeReleases), context.ReferencesGitRepo(), bind(api.CreateReleaseOption{}), repo.CreateRelease)
 					m.Combo("/latest").Get(repo.GetLatestRelease)
+					m.Get("/search", repo.SearchReleases)
 					m.Group("/{id}", func() {
 						m.Combo("").Get(repo.GetRelease).
 							Patch(reqToken(), reqRepoWriter(unit.TypeReleases), context.ReferencesGitRepo(), bind(api.EditReleaseOption{}), repo.EditRelease).
@@ -1399,6 +1404,9 @@ func Routes() *web.Router {
 				m.Get("/issue_config/validate", context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
 				m.Get("/licenses", reqRepoReader(unit.TypeCode), repo.GetLicenses)
+				m.Get("/symbols", reqRepoReader(unit.TypeCode), repo.ListCodeSymbols)
+				m.Get("/search/code", reqRepoReader(unit.TypeCode), repo.SearchCode)
+				m.Get("/search/commits", reqRepoReader(unit.TypeCode), repo.SearchIndexedCommits)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
 				m.Group("/avatar", func() {
@@ -1432,6 +1440,7 @@ func Routes() *web.Router {
 					m.Combo("").Get(repo.ListIssues).
 						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), repo.CreateIssue)
 					m.Get("/pinned", reqRepoReader(unit.TypeIssues), repo.ListPinnedIssues)
+					m.Get("/similar", reqRepoReader(unit.TypeIssues), repo.ListSimilarIssues)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -1539,15 +1548,37 @@ func Routes() *web.Router {
 
 		// NOTE: these are Gitea package management API - see packages.CommonRoutes and packages.DockerContainerRoutes for endpoints that implement package manager APIs
 		m.Group("/packages/{username}", func() {
+			m.Group("/-/signing_keys/{type}", func() {
+				m.Get("", packages.ListPackageSigningKeys)
+				m.Post("/rotate", reqPackageAccess(perm.AccessModeAdmin), packages.RotatePackageSigningKey)
+				m.Post("/{id}/revoke", reqPackageAccess(perm.AccessModeAdmin), packages.RevokePackageSigningKey)
+			})
+			m.Get("/-/search", packages.SearchPackages)
 			m.Group("/{type}/{name}", func() {
 				m.Group("/{version}", func() {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Get("/downloads", packages.ListPackageVersionDownloadStats)
+					m.Group("/provenance", func() {
+						m.Combo("").Get(packages.ListPackageProvenance).
+							Post(reqPackageAccess(perm.AccessModeWrite), packages.AddPackageProvenance)
+						m.Combo("/{id}").Get(packages.GetPackageProvenanceContent).
+							Delete(reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageProvenance)
+					})
 				})
 
+				m.Get("/-/downloads", packages.ListPackageDownloadStats)
 				m.Post("/-/link/{repo_name}", reqPackageAccess(perm.AccessModeWrite), packages.LinkPackage)
 				m.Post("/-/unlink", reqPackageAccess(perm.AccessModeWrite), packages.UnlinkPackage)
+				m.Group("/-/access", func() {
+					m.Combo("").Get(packages.GetPackageAccess).
+						Patch(bind(api.EditPackageAccessOption{}), packages.EditPackageAccess)
+					m.Combo("/teams/{team}").Put(bind(api.PackageAccessGrantOption{}), packages.GrantPackageTeamAccess).
+						Delete(packages.RevokePackageTeamAccess)
+					m.Combo("/collaborators/{collaborator}").Put(bind(api.PackageAccessGrantOption{}), packages.GrantPackageCollaboratorAccess).
+						Delete(packages.RevokePackageCollaboratorAccess)
+				}, reqPackageAccess(perm.AccessModeAdmin))
 			})
 
 			m.Get("/", packages.ListPackages)
@@ -1644,6 +1675,11 @@ func Routes() *web.Router {
 				m.Get("", admin.ListCronTasks)
 				m.Post("/{task}", admin.PostCronTask)
 			})
+			m.Group("/indexers", func() {
+				m.Get("", admin.ListIndexers)
+				m.Post("/{indexer}/rebuild", admin.RebuildIndexer)
+				m.Post("/{indexer}/repos/{owner}/{repo}", admin.ReindexRepo)
+			})
 			m.Get("/orgs", admin.GetAllOrgs)
 			m.Group("/users", func() {
 				m.Get("", admin.SearchUsers)
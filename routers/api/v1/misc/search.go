@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package misc
+
+import (
+	"net/http"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	search_service "code.gitea.io/gitea/services/search"
+)
+
+// searchTypeScopeCategories are the token scope categories required to search the items of each type
+var searchTypeScopeCategories = map[search_service.Type]auth_model.AccessTokenScopeCategory{
+	search_service.TypeRepository: auth_model.AccessTokenScopeCategoryRepository,
+	search_service.TypeCode:       auth_model.AccessTokenScopeCategoryRepository,
+	search_service.TypeIssue:      auth_model.AccessTokenScopeCategoryIssue,
+	search_service.TypeUser:       auth_model.AccessTokenScopeCategoryUser,
+	search_service.TypePackage:    auth_model.AccessTokenScopeCategoryPackage,
+}
+
+// Search searches repositories, code, issues, users and packages
+func Search(ctx *context.APIContext) {
+	// swagger:operation GET /search miscellaneous search
+	// ---
+	// summary: Search repositories, code, issues, users and packages at once
+	// description: The results of all types are merged by relevance. Code is only searched if the code indexer is enabled.
+	//   The types a token doesn't have the read scope of are not searched.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: q
+	//   in: query
+	//   description: keyword to search for
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: query
+	//   description: types of the items to search, all types if empty
+	//   type: array
+	//   collectionFormat: multi
+	//   items:
+	//     type: string
+	//     enum: [repository, code, issue, user, package]
+	// - name: cursor
+	//   in: query
+	//   description: the next_cursor of the previous page, empty for the first page
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SearchResponse"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	keyword := ctx.FormTrim("q")
+	if keyword == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "q is required")
+		return
+	}
+
+	cursor, err := search_service.ParseCursor(ctx.FormString("cursor"))
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	types := search_service.EnabledTypes()
+	if formTypes := ctx.FormStrings("type"); len(formTypes) > 0 {
+		types = types[:0]
+		for _, s := range formTypes {
+			t := search_service.Type(s)
+			if !t.IsValid() {
+				ctx.APIError(http.StatusUnprocessableEntity, "invalid type: "+s)
+				return
+			}
+			if t.IsEnabled() {
+				types = append(types, t)
+			}
+		}
+	}
+
+	doer := ctx.Doer
+	if scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope); ok && ctx.Data["IsApiToken"] == true {
+		allowedTypes := make([]search_service.Type, 0, len(types))
+		for _, t := range types {
+			allow, err := scope.HasScope(auth_model.GetRequiredScopes(auth_model.Read, searchTypeScopeCategories[t])...)
+			if err != nil {
+				ctx.APIError(http.StatusForbidden, "checking scope failed: "+err.Error())
+				return
+			}
+			if allow {
+				allowedTypes = append(allowedTypes, t)
+			}
+		}
+		types = allowedTypes
+
+		publicOnly, err := scope.PublicOnly()
+		if err != nil {
+			ctx.APIError(http.StatusForbidden, "parsing public resource scope failed: "+err.Error())
+			return
+		}
+		if publicOnly {
+			doer = nil // only search what is visible to everyone
+		}
+	}
+
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = setting.API.DefaultPagingNum
+	}
+	limit = min(limit, setting.API.MaxResponseItems)
+
+	resp := &search_service.Response{Counts: map[search_service.Type]int64{}}
+	if len(types) > 0 {
+		resp, err = search_service.Search(ctx, &search_service.Options{
+			Doer:    doer,
+			Keyword: keyword,
+			Types:   types,
+			Cursor:  cursor,
+			Limit:   limit,
+		})
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	apiResp, err := convert.ToSearchResponse(ctx, resp, doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiResp)
+}
@@ -322,7 +322,7 @@ func DeleteMember(ctx *context.APIContext) {
 	if ctx.Written() {
 		return
 	}
-	if err := org_service.RemoveOrgUser(ctx, ctx.Org.Organization, member); err != nil {
+	if err := org_service.RemoveOrgUser(ctx, ctx.Doer, ctx.Org.Organization, member); err != nil {
 		ctx.APIErrorInternal(err)
 	}
 	ctx.Status(http.StatusNoContent)
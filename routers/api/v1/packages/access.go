@@ -0,0 +1,412 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// GetPackageAccess gets the visibility and the access grants of a package
+func GetPackageAccess(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/-/access package getPackageAccess
+	// ---
+	// summary: Gets the visibility and the access grants of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAccess"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	writePackageAccess(ctx, p)
+}
+
+// EditPackageAccess changes the visibility of a package
+func EditPackageAccess(ctx *context.APIContext) {
+	// swagger:operation PATCH /packages/{owner}/{type}/{name}/-/access package editPackageAccess
+	// ---
+	// summary: Change the visibility of a package
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EditPackageAccessOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAccess"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditPackageAccessOption)
+
+	visibility, ok := packages.ParseVisibility(form.Visibility)
+	if !ok {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid visibility")
+		return
+	}
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_service.SetPackageVisibility(ctx, ctx.Doer, p, visibility); err != nil {
+		writeAccessError(ctx, err)
+		return
+	}
+
+	writePackageAccess(ctx, p)
+}
+
+// GrantPackageTeamAccess grants a team access to a package
+func GrantPackageTeamAccess(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/{type}/{name}/-/access/teams/{team} package grantPackageTeamAccess
+	// ---
+	// summary: Grant a team of the owner organization access to a package
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: team
+	//   in: path
+	//   description: name of the team
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PackageAccessGrantOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.PackageAccessGrantOption)
+
+	mode, err := packages_service.ParseGrantAccessMode(form.Permission)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	team := getTeamFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_service.GrantTeamAccess(ctx, ctx.Doer, p, team, mode); err != nil {
+		writeAccessError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RevokePackageTeamAccess removes the access grant of a team
+func RevokePackageTeamAccess(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/-/access/teams/{team} package revokePackageTeamAccess
+	// ---
+	// summary: Remove the access grant of a team
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: team
+	//   in: path
+	//   description: name of the team
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	team := getTeamFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_service.RevokeTeamAccess(ctx, ctx.Doer, p, team); err != nil {
+		writeAccessError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GrantPackageCollaboratorAccess grants a user access to a package
+func GrantPackageCollaboratorAccess(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/{type}/{name}/-/access/collaborators/{collaborator} package grantPackageCollaboratorAccess
+	// ---
+	// summary: Grant a user access to a package
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: collaborator
+	//   in: path
+	//   description: username of the collaborator
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PackageAccessGrantOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.PackageAccessGrantOption)
+
+	mode, err := packages_service.ParseGrantAccessMode(form.Permission)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	u := getCollaboratorFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_service.GrantUserAccess(ctx, ctx.Doer, p, u, mode); err != nil {
+		writeAccessError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RevokePackageCollaboratorAccess removes the access grant of a user
+func RevokePackageCollaboratorAccess(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/-/access/collaborators/{collaborator} package revokePackageCollaboratorAccess
+	// ---
+	// summary: Remove the access grant of a user
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: collaborator
+	//   in: path
+	//   description: username of the collaborator
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	u := getCollaboratorFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_service.RevokeUserAccess(ctx, ctx.Doer, p, u); err != nil {
+		writeAccessError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func getPackageFromPath(ctx *context.APIContext) *packages.Package {
+	p, err := packages.GetPackageByName(ctx, ctx.ContextUser.ID, packages.Type(ctx.PathParam("type")), ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return p
+}
+
+func getTeamFromPath(ctx *context.APIContext) *organization.Team {
+	team, err := organization.GetTeam(ctx, ctx.ContextUser.ID, ctx.PathParam("team"))
+	if err != nil {
+		if organization.IsErrTeamNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return team
+}
+
+func getCollaboratorFromPath(ctx *context.APIContext) *user_model.User {
+	u, err := user_model.GetUserByName(ctx, ctx.PathParam("collaborator"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return u
+}
+
+func writePackageAccess(ctx *context.APIContext, p *packages.Package) {
+	access, err := convert.ToPackageAccess(ctx, p, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, access)
+}
+
+func writeAccessError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIError(http.StatusNotFound, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
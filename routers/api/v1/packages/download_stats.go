@@ -0,0 +1,177 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListPackageDownloadStats gets the download statistics of all versions of a package
+func ListPackageDownloadStats(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/-/downloads package listPackageDownloadStats
+	// ---
+	// summary: Gets the download statistics of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: only return statistics of periods after this time
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only return statistics of periods before this time
+	//   type: string
+	//   format: date-time
+	// - name: group_by
+	//   in: query
+	//   description: additionally group the statistics by the client or the version
+	//   type: string
+	//   enum: [client, version]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageDownloadStatsList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := getDownloadStatsSearchOptions(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	p := getPackageFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	opts.PackageID = p.ID
+
+	writeDownloadStats(ctx, opts)
+}
+
+// ListPackageVersionDownloadStats gets the download statistics of a package version
+func ListPackageVersionDownloadStats(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/downloads package listPackageVersionDownloadStats
+	// ---
+	// summary: Gets the download statistics of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: only return statistics of periods after this time
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only return statistics of periods before this time
+	//   type: string
+	//   format: date-time
+	// - name: group_by
+	//   in: query
+	//   description: additionally group the statistics by the client
+	//   type: string
+	//   enum: [client]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageDownloadStatsList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := getDownloadStatsSearchOptions(ctx)
+	if ctx.Written() {
+		return
+	}
+	opts.VersionID = ctx.Package.Descriptor.Version.ID
+	opts.GroupByVersion = false
+
+	writeDownloadStats(ctx, opts)
+}
+
+func getDownloadStatsSearchOptions(ctx *context.APIContext) *packages.DownloadStatsSearchOptions {
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return nil
+	}
+
+	opts := &packages.DownloadStatsSearchOptions{}
+	if since != 0 {
+		opts.Since = time.Unix(since, 0)
+	}
+	if before != 0 {
+		opts.Before = time.Unix(before, 0)
+	}
+
+	switch groupBy := ctx.FormString("group_by"); groupBy {
+	case "":
+	case "client":
+		opts.GroupByClient = true
+	case "version":
+		opts.GroupByVersion = true
+	default:
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid group_by: "+groupBy)
+		return nil
+	}
+	return opts
+}
+
+func writeDownloadStats(ctx *context.APIContext, opts *packages.DownloadStatsSearchOptions) {
+	points, err := packages.SearchDownloadStats(ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	stats, err := convert.ToPackageDownloadStats(ctx, points)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
@@ -6,9 +6,11 @@ package packages
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/optional"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
@@ -43,28 +45,40 @@ func ListPackages(ctx *context.APIContext) {
 	//   in: query
 	//   description: package type filter
 	//   type: string
-	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, vagrant]
+	//   enum: [alpine, ansible, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, hex, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, terraform, vagrant]
 	// - name: q
 	//   in: query
 	//   description: name filter
 	//   type: string
+	// - name: commit
+	//   in: query
+	//   description: only return package versions built from the commit, a prefix of at least 7 characters of the commit id is allowed
+	//   type: string
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/PackageList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	listOptions := utils.GetListOptions(ctx)
 
 	packageType := ctx.FormTrim("type")
 	query := ctx.FormTrim("q")
+	commit := strings.ToLower(ctx.FormTrim("commit"))
+	if commit != "" && !git.IsStringLikelyCommitID(nil, commit, 7) {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid commit id")
+		return
+	}
 
 	pvs, count, err := packages.SearchVersions(ctx, &packages.PackageSearchOptions{
-		OwnerID:    ctx.Package.Owner.ID,
-		Type:       packages.Type(packageType),
-		Name:       packages.SearchValue{Value: query},
-		IsInternal: optional.Some(false),
-		Paginator:  &listOptions,
+		OwnerID:         ctx.Package.Owner.ID,
+		Type:            packages.Type(packageType),
+		Name:            packages.SearchValue{Value: query},
+		SourceCommitSHA: commit,
+		IsInternal:      optional.Some(false),
+		Paginator:       &listOptions,
 	})
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -164,12 +178,18 @@ func DeletePackage(ctx *context.APIContext) {
 	// responses:
 	//   "204":
 	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
 	err := packages_service.RemovePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version)
 	if err != nil {
-		ctx.APIErrorInternal(err)
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.APIError(http.StatusForbidden, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
 		return
 	}
 	ctx.Status(http.StatusNoContent)
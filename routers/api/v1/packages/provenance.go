@@ -0,0 +1,235 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// ListPackageProvenance gets the provenance attestations of a package version
+func ListPackageProvenance(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/provenance package listPackageProvenance
+	// ---
+	// summary: Gets the provenance attestations of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageProvenanceList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pps, err := packages_model.GetProvenancesByVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiProvenances := make([]*api.PackageProvenance, 0, len(pps))
+	for _, pp := range pps {
+		apiProvenances = append(apiProvenances, convert.ToPackageProvenance(pp))
+	}
+
+	ctx.JSON(http.StatusOK, apiProvenances)
+}
+
+// AddPackageProvenance attaches a provenance attestation to a package version
+func AddPackageProvenance(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/provenance package addPackageProvenance
+	// ---
+	// summary: Attach an in-toto provenance attestation to a package version
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: in-toto statement, optionally wrapped in a DSSE envelope
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageProvenance"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pp, err := packages_service.AddProvenance(ctx, ctx.Doer, ctx.Package.Descriptor, ctx.Req.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageProvenance(pp))
+}
+
+// GetPackageProvenanceContent gets the document of a provenance attestation
+func GetPackageProvenanceContent(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/provenance/{id} package getPackageProvenanceContent
+	// ---
+	// summary: Gets the document of a provenance attestation
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the attestation as uploaded
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pp, err := packages_model.GetProvenanceByID(ctx, ctx.Package.Descriptor.Version.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Status(http.StatusOK)
+	_, _ = ctx.Resp.Write([]byte(pp.Content))
+}
+
+// DeletePackageProvenance removes a provenance attestation from a package version
+func DeletePackageProvenance(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/provenance/{id} package deletePackageProvenance
+	// ---
+	// summary: Remove a provenance attestation from a package version
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := packages_service.DeleteProvenance(ctx, ctx.Package.Descriptor, ctx.PathParamInt64("id")); err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.APIErrorNotFound()
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
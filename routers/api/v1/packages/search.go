@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"net/http"
+	"slices"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/indexer"
+	package_indexer "code.gitea.io/gitea/modules/indexer/packages"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// SearchPackages searches the packages of an owner by their metadata
+func SearchPackages(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/search package searchPackages
+	// ---
+	// summary: Search the packages of an owner by their name, description, keywords and properties
+	// description: The packages are searched with the package indexer, so it has to be enabled. The latest versions of the packages are returned sorted by relevance.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for in the name, description, keywords and properties of the latest versions
+	//   type: string
+	// - name: type
+	//   in: query
+	//   description: package type filter
+	//   type: string
+	//   enum: [alpine, ansible, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, hex, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, terraform, vagrant]
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the package indexer, like "exact" or "words"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Indexer.PackageIndexerEnabled {
+		ctx.APIErrorNotFound("package indexer is disabled")
+		return
+	}
+
+	opts := &package_indexer.SearchOptions{
+		Keyword:    ctx.FormTrim("q"),
+		SearchMode: indexer.SearchModeType(ctx.FormTrim("search_mode")),
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       ctx.FormTrim("type"),
+	}
+	if opts.SearchMode != "" && !slices.ContainsFunc(package_indexer.SupportedSearchModes(), func(m indexer.SearchMode) bool {
+		return m.ModeValue == opts.SearchMode
+	}) {
+		ctx.APIError(http.StatusUnprocessableEntity, "unsupported search mode")
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	opts.Paginator = &listOptions
+	pvs, count, err := package_indexer.SearchPackages(ctx, opts, &packages.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	pds, err := packages.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPackages := make([]*api.Package, 0, len(pds))
+	for _, pd := range pds {
+		apiPackage, err := convert.ToPackage(ctx, pd, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiPackages = append(apiPackages, apiPackage)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiPackages)
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	packages_signing_service "code.gitea.io/gitea/services/packages/signing"
+)
+
+// ListPackageSigningKeys lists the signing keys of the repository metadata of a package type
+func ListPackageSigningKeys(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/signing_keys/{type} package listPackageSigningKeys
+	// ---
+	// summary: Gets the PGP keys which sign the repository metadata of a package type
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the packages
+	//   type: string
+	//   enum: [debian, rpm, terraform]
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageSigningKeyList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	packageType := getSigningKeyTypeFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	keys, err := packages.GetSigningKeys(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiKeys := make([]*api.PackageSigningKey, 0, len(keys))
+	for _, k := range keys {
+		apiKeys = append(apiKeys, convert.ToPackageSigningKey(k))
+	}
+
+	ctx.JSON(http.StatusOK, apiKeys)
+}
+
+// RotatePackageSigningKey creates a new signing key for the repository metadata of a package type
+func RotatePackageSigningKey(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/-/signing_keys/{type}/rotate package rotatePackageSigningKey
+	// ---
+	// summary: Create a new signing key, the previous keys are retired after the configured overlap window
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the packages
+	//   type: string
+	//   enum: [debian, rpm, terraform]
+	//   required: true
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageSigningKey"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	packageType := getSigningKeyTypeFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	k, err := packages_signing_service.RotateKey(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageSigningKey(k))
+}
+
+// RevokePackageSigningKey revokes a compromised signing key
+func RevokePackageSigningKey(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/-/signing_keys/{type}/{id}/revoke package revokePackageSigningKey
+	// ---
+	// summary: Revoke a compromised signing key, a new key is created if no other active key is left
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the packages
+	//   type: string
+	//   enum: [debian, rpm, terraform]
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the signing key
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageSigningKey"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	packageType := getSigningKeyTypeFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	k, err := packages.GetSigningKeyByID(ctx, ctx.Package.Owner.ID, packageType, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if err := packages_signing_service.RevokeKey(ctx, k); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageSigningKey(k))
+}
+
+func getSigningKeyTypeFromPath(ctx *context.APIContext) packages.Type {
+	packageType := packages.Type(ctx.PathParam("type"))
+	if !packages.IsSigningKeyType(packageType) {
+		ctx.APIErrorNotFound()
+	}
+	return packageType
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"code.gitea.io/gitea/modules/indexer"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// SearchCode searches the files of the default branch or an indexed branch or tag of a repository
+func SearchCode(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/search/code repository repoSearchCode
+	// ---
+	// summary: Search the code of a repository
+	// description: The code is searched with the code indexer, so it has to be enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: indexed branch or tag to search in, by its short or full name (default is the default branch)
+	//   type: string
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the code indexer, like "exact" or "words"
+	//   type: string
+	// - name: language
+	//   in: query
+	//   description: language of the files
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Indexer.RepoIndexerEnabled {
+		ctx.APIErrorNotFound("code indexer is disabled")
+		return
+	}
+
+	keyword := ctx.FormTrim("q")
+	if keyword == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "q is required")
+		return
+	}
+
+	searchMode := indexer.SearchModeType(ctx.FormTrim("search_mode"))
+	if searchMode != "" && !slices.ContainsFunc(code_indexer.SupportedSearchModes(), func(m indexer.SearchMode) bool {
+		return m.ModeValue == searchMode
+	}) {
+		ctx.APIError(http.StatusUnprocessableEntity, "unsupported search mode")
+		return
+	}
+
+	ref, err := code_indexer.ResolveIndexedRef(ctx, ctx.Repo.Repository, ctx.FormTrim("ref"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	total, results, _, err := code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+		RepoIDs:    []int64{ctx.Repo.Repository.ID},
+		Keyword:    keyword,
+		Language:   ctx.FormTrim("language"),
+		Ref:        ref,
+		SearchMode: searchMode,
+		Paginator:  &listOptions,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiResults := make([]*api.CodeSearchResult, len(results))
+	for i, r := range results {
+		apiResults[i] = convert.ToCodeSearchResult(ctx.Repo.Repository, r)
+	}
+
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(int64(total))
+	ctx.JSON(http.StatusOK, apiResults)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer/code/symbol"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListCodeSymbols searches the symbols defined in the default branch of a repository
+func ListCodeSymbols(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/symbols repository repoListCodeSymbols
+	// ---
+	// summary: Search the functions, methods, types and constants defined in the default branch of a repository
+	// description: The symbols are extracted by the code indexer, so it has to be enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: part of the symbol name, case-insensitive
+	//   type: string
+	// - name: exact
+	//   in: query
+	//   description: if true, the symbol name has to be equal to q
+	//   type: boolean
+	// - name: kind
+	//   in: query
+	//   description: kind of the symbols
+	//   type: string
+	//   enum: [function, method, type, constant]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeSymbolList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Indexer.RepoIndexerEnabled {
+		ctx.APIErrorNotFound("code indexer is disabled")
+		return
+	}
+
+	kind := ctx.FormTrim("kind")
+	if kind != "" && !symbol.IsValidKind(symbol.Kind(kind)) {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid kind")
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	symbols, total, err := repo_model.SearchCodeSymbols(ctx, &repo_model.SearchCodeSymbolsOptions{
+		ListOptions: listOptions,
+		RepoIDs:     []int64{ctx.Repo.Repository.ID},
+		Keyword:     ctx.FormTrim("q"),
+		Exact:       ctx.FormBool("exact"),
+		Kind:        kind,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiSymbols := make([]*api.CodeSymbol, len(symbols))
+	for i, s := range symbols {
+		apiSymbols[i] = convert.ToCodeSymbol(ctx.Repo.Repository, s)
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiSymbols)
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"slices"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// SearchIndexedCommits searches the commits of the default branch of a repository
+func SearchIndexedCommits(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/search/commits repository repoSearchCommits
+	// ---
+	// summary: Search the commits of a repository
+	// description: The commits of the default branch are searched with the commit indexer, so it has to be enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for in the message, author, committer and touched paths
+	//   type: string
+	// - name: author
+	//   in: query
+	//   description: name or email of the author
+	//   type: string
+	// - name: path
+	//   in: query
+	//   description: prefix of a path touched by the commits
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: only commits committed after this date will be returned (ISO 8601 format)
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only commits committed before this date will be returned (ISO 8601 format)
+	//   type: string
+	//   format: date-time
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the commit indexer, like "exact" or "words"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	searchIndexedCommits(ctx, []int64{ctx.Repo.Repository.ID})
+}
+
+// SearchAllIndexedCommits searches the commits of the default branches of all accessible repositories
+func SearchAllIndexedCommits(ctx *context.APIContext) {
+	// swagger:operation GET /repos/commits/search repository repoSearchAllCommits
+	// ---
+	// summary: Search the commits of all repositories the user can read the code of
+	// description: The commits of the default branches are searched with the commit indexer, so it has to be enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: q
+	//   in: query
+	//   description: keyword to search for in the message, author, committer and touched paths
+	//   type: string
+	// - name: author
+	//   in: query
+	//   description: name or email of the author
+	//   type: string
+	// - name: path
+	//   in: query
+	//   description: prefix of a path touched by the commits
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: only commits committed after this date will be returned (ISO 8601 format)
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only commits committed before this date will be returned (ISO 8601 format)
+	//   type: string
+	//   format: date-time
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the commit indexer, like "exact" or "words"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	doer := ctx.Doer
+	if ctx.PublicOnly {
+		doer = nil // only search the repositories visible to everyone
+	}
+
+	var repoIDs []int64
+	if doer == nil || !doer.IsAdmin {
+		var err error
+		repoIDs, err = repo_model.FindUserCodeAccessibleRepoIDs(ctx, doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if len(repoIDs) == 0 {
+			ctx.SetTotalCountHeader(0)
+			ctx.JSON(http.StatusOK, []*api.CommitSearchResult{})
+			return
+		}
+	}
+	searchIndexedCommits(ctx, repoIDs)
+}
+
+func searchIndexedCommits(ctx *context.APIContext, repoIDs []int64) {
+	if !setting.Indexer.CommitIndexerEnabled {
+		ctx.APIErrorNotFound("commit indexer is disabled")
+		return
+	}
+
+	opts := &commit_indexer.SearchOptions{
+		Keyword:    ctx.FormTrim("q"),
+		SearchMode: indexer.SearchModeType(ctx.FormTrim("search_mode")),
+		RepoIDs:    repoIDs,
+		Author:     ctx.FormTrim("author"),
+		Path:       ctx.FormTrim("path"),
+	}
+	if opts.SearchMode != "" && !slices.ContainsFunc(commit_indexer.SupportedSearchModes(), func(m indexer.SearchMode) bool {
+		return m.ModeValue == opts.SearchMode
+	}) {
+		ctx.APIError(http.StatusUnprocessableEntity, "unsupported search mode")
+		return
+	}
+
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	if since != 0 {
+		opts.CommittedAfterUnix = optional.Some(since)
+	}
+	if before != 0 {
+		opts.CommittedBeforeUnix = optional.Some(before)
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	opts.Paginator = &listOptions
+	result, err := commit_indexer.SearchCommits(ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	repoIDsOfHits := make([]int64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		repoIDsOfHits = append(repoIDsOfHits, hit.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDsOfHits)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiResults := make([]*api.CommitSearchResult, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		// the commits of deleted repositories may not be removed from the indexer yet
+		if repo, ok := repos[hit.RepoID]; ok {
+			apiResults = append(apiResults, convert.ToCommitSearchResult(repo, hit))
+		}
+	}
+
+	ctx.SetLinkHeader(int(result.Total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(result.Total)
+	ctx.JSON(http.StatusOK, apiResults)
+}
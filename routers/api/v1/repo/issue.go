@@ -56,7 +56,7 @@ func SearchIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: Search string
+	//   description: Search string, which supports qualifiers like `is:open`, `label:bug`, `-label:wontfix`, `author:@me` or `created:>2025-01-01`
 	//   type: string
 	// - name: priority_repo_id
 	//   in: query
@@ -228,6 +228,23 @@ func SearchIssues(ctx *context.APIContext) {
 		isPull = optional.None[bool]()
 	}
 
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err == nil {
+		var labelRepoIDs []int64 // labels are looked up in all repositories when searching all public ones
+		if !allPublic {
+			labelRepoIDs = repoIDs
+		}
+		err = query.Resolve(ctx, ctx.Doer, labelRepoIDs)
+	}
+	if err != nil {
+		if issue_indexer.IsErrInvalidQuery(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
 	var includedAnyLabels []int64
 	{
 		labels := ctx.FormTrim("labels")
@@ -306,6 +323,8 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	query.Apply(searchOpt)
+
 	// FIXME: It's unsupported to sort by priority repo when searching by indexer,
 	//        it's indeed an regression, but I think it is worth to support filtering by indexer first.
 	_ = ctx.FormInt64("priority_repo_id")
@@ -355,7 +374,7 @@ func ListIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: search string
+	//   description: search string, which supports qualifiers like `is:open`, `label:bug`, `-label:wontfix`, `author:@me` or `created:>2025-01-01`
 	//   type: string
 	// - name: type
 	//   in: query
@@ -403,6 +422,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.APIError(http.StatusUnprocessableEntity, err)
@@ -423,6 +444,18 @@ func ListIssues(ctx *context.APIContext) {
 	if strings.IndexByte(keyword, 0) >= 0 {
 		keyword = ""
 	}
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err == nil {
+		err = query.Resolve(ctx, ctx.Doer, []int64{ctx.Repo.Repository.ID})
+	}
+	if err != nil {
+		if issue_indexer.IsErrInvalidQuery(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
 
 	var labelIDs []int64
 	if splitted := strings.Split(ctx.FormString("labels"), ","); len(splitted) > 0 {
@@ -472,6 +505,9 @@ func ListIssues(ctx *context.APIContext) {
 	case "issues":
 		isPull = optional.Some(false)
 	}
+	if query.IsPull.Has() {
+		isPull = query.IsPull
+	}
 
 	if isPull.Has() && !ctx.Repo.CanReadIssuesOrPulls(isPull.Value()) {
 		ctx.APIErrorNotFound()
@@ -546,6 +582,7 @@ func ListIssues(ctx *context.APIContext) {
 	if mentionedByID > 0 {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
+	query.Apply(searchOpt)
 
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
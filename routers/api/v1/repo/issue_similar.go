@@ -0,0 +1,73 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListSimilarIssues lists the issues of a repository similar to a title and body
+func ListSimilarIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/similar issue issueListSimilarIssues
+	// ---
+	// summary: List the open and closed issues of a repository similar to a title and body, the most similar first
+	// description: It can be used to find the possible duplicates of an issue before creating it.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: title
+	//   in: query
+	//   description: title of the issue to find the similar issues of
+	//   type: string
+	// - name: body
+	//   in: query
+	//   description: body of the issue to find the similar issues of
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: maximum number of similar issues to return, defaults to 5
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	title, body := ctx.FormTrim("title"), ctx.FormTrim("body")
+	if title == "" && body == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "title or body is required")
+		return
+	}
+
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = issue_service.DefaultSimilarIssuesLimit
+	}
+	limit = min(limit, setting.API.MaxResponseItems)
+
+	issues, err := issue_service.FindSimilarIssues(ctx, ctx.Repo.Repository, title, body, limit)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"slices"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	release_indexer "code.gitea.io/gitea/modules/indexer/releases"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// SearchReleases searches the published releases of a repository
+func SearchReleases(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/search repository repoSearchReleases
+	// ---
+	// summary: Search the published releases of a repository
+	// description: The releases are searched with the release indexer, so it has to be enabled. They are sorted by relevance.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for in the tag name, title, notes and attachment names of the releases
+	//   type: string
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the release indexer, like "exact" or "words"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Indexer.ReleaseIndexerEnabled {
+		ctx.APIErrorNotFound("release indexer is disabled")
+		return
+	}
+
+	opts := &release_indexer.SearchOptions{
+		Keyword:    ctx.FormTrim("q"),
+		SearchMode: indexer.SearchModeType(ctx.FormTrim("search_mode")),
+		RepoIDs:    []int64{ctx.Repo.Repository.ID},
+	}
+	if opts.SearchMode != "" && !slices.ContainsFunc(release_indexer.SupportedSearchModes(), func(m indexer.SearchMode) bool {
+		return m.ModeValue == opts.SearchMode
+	}) {
+		ctx.APIError(http.StatusUnprocessableEntity, "unsupported search mode")
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	opts.Paginator = &listOptions
+	ids, total, err := release_indexer.SearchReleases(ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	releases, err := repo_model.GetReleasesMapByIDs(ctx, ids)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	rels := make([]*api.Release, 0, len(ids))
+	for _, id := range ids {
+		// the deleted releases and the ones turned into drafts may not be removed from the indexer yet
+		release, ok := releases[id]
+		if !ok || release.RepoID != ctx.Repo.Repository.ID || release.IsDraft || release.IsTag {
+			continue
+		}
+		release.Repo = ctx.Repo.Repository
+		if err := release.LoadAttributes(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		rels = append(rels, convert.ToAPIRelease(ctx, ctx.Repo.Repository, release))
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, rels)
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"slices"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/indexer"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	wiki_service "code.gitea.io/gitea/services/wiki"
+)
+
+// SearchWiki searches the pages of the wiki of a repository
+func SearchWiki(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/wiki/search repository repoSearchWiki
+	// ---
+	// summary: Search the pages of the wiki of a repository
+	// description: The pages are searched with the wiki indexer, so it has to be enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for in the title and content of the pages
+	//   type: string
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the wiki indexer, like "exact" or "words"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WikiSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	searchWiki(ctx, []int64{ctx.Repo.Repository.ID})
+}
+
+// SearchAllWikis searches the pages of the wikis of all accessible repositories
+func SearchAllWikis(ctx *context.APIContext) {
+	// swagger:operation GET /repos/wikis/search repository repoSearchAllWikis
+	// ---
+	// summary: Search the pages of the wikis of all repositories the user can read the wiki of
+	// description: The pages are searched with the wiki indexer, so it has to be enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: q
+	//   in: query
+	//   description: keyword to search for in the title and content of the pages
+	//   type: string
+	// - name: search_mode
+	//   in: query
+	//   description: search mode supported by the wiki indexer, like "exact" or "words"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WikiSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	doer := ctx.Doer
+	if ctx.PublicOnly {
+		doer = nil // only search the repositories visible to everyone
+	}
+
+	var repoIDs []int64
+	if doer == nil || !doer.IsAdmin {
+		var err error
+		repoIDs, err = repo_model.FindUserWikiAccessibleRepoIDs(ctx, doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if len(repoIDs) == 0 {
+			ctx.SetTotalCountHeader(0)
+			ctx.JSON(http.StatusOK, []*api.WikiSearchResult{})
+			return
+		}
+	}
+	searchWiki(ctx, repoIDs)
+}
+
+func searchWiki(ctx *context.APIContext, repoIDs []int64) {
+	if !setting.Indexer.WikiIndexerEnabled {
+		ctx.APIErrorNotFound("wiki indexer is disabled")
+		return
+	}
+
+	opts := &wiki_indexer.SearchOptions{
+		Keyword:    ctx.FormTrim("q"),
+		SearchMode: indexer.SearchModeType(ctx.FormTrim("search_mode")),
+		RepoIDs:    repoIDs,
+	}
+	if opts.SearchMode != "" && !slices.ContainsFunc(wiki_indexer.SupportedSearchModes(), func(m indexer.SearchMode) bool {
+		return m.ModeValue == opts.SearchMode
+	}) {
+		ctx.APIError(http.StatusUnprocessableEntity, "unsupported search mode")
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	opts.Paginator = &listOptions
+	total, results, err := wiki_indexer.SearchWiki(ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	repoIDsOfHits := make([]int64, 0, len(results))
+	for _, result := range results {
+		repoIDsOfHits = append(repoIDsOfHits, result.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDsOfHits)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiResults := make([]*api.WikiSearchResult, 0, len(results))
+	for _, result := range results {
+		// the pages of deleted repositories or disabled wikis may not be removed from the indexer yet
+		repo, ok := repos[result.RepoID]
+		if !ok || !repo.UnitEnabled(ctx, unit.TypeWiki) {
+			continue
+		}
+		apiResult, err := wiki_service.ToWikiSearchResult(repo, result)
+		if err != nil {
+			continue // not a valid wiki page name
+		}
+		apiResults = append(apiResults, apiResult)
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiResults)
+}
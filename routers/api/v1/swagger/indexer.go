@@ -0,0 +1,15 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package swagger
+
+import (
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// IndexerStatusList
+// swagger:response IndexerStatusList
+type swaggerResponseIndexerStatusList struct {
+	// in:body
+	Body []api.IndexerStatus `json:"body"`
+}
@@ -62,3 +62,10 @@ type swaggerResponseLabelTemplateInfo struct {
 	// in:body
 	Body []api.LabelTemplate `json:"body"`
 }
+
+// SearchResponse
+// swagger:response SearchResponse
+type swaggerResponseSearchResponse struct {
+	// in:body
+	Body api.SearchResponse `json:"body"`
+}
@@ -216,4 +216,10 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	UpdateVariableOption api.UpdateVariableOption
+
+	// in:body
+	EditPackageAccessOption api.EditPackageAccessOption
+
+	// in:body
+	PackageAccessGrantOption api.PackageAccessGrantOption
 }
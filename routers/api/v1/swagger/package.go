@@ -27,3 +27,45 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageDownloadStatsList
+// swagger:response PackageDownloadStatsList
+type swaggerPackageDownloadStatsList struct {
+	// in:body
+	Body []api.PackageDownloadStats `json:"body"`
+}
+
+// PackageProvenance
+// swagger:response PackageProvenance
+type swaggerResponsePackageProvenance struct {
+	// in:body
+	Body api.PackageProvenance `json:"body"`
+}
+
+// PackageProvenanceList
+// swagger:response PackageProvenanceList
+type swaggerResponsePackageProvenanceList struct {
+	// in:body
+	Body []api.PackageProvenance `json:"body"`
+}
+
+// PackageSigningKey
+// swagger:response PackageSigningKey
+type swaggerResponsePackageSigningKey struct {
+	// in:body
+	Body api.PackageSigningKey `json:"body"`
+}
+
+// PackageSigningKeyList
+// swagger:response PackageSigningKeyList
+type swaggerResponsePackageSigningKeyList struct {
+	// in:body
+	Body []api.PackageSigningKey `json:"body"`
+}
+
+// PackageAccess
+// swagger:response PackageAccess
+type swaggerResponsePackageAccess struct {
+	// in:body
+	Body api.PackageAccess `json:"body"`
+}
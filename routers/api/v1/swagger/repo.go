@@ -474,3 +474,31 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// CodeSymbolList
+// swagger:response CodeSymbolList
+type swaggerCodeSymbolList struct {
+	// in:body
+	Body []api.CodeSymbol `json:"body"`
+}
+
+// CodeSearchResultList
+// swagger:response CodeSearchResultList
+type swaggerCodeSearchResultList struct {
+	// in:body
+	Body []api.CodeSearchResult `json:"body"`
+}
+
+// CommitSearchResultList
+// swagger:response CommitSearchResultList
+type swaggerCommitSearchResultList struct {
+	// in:body
+	Body []api.CommitSearchResult `json:"body"`
+}
+
+// WikiSearchResultList
+// swagger:response WikiSearchResultList
+type swaggerWikiSearchResultList struct {
+	// in:body
+	Body []api.WikiSearchResult `json:"body"`
+}
@@ -155,6 +155,56 @@ func pullHook(events []string, event string) bool {
 	return util.SliceContainsString(events, event, true) || util.SliceContainsString(events, string(webhook_module.HookEventPullRequest), true)
 }
 
+// validateHookTransport checks the TLS and proxy settings of the webhook. If they are
+// invalid, write to `ctx` accordingly. Return whether they are valid
+func validateHookTransport(ctx *context.APIContext, w *webhook.Webhook) bool {
+	if err := webhook_service.ValidateTransport(w); err != nil {
+		if webhook_service.IsErrInvalidTransport(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return false
+	}
+	return true
+}
+
+// parseNtfyHookConfig returns the ntfy metadata of the hook config. If it is invalid,
+// write to `ctx` accordingly. Return (meta, ok)
+func parseNtfyHookConfig(ctx *context.APIContext, config map[string]string) (string, bool) {
+	topic, ok := config["topic"]
+	if !ok {
+		ctx.APIError(http.StatusUnprocessableEntity, "Missing config option: topic")
+		return "", false
+	}
+	topic = strings.TrimSpace(topic)
+	if !webhook_service.IsValidNtfyTopic(topic) {
+		ctx.APIError(http.StatusUnprocessableEntity, "Invalid ntfy topic name")
+		return "", false
+	}
+
+	var priority int
+	if config["priority"] != "" {
+		var err error
+		priority, err = strconv.Atoi(config["priority"])
+		if err != nil || !webhook_service.IsValidNtfyPriority(priority) {
+			ctx.APIError(http.StatusUnprocessableEntity, "Invalid ntfy priority, it must be between 1 and 5")
+			return "", false
+		}
+	}
+
+	meta, err := json.Marshal(&webhook_service.NtfyMeta{
+		Topic:    topic,
+		Priority: priority,
+		Tags:     webhook_service.ParseNtfyTags(config["tags"]),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return "", false
+	}
+	return string(meta), true
+}
+
 // addHook add the hook specified by `form`, `ownerID` and `repoID`. If there is
 // an error, write to `ctx` accordingly. Return (webhook, ok)
 func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoID int64) (*webhook.Webhook, bool) {
@@ -208,6 +258,13 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 				webhook_module.HookEventPackage:                  util.SliceContainsString(form.Events, string(webhook_module.HookEventPackage), true),
 				webhook_module.HookEventStatus:                   util.SliceContainsString(form.Events, string(webhook_module.HookEventStatus), true),
 				webhook_module.HookEventWorkflowJob:              util.SliceContainsString(form.Events, string(webhook_module.HookEventWorkflowJob), true),
+				webhook_module.HookEventOrganization:             util.SliceContainsString(form.Events, string(webhook_module.HookEventOrganization), true),
+				webhook_module.HookEventMembership:               util.SliceContainsString(form.Events, string(webhook_module.HookEventMembership), true),
+				webhook_module.HookEventTeam:                     util.SliceContainsString(form.Events, string(webhook_module.HookEventTeam), true),
+				webhook_module.HookEventMember:                   util.SliceContainsString(form.Events, string(webhook_module.HookEventMember), true),
+				webhook_module.HookEventBranchProtectionRule:     util.SliceContainsString(form.Events, string(webhook_module.HookEventBranchProtectionRule), true),
+				webhook_module.HookEventStar:                     util.SliceContainsString(form.Events, string(webhook_module.HookEventStar), true),
+				webhook_module.HookEventUser:                     util.SliceContainsString(form.Events, string(webhook_module.HookEventUser), true),
 			},
 			BranchFilter: form.BranchFilter,
 		},
@@ -219,6 +276,20 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		ctx.APIErrorInternal(err)
 		return nil, false
 	}
+	w.ClientCertificate = form.ClientCertificate
+	w.CACertificate = form.CACertificate
+	w.TLSServerName = form.TLSServerName
+	if err := w.SetClientKey(form.ClientKey); err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, false
+	}
+	if err := w.SetProxyURL(form.ProxyURL); err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, false
+	}
+	if !validateHookTransport(ctx, w) {
+		return nil, false
+	}
 	if w.Type == webhook_module.SLACK {
 		channel, ok := form.Config["channel"]
 		if !ok {
@@ -243,6 +314,12 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 			return nil, false
 		}
 		w.Meta = string(meta)
+	} else if w.Type == webhook_module.NTFY {
+		meta, ok := parseNtfyHookConfig(ctx, form.Config)
+		if !ok {
+			return nil, false
+		}
+		w.Meta = meta
 	}
 
 	if err := w.UpdateEvent(); err != nil {
@@ -349,6 +426,14 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 				}
 				w.Meta = string(meta)
 			}
+		} else if w.Type == webhook_module.NTFY {
+			if _, ok := form.Config["topic"]; ok {
+				meta, ok := parseNtfyHookConfig(ctx, form.Config)
+				if !ok {
+					return false
+				}
+				w.Meta = meta
+			}
 		}
 	}
 
@@ -366,6 +451,13 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.HookEvents[webhook_module.HookEventRepository] = util.SliceContainsString(form.Events, string(webhook_module.HookEventRepository), true)
 	w.HookEvents[webhook_module.HookEventWiki] = util.SliceContainsString(form.Events, string(webhook_module.HookEventWiki), true)
 	w.HookEvents[webhook_module.HookEventRelease] = util.SliceContainsString(form.Events, string(webhook_module.HookEventRelease), true)
+	w.HookEvents[webhook_module.HookEventOrganization] = util.SliceContainsString(form.Events, string(webhook_module.HookEventOrganization), true)
+	w.HookEvents[webhook_module.HookEventMembership] = util.SliceContainsString(form.Events, string(webhook_module.HookEventMembership), true)
+	w.HookEvents[webhook_module.HookEventTeam] = util.SliceContainsString(form.Events, string(webhook_module.HookEventTeam), true)
+	w.HookEvents[webhook_module.HookEventMember] = util.SliceContainsString(form.Events, string(webhook_module.HookEventMember), true)
+	w.HookEvents[webhook_module.HookEventBranchProtectionRule] = util.SliceContainsString(form.Events, string(webhook_module.HookEventBranchProtectionRule), true)
+	w.HookEvents[webhook_module.HookEventStar] = util.SliceContainsString(form.Events, string(webhook_module.HookEventStar), true)
+	w.HookEvents[webhook_module.HookEventUser] = util.SliceContainsString(form.Events, string(webhook_module.HookEventUser), true)
 	w.BranchFilter = form.BranchFilter
 
 	err := w.SetHeaderAuthorization(form.AuthorizationHeader)
@@ -374,6 +466,35 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 		return false
 	}
 
+	if form.ClientCertificate != nil {
+		if w.ClientCertificate != *form.ClientCertificate && form.ClientKey == nil {
+			// the key of the previous certificate can't be used with the new one
+			w.ClientKeyEncrypted = ""
+		}
+		w.ClientCertificate = *form.ClientCertificate
+	}
+	if form.ClientKey != nil {
+		if err := w.SetClientKey(*form.ClientKey); err != nil {
+			ctx.APIErrorInternal(err)
+			return false
+		}
+	}
+	if form.CACertificate != nil {
+		w.CACertificate = *form.CACertificate
+	}
+	if form.TLSServerName != nil {
+		w.TLSServerName = *form.TLSServerName
+	}
+	if form.ProxyURL != nil {
+		if err := w.SetProxyURL(*form.ProxyURL); err != nil {
+			ctx.APIErrorInternal(err)
+			return false
+		}
+	}
+	if !validateHookTransport(ctx, w) {
+		return false
+	}
+
 	// Issues
 	w.HookEvents[webhook_module.HookEventIssues] = issuesHook(form.Events, "issues_only")
 	w.HookEvents[webhook_module.HookEventIssueAssign] = issuesHook(form.Events, string(webhook_module.HookEventIssueAssign))
@@ -0,0 +1,31 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"code.gitea.io/gitea/modules/indexer"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
+	"code.gitea.io/gitea/services/context"
+)
+
+// PrepareCommitSearch reads the commit search form and fills the template data of the search box
+func PrepareCommitSearch(ctx *context.Context) (ret struct {
+	Keyword    string
+	Author     string
+	Path       string
+	SearchMode indexer.SearchModeType
+},
+) {
+	ret.Keyword = ctx.FormTrim("q")
+	ret.Author = ctx.FormTrim("author")
+	ret.Path = ctx.FormTrim("path")
+	ret.SearchMode = indexer.SearchModeType(ctx.FormTrim("search_mode"))
+
+	ctx.Data["Keyword"] = ret.Keyword
+	ctx.Data["Author"] = ret.Author
+	ctx.Data["Path"] = ret.Path
+	ctx.Data["SelectedSearchMode"] = string(ret.SearchMode)
+	ctx.Data["SearchModes"] = commit_indexer.SupportedSearchModes()
+	return ret
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"code.gitea.io/gitea/modules/indexer"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/services/context"
+	wiki_service "code.gitea.io/gitea/services/wiki"
+)
+
+// WikiSearchResult a wiki page found by the wiki search to display
+type WikiSearchResult struct {
+	RepoID  int64
+	Title   string
+	SubURL  string // the path of the page relative to the wiki link of the repository
+	Snippet string
+}
+
+// PrepareWikiSearch reads the wiki search form and fills the template data of the search box
+func PrepareWikiSearch(ctx *context.Context) (ret struct {
+	Keyword    string
+	SearchMode indexer.SearchModeType
+},
+) {
+	ret.Keyword = ctx.FormTrim("q")
+	ret.SearchMode = indexer.SearchModeType(ctx.FormTrim("search_mode"))
+
+	ctx.Data["Keyword"] = ret.Keyword
+	ctx.Data["SelectedSearchMode"] = string(ret.SearchMode)
+	ctx.Data["SearchModes"] = wiki_indexer.SupportedSearchModes()
+	return ret
+}
+
+// ToWikiSearchResults converts the results of the wiki indexer to display them,
+// the pages whose file names are not valid wiki names are skipped
+func ToWikiSearchResults(results []*wiki_indexer.Result) []*WikiSearchResult {
+	ret := make([]*WikiSearchResult, 0, len(results))
+	for _, result := range results {
+		wikiName, err := wiki_service.GitPathToWebPath(result.Path)
+		if err != nil {
+			continue
+		}
+		_, title := wiki_service.WebPathToUserTitle(wikiName)
+		ret = append(ret, &WikiSearchResult{
+			RepoID:  result.RepoID,
+			Title:   title,
+			SubURL:  wiki_service.WebPathToURLPath(wikiName),
+			Snippet: result.Snippet,
+		})
+	}
+	return ret
+}
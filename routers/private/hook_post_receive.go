@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -16,6 +17,7 @@ import (
 	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/private"
 	repo_module "code.gitea.io/gitea/modules/repository"
@@ -41,6 +43,20 @@ func HookPostReceive(ctx *gitea_context.PrivateContext) {
 	ownerName := ctx.PathParam("owner")
 	repoName := ctx.PathParam("repo")
 
+	if opts.IsWiki {
+		// the pushes to wikis are not recorded, only the pages are reindexed
+		if setting.Indexer.WikiIndexerEnabled {
+			repo := loadRepository(ctx, ownerName, strings.TrimSuffix(repoName, ".wiki"))
+			if ctx.Written() {
+				// Error handled in loadRepository
+				return
+			}
+			wiki_indexer.UpdateRepoIndexer(repo)
+		}
+		ctx.JSON(http.StatusOK, private.HookPostReceiveResult{})
+		return
+	}
+
 	// defer getting the repository at this point - as we should only retrieve it if we're going to call update
 	var (
 		repo    *repo_model.Repository
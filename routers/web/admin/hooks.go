@@ -6,11 +6,13 @@ package admin
 import (
 	"net/http"
 
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/services/context"
+	webhook_service "code.gitea.io/gitea/services/webhook"
 )
 
 const (
@@ -56,9 +58,35 @@ func DefaultOrSystemWebhooks(ctx *context.Context) {
 	ctx.Data["DefaultWebhooks"] = def
 	ctx.Data["SystemWebhooks"] = sys
 
+	ctx.Data["StuckHookTasks"], ctx.Data["StuckHookTaskCount"], err = db.FindAndCount[webhook.HookTask](ctx, webhook.FindStuckHookTasksOptions{
+		ListOptions: db.ListOptions{PageSize: setting.Webhook.PagingNum, Page: 1},
+	})
+	if err != nil {
+		ctx.ServerError("FindStuckHookTasks", err)
+		return
+	}
+
+	ctx.Data["HookDeliveryMaxAttempts"] = setting.Webhook.DeliveryMaxAttempts
+
 	ctx.HTML(http.StatusOK, tplAdminHooks)
 }
 
+// RetryStuckHookTask resets the delivery attempts of a stuck hook task and delivers it again
+func RetryStuckHookTask(ctx *context.Context) {
+	if err := webhook_service.RetryHookTask(ctx, ctx.FormInt64("id")); err != nil {
+		if webhook.IsErrHookTaskNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("admin.hooks.stuck_tasks.retry_not_exist"))
+		} else {
+			ctx.ServerError("RetryHookTask", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.hooks.stuck_tasks.retry_success"))
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/hooks")
+}
+
 // DeleteDefaultOrSystemWebhook handler to delete an admin-defined system or default webhook
 func DeleteDefaultOrSystemWebhook(ctx *context.Context) {
 	if err := webhook.DeleteDefaultSystemWebhook(ctx, ctx.FormInt64("id")); err != nil {
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	indexer_service "code.gitea.io/gitea/services/indexer"
+)
+
+// Indexers shows the health and the rebuild progress of the indexers
+func Indexers(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.monitor.indexers")
+	ctx.Data["PageIsAdminMonitorIndexer"] = true
+
+	statuses, err := indexer_service.GetStatuses(ctx)
+	if err != nil {
+		ctx.ServerError("GetStatuses", err)
+		return
+	}
+	ctx.Data["Indexers"] = statuses
+	ctx.HTML(http.StatusOK, tplIndexers)
+}
+
+// IndexerRebuild starts rebuilding the whole index of an indexer
+func IndexerRebuild(ctx *context.Context) {
+	name := ctx.PathParam("name")
+	if err := indexer_service.Rebuild(name); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+			return
+		}
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrAlreadyExist) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(setting.AppSubURL + "/-/admin/monitor/indexers")
+			return
+		}
+		ctx.ServerError("Rebuild", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.monitor.indexer.rebuild_started", name))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/monitor/indexers")
+}
+
+// IndexerReindexRepo reindexes a repository with an indexer from scratch
+func IndexerReindexRepo(ctx *context.Context) {
+	name := ctx.FormString("indexer")
+	ownerName, repoName, _ := strings.Cut(strings.TrimSpace(ctx.FormString("repo")), "/")
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("admin.monitor.indexer.repo_not_exist", ctx.FormString("repo")))
+			ctx.Redirect(setting.AppSubURL + "/-/admin/monitor/indexers")
+			return
+		}
+		ctx.ServerError("GetRepositoryByOwnerAndName", err)
+		return
+	}
+
+	if err := indexer_service.ReindexRepo(ctx, name, repo); err != nil {
+		if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(setting.AppSubURL + "/-/admin/monitor/indexers")
+			return
+		}
+		ctx.ServerError("ReindexRepo", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.monitor.indexer.reindex_repo_started", repo.FullName(), name))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/monitor/indexers")
+}
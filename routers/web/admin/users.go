@@ -29,6 +29,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/mailer"
+	notify_service "code.gitea.io/gitea/services/notify"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -203,6 +204,7 @@ func NewUserPost(ctx *context.Context) {
 	}
 
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
+	notify_service.CreateUser(ctx, ctx.Doer, u)
 
 	// Send email notification.
 	if form.SendNotify {
@@ -388,6 +390,7 @@ func EditUserPost(ctx *context.Context) {
 		authOpts.LoginSource = optional.Some(authSource)
 	}
 
+	wasProhibitLogin := u.ProhibitLogin
 	if err := user_service.UpdateAuth(ctx, u, authOpts); err != nil {
 		switch {
 		case errors.Is(err, password.ErrMinLength):
@@ -407,6 +410,9 @@ func EditUserPost(ctx *context.Context) {
 		}
 		return
 	}
+	if u.ProhibitLogin != wasProhibitLogin {
+		notify_service.SuspendUser(ctx, ctx.Doer, u, u.ProhibitLogin)
+	}
 
 	if form.Email != "" {
 		if err := user_service.AdminAddOrSetPrimaryEmailAddress(ctx, u, form.Email); err != nil {
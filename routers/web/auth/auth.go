@@ -32,6 +32,7 @@ import (
 	"code.gitea.io/gitea/services/externalaccount"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/mailer"
+	notify_service "code.gitea.io/gitea/services/notify"
 	user_service "code.gitea.io/gitea/services/user"
 
 	"github.com/markbates/goth"
@@ -591,6 +592,7 @@ func createUserInContext(ctx *context.Context, tpl templates.TplName, form any,
 		return false
 	}
 	log.Trace("Account created: %s", u.Name)
+	notify_service.CreateUser(ctx, nil, u)
 	return true
 }
 
@@ -0,0 +1,115 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// tplExploreCommits explore commits page template
+const tplExploreCommits templates.TplName = "explore/commits"
+
+// Commits render explore commits page
+func Commits(ctx *context.Context) {
+	if !setting.Indexer.CommitIndexerEnabled || setting.Service.Explore.DisableCodePage {
+		ctx.Redirect(setting.AppSubURL + "/explore")
+		return
+	}
+
+	ctx.Data["UsersPageIsDisabled"] = setting.Service.Explore.DisableUsersPage
+	ctx.Data["OrganizationsPageIsDisabled"] = setting.Service.Explore.DisableOrganizationsPage
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsCommitIndexerEnabled"] = setting.Indexer.CommitIndexerEnabled
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
+	ctx.Data["Title"] = ctx.Tr("explore")
+	ctx.Data["PageIsExplore"] = true
+	ctx.Data["PageIsExploreCommits"] = true
+
+	prepareSearch := common.PrepareCommitSearch(ctx)
+	if prepareSearch.Keyword == "" && prepareSearch.Author == "" && prepareSearch.Path == "" {
+		ctx.HTML(http.StatusOK, tplExploreCommits)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	var (
+		repoIDs []int64
+		err     error
+	)
+	isAdmin := ctx.Doer != nil && ctx.Doer.IsAdmin
+
+	// guest user or non-admin user
+	if !isAdmin {
+		repoIDs, err = repo_model.FindUserCodeAccessibleRepoIDs(ctx, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("FindUserCodeAccessibleRepoIDs", err)
+			return
+		}
+	}
+
+	var total int64
+	var searchResults []*commit_indexer.IndexerData
+	if len(repoIDs) > 0 || isAdmin {
+		result, err := commit_indexer.SearchCommits(ctx, &commit_indexer.SearchOptions{
+			Keyword:    prepareSearch.Keyword,
+			SearchMode: prepareSearch.SearchMode,
+			RepoIDs:    repoIDs,
+			Author:     prepareSearch.Author,
+			Path:       prepareSearch.Path,
+			Paginator: &db.ListOptions{
+				Page:     page,
+				PageSize: setting.UI.RepoSearchPagingNum,
+			},
+		})
+		if err != nil {
+			if commit_indexer.IsAvailable(ctx) {
+				ctx.ServerError("SearchCommits", err)
+				return
+			}
+			ctx.Data["CommitIndexerUnavailable"] = true
+		} else {
+			total, searchResults = result.Total, result.Hits
+		}
+
+		loadRepoIDs := make([]int64, 0, len(searchResults))
+		for _, result := range searchResults {
+			loadRepoIDs = append(loadRepoIDs, result.RepoID)
+		}
+		repoMaps, err := repo_model.GetRepositoriesMapByIDs(ctx, loadRepoIDs)
+		if err != nil {
+			ctx.ServerError("GetRepositoriesMapByIDs", err)
+			return
+		}
+		ctx.Data["RepoMaps"] = repoMaps
+
+		// Remove deleted repos from search results
+		cleanedSearchResults := make([]*commit_indexer.IndexerData, 0, len(searchResults))
+		for _, sr := range searchResults {
+			if _, found := repoMaps[sr.RepoID]; found {
+				cleanedSearchResults = append(cleanedSearchResults, sr)
+			}
+		}
+		searchResults = cleanedSearchResults
+	}
+
+	ctx.Data["SearchResults"] = searchResults
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplExploreCommits)
+}
@@ -136,6 +136,8 @@ func RenderRepoSearch(ctx *context.Context, opts *RepoSearchOptions) {
 	ctx.Data["Total"] = count
 	ctx.Data["Repos"] = repos
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsCommitIndexerEnabled"] = setting.Indexer.CommitIndexerEnabled
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
 
 	pager := context.NewPagination(int(count), opts.PageSize, page, 5)
 	pager.AddParamFromRequest(ctx.Req)
@@ -153,6 +155,8 @@ func Repos(ctx *context.Context) {
 	ctx.Data["PageIsExplore"] = true
 	ctx.Data["PageIsExploreRepositories"] = true
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsCommitIndexerEnabled"] = setting.Indexer.CommitIndexerEnabled
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
 
 	var ownerID int64
 	if ctx.Doer != nil && !ctx.Doer.IsAdmin {
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"net/http"
+	"net/url"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	search_service "code.gitea.io/gitea/services/search"
+)
+
+// tplExploreSearch explore search page template
+const tplExploreSearch templates.TplName = "explore/search"
+
+// Search render explore search page, which searches repositories, code, issues, users and packages at once
+func Search(ctx *context.Context) {
+	ctx.Data["UsersPageIsDisabled"] = setting.Service.Explore.DisableUsersPage
+	ctx.Data["OrganizationsPageIsDisabled"] = setting.Service.Explore.DisableOrganizationsPage
+	ctx.Data["CodePageIsDisabled"] = setting.Service.Explore.DisableCodePage
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsCommitIndexerEnabled"] = setting.Indexer.CommitIndexerEnabled
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
+	ctx.Data["Title"] = ctx.Tr("explore")
+	ctx.Data["PageIsExplore"] = true
+	ctx.Data["PageIsExploreSearch"] = true
+
+	keyword := ctx.FormTrim("q")
+	searchType := search_service.Type(ctx.FormTrim("type"))
+	if searchType != "" && (!searchType.IsValid() || !searchType.IsEnabled()) {
+		searchType = ""
+	}
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["SearchType"] = searchType
+	ctx.Data["SearchTypes"] = search_service.EnabledTypes()
+	if keyword == "" || !isKeywordValid(keyword) {
+		ctx.HTML(http.StatusOK, tplExploreSearch)
+		return
+	}
+
+	cursor, err := search_service.ParseCursor(ctx.FormString("cursor"))
+	if err != nil {
+		cursor = search_service.Cursor{} // start from the first page if the cursor has been modified
+	}
+
+	opts := &search_service.Options{
+		Doer:    ctx.Doer,
+		Keyword: keyword,
+		Cursor:  cursor,
+		Limit:   setting.UI.ExplorePagingNum,
+	}
+	if searchType != "" {
+		opts.Types = []search_service.Type{searchType}
+	}
+	resp, err := search_service.Search(ctx, opts)
+	if err != nil {
+		ctx.ServerError("Search", err)
+		return
+	}
+
+	ctx.Data["Counts"] = resp.Counts
+	ctx.Data["Results"] = resp.Results
+	ctx.Data["Unavailable"] = resp.Unavailable
+	if resp.NextCursor != nil {
+		query := url.Values{"q": {keyword}, "cursor": {resp.NextCursor.String()}}
+		if searchType != "" {
+			query.Set("type", string(searchType))
+		}
+		ctx.Data["NextPageLink"] = setting.AppSubURL + "/explore/search?" + query.Encode()
+	}
+
+	ctx.HTML(http.StatusOK, tplExploreSearch)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// tplExploreWikis explore wikis page template
+const tplExploreWikis templates.TplName = "explore/wikis"
+
+// Wikis render explore wikis page
+func Wikis(ctx *context.Context) {
+	if !setting.Indexer.WikiIndexerEnabled {
+		ctx.Redirect(setting.AppSubURL + "/explore")
+		return
+	}
+
+	ctx.Data["UsersPageIsDisabled"] = setting.Service.Explore.DisableUsersPage
+	ctx.Data["OrganizationsPageIsDisabled"] = setting.Service.Explore.DisableOrganizationsPage
+	ctx.Data["CodePageIsDisabled"] = setting.Service.Explore.DisableCodePage
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsCommitIndexerEnabled"] = setting.Indexer.CommitIndexerEnabled
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
+	ctx.Data["Title"] = ctx.Tr("explore")
+	ctx.Data["PageIsExplore"] = true
+	ctx.Data["PageIsExploreWikis"] = true
+
+	prepareSearch := common.PrepareWikiSearch(ctx)
+	if prepareSearch.Keyword == "" {
+		ctx.HTML(http.StatusOK, tplExploreWikis)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	var (
+		repoIDs []int64
+		err     error
+	)
+	isAdmin := ctx.Doer != nil && ctx.Doer.IsAdmin
+
+	// guest user or non-admin user
+	if !isAdmin {
+		repoIDs, err = repo_model.FindUserWikiAccessibleRepoIDs(ctx, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("FindUserWikiAccessibleRepoIDs", err)
+			return
+		}
+	}
+
+	var total int64
+	var searchResults []*wiki_indexer.Result
+	if len(repoIDs) > 0 || isAdmin {
+		total, searchResults, err = wiki_indexer.SearchWiki(ctx, &wiki_indexer.SearchOptions{
+			Keyword:    prepareSearch.Keyword,
+			SearchMode: prepareSearch.SearchMode,
+			RepoIDs:    repoIDs,
+			Paginator: &db.ListOptions{
+				Page:     page,
+				PageSize: setting.UI.RepoSearchPagingNum,
+			},
+		})
+		if err != nil {
+			if wiki_indexer.IsAvailable(ctx) {
+				ctx.ServerError("SearchWiki", err)
+				return
+			}
+			ctx.Data["WikiIndexerUnavailable"] = true
+		}
+
+		loadRepoIDs := make([]int64, 0, len(searchResults))
+		for _, result := range searchResults {
+			loadRepoIDs = append(loadRepoIDs, result.RepoID)
+		}
+		repoMaps, err := repo_model.GetRepositoriesMapByIDs(ctx, loadRepoIDs)
+		if err != nil {
+			ctx.ServerError("GetRepositoriesMapByIDs", err)
+			return
+		}
+		ctx.Data["RepoMaps"] = repoMaps
+
+		// Remove deleted repos and the repos whose wiki has been disabled from search results
+		cleanedSearchResults := make([]*wiki_indexer.Result, 0, len(searchResults))
+		for _, sr := range searchResults {
+			if repo, found := repoMaps[sr.RepoID]; found && repo.UnitEnabled(ctx, unit.TypeWiki) {
+				cleanedSearchResults = append(cleanedSearchResults, sr)
+			}
+		}
+		searchResults = cleanedSearchResults
+	}
+
+	ctx.Data["SearchResults"] = common.ToWikiSearchResults(searchResults)
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplExploreWikis)
+}
@@ -108,14 +108,14 @@ func MembersAction(ctx *context.Context) {
 			ctx.HTTPError(http.StatusNotFound)
 			return
 		}
-		err = org_service.RemoveOrgUser(ctx, org, member)
+		err = org_service.RemoveOrgUser(ctx, ctx.Doer, org, member)
 		if organization.IsErrLastOrgOwner(err) {
 			ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
 			ctx.JSONRedirect(ctx.Org.OrgLink + "/members")
 			return
 		}
 	case "leave":
-		err = org_service.RemoveOrgUser(ctx, org, ctx.Doer)
+		err = org_service.RemoveOrgUser(ctx, ctx.Doer, org, ctx.Doer)
 		if err == nil {
 			ctx.Flash.Success(ctx.Tr("form.organization_leave_success", org.DisplayName()))
 			ctx.JSON(http.StatusOK, map[string]any{
@@ -18,6 +18,7 @@ const (
 	tplSettingsPackages            templates.TplName = "org/settings/packages"
 	tplSettingsPackagesRuleEdit    templates.TplName = "org/settings/packages_cleanup_rules_edit"
 	tplSettingsPackagesRulePreview templates.TplName = "org/settings/packages_cleanup_rules_preview"
+	tplSettingsPackagesProxyEdit   templates.TplName = "org/settings/packages_proxies_edit"
 )
 
 func Packages(ctx *context.Context) {
@@ -110,6 +111,64 @@ func PackagesRulePreview(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplSettingsPackagesRulePreview)
 }
 
+func PackagesProxyAdd(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	err := shared_user.LoadHeaderCount(ctx)
+	if err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	shared.SetProxyAddContext(ctx)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesProxyEdit)
+}
+
+func PackagesProxyEdit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	err := shared_user.LoadHeaderCount(ctx)
+	if err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	shared.SetProxyEditContext(ctx, ctx.ContextUser)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesProxyEdit)
+}
+
+func PackagesProxyAddPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.PerformProxyAddPost(
+		ctx,
+		ctx.ContextUser,
+		fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name),
+		tplSettingsPackagesProxyEdit,
+	)
+}
+
+func PackagesProxyEditPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.PerformProxyEditPost(
+		ctx,
+		ctx.ContextUser,
+		fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name),
+		tplSettingsPackagesProxyEdit,
+	)
+}
+
 func InitializeCargoIndex(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("packages.title")
 	ctx.Data["PageIsOrgSettings"] = true
@@ -129,3 +188,21 @@ func RebuildCargoIndex(ctx *context.Context) {
 
 	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
 }
+
+func RotateSigningKey(ctx *context.Context) {
+	shared.RotateSigningKey(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
+
+func RevokeSigningKey(ctx *context.Context) {
+	shared.RevokeSigningKey(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSONRedirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
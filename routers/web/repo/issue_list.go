@@ -30,8 +30,10 @@ import (
 	pull_service "code.gitea.io/gitea/services/pull"
 )
 
-func issueIDsFromSearch(ctx *context.Context, keyword string, opts *issues_model.IssuesOptions) ([]int64, error) {
-	ids, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts))
+func issueIDsFromSearch(ctx *context.Context, query *issue_indexer.Query, opts *issues_model.IssuesOptions) ([]int64, error) {
+	searchOpt := issue_indexer.ToSearchOptions(query.Keyword, opts)
+	query.Apply(searchOpt)
+	ids, _, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		return nil, fmt.Errorf("SearchIssues: %w", err)
 	}
@@ -515,6 +517,18 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	if bytes.Contains([]byte(keyword), []byte{0x00}) {
 		keyword = ""
 	}
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err == nil {
+		err = query.Resolve(ctx, ctx.Doer, []int64{repo.ID})
+	}
+	if err != nil {
+		if !issue_indexer.IsErrInvalidQuery(err) {
+			ctx.ServerError("ParseQuery", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.filter_query_invalid", err.Error()), true)
+		query = &issue_indexer.Query{Keyword: keyword}
+	}
 
 	var mileIDs []int64
 	if milestoneID > 0 || milestoneID == db.NoConditionID { // -1 to get those issues which have no any milestone assigned
@@ -541,7 +555,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		IssueIDs:          nil,
 	}
 	if keyword != "" {
-		allIssueIDs, err := issueIDsFromSearch(ctx, keyword, statsOpts)
+		allIssueIDs, err := issueIDsFromSearch(ctx, query, statsOpts)
 		if err != nil {
 			if issue_indexer.IsAvailable(ctx) {
 				ctx.ServerError("issueIDsFromSearch", err)
@@ -579,6 +593,10 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	if len(ctx.FormString("state")) == 0 && issueStats.OpenCount == 0 && issueStats.ClosedCount != 0 {
 		isShowClosed = optional.None[bool]()
 	}
+	// an "is:open" or "is:closed" qualifier in the query takes precedence over the state tabs
+	if query.IsClosed.Has() {
+		isShowClosed = query.IsClosed
+	}
 
 	if repo.IsTimetrackerEnabled(ctx) {
 		totalTrackedTime, err := issues_model.GetIssueTotalTrackedTime(ctx, statsOpts, isShowClosed)
@@ -607,7 +625,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 
 	var issues issues_model.IssueList
 	{
-		ids, err := issueIDsFromSearch(ctx, keyword, &issues_model.IssuesOptions{
+		ids, err := issueIDsFromSearch(ctx, query, &issues_model.IssuesOptions{
 			Paginator: &db.ListOptions{
 				Page:     pager.Paginater.Current(),
 				PageSize: setting.UI.IssuePagingNum,
@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+const tplSimilarIssues templates.TplName = "repo/issue/similar_issues"
+
+// SimilarIssues renders the existing issues similar to the title and content of a new issue
+func SimilarIssues(ctx *context.Context) {
+	issues, err := issue_service.FindSimilarIssues(ctx, ctx.Repo.Repository, ctx.FormTrim("title"), ctx.FormTrim("content"), issue_service.DefaultSimilarIssuesLimit)
+	if err != nil {
+		// the suggestions are optional, so creating an issue shouldn't be disturbed if the indexer is not available
+		log.Error("FindSimilarIssues: %v", err)
+	}
+	ctx.Data["SimilarIssues"] = issues
+	ctx.HTML(http.StatusOK, tplSimilarIssues)
+}
@@ -38,6 +38,8 @@ func Packages(ctx *context.Context) {
 		Type:       packages.Type(packageType),
 		Name:       packages.SearchValue{Value: query},
 		IsInternal: optional.Some(false),
+		// the readers of the repository don't necessarily have access to the private packages linked to it
+		PackageCond: packages.UserReadableCond(ctx.Doer),
 	})
 	if err != nil {
 		ctx.ServerError("SearchLatestVersions", err)
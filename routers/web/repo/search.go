@@ -4,24 +4,59 @@
 package repo
 
 import (
+	"errors"
+	"maps"
 	"net/http"
+	"slices"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/git"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/indexer/code/gitgrep"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
 
-const tplSearch templates.TplName = "repo/search"
+const (
+	tplSearch        templates.TplName = "repo/search"
+	tplSearchCommits templates.TplName = "repo/search_commits"
+)
 
 // Search render repository search page
 func Search(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
+	ctx.Data["IsCommitIndexerEnabled"] = setting.Indexer.CommitIndexerEnabled
 	prepareSearch := common.PrepareCodeSearch(ctx)
+
+	ref := ctx.FormTrim("ref")
+	if setting.Indexer.RepoIndexerEnabled {
+		refs, err := repo_model.GetIndexerRefs(ctx, ctx.Repo.Repository.ID)
+		if err != nil {
+			ctx.ServerError("GetIndexerRefs", err)
+			return
+		}
+		searchRefs := make([]git.RefName, 0, len(refs))
+		for _, refName := range slices.Sorted(maps.Keys(refs)) {
+			searchRefs = append(searchRefs, git.RefName(refName))
+		}
+		ctx.Data["SearchRefs"] = searchRefs
+
+		if ref, err = code_indexer.ResolveIndexedRef(ctx, ctx.Repo.Repository, ref); err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				ctx.NotFound(err)
+			} else {
+				ctx.ServerError("ResolveIndexedRef", err)
+			}
+			return
+		}
+	}
+	ctx.Data["SearchRef"] = ref
+
 	if prepareSearch.Keyword == "" {
 		ctx.HTML(http.StatusOK, tplSearch)
 		return
@@ -42,6 +77,7 @@ func Search(ctx *context.Context) {
 			Keyword:    prepareSearch.Keyword,
 			SearchMode: prepareSearch.SearchMode,
 			Language:   prepareSearch.Language,
+			Ref:        ref,
 			Paginator: &db.ListOptions{
 				Page:     page,
 				PageSize: setting.UI.RepoSearchPagingNum,
@@ -60,6 +96,17 @@ func Search(ctx *context.Context) {
 		var err error
 		// ref should be default branch or the first existing branch
 		searchRef := git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch)
+		if ref != "" {
+			switch {
+			case ctx.Repo.GitRepo.IsBranchExist(ref):
+				searchRef = git.RefNameFromBranch(ref)
+			case ctx.Repo.GitRepo.IsTagExist(ref):
+				searchRef = git.RefNameFromTag(ref)
+			default:
+				ctx.NotFound(nil)
+				return
+			}
+		}
 		searchResults, total, err = gitgrep.PerformSearch(ctx, page, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, searchRef, prepareSearch.Keyword, prepareSearch.SearchMode)
 		if err != nil {
 			ctx.ServerError("gitgrep.PerformSearch", err)
@@ -77,3 +124,55 @@ func Search(ctx *context.Context) {
 
 	ctx.HTML(http.StatusOK, tplSearch)
 }
+
+// SearchIndexedCommits render repository commit search page
+func SearchIndexedCommits(ctx *context.Context) {
+	if !setting.Indexer.CommitIndexerEnabled {
+		ctx.NotFound(nil)
+		return
+	}
+	ctx.Data["PageIsViewCode"] = true
+	ctx.Data["PageIsSearchCommits"] = true
+	ctx.Data["IsCommitIndexerEnabled"] = true
+	ctx.Data["Repo"] = ctx.Repo.Repository
+	prepareSearch := common.PrepareCommitSearch(ctx)
+
+	if prepareSearch.Keyword == "" && prepareSearch.Author == "" && prepareSearch.Path == "" {
+		ctx.HTML(http.StatusOK, tplSearchCommits)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	var total int64
+	result, err := commit_indexer.SearchCommits(ctx, &commit_indexer.SearchOptions{
+		Keyword:    prepareSearch.Keyword,
+		SearchMode: prepareSearch.SearchMode,
+		RepoIDs:    []int64{ctx.Repo.Repository.ID},
+		Author:     prepareSearch.Author,
+		Path:       prepareSearch.Path,
+		Paginator: &db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.RepoSearchPagingNum,
+		},
+	})
+	if err != nil {
+		if commit_indexer.IsAvailable(ctx) {
+			ctx.ServerError("SearchCommits", err)
+			return
+		}
+		ctx.Data["CommitIndexerUnavailable"] = true
+	} else {
+		total = result.Total
+		ctx.Data["SearchResults"] = result.Hits
+	}
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplSearchCommits)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,7 +21,6 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/indexer/code"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
-	"code.gitea.io/gitea/modules/indexer/stats"
 	"code.gitea.io/gitea/modules/lfs"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -33,6 +33,7 @@ import (
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	indexer_service "code.gitea.io/gitea/services/indexer"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -73,6 +74,7 @@ func SettingsCtxData(ctx *context.Context) {
 	ctx.Data["SigningKeyAvailable"] = len(signing) > 0
 	ctx.Data["SigningSettings"] = setting.Repository.Signing
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["CodeIndexerMaxRefs"] = setting.Indexer.MaxIndexerRefs
 
 	if ctx.Doer.IsAdmin {
 		if setting.Indexer.RepoIndexerEnabled {
@@ -453,10 +455,30 @@ func SettingsPost(ctx *context.Context) {
 			repoChanged = true
 		}
 
+		var codeIndexerRefsChanged bool
 		if form.EnableCode && !unit_model.TypeCode.UnitGlobalDisabled() {
+			codeConfig := repo.MustGetUnit(ctx, unit_model.TypeCode).CodeConfig()
+			if setting.Indexer.RepoIndexerEnabled {
+				var patterns []string
+				for _, pattern := range strings.Split(form.CodeIndexerRefPatterns, "\n") {
+					pattern = strings.TrimSpace(pattern)
+					if pattern == "" {
+						continue
+					}
+					if _, err := setting.GlobMatcherCompile(pattern, '/'); err != nil {
+						ctx.Flash.Error(ctx.Tr("repo.settings.code_indexer_ref_patterns_error", pattern))
+						ctx.Redirect(repo.Link() + "/settings")
+						return
+					}
+					patterns = append(patterns, pattern)
+				}
+				codeIndexerRefsChanged = !slices.Equal(codeConfig.IndexerRefPatterns, patterns)
+				codeConfig = &repo_model.CodeConfig{IndexerRefPatterns: patterns}
+			}
 			units = append(units, repo_model.RepoUnit{
 				RepoID:             repo.ID,
 				Type:               unit_model.TypeCode,
+				Config:             codeConfig,
 				EveryoneAccessMode: parseEveryoneAccessMode(form.DefaultCodeEveryoneAccess, perm.AccessModeNone, perm.AccessModeRead),
 			})
 		} else if !unit_model.TypeCode.UnitGlobalDisabled() {
@@ -623,6 +645,9 @@ func SettingsPost(ctx *context.Context) {
 				return
 			}
 		}
+		if codeIndexerRefsChanged && !repo.IsEmpty {
+			code.UpdateRepoIndexer(repo)
+		}
 		log.Trace("Repository advanced settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
 
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
@@ -673,20 +698,15 @@ func SettingsPost(ctx *context.Context) {
 			return
 		}
 
-		switch form.RequestReindexType {
-		case "stats":
-			if err := stats.UpdateRepoIndexer(ctx.Repo.Repository); err != nil {
-				ctx.ServerError("UpdateStatsRepondexer", err)
-				return
-			}
-		case "code":
-			if !setting.Indexer.RepoIndexerEnabled {
+		if err := indexer_service.ReindexRepo(ctx, form.RequestReindexType, ctx.Repo.Repository); err != nil {
+			switch {
+			case errors.Is(err, util.ErrNotExist):
+				ctx.NotFound(nil)
+			case errors.Is(err, util.ErrInvalidArgument):
 				ctx.HTTPError(http.StatusForbidden)
-				return
+			default:
+				ctx.ServerError("ReindexRepo", err)
 			}
-			code.UpdateRepoIndexer(ctx.Repo.Repository)
-		default:
-			ctx.NotFound(nil)
 			return
 		}
 
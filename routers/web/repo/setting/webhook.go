@@ -186,6 +186,13 @@ func ParseHookEvent(form forms.WebhookForm) *webhook_module.HookEvent {
 			webhook_module.HookEventPackage:                  form.Package,
 			webhook_module.HookEventStatus:                   form.Status,
 			webhook_module.HookEventWorkflowJob:              form.WorkflowJob,
+			webhook_module.HookEventOrganization:             form.Organization,
+			webhook_module.HookEventMembership:               form.Membership,
+			webhook_module.HookEventTeam:                     form.Team,
+			webhook_module.HookEventMember:                   form.Member,
+			webhook_module.HookEventBranchProtectionRule:     form.BranchProtectionRule,
+			webhook_module.HookEventStar:                     form.Star,
+			webhook_module.HookEventUser:                     form.User,
 		},
 		BranchFilter: form.BranchFilter,
 	}
@@ -203,6 +210,27 @@ type webhookParams struct {
 	Meta        any
 }
 
+// setWebhookTransport sets the TLS and proxy settings of the webhook from the form and validates them
+func setWebhookTransport(w *webhook.Webhook, form forms.WebhookForm) error {
+	certChanged := w.ClientCertificate != form.ClientCertificate
+	w.ClientCertificate = form.ClientCertificate
+	w.CACertificate = form.CACertificate
+	w.TLSServerName = form.TLSServerName
+	// the proxy URL is rendered without its password, keep the current one if it hasn't been changed
+	if form.ProxyURL != w.RedactedProxyURL() {
+		if err := w.SetProxyURL(form.ProxyURL); err != nil {
+			return err
+		}
+	}
+	// the key is never rendered, a blank key keeps the current one as long as the certificate is unchanged
+	if form.ClientKey != "" || certChanged || w.ClientCertificate == "" {
+		if err := w.SetClientKey(form.ClientKey); err != nil {
+			return err
+		}
+	}
+	return webhook_service.ValidateTransport(w)
+}
+
 func createWebhook(ctx *context.Context, params webhookParams) {
 	ctx.Data["Title"] = ctx.Tr("repo.settings.add_webhook")
 	ctx.Data["PageIsSettingsHooks"] = true
@@ -249,6 +277,16 @@ func createWebhook(ctx *context.Context, params webhookParams) {
 		ctx.ServerError("SetHeaderAuthorization", err)
 		return
 	}
+	if err := setWebhookTransport(w, params.WebhookForm); err != nil {
+		if webhook_service.IsErrInvalidTransport(err) {
+			ctx.Data["Webhook"] = w
+			ctx.Data["Err_Transport"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.webhook.invalid_transport", err.Error()), orCtx.NewTemplate, nil)
+			return
+		}
+		ctx.ServerError("setWebhookTransport", err)
+		return
+	}
 	if err := w.UpdateEvent(); err != nil {
 		ctx.ServerError("UpdateEvent", err)
 		return
@@ -300,6 +338,15 @@ func editWebhook(ctx *context.Context, params webhookParams) {
 		ctx.ServerError("SetHeaderAuthorization", err)
 		return
 	}
+	if err := setWebhookTransport(w, params.WebhookForm); err != nil {
+		if webhook_service.IsErrInvalidTransport(err) {
+			ctx.Data["Err_Transport"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.webhook.invalid_transport", err.Error()), orCtx.NewTemplate, nil)
+			return
+		}
+		ctx.ServerError("setWebhookTransport", err)
+		return
+	}
 
 	if err := w.UpdateEvent(); err != nil {
 		ctx.ServerError("UpdateEvent", err)
@@ -583,6 +630,53 @@ func packagistHookParams(ctx *context.Context) webhookParams {
 	}
 }
 
+// GoogleChatHooksNewPost response for creating Google Chat webhook
+func GoogleChatHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, googleChatHookParams(ctx))
+}
+
+// GoogleChatHooksEditPost response for editing Google Chat webhook
+func GoogleChatHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, googleChatHookParams(ctx))
+}
+
+func googleChatHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewGoogleChatHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.GOOGLECHAT,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		WebhookForm: form.WebhookForm,
+	}
+}
+
+// NtfyHooksNewPost response for creating ntfy webhook
+func NtfyHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, ntfyHookParams(ctx))
+}
+
+// NtfyHooksEditPost response for editing ntfy webhook
+func NtfyHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, ntfyHookParams(ctx))
+}
+
+func ntfyHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewNtfyHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.NTFY,
+		URL:         form.ServerURL,
+		ContentType: webhook.ContentTypeJSON,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.NtfyMeta{
+			Topic:    strings.TrimSpace(form.Topic),
+			Priority: form.Priority,
+			Tags:     webhook_service.ParseNtfyTags(form.Tags),
+		},
+	}
+}
+
 func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 	orCtx, err := getOwnerRepoCtx(ctx)
 	if err != nil {
@@ -621,6 +715,8 @@ func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 		ctx.Data["MatrixHook"] = webhook_service.GetMatrixHook(w)
 	case webhook_module.PACKAGIST:
 		ctx.Data["PackagistHook"] = webhook_service.GetPackagistHook(w)
+	case webhook_module.NTFY:
+		ctx.Data["NtfyHook"] = webhook_service.GetNtfyHook(w)
 	}
 
 	ctx.Data["History"], err = w.History(ctx, 1)
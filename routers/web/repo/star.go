@@ -9,12 +9,13 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 const tplStarUnstar templates.TplName = "repo/star_unstar"
 
 func ActionStar(ctx *context.Context) {
-	err := repo_model.StarRepo(ctx, ctx.Doer, ctx.Repo.Repository, ctx.PathParam("action") == "star")
+	err := repo_service.StarRepo(ctx, ctx.Doer, ctx.Repo.Repository, ctx.PathParam("action") == "star")
 	if err != nil {
 		handleActionError(ctx, err)
 		return
@@ -19,6 +19,7 @@ import (
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/highlight"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/setting"
@@ -211,6 +212,9 @@ func prepareToRenderFile(ctx *context.Context, entry *git.TreeEntry) {
 			if err != nil {
 				log.Error("highlight.File failed, fallback to plain text: %v", err)
 				fileContent = highlight.PlainText(buf)
+			} else if setting.Indexer.RepoIndexerEnabled && ctx.Repo.RefFullName == git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch) {
+				// the symbols are only extracted from the default branch
+				fileContent = code_indexer.LinkSymbolDefinitions(ctx, ctx.Repo.Repository, ctx.Repo.TreePath, buf, fileContent)
 			}
 			status := &charset.EscapeStatus{}
 			statuses := make([]*charset.EscapeStatus, len(fileContent))
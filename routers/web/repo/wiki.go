@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/renderhelper"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
@@ -21,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/markup/markdown"
@@ -43,6 +45,7 @@ const (
 	tplWikiRevision templates.TplName = "repo/wiki/revision"
 	tplWikiNew      templates.TplName = "repo/wiki/new"
 	tplWikiPages    templates.TplName = "repo/wiki/pages"
+	tplWikiSearch   templates.TplName = "repo/wiki/search"
 )
 
 // MustEnableWiki check if wiki is enabled, if external then redirect
@@ -534,10 +537,15 @@ func WikiPost(ctx *context.Context) {
 func Wiki(ctx *context.Context) {
 	ctx.Data["CanWriteWiki"] = ctx.Repo.CanWrite(unit.TypeWiki) && !ctx.Repo.Repository.IsArchived
 
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
+
 	switch ctx.FormString("action") {
 	case "_pages":
 		WikiPages(ctx)
 		return
+	case "_search":
+		WikiSearch(ctx)
+		return
 	case "_revision":
 		WikiRevision(ctx)
 		return
@@ -699,6 +707,52 @@ func WikiPages(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplWikiPages)
 }
 
+// WikiSearch renders the pages of the wiki matching the keyword
+func WikiSearch(ctx *context.Context) {
+	if !setting.Indexer.WikiIndexerEnabled || !ctx.Repo.Repository.HasWiki() {
+		ctx.Redirect(ctx.Repo.RepoLink + "/wiki")
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.wiki.search")
+	ctx.Data["PageIsWikiSearch"] = true
+
+	prepareSearch := common.PrepareWikiSearch(ctx)
+	if prepareSearch.Keyword == "" {
+		ctx.HTML(http.StatusOK, tplWikiSearch)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	total, searchResults, err := wiki_indexer.SearchWiki(ctx, &wiki_indexer.SearchOptions{
+		Keyword:    prepareSearch.Keyword,
+		SearchMode: prepareSearch.SearchMode,
+		RepoIDs:    []int64{ctx.Repo.Repository.ID},
+		Paginator: &db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.RepoSearchPagingNum,
+		},
+	})
+	if err != nil {
+		if wiki_indexer.IsAvailable(ctx) {
+			ctx.ServerError("SearchWiki", err)
+			return
+		}
+		ctx.Data["WikiIndexerUnavailable"] = true
+	}
+	ctx.Data["SearchResults"] = common.ToWikiSearchResults(searchResults)
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplWikiSearch)
+}
+
 // WikiRaw outputs raw blob requested by user (image for example)
 func WikiRaw(ctx *context.Context) {
 	wikiRepo, commit, err := findWikiRepoCommit(ctx)
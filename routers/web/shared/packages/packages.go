@@ -4,6 +4,7 @@
 package packages
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -14,11 +15,13 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	signing_service "code.gitea.io/gitea/services/packages/signing"
 )
 
 func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
@@ -29,6 +32,30 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	ctx.Data["CleanupRules"] = pcrs
+
+	pps, err := packages_model.GetProxiesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetProxiesByOwner", err)
+		return
+	}
+
+	ctx.Data["Proxies"] = pps
+
+	type signingKeySet struct {
+		Type packages_model.Type
+		Keys []*packages_model.PackageSigningKey
+	}
+	signingKeySets := make([]*signingKeySet, 0, len(packages_model.SigningKeyTypes))
+	for _, pt := range packages_model.SigningKeyTypes {
+		keys, err := packages_model.GetSigningKeys(ctx, owner.ID, pt)
+		if err != nil {
+			ctx.ServerError("GetSigningKeys", err)
+			return
+		}
+		signingKeySets = append(signingKeySets, &signingKeySet{Type: pt, Keys: keys})
+	}
+
+	ctx.Data["SigningKeySets"] = signingKeySets
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -226,6 +253,148 @@ func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *pack
 	return nil
 }
 
+func SetProxyAddContext(ctx *context.Context) {
+	setProxyEditContext(ctx, nil)
+}
+
+func SetProxyEditContext(ctx *context.Context, owner *user_model.User) {
+	pp := getProxyByContext(ctx, owner)
+	if pp == nil {
+		return
+	}
+
+	setProxyEditContext(ctx, pp)
+}
+
+func setProxyEditContext(ctx *context.Context, pp *packages_model.PackageProxy) {
+	ctx.Data["IsEditProxy"] = pp != nil
+
+	if pp == nil {
+		pp = &packages_model.PackageProxy{
+			MetadataTTL: 300,
+		}
+	}
+	ctx.Data["Proxy"] = pp
+	ctx.Data["AvailableTypes"] = packages_model.ProxyTypes
+}
+
+func PerformProxyAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performProxyEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformProxyEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pp := getProxyByContext(ctx, owner)
+	if pp == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageProxyForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteProxyByID(ctx, pp.ID); err != nil {
+			ctx.ServerError("DeleteProxyByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.proxies.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performProxyEditPost(ctx, owner, pp, redirectURL, template)
+	}
+}
+
+func performProxyEditPost(ctx *context.Context, owner *user_model.User, pp *packages_model.PackageProxy, redirectURL string, template templates.TplName) {
+	isEditProxy := pp != nil
+
+	if pp == nil {
+		pp = &packages_model.PackageProxy{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageProxyForm)
+
+	upstreamChanged := pp.UpstreamURL != form.UpstreamURL
+
+	pp.Enabled = form.Enabled
+	pp.OwnerID = owner.ID
+	pp.UpstreamURL = form.UpstreamURL
+	pp.MetadataTTL = form.MetadataTTL
+	pp.Allowlist = form.Allowlist
+
+	ctx.Data["IsEditProxy"] = isEditProxy
+	ctx.Data["Proxy"] = pp
+	ctx.Data["AvailableTypes"] = packages_model.ProxyTypes
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	if err := pp.CompilePatterns(); err != nil {
+		ctx.Data["Err_Allowlist"] = true
+		ctx.RenderWithErr(ctx.Tr("packages.owner.settings.proxies.allowlist.invalid", err), template, nil)
+		return
+	}
+
+	if isEditProxy {
+		if err := packages_model.UpdateProxy(ctx, pp); err != nil {
+			ctx.ServerError("UpdateProxy", err)
+			return
+		}
+		// the cached metadata belongs to the previous upstream registry
+		if upstreamChanged {
+			if err := packages_model.DeleteProxyMetadata(ctx, pp.ID); err != nil {
+				ctx.ServerError("DeleteProxyMetadata", err)
+				return
+			}
+		}
+	} else {
+		pp.Type = packages_model.Type(form.Type)
+
+		if has, err := packages_model.HasOwnerProxyForPackageType(ctx, owner.ID, pp.Type); err != nil {
+			ctx.ServerError("HasOwnerProxyForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pp, err = packages_model.InsertProxy(ctx, pp); err != nil {
+			ctx.ServerError("InsertProxy", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.proxies.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/proxies/%d", redirectURL, pp.ID))
+}
+
+func getProxyByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageProxy {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pp, err := packages_model.GetProxyByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageProxyNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetProxyByID", err)
+		}
+		return nil
+	}
+
+	if pp.OwnerID == owner.ID {
+		return pp
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageProxy[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
 func InitializeCargoIndex(ctx *context.Context, owner *user_model.User) {
 	err := cargo_service.InitializeIndexRepository(ctx, owner, owner)
 	if err != nil {
@@ -245,3 +414,38 @@ func RebuildCargoIndex(ctx *context.Context, owner *user_model.User) {
 		ctx.Flash.Success(ctx.Tr("packages.owner.settings.cargo.rebuild.success"))
 	}
 }
+
+func RotateSigningKey(ctx *context.Context, owner *user_model.User) {
+	packageType := packages_model.Type(ctx.PathParam("type"))
+	if !packages_model.IsSigningKeyType(packageType) {
+		ctx.NotFound(nil)
+		return
+	}
+
+	k, err := signing_service.RotateKey(ctx, owner.ID, packageType)
+	if err != nil {
+		log.Error("RotateKey failed: %v", err)
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.signing_keys.rotate.error", err))
+	} else {
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.signing_keys.rotate.success", k.Fingerprint))
+	}
+}
+
+func RevokeSigningKey(ctx *context.Context, owner *user_model.User) {
+	k, err := packages_model.GetSigningKeyByID(ctx, owner.ID, packages_model.Type(ctx.PathParam("type")), ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetSigningKeyByID", err)
+		}
+		return
+	}
+
+	if err := signing_service.RevokeKey(ctx, k); err != nil {
+		log.Error("RevokeKey failed: %v", err)
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.signing_keys.revoke.error", err))
+	} else {
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.signing_keys.revoke.success", k.Fingerprint))
+	}
+}
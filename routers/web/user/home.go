@@ -516,8 +516,29 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	keyword := strings.Trim(ctx.FormString("q"), " ")
 	ctx.Data["Keyword"] = keyword
 
+	// query holds the qualifiers like "label:bug" or "author:@me" of the search term.
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err == nil {
+		var labelRepoIDs []int64 // labels are looked up in all repositories when searching all public ones
+		if !opts.AllPublic {
+			labelRepoIDs = opts.RepoIDs
+		}
+		err = query.Resolve(ctx, ctx.Doer, labelRepoIDs)
+	}
+	if err != nil {
+		if !issue_indexer.IsErrInvalidQuery(err) {
+			ctx.ServerError("ParseQuery", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.filter_query_invalid", err.Error()), true)
+		query = &issue_indexer.Query{Keyword: keyword}
+	}
+
 	// Educated guess: Do or don't show closed issues.
 	isShowClosed := ctx.FormString("state") == "closed"
+	if query.IsClosed.Has() {
+		isShowClosed = query.IsClosed.Value()
+	}
 	opts.IsClosed = optional.Some(isShowClosed)
 
 	// Make sure page number is at least 1. Will be posted to ctx.Data.
@@ -550,6 +571,7 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	var issues issues_model.IssueList
 	{
 		issueIDs, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts).Copy(
+			query.Apply,
 			func(o *issue_indexer.SearchOptions) {
 				o.SearchMode = indexer.SearchModeType(searchMode)
 			},
@@ -580,6 +602,7 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// Fill stats to post to ctx.Data.
 	// -------------------------------
 	issueStats, err := getUserIssueStats(ctx, ctxUser, filterMode, issue_indexer.ToSearchOptions(keyword, opts).Copy(
+		query.Apply,
 		func(o *issue_indexer.SearchOptions) {
 			o.SearchMode = indexer.SearchModeType(searchMode)
 		},
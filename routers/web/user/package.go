@@ -4,9 +4,12 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
+	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	org_model "code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -14,12 +17,14 @@ import (
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/httplib"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	alpine_module "code.gitea.io/gitea/modules/packages/alpine"
 	arch_module "code.gitea.io/gitea/modules/packages/arch"
+	container_module "code.gitea.io/gitea/modules/packages/container"
 	debian_module "code.gitea.io/gitea/modules/packages/debian"
 	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
 	"code.gitea.io/gitea/modules/setting"
@@ -29,8 +34,10 @@ import (
 	packages_helper "code.gitea.io/gitea/routers/api/packages/helper"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
+	container_service "code.gitea.io/gitea/services/packages/container"
 )
 
 const (
@@ -40,6 +47,9 @@ const (
 	tplPackagesSettings   templates.TplName = "package/settings"
 )
 
+// packageDownloadStatsDays is the number of days shown in the download chart of the package page
+const packageDownloadStatsDays = 90
+
 // ListPackages displays a list of all packages of the context user
 func ListPackages(ctx *context.Context) {
 	shared_user.PrepareContextForProfileBigAvatar(ctx)
@@ -183,6 +193,8 @@ func ViewPackageVersion(ctx *context.Context) {
 		registryHostURL, _ = url.Parse(setting.AppURL)
 	}
 	ctx.Data["PackageRegistryHost"] = registryHostURL.Host
+	ctx.Data["ShowDownloadStats"] = setting.Packages.DownloadStatsEnabled
+	ctx.Data["DownloadStatsDays"] = packageDownloadStatsDays
 
 	switch pd.Package.Type {
 	case packages_model.TypeAlpine:
@@ -261,6 +273,20 @@ func ViewPackageVersion(ctx *context.Context) {
 
 		ctx.Data["Groups"] = util.Sorted(groups.Values())
 		ctx.Data["Architectures"] = util.Sorted(architectures.Values())
+	case packages_model.TypeContainer:
+		for _, f := range pd.Files {
+			if f.File.LowerName != container_model.ManifestFilename {
+				continue
+			}
+
+			referrers, err := container_service.GetReferrers(ctx, pd.Package, f.Properties.GetByName(container_module.PropertyDigest))
+			if err != nil {
+				ctx.ServerError("GetReferrers", err)
+				return
+			}
+
+			ctx.Data["Referrers"] = referrers
+		}
 	}
 
 	var (
@@ -302,6 +328,49 @@ func ViewPackageVersion(ctx *context.Context) {
 	}
 	ctx.Data["HasRepositoryAccess"] = hasRepositoryAccess
 
+	if pd.Version.HasBuildSource() {
+		sourceRepo, err := repo_model.GetRepositoryByID(ctx, pd.Version.SourceRepoID)
+		if err != nil && !repo_model.IsErrRepoNotExist(err) {
+			ctx.ServerError("GetRepositoryByID", err)
+			return
+		}
+		if sourceRepo != nil {
+			permission, err := access_model.GetUserRepoPermission(ctx, sourceRepo, ctx.Doer)
+			if err != nil {
+				ctx.ServerError("GetUserRepoPermission", err)
+				return
+			}
+			if permission.CanRead(unit.TypeCode) {
+				ctx.Data["SourceRepository"] = sourceRepo
+				if permission.CanRead(unit.TypeActions) {
+					run, err := actions_model.GetRunByID(ctx, pd.Version.SourceRunID)
+					if err != nil && !errors.Is(err, util.ErrNotExist) {
+						ctx.ServerError("GetRunByID", err)
+						return
+					}
+					if run != nil {
+						run.Repo = sourceRepo
+						ctx.Data["SourceRunLink"] = run.Link()
+					}
+				}
+			}
+		}
+	}
+
+	provenances, err := packages_model.GetProvenancesByVersionID(ctx, pd.Version.ID)
+	if err != nil {
+		ctx.ServerError("GetProvenancesByVersionID", err)
+		return
+	}
+	matchingProvenances := 0
+	for _, pp := range provenances {
+		if pp.MatchesFiles {
+			matchingProvenances++
+		}
+	}
+	ctx.Data["ProvenanceCount"] = len(provenances)
+	ctx.Data["MatchingProvenanceCount"] = matchingProvenances
+
 	err = shared_user.LoadHeaderCount(ctx)
 	if err != nil {
 		ctx.ServerError("LoadHeaderCount", err)
@@ -422,6 +491,7 @@ func PackageSettings(ctx *context.Context) {
 	})
 	ctx.Data["Repos"] = repos
 	ctx.Data["CanWritePackages"] = ctx.Package.AccessMode >= perm.AccessModeWrite || ctx.IsUserSiteAdmin()
+	ctx.Data["CanManagePackageAccess"] = ctx.Package.AccessMode >= perm.AccessModeAdmin || ctx.IsUserSiteAdmin()
 
 	err := shared_user.LoadHeaderCount(ctx)
 	if err != nil {
@@ -469,6 +539,28 @@ func PackageSettingsPost(ctx *context.Context) {
 			ctx.Flash.Error(ctx.Tr("packages.settings.link.error"))
 		}
 
+		ctx.Redirect(ctx.Link)
+		return
+	case "visibility":
+		if ctx.Package.AccessMode < perm.AccessModeAdmin && !ctx.IsUserSiteAdmin() {
+			ctx.NotFound(nil)
+			return
+		}
+
+		visibility, ok := packages_model.ParseVisibility(form.Visibility)
+		if !ok {
+			ctx.Flash.Error(ctx.Tr("packages.settings.visibility.error"))
+		} else if err := packages_service.SetPackageVisibility(ctx, ctx.Doer, pd.Package, visibility); err != nil {
+			if errors.Is(err, packages_service.ErrSharedRepositoryIndex) {
+				ctx.Flash.Error(ctx.Tr("packages.settings.visibility.shared_index"))
+			} else {
+				log.Error("Error updating package visibility: %v", err)
+				ctx.Flash.Error(ctx.Tr("packages.settings.visibility.error"))
+			}
+		} else {
+			ctx.Flash.Success(ctx.Tr("packages.settings.visibility.success"))
+		}
+
 		ctx.Redirect(ctx.Link)
 		return
 	case "delete":
@@ -511,3 +603,23 @@ func DownloadPackageFile(ctx *context.Context) {
 
 	packages_helper.ServePackageFile(ctx, s, u, pf)
 }
+
+// PackageDownloadStatsData returns the daily downloads of the package per client as JSON
+func PackageDownloadStatsData(ctx *context.Context) {
+	points, err := packages_model.SearchDownloadStats(ctx, &packages_model.DownloadStatsSearchOptions{
+		PackageID:     ctx.Package.Descriptor.Package.ID,
+		Since:         time.Now().AddDate(0, 0, -packageDownloadStatsDays),
+		GroupByClient: true,
+	})
+	if err != nil {
+		ctx.ServerError("SearchDownloadStats", err)
+		return
+	}
+
+	stats, err := convert.ToPackageDownloadStats(ctx, points)
+	if err != nil {
+		ctx.ServerError("ToPackageDownloadStats", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
@@ -20,6 +20,7 @@ const (
 	tplSettingsPackages            templates.TplName = "user/settings/packages"
 	tplSettingsPackagesRuleEdit    templates.TplName = "user/settings/packages_cleanup_rules_edit"
 	tplSettingsPackagesRulePreview templates.TplName = "user/settings/packages_cleanup_rules_preview"
+	tplSettingsPackagesProxyEdit   templates.TplName = "user/settings/packages_proxies_edit"
 )
 
 func Packages(ctx *context.Context) {
@@ -88,6 +89,52 @@ func PackagesRulePreview(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplSettingsPackagesRulePreview)
 }
 
+func PackagesProxyAdd(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.SetProxyAddContext(ctx)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesProxyEdit)
+}
+
+func PackagesProxyEdit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.SetProxyEditContext(ctx, ctx.Doer)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesProxyEdit)
+}
+
+func PackagesProxyAddPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.PerformProxyAddPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesProxyEdit,
+	)
+}
+
+func PackagesProxyEditPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.PerformProxyEditPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesProxyEdit,
+	)
+}
+
 func InitializeCargoIndex(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("packages.title")
 	ctx.Data["PageIsSettingsPackages"] = true
@@ -106,6 +153,24 @@ func RebuildCargoIndex(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
 }
 
+func RotateSigningKey(ctx *context.Context) {
+	shared.RotateSigningKey(ctx, ctx.Doer)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
+}
+
+func RevokeSigningKey(ctx *context.Context) {
+	shared.RevokeSigningKey(ctx, ctx.Doer)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/packages")
+}
+
 func RegenerateChefKeyPair(ctx *context.Context) {
 	priv, pub, err := util.GenerateKeyPair(chef_module.KeyBits)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/modules/web/middleware"
 	"code.gitea.io/gitea/modules/web/routing"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/routers/web/admin"
 	"code.gitea.io/gitea/routers/web/auth"
@@ -44,7 +45,9 @@ import (
 	"code.gitea.io/gitea/routers/web/user/setting/security"
 	auth_service "code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/eventexport"
 	"code.gitea.io/gitea/services/forms"
+	indexer_service "code.gitea.io/gitea/services/indexer"
 
 	_ "code.gitea.io/gitea/modules/session" // to registers all internal adapters
 
@@ -260,6 +263,10 @@ func Routes() *web.Router {
 
 	if setting.Metrics.Enabled {
 		prometheus.MustRegister(metrics.NewCollector())
+		prometheus.MustRegister(indexer_service.NewCollector())
+		if setting.EventExporter.Enabled {
+			prometheus.MustRegister(eventexport.NewCollector())
+		}
 		routes.Get("/metrics", append(mid, Metrics)...)
 	}
 
@@ -432,6 +439,8 @@ func registerRoutes(m *web.Router) {
 		m.Post("/feishu/new", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksNewPost)
 		m.Post("/wechatwork/new", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksNewPost)
 		m.Post("/packagist/new", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksNewPost)
+		m.Post("/googlechat/new", web.Bind(forms.NewGoogleChatHookForm{}), repo_setting.GoogleChatHooksNewPost)
+		m.Post("/ntfy/new", web.Bind(forms.NewNtfyHookForm{}), repo_setting.NtfyHooksNewPost)
 	}
 
 	addWebhookEditRoutes := func() {
@@ -446,6 +455,8 @@ func registerRoutes(m *web.Router) {
 		m.Post("/feishu/{id}", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksEditPost)
 		m.Post("/wechatwork/{id}", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksEditPost)
 		m.Post("/packagist/{id}", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksEditPost)
+		m.Post("/googlechat/{id}", web.Bind(forms.NewGoogleChatHookForm{}), repo_setting.GoogleChatHooksEditPost)
+		m.Post("/ntfy/{id}", web.Bind(forms.NewNtfyHookForm{}), repo_setting.NtfyHooksEditPost)
 	}
 
 	addSettingsVariablesRoutes := func() {
@@ -490,6 +501,7 @@ func registerRoutes(m *web.Router) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
 		m.Get("/passkey-endpoints", passkeyEndpoints)
+		m.Get("/terraform.json", packagesEnabled, terraform.ServiceDiscovery)
 		m.Methods("GET, HEAD", "/*", public.FileHandlerFunc())
 	}, optionsCorsHandler())
 
@@ -510,6 +522,19 @@ func registerRoutes(m *web.Router) {
 				return
 			}
 		}, explore.Code)
+		m.Get("/commits", func(ctx *context.Context) {
+			if unit.TypeCode.UnitGlobalDisabled() {
+				ctx.NotFound(nil)
+				return
+			}
+		}, explore.Commits)
+		m.Get("/wikis", func(ctx *context.Context) {
+			if unit.TypeWiki.UnitGlobalDisabled() {
+				ctx.NotFound(nil)
+				return
+			}
+		}, explore.Wikis)
+		m.Get("/search", explore.Search)
 		m.Get("/topics/search", explore.TopicSearch)
 	}, optExploreSignIn)
 
@@ -651,10 +676,24 @@ func registerRoutes(m *web.Router) {
 					m.Get("/preview", user_setting.PackagesRulePreview)
 				})
 			})
+			m.Group("/proxies", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesProxyAdd)
+					m.Post("", web.Bind(forms.PackageProxyForm{}), user_setting.PackagesProxyAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesProxyEdit)
+					m.Post("", web.Bind(forms.PackageProxyForm{}), user_setting.PackagesProxyEditPost)
+				})
+			})
 			m.Group("/cargo", func() {
 				m.Post("/initialize", user_setting.InitializeCargoIndex)
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
 			})
+			m.Group("/signing_keys/{type}", func() {
+				m.Post("/rotate", user_setting.RotateSigningKey)
+				m.Post("/{id}/revoke", user_setting.RevokeSigningKey)
+			})
 			m.Post("/chef/regenerate_keypair", user_setting.RegenerateChefKeyPair)
 		}, packagesEnabled)
 
@@ -738,6 +777,9 @@ func registerRoutes(m *web.Router) {
 				m.Post("/set", admin.QueueSet)
 				m.Post("/remove-all-items", admin.QueueRemoveAllItems)
 			})
+			m.Get("/indexers", admin.Indexers)
+			m.Post("/indexers/reindex-repo", admin.IndexerReindexRepo)
+			m.Post("/indexers/{name}/rebuild", admin.IndexerRebuild)
 			m.Get("/diagnosis", admin.MonitorDiagnosis)
 		})
 
@@ -776,6 +818,7 @@ func registerRoutes(m *web.Router) {
 		m.Group("/hooks", func() {
 			m.Get("", admin.DefaultOrSystemWebhooks)
 			m.Post("/delete", admin.DeleteDefaultOrSystemWebhook)
+			m.Post("/retry-task", admin.RetryStuckHookTask)
 			m.Group("/{id}", func() {
 				m.Get("", repo_setting.WebHooksEdit)
 				m.Post("/replay/{uuid}", repo_setting.ReplayWebhook)
@@ -981,10 +1024,24 @@ func registerRoutes(m *web.Router) {
 							m.Get("/preview", org.PackagesRulePreview)
 						})
 					})
+					m.Group("/proxies", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesProxyAdd)
+							m.Post("", web.Bind(forms.PackageProxyForm{}), org.PackagesProxyAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesProxyEdit)
+							m.Post("", web.Bind(forms.PackageProxyForm{}), org.PackagesProxyEditPost)
+						})
+					})
 					m.Group("/cargo", func() {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
 					})
+					m.Group("/signing_keys/{type}", func() {
+						m.Post("/rotate", org.RotateSigningKey)
+						m.Post("/{id}/revoke", org.RevokeSigningKey)
+					})
 				}, packagesEnabled)
 
 				m.Group("/blocked_users", func() {
@@ -1015,6 +1072,7 @@ func registerRoutes(m *web.Router) {
 					m.Group("/{version}", func() {
 						m.Get("", user.ViewPackageVersion)
 						m.Get("/files/{fileid}", user.DownloadPackageFile)
+						m.Get("/downloads", user.PackageDownloadStatsData)
 						m.Group("/settings", func() {
 							m.Get("", user.PackageSettings)
 							m.Post("", web.Bind(forms.PackageSettingForm{}), user.PackageSettingsPost)
@@ -1229,6 +1287,7 @@ func registerRoutes(m *web.Router) {
 				m.Get("/choose", context.RepoRef(), repo.NewIssueChooseTemplate)
 			})
 			m.Get("/search", repo.SearchRepoIssuesJSON)
+			m.Get("/similar", repo.SimilarIssues)
 		}, reqUnitIssuesReader)
 
 		addIssuesPullsUpdateRoutes := func() {
@@ -1614,6 +1673,7 @@ func registerRoutes(m *web.Router) {
 		m.Get("/stars", starsEnabled, repo.Stars)
 		m.Get("/watchers", repo.Watchers)
 		m.Get("/search", reqUnitCodeReader, repo.Search)
+		m.Get("/search/commits", reqUnitCodeReader, repo.SearchIndexedCommits)
 		m.Post("/action/{action:star|unstar}", reqSignIn, starsEnabled, repo.ActionStar)
 		m.Post("/action/{action:watch|unwatch}", reqSignIn, repo.ActionWatch)
 		m.Post("/action/{action:accept_transfer|reject_transfer}", reqSignIn, repo.ActionTransfer)
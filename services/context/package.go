@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"net/http"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
@@ -18,10 +20,30 @@ import (
 )
 
 // Package contains owner, access mode and optional the package descriptor
+// AccessMode is the access mode for the package of the descriptor if it is set,
+// otherwise the highest access mode the doer has for any package of the owner.
 type Package struct {
-	Owner      *user_model.User
-	AccessMode perm.AccessMode
-	Descriptor *packages_model.PackageDescriptor
+	Owner       *user_model.User
+	AccessMode  perm.AccessMode
+	AccessScope *packages_model.AccessScope
+	Descriptor  *packages_model.PackageDescriptor
+}
+
+// RestrictToPublic restricts the package access of the request to the packages visible to everyone,
+// it is used for tokens limited to public resources. It returns false if the package of the request isn't visible to everyone.
+func (p *Package) RestrictToPublic() bool {
+	p.AccessScope.PublicOnly = true
+	p.AccessScope.OwnerIsPublic = p.Owner.Visibility.IsPublic()
+	if p.Descriptor == nil {
+		return !p.Owner.Visibility.IsPrivate()
+	}
+	switch p.Descriptor.Package.Visibility {
+	case packages_model.VisibilityPublic:
+		return true
+	case packages_model.VisibilityPrivate:
+		return false
+	}
+	return p.AccessScope.OwnerIsPublic
 }
 
 type packageAssignmentCtx struct {
@@ -61,13 +83,38 @@ func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, any)) *Package
 	pkg := &Package{
 		Owner: ctx.ContextUser,
 	}
-	var err error
-	pkg.AccessMode, err = determineAccessMode(ctx.Base, pkg, ctx.Doer)
+	ownerAccessMode, err := determineAccessMode(ctx.Base, pkg, ctx.Doer)
 	if err != nil {
 		errCb(http.StatusInternalServerError, fmt.Errorf("determineAccessMode: %w", err))
 		return pkg
 	}
 
+	// The scope restricts all package lookups of the request to the packages the doer can access
+	pkg.AccessScope = &packages_model.AccessScope{
+		OwnerID:         pkg.Owner.ID,
+		OwnerAccessMode: ownerAccessMode,
+		AllowPublic:     canAccessPackages(ctx.Doer),
+	}
+	if ctx.Doer != nil && ctx.Doer.IsGiteaActions() {
+		if err := determineActionsAccess(ctx.Base, pkg); err != nil {
+			errCb(http.StatusInternalServerError, fmt.Errorf("determineActionsAccess: %w", err))
+			return pkg
+		}
+	} else if ctx.Doer != nil && !ctx.Doer.IsGhost() && canAccessPackages(ctx.Doer) {
+		pkg.AccessScope.UserID = ctx.Doer.ID
+		if ctx.Doer.IsAdmin {
+			// site admins are not restricted by the package visibility
+			pkg.AccessScope.OwnerAccessMode = max(ownerAccessMode, perm.AccessModeAdmin)
+		}
+	}
+	ctx.SetContextValue(packages_model.AccessScopeContextKey, pkg.AccessScope)
+
+	pkg.AccessMode, err = pkg.AccessScope.MaxAccessMode(ctx)
+	if err != nil {
+		errCb(http.StatusInternalServerError, fmt.Errorf("MaxAccessMode: %w", err))
+		return pkg
+	}
+
 	packageType := ctx.PathParam("type")
 	name := ctx.PathParam("name")
 	version := ctx.PathParam("version")
@@ -87,21 +134,35 @@ func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, any)) *Package
 			errCb(http.StatusInternalServerError, fmt.Errorf("GetPackageDescriptor: %w", err))
 			return pkg
 		}
+
+		pkg.AccessMode, err = pkg.AccessScope.AccessMode(ctx, pkg.Descriptor.Package)
+		if err != nil {
+			errCb(http.StatusInternalServerError, fmt.Errorf("AccessMode: %w", err))
+			return pkg
+		}
 	}
 
 	return pkg
 }
 
-func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.AccessMode, error) {
+// canAccessPackages checks if the doer may access any packages at all
+func canAccessPackages(doer *user_model.User) bool {
 	if setting.Service.RequireSignInView && (doer == nil || doer.IsGhost()) {
+		return false
+	}
+	return doer == nil || doer.IsGhost() || (doer.IsActive && !doer.ProhibitLogin)
+}
+
+func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.AccessMode, error) {
+	if !canAccessPackages(doer) {
 		return perm.AccessModeNone, nil
 	}
 
-	if doer != nil && !doer.IsGhost() && (!doer.IsActive || doer.ProhibitLogin) {
+	if doer != nil && doer.IsGiteaActions() {
+		// the access of Actions tasks is determined by determineActionsAccess
 		return perm.AccessModeNone, nil
 	}
 
-	// TODO: ActionUser permission check
 	accessMode := perm.AccessModeNone
 	if pkg.Owner.IsOrganization() {
 		org := organization.OrgFromUser(pkg.Owner)
@@ -148,6 +209,62 @@ func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.A
 	return accessMode, nil
 }
 
+// determineActionsAccess restricts the access of the task of an Actions run to the packages of the repository owner.
+// The task can read the packages of the owner and, if the Packages unit of the repository is enabled, write the packages
+// which are not linked to a repository or linked to the repository of the run. The run is recorded as build source of the
+// packages it creates.
+func determineActionsAccess(ctx *Base, pkg *Package) error {
+	scope := pkg.AccessScope
+
+	taskID, ok := ctx.Data["ActionsTaskID"].(int64)
+	if !ok {
+		return nil
+	}
+	task, err := actions_model.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task.Status != actions_model.StatusRunning {
+		// registry tokens outlive the task they were issued to
+		return nil
+	}
+	if task.OwnerID != pkg.Owner.ID {
+		if pkg.Owner.Visibility == structs.VisibleTypePublic {
+			scope.OwnerAccessMode = perm.AccessModeRead
+		}
+		return nil
+	}
+	scope.OwnerAccessMode = perm.AccessModeRead
+	if task.IsForkPullRequest {
+		return nil
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, task.RepoID)
+	if err != nil {
+		return err
+	}
+	if !repo.UnitEnabled(ctx, unit.TypePackages) {
+		return nil
+	}
+
+	if err := task.LoadJob(ctx); err != nil {
+		return err
+	}
+	if err := task.Job.LoadRun(ctx); err != nil {
+		return err
+	}
+	ctx.SetContextValue(packages_model.BuildSourceContextKey, &packages_model.BuildSource{
+		RepoID:     task.RepoID,
+		CommitSHA:  task.CommitSHA,
+		WorkflowID: task.Job.Run.WorkflowID,
+		RunID:      task.Job.RunID,
+	})
+
+	scope.RepoID = task.RepoID
+	scope.RepoAccessMode = perm.AccessModeWrite
+	return nil
+}
+
 // PackageContexter initializes a package context for a request.
 func PackageContexter() func(next http.Handler) http.Handler {
 	renderer := templates.HTMLRenderer()
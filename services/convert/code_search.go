@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToCodeSearchResult converts a code search result of the repository to API format
+func ToCodeSearchResult(repo *repo_model.Repository, r *code_indexer.Result) *api.CodeSearchResult {
+	lines := make([]*api.CodeSearchResultLine, len(r.Lines))
+	for i, line := range r.Lines {
+		lines[i] = &api.CodeSearchResultLine{
+			Num:     line.Num,
+			Content: line.PlainContent(),
+		}
+	}
+	return &api.CodeSearchResult{
+		Path:     r.Filename,
+		Language: r.Language,
+		CommitID: r.CommitID,
+		HTMLURL:  repo.HTMLURL() + "/src/commit/" + util.PathEscapeSegments(r.CommitID) + "/" + util.PathEscapeSegments(r.Filename),
+		Lines:    lines,
+	}
+}
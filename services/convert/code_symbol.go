@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"strconv"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToCodeSymbol converts a code symbol of the repository to API format
+func ToCodeSymbol(repo *repo_model.Repository, s *repo_model.RepoCodeSymbol) *api.CodeSymbol {
+	return &api.CodeSymbol{
+		Name:      s.Name,
+		Kind:      s.Kind,
+		Container: s.Container,
+		Language:  s.Language,
+		Path:      s.Filename,
+		Line:      s.Line,
+		HTMLURL:   repo.HTMLURL() + "/src/branch/" + util.PathEscapeSegments(repo.DefaultBranch) + "/" + util.PathEscapeSegments(s.Filename) + "#L" + strconv.Itoa(s.Line),
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToCommitSearchResult converts a commit found by the commit indexer to API format
+func ToCommitSearchResult(repo *repo_model.Repository, c *commit_indexer.IndexerData) *api.CommitSearchResult {
+	return &api.CommitSearchResult{
+		Repository: repo.FullName(),
+		SHA:        c.CommitID,
+		Message:    c.Message,
+		Committed:  c.CommittedUnix.AsTime(),
+		Author: &api.Identity{
+			Name:  c.AuthorName,
+			Email: c.AuthorEmail,
+		},
+		Committer: &api.Identity{
+			Name:  c.CommitterName,
+			Email: c.CommitterEmail,
+		},
+		Files:   util.SliceNilAsEmpty(c.Paths),
+		HTMLURL: repo.HTMLURL() + "/commit/" + util.PathEscapeSegments(c.CommitID),
+	}
+}
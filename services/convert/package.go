@@ -5,11 +5,17 @@ package convert
 
 import (
 	"context"
+	"errors"
 
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 )
 
 // ToPackage convert a packages.PackageDescriptor to api.Package
@@ -26,6 +32,11 @@ func ToPackage(ctx context.Context, pd *packages.PackageDescriptor, doer *user_m
 		}
 	}
 
+	source, err := toPackageSource(ctx, pd.Version, doer)
+	if err != nil {
+		return nil, err
+	}
+
 	return &api.Package{
 		ID:         pd.Version.ID,
 		Owner:      ToUser(ctx, pd.Owner, doer),
@@ -36,9 +47,83 @@ func ToPackage(ctx context.Context, pd *packages.PackageDescriptor, doer *user_m
 		Version:    pd.Version.Version,
 		CreatedAt:  pd.Version.CreatedUnix.AsTime(),
 		HTMLURL:    pd.VersionHTMLURL(),
+		Source:     source,
 	}, nil
 }
 
+// toPackageSource converts the build source of a package version if the doer can read the source repository
+func toPackageSource(ctx context.Context, pv *packages.PackageVersion, doer *user_model.User) (*api.PackageSource, error) {
+	if !pv.HasBuildSource() {
+		return nil, nil
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, pv.SourceRepoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	permission, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.CanRead(unit.TypeCode) {
+		return nil, nil
+	}
+
+	source := &api.PackageSource{
+		Repository: ToRepo(ctx, repo, permission),
+		CommitSHA:  pv.SourceCommitSHA,
+		Workflow:   pv.SourceWorkflowID,
+		RunID:      pv.SourceRunID,
+	}
+	if permission.CanRead(unit.TypeActions) {
+		if run, err := actions_model.GetRunByID(ctx, pv.SourceRunID); err == nil {
+			run.Repo = repo
+			source.RunURL = run.HTMLURL()
+		} else if !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return source, nil
+}
+
+// ToPackageProvenance converts a packages.PackageProvenance to api.PackageProvenance
+func ToPackageProvenance(pp *packages.PackageProvenance) *api.PackageProvenance {
+	return &api.PackageProvenance{
+		ID:                 pp.ID,
+		PredicateType:      pp.PredicateType,
+		SourceURI:          pp.SourceURI,
+		SourceDigest:       pp.SourceDigest,
+		HasSignatures:      pp.HasSignatures,
+		MatchesFiles:       pp.MatchesFiles,
+		MatchesBuildSource: pp.MatchesBuildSource,
+		CreatedAt:          pp.CreatedUnix.AsTime(),
+	}
+}
+
+// ToPackageSigningKey converts a packages.PackageSigningKey to api.PackageSigningKey
+func ToPackageSigningKey(k *packages.PackageSigningKey) *api.PackageSigningKey {
+	apiKey := &api.PackageSigningKey{
+		ID:          k.ID,
+		Type:        string(k.Type),
+		Fingerprint: k.Fingerprint,
+		PublicKey:   k.PublicKey,
+		Status:      k.Status.String(),
+		CreatedAt:   k.CreatedUnix.AsTime(),
+	}
+	if k.RetireUnix != 0 {
+		retireAt := k.RetireUnix.AsTime()
+		apiKey.RetireAt = &retireAt
+	}
+	if k.RevokedUnix != 0 {
+		revokedAt := k.RevokedUnix.AsTime()
+		apiKey.RevokedAt = &revokedAt
+	}
+	return apiKey
+}
+
 // ToPackageFile converts packages.PackageFileDescriptor to api.PackageFile
 func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 	return &api.PackageFile{
@@ -51,3 +136,85 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageDownloadStats converts aggregated download statistics to api.PackageDownloadStats
+func ToPackageDownloadStats(ctx context.Context, points []*packages.DownloadStatsPoint) ([]*api.PackageDownloadStats, error) {
+	versions := make(map[int64]string)
+
+	stats := make([]*api.PackageDownloadStats, 0, len(points))
+	for _, point := range points {
+		var version string
+		if point.VersionID != 0 {
+			var ok bool
+			if version, ok = versions[point.VersionID]; !ok {
+				pv, err := packages.GetVersionByID(ctx, point.VersionID)
+				if err != nil && err != packages.ErrPackageNotExist {
+					return nil, err
+				}
+				if pv != nil {
+					version = pv.Version
+				}
+				versions[point.VersionID] = version
+			}
+			if version == "" {
+				// the version was deleted and its statistics were not yet cleaned up
+				continue
+			}
+		}
+
+		stats = append(stats, &api.PackageDownloadStats{
+			Date:    point.PeriodUnix.AsTime().UTC(),
+			Period:  point.Granularity.String(),
+			Version: version,
+			Client:  point.Client,
+			Count:   point.Count,
+		})
+	}
+	return stats, nil
+}
+
+// ToPackageAccess converts the visibility and the access grants of a package to api.PackageAccess
+func ToPackageAccess(ctx context.Context, p *packages.Package, doer *user_model.User) (*api.PackageAccess, error) {
+	pas, err := packages.GetAccessesByPackageID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &api.PackageAccess{
+		Visibility:    p.Visibility.String(),
+		Teams:         make([]*api.PackageTeamAccess, 0, len(pas)),
+		Collaborators: make([]*api.PackageCollaboratorAccess, 0, len(pas)),
+	}
+	for _, pa := range pas {
+		if pa.TeamID != 0 {
+			team, err := organization.GetTeamByID(ctx, pa.TeamID)
+			if err != nil {
+				if organization.IsErrTeamNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			apiTeam, err := ToTeam(ctx, team)
+			if err != nil {
+				return nil, err
+			}
+			access.Teams = append(access.Teams, &api.PackageTeamAccess{
+				Team:       apiTeam,
+				Permission: pa.AccessMode.ToString(),
+			})
+		} else {
+			u, err := user_model.GetUserByID(ctx, pa.UserID)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			access.Collaborators = append(access.Collaborators, &api.PackageCollaboratorAccess{
+				User:       ToUser(ctx, u, doer),
+				Permission: pa.AccessMode.ToString(),
+			})
+		}
+	}
+	return access, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	search_service "code.gitea.io/gitea/services/search"
+)
+
+// ToSearchResponse converts a page of the results of a search across all types to API format
+func ToSearchResponse(ctx context.Context, resp *search_service.Response, doer *user_model.User) (*api.SearchResponse, error) {
+	apiResp := &api.SearchResponse{
+		Counts:      make(map[string]int64, len(resp.Counts)),
+		Results:     make([]*api.SearchResult, 0, len(resp.Results)),
+		Unavailable: make([]string, 0, len(resp.Unavailable)),
+	}
+	for t, count := range resp.Counts {
+		apiResp.Counts[string(t)] = count
+	}
+	for _, t := range resp.Unavailable {
+		apiResp.Unavailable = append(apiResp.Unavailable, string(t))
+	}
+	if resp.NextCursor != nil {
+		apiResp.NextCursor = resp.NextCursor.String()
+	}
+
+	for _, r := range resp.Results {
+		apiResult, err := ToSearchResult(ctx, r, doer)
+		if err != nil {
+			return nil, err
+		}
+		apiResp.Results = append(apiResp.Results, apiResult)
+	}
+	return apiResp, nil
+}
+
+// ToSearchResult converts a result of a search across all types to API format
+func ToSearchResult(ctx context.Context, r *search_service.Result, doer *user_model.User) (*api.SearchResult, error) {
+	apiResult := &api.SearchResult{
+		Type:       string(r.Type),
+		Score:      r.Score,
+		Title:      r.Title(),
+		HTMLURL:    r.HTMLURL(),
+		Highlights: make([]*api.SearchHighlight, 0, len(r.Highlights)),
+	}
+	for _, h := range r.Highlights {
+		apiResult.Highlights = append(apiResult.Highlights, &api.SearchHighlight{
+			Field:    h.Field,
+			Fragment: h.Fragment,
+			Matches:  h.Matches,
+		})
+	}
+
+	if r.Repository != nil {
+		perm, err := access_model.GetUserRepoPermission(ctx, r.Repository, doer)
+		if err != nil {
+			return nil, err
+		}
+		apiResult.Repository = ToRepo(ctx, r.Repository, perm)
+	}
+	switch r.Type {
+	case search_service.TypeCode:
+		apiResult.Code = ToCodeSearchResult(r.Repository, r.Code)
+	case search_service.TypeIssue:
+		apiResult.Issue = ToAPIIssue(ctx, doer, r.Issue)
+	case search_service.TypeUser:
+		apiResult.User = ToUser(ctx, r.User, doer)
+	case search_service.TypePackage:
+		pkg, err := ToPackage(ctx, r.Package, doer)
+		if err != nil {
+			return nil, err
+		}
+		apiResult.Package = pkg
+	}
+	return apiResult, nil
+}
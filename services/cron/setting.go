@@ -32,6 +32,13 @@ type OlderThanConfig struct {
 	OlderThan time.Duration
 }
 
+// PackageDownloadStatsConfig represents a cron task with settings to roll up and expire package download statistics
+type PackageDownloadStatsConfig struct {
+	BaseConfig
+	RollupAfter time.Duration
+	Retention   time.Duration
+}
+
 // UpdateExistingConfig represents a cron task with UpdateExisting setting
 type UpdateExistingConfig struct {
 	BaseConfig
@@ -17,6 +17,7 @@ import (
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	packages_signing_service "code.gitea.io/gitea/services/packages/signing"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 )
@@ -156,6 +157,30 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerPackageDownloadStats() {
+	RegisterTaskFatal("package_download_stats", &PackageDownloadStatsConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		RollupAfter: 90 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*PackageDownloadStatsConfig)
+		return packages_cleanup_service.CleanupDownloadStats(ctx, realConfig.RollupAfter, realConfig.Retention)
+	})
+}
+
+func registerPackageSigningKeys() {
+	RegisterTaskFatal("package_signing_keys", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return packages_signing_service.UpdateKeysTask(ctx)
+	})
+}
+
 func registerSyncRepoLicenses() {
 	RegisterTaskFatal("sync_repo_licenses", &BaseConfig{
 		Enabled:    false,
@@ -181,6 +206,8 @@ func initBasicTasks() {
 	registerCleanupHookTaskTable()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
+		registerPackageDownloadStats()
+		registerPackageSigningKeys()
 	}
 	registerSyncRepoLicenses()
 }
@@ -12,9 +12,11 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/updatechecker"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	container_service "code.gitea.io/gitea/services/packages/container"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 	user_service "code.gitea.io/gitea/services/user"
@@ -213,13 +215,41 @@ func registerGCLFS() {
 	})
 }
 
+func registerGCContainerRegistry() {
+	if !setting.Packages.Enabled {
+		return
+	}
+
+	RegisterTaskFatal("gc_container_registry", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 24h",
+		},
+		// Only collect manifests older than a day because the manifests of a multi-arch image are pushed
+		// before the image index which references them.
+		OlderThan: 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		olderThanConfig := config.(*OlderThanConfig)
+		report, err := container_service.GarbageCollect(ctx, container_service.GarbageCollectOptions{
+			AutoFix:   true,
+			OlderThan: time.Now().Add(-olderThanConfig.OlderThan),
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Container registry garbage collection removed %d manifests and %d blobs (%d bytes)", report.UnreachableManifests, report.ReclaimableBlobs, report.ReclaimableSize)
+		return nil
+	})
+}
+
 func registerRebuildIssueIndexer() {
 	RegisterTaskFatal("rebuild_issue_indexer", &BaseConfig{
 		Enabled:    false,
 		RunAtStart: false,
 		Schedule:   "@annually",
 	}, func(ctx context.Context, _ *user_model.User, config Config) error {
-		return issue_indexer.PopulateIssueIndexer(ctx)
+		return issue_indexer.RebuildIssueIndexer(ctx)
 	})
 }
 
@@ -237,5 +267,6 @@ func initExtendedTasks() {
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
 	registerGCLFS()
+	registerGCContainerRegistry()
 	registerRebuildIssueIndexer()
 }
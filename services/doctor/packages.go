@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+	"time"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/log"
+	container_service "code.gitea.io/gitea/services/packages/container"
+)
+
+func init() {
+	Register(&Check{
+		Title:                      "Garbage collect container registry",
+		Name:                       "gc-container-registry",
+		IsDefault:                  false,
+		Run:                        garbageCollectContainerRegistryCheck,
+		AbortIfFailed:              false,
+		SkipDatabaseInitialization: false,
+		InitStorage:                true,
+		Priority:                   1,
+	})
+}
+
+func garbageCollectContainerRegistryCheck(ctx context.Context, logger log.Logger, autofix bool) error {
+	report, err := container_service.GarbageCollect(ctx, container_service.GarbageCollectOptions{
+		LogDetail: logger.Info,
+		AutoFix:   autofix,
+		// Keep recent manifests because the manifests of a multi-arch image are pushed before the image index
+		OlderThan: time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, osr := range report.Owners {
+		name := user_model.NewGhostUser().Name
+		if u, err := user_model.GetPossibleUserByID(ctx, osr.OwnerID); err == nil {
+			name = u.Name
+		}
+		logger.Info("Owner %s: %d blobs (%s), %d unreachable manifests, %d blobs (%s) reclaimable", name, osr.Blobs, base.FileSize(osr.Size), osr.UnreachableManifests, osr.ReclaimableBlobs, base.FileSize(osr.ReclaimableSize))
+	}
+
+	if report.UnreachableManifests == 0 {
+		logger.Info("No unreachable container manifests found")
+	} else if autofix {
+		logger.Info("Removed %d unreachable container manifests and %d blobs (%s)", report.UnreachableManifests, report.ReclaimableBlobs, base.FileSize(report.ReclaimableSize))
+	} else {
+		logger.Warn("Found %d unreachable container manifests, %d blobs (%s) can be reclaimed", report.UnreachableManifests, report.ReclaimableBlobs, base.FileSize(report.ReclaimableSize))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package eventexport publishes the payloads of webhook events to Redis Streams,
+// so that external consumers can read and replay them from any stream entry ID.
+package eventexport
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/nosql"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+	notify_service "code.gitea.io/gitea/services/notify"
+	webhook_service "code.gitea.io/gitea/services/webhook"
+
+	gouuid "github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// streamEntry is an event waiting to be added to a stream
+type streamEntry struct {
+	Stream    string
+	Event     string
+	Delivery  string
+	RepoID    int64
+	OwnerID   int64
+	Payload   string
+	CreatedAt int64
+}
+
+var (
+	client     redis.UniversalClient
+	entryQueue *queue.WorkerPoolQueue[*streamEntry]
+
+	publishedCount atomic.Int64
+	failedCount    atomic.Int64
+)
+
+// Init starts the exporter and registers its notifier if the event exporter is enabled
+func Init() error {
+	if !setting.EventExporter.Enabled {
+		return nil
+	}
+
+	client = nosql.GetManager().GetRedisClient(setting.EventExporter.ConnStr)
+	if client == nil {
+		return errors.New("unable to connect to the event exporter redis")
+	}
+
+	entryQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "event_exporter", handler)
+	if entryQueue == nil {
+		return errors.New("unable to create event_exporter queue")
+	}
+	go graceful.GetManager().RunWithCancel(entryQueue)
+
+	notify_service.RegisterNotifier(webhook_service.NewPayloadNotifier(prepareEntry))
+	return nil
+}
+
+func isEventExported(event webhook_module.HookEventType) bool {
+	return len(setting.EventExporter.Events) == 0 || setting.EventExporter.Events.Contains(string(event))
+}
+
+func newStreamEntry(source webhook_service.EventSource, event webhook_module.HookEventType, p api.Payloader) (*streamEntry, error) {
+	data, err := p.JSONPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &streamEntry{
+		Stream:    setting.EventExporterStreamName(string(event)),
+		Event:     string(event),
+		Delivery:  gouuid.New().String(),
+		Payload:   string(data),
+		CreatedAt: time.Now().Unix(),
+	}
+	if source.Repository != nil {
+		entry.RepoID = source.Repository.ID
+		entry.OwnerID = source.Repository.OwnerID
+	} else if source.Owner != nil {
+		entry.OwnerID = source.Owner.ID
+	}
+	return entry, nil
+}
+
+func prepareEntry(_ context.Context, source webhook_service.EventSource, event webhook_module.HookEventType, p api.Payloader) error {
+	if !isEventExported(event) {
+		return nil
+	}
+
+	entry, err := newStreamEntry(source, event, p)
+	if err != nil {
+		return err
+	}
+	return entryQueue.Push(entry)
+}
+
+func (e *streamEntry) toXAddArgs() *redis.XAddArgs {
+	args := &redis.XAddArgs{
+		Stream: e.Stream,
+		Values: []string{
+			"event", e.Event,
+			"delivery", e.Delivery,
+			"repo_id", strconv.FormatInt(e.RepoID, 10),
+			"owner_id", strconv.FormatInt(e.OwnerID, 10),
+			"created_at", strconv.FormatInt(e.CreatedAt, 10),
+			"payload", e.Payload,
+		},
+	}
+	if setting.EventExporter.MaxLength > 0 {
+		// approximate trimming lets redis drop whole macro nodes, which is much cheaper than exact trimming
+		args.MaxLen = setting.EventExporter.MaxLength
+		args.Approx = true
+	}
+	return args
+}
+
+func handler(items ...*streamEntry) (unhandled []*streamEntry) {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, entry := range items {
+		if err := client.XAdd(ctx, entry.toXAddArgs()).Err(); err != nil {
+			log.Error("Unable to add event %s to stream %s: %v", entry.Delivery, entry.Stream, err)
+			failedCount.Add(1)
+			unhandled = append(unhandled, entry)
+			continue
+		}
+		publishedCount.Add(1)
+	}
+	return unhandled
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package eventexport
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+	webhook_service "code.gitea.io/gitea/services/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStreamEntry(t *testing.T) {
+	defer test.MockVariableValue(&setting.EventExporter.StreamPrefix, "gitea:events:")()
+	defer test.MockVariableValue(&setting.EventExporter.Streams, map[string]string{"push": "analytics:pushes"})()
+	defer test.MockVariableValue(&setting.EventExporter.MaxLength, int64(1000))()
+
+	source := webhook_service.EventSource{Repository: &repo_model.Repository{ID: 3, OwnerID: 2}}
+	entry, err := newStreamEntry(source, webhook_module.HookEventPush, &api.PushPayload{Ref: "refs/heads/main"})
+	require.NoError(t, err)
+	assert.Equal(t, "analytics:pushes", entry.Stream)
+	assert.Equal(t, "push", entry.Event)
+	assert.EqualValues(t, 3, entry.RepoID)
+	assert.EqualValues(t, 2, entry.OwnerID)
+	assert.NotEmpty(t, entry.Delivery)
+	assert.Contains(t, entry.Payload, `"ref": "refs/heads/main"`)
+
+	args := entry.toXAddArgs()
+	assert.Equal(t, "analytics:pushes", args.Stream)
+	assert.EqualValues(t, 1000, args.MaxLen)
+	assert.True(t, args.Approx)
+
+	entry, err = newStreamEntry(webhook_service.EventSource{}, webhook_module.HookEventUser, &api.UserPayload{})
+	require.NoError(t, err)
+	assert.Equal(t, "gitea:events:user", entry.Stream)
+	assert.Zero(t, entry.RepoID)
+	assert.Zero(t, entry.OwnerID)
+}
+
+func TestExportedStreams(t *testing.T) {
+	defer test.MockVariableValue(&setting.EventExporter.StreamPrefix, "gitea:events:")()
+	defer test.MockVariableValue(&setting.EventExporter.Streams, map[string]string{"issues": "analytics:all", "push": "analytics:all"})()
+	defer test.MockVariableValue(&setting.EventExporter.Events, container.SetOf("push", "issues", "release"))()
+
+	assert.True(t, isEventExported(webhook_module.HookEventRelease))
+	assert.False(t, isEventExported(webhook_module.HookEventFork))
+	assert.ElementsMatch(t, []string{"analytics:all", "gitea:events:release"}, exportedStreams())
+}
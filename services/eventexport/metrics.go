@@ -0,0 +1,130 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package eventexport
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "gitea_event_exporter_"
+
+// Collector implements the prometheus.Collector interface and
+// exposes the backlog of the event exporter for prometheus
+type Collector struct {
+	Published     *prometheus.Desc
+	Failed        *prometheus.Desc
+	QueueLength   *prometheus.Desc
+	StreamLength  *prometheus.Desc
+	GroupPending  *prometheus.Desc
+	GroupLag      *prometheus.Desc
+	scrapeTimeout time.Duration
+}
+
+// NewCollector returns a new Collector with all prometheus.Desc initialized
+func NewCollector() Collector {
+	return Collector{
+		Published: prometheus.NewDesc(
+			namespace+"published_total",
+			"Number of events added to the streams",
+			nil, nil,
+		),
+		Failed: prometheus.NewDesc(
+			namespace+"failed_total",
+			"Number of failed attempts to add an event to a stream",
+			nil, nil,
+		),
+		QueueLength: prometheus.NewDesc(
+			namespace+"queue_length",
+			"Number of events waiting to be added to the streams",
+			nil, nil,
+		),
+		StreamLength: prometheus.NewDesc(
+			namespace+"stream_length",
+			"Number of entries in a stream",
+			[]string{"stream"}, nil,
+		),
+		GroupPending: prometheus.NewDesc(
+			namespace+"consumer_group_pending",
+			"Number of entries delivered to a consumer group but not acknowledged",
+			[]string{"stream", "group"}, nil,
+		),
+		GroupLag: prometheus.NewDesc(
+			namespace+"consumer_group_lag",
+			"Number of entries in a stream not yet delivered to a consumer group",
+			[]string{"stream", "group"}, nil,
+		),
+		scrapeTimeout: 5 * time.Second,
+	}
+}
+
+// Describe returns all possible prometheus.Desc
+func (c Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Published
+	ch <- c.Failed
+	ch <- c.QueueLength
+	ch <- c.StreamLength
+	ch <- c.GroupPending
+	ch <- c.GroupLag
+}
+
+// Collect returns the metrics with values
+func (c Collector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(c.Published, prometheus.CounterValue, float64(publishedCount.Load()))
+	ch <- prometheus.MustNewConstMetric(c.Failed, prometheus.CounterValue, float64(failedCount.Load()))
+	if entryQueue != nil {
+		ch <- prometheus.MustNewConstMetric(c.QueueLength, prometheus.GaugeValue, float64(entryQueue.GetQueueItemNumber()))
+	}
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.scrapeTimeout)
+	defer cancel()
+
+	for _, stream := range exportedStreams() {
+		length, err := client.XLen(ctx, stream).Result()
+		if err != nil {
+			log.Error("Unable to get the length of stream %s: %v", stream, err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.StreamLength, prometheus.GaugeValue, float64(length), stream)
+		if length == 0 {
+			// the stream may not exist yet, and XINFO GROUPS fails for a missing key
+			continue
+		}
+
+		groups, err := client.XInfoGroups(ctx, stream).Result()
+		if err != nil {
+			log.Error("Unable to get the consumer groups of stream %s: %v", stream, err)
+			continue
+		}
+		for _, group := range groups {
+			ch <- prometheus.MustNewConstMetric(c.GroupPending, prometheus.GaugeValue, float64(group.Pending), stream, group.Name)
+			ch <- prometheus.MustNewConstMetric(c.GroupLag, prometheus.GaugeValue, float64(group.Lag), stream, group.Name)
+		}
+	}
+}
+
+// exportedStreams returns the distinct names of the streams which events are published to
+func exportedStreams() []string {
+	streams := make(container.Set[string])
+	var names []string
+	for _, event := range webhook_module.AllEvents() {
+		if !isEventExported(event) {
+			continue
+		}
+		if name := setting.EventExporterStreamName(string(event)); streams.Add(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -15,7 +15,7 @@ import (
 type PackageCleanupRuleForm struct {
 	ID            int64
 	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,vagrant)"`
+	Type          string `binding:"Required;In(alpine,ansible,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
 	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
 	KeepPattern   string `binding:"RegexPattern"`
 	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
@@ -28,3 +28,18 @@ func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+type PackageProxyForm struct {
+	ID          int64
+	Enabled     bool
+	Type        string `binding:"Required;In(maven,npm,pypi)"`
+	UpstreamURL string `binding:"Required;ValidUrl" locale:"packages.owner.settings.proxies.upstream_url"`
+	MetadataTTL int64  `binding:"Range(0,604800)" locale:"packages.owner.settings.proxies.metadata_ttl"`
+	Allowlist   string
+	Action      string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageProxyForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
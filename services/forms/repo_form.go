@@ -112,6 +112,7 @@ type RepoSettingForm struct {
 	// Advanced settings
 	EnableCode                bool
 	DefaultCodeEveryoneAccess string
+	CodeIndexerRefPatterns    string
 
 	EnableWiki                bool
 	EnableExternalWiki        bool
@@ -238,9 +239,21 @@ type WebhookForm struct {
 	Package                  bool
 	Status                   bool
 	WorkflowJob              bool
+	Organization             bool
+	Membership               bool
+	Team                     bool
+	Member                   bool
+	BranchProtectionRule     bool
+	Star                     bool
+	User                     bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
 	AuthorizationHeader      string
+	ClientCertificate        string
+	ClientKey                string
+	CACertificate            string `form:"ca_certificate"`
+	TLSServerName            string `form:"tls_server_name" binding:"MaxSize(255)"`
+	ProxyURL                 string `form:"proxy_url"`
 }
 
 // PushOnly if the hook will be triggered when push
@@ -414,6 +427,47 @@ func (f *NewPackagistHookForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// NewGoogleChatHookForm form for creating Google Chat hook
+type NewGoogleChatHookForm struct {
+	PayloadURL string `binding:"Required;ValidUrl"`
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewGoogleChatHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewNtfyHookForm form for creating ntfy hook
+type NewNtfyHookForm struct {
+	ServerURL string `binding:"Required;ValidUrl"`
+	Topic     string `binding:"Required"`
+	Priority  int
+	Tags      string
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewNtfyHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	if !webhook.IsValidNtfyTopic(strings.TrimSpace(f.Topic)) {
+		errs = append(errs, binding.Error{
+			FieldNames:     []string{"Topic"},
+			Classification: "",
+			Message:        ctx.Locale.TrString("repo.settings.ntfy.invalid_topic"),
+		})
+	}
+	if !webhook.IsValidNtfyPriority(f.Priority) {
+		errs = append(errs, binding.Error{
+			FieldNames:     []string{"Priority"},
+			Classification: "",
+			Message:        ctx.Locale.TrString("repo.settings.ntfy.invalid_priority"),
+		})
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // .___
 // |   | ______ ________ __   ____
 // |   |/  ___//  ___/  |  \_/ __ \
@@ -5,8 +5,12 @@ package indexer
 
 import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	package_indexer "code.gitea.io/gitea/modules/indexer/packages"
+	release_indexer "code.gitea.io/gitea/modules/indexer/releases"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -16,5 +20,9 @@ func Init() error {
 
 	issue_indexer.InitIssueIndexer(false)
 	code_indexer.Init()
+	commit_indexer.Init()
+	wiki_indexer.Init()
+	release_indexer.Init()
+	package_indexer.Init()
 	return stats_indexer.Init()
 }
@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "gitea_indexer_"
+
+// Collector implements the prometheus.Collector interface and
+// exposes the health and the rebuild progress of the indexers for prometheus
+type Collector struct {
+	Up            *prometheus.Desc
+	Rebuilding    *prometheus.Desc
+	RebuildDone   *prometheus.Desc
+	RebuildTotal  *prometheus.Desc
+	RebuildETA    *prometheus.Desc
+	IndexedRepos  *prometheus.Desc
+	TotalRepos    *prometheus.Desc
+	scrapeTimeout time.Duration
+}
+
+// NewCollector returns a new Collector with all prometheus.Desc initialized
+func NewCollector() Collector {
+	return Collector{
+		Up: prometheus.NewDesc(
+			namespace+"up",
+			"Whether an enabled indexer is available",
+			[]string{"indexer", "type"}, nil,
+		),
+		Rebuilding: prometheus.NewDesc(
+			namespace+"rebuilding",
+			"Whether an index is being rebuilt",
+			[]string{"indexer"}, nil,
+		),
+		RebuildDone: prometheus.NewDesc(
+			namespace+"rebuild_done_items",
+			"Number of items indexed by the running rebuild of an index",
+			[]string{"indexer"}, nil,
+		),
+		RebuildTotal: prometheus.NewDesc(
+			namespace+"rebuild_total_items",
+			"Number of items to index by the running rebuild of an index",
+			[]string{"indexer"}, nil,
+		),
+		RebuildETA: prometheus.NewDesc(
+			namespace+"rebuild_eta_seconds",
+			"Estimated number of seconds until the running rebuild of an index completes",
+			[]string{"indexer"}, nil,
+		),
+		IndexedRepos: prometheus.NewDesc(
+			namespace+"indexed_repositories",
+			"Number of repositories indexed by an indexer",
+			[]string{"indexer"}, nil,
+		),
+		TotalRepos: prometheus.NewDesc(
+			namespace+"repositories",
+			"Number of repositories which can be indexed by an indexer",
+			[]string{"indexer"}, nil,
+		),
+		scrapeTimeout: 5 * time.Second,
+	}
+}
+
+// Describe returns all possible prometheus.Desc
+func (c Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Up
+	ch <- c.Rebuilding
+	ch <- c.RebuildDone
+	ch <- c.RebuildTotal
+	ch <- c.RebuildETA
+	ch <- c.IndexedRepos
+	ch <- c.TotalRepos
+}
+
+// Collect returns the metrics with values
+func (c Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.scrapeTimeout)
+	defer cancel()
+
+	statuses, err := GetStatuses(ctx)
+	if err != nil {
+		log.Error("Unable to get the status of the indexers: %v", err)
+		return
+	}
+	for _, status := range statuses {
+		if !status.Enabled {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.Up, prometheus.GaugeValue, float64(util.Iif(status.Available, 1, 0)), status.Name, status.Type)
+		if status.CanRebuild {
+			ch <- prometheus.MustNewConstMetric(c.Rebuilding, prometheus.GaugeValue, float64(util.Iif(status.Rebuild != nil, 1, 0)), status.Name)
+		}
+		if status.Rebuild != nil {
+			ch <- prometheus.MustNewConstMetric(c.RebuildDone, prometheus.GaugeValue, float64(status.Rebuild.Done()), status.Name)
+			ch <- prometheus.MustNewConstMetric(c.RebuildTotal, prometheus.GaugeValue, float64(status.Rebuild.Total()), status.Name)
+			ch <- prometheus.MustNewConstMetric(c.RebuildETA, prometheus.GaugeValue, status.Rebuild.ETA().Seconds(), status.Name)
+		}
+		if status.IsRepoIndexer {
+			ch <- prometheus.MustNewConstMetric(c.IndexedRepos, prometheus.GaugeValue, float64(status.IndexedRepos), status.Name)
+			ch <- prometheus.MustNewConstMetric(c.TotalRepos, prometheus.GaugeValue, float64(status.TotalRepos), status.Name)
+		}
+	}
+}
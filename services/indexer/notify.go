@@ -7,11 +7,17 @@ import (
 	"context"
 
 	issues_model "code.gitea.io/gitea/models/issues"
+	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	package_indexer "code.gitea.io/gitea/modules/indexer/packages"
+	release_indexer "code.gitea.io/gitea/modules/indexer/releases"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
@@ -72,6 +78,15 @@ func (r *indexerNotifier) DeleteRepository(ctx context.Context, doer *user_model
 	if setting.Indexer.RepoIndexerEnabled {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.CommitIndexerEnabled {
+		commit_indexer.UpdateRepoIndexer(repo)
+	}
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
+	if setting.Indexer.ReleaseIndexerEnabled {
+		release_indexer.DeleteRepoReleaseIndexer(repo.ID)
+	}
 }
 
 func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
@@ -79,41 +94,77 @@ func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_m
 	if setting.Indexer.RepoIndexerEnabled && !repo.IsEmpty {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.CommitIndexerEnabled && !repo.IsEmpty {
+		commit_indexer.UpdateRepoIndexer(repo)
+	}
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
+	if setting.Indexer.ReleaseIndexerEnabled {
+		if err := release_indexer.UpdateRepoIndexer(ctx, repo.ID); err != nil {
+			log.Error("release_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
 }
 
 func (r *indexerNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if setting.Indexer.RepoIndexerEnabled && !opts.IsDelRef() && code_indexer.IsIndexedRef(ctx, repo, opts.RefFullName) {
+		code_indexer.UpdateRepoIndexer(repo)
+	}
 	if !opts.RefFullName.IsBranch() {
 		return
 	}
-
-	if setting.Indexer.RepoIndexerEnabled && opts.RefFullName.BranchName() == repo.DefaultBranch {
-		code_indexer.UpdateRepoIndexer(repo)
+	if setting.Indexer.CommitIndexerEnabled && !opts.IsDelRef() && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		commit_indexer.UpdateRepoIndexer(repo)
 	}
+
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
 }
 
 func (r *indexerNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if setting.Indexer.RepoIndexerEnabled && !opts.IsDelRef() && code_indexer.IsIndexedRef(ctx, repo, opts.RefFullName) {
+		code_indexer.UpdateRepoIndexer(repo)
+	}
 	if !opts.RefFullName.IsBranch() {
 		return
 	}
-
-	if setting.Indexer.RepoIndexerEnabled && opts.RefFullName.BranchName() == repo.DefaultBranch {
-		code_indexer.UpdateRepoIndexer(repo)
+	if setting.Indexer.CommitIndexerEnabled && !opts.IsDelRef() && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		commit_indexer.UpdateRepoIndexer(repo)
 	}
+
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
 }
 
+func (r *indexerNotifier) DeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
+	if setting.Indexer.RepoIndexerEnabled && code_indexer.IsIndexedRef(ctx, repo, refFullName) {
+		code_indexer.UpdateRepoIndexer(repo)
+	}
+	// the releases of the deleted tags become drafts
+	if setting.Indexer.ReleaseIndexerEnabled && refFullName.IsTag() {
+		if err := release_indexer.UpdateRepoIndexer(ctx, repo.ID); err != nil {
+			log.Error("release_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
+}
+
+func (r *indexerNotifier) SyncDeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
+	r.DeleteRef(ctx, doer, repo, refFullName)
+}
+
 func (r *indexerNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	if setting.Indexer.RepoIndexerEnabled && !repo.IsEmpty {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.CommitIndexerEnabled && !repo.IsEmpty {
+		commit_indexer.UpdateRepoIndexer(repo)
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
@@ -168,3 +219,33 @@ func (r *indexerNotifier) AutoMergePullRequest(ctx context.Context, doer *user_m
 	}
 	issue_indexer.UpdateIssueIndexer(ctx, pr.Issue.ID)
 }
+
+func (r *indexerNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
+	if setting.Indexer.ReleaseIndexerEnabled {
+		release_indexer.UpdateReleaseIndexer(rel.ID)
+	}
+}
+
+func (r *indexerNotifier) UpdateRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	if setting.Indexer.ReleaseIndexerEnabled {
+		release_indexer.UpdateReleaseIndexer(rel.ID)
+	}
+}
+
+func (r *indexerNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	if setting.Indexer.ReleaseIndexerEnabled {
+		release_indexer.UpdateReleaseIndexer(rel.ID)
+	}
+}
+
+func (r *indexerNotifier) PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	if setting.Indexer.PackageIndexerEnabled {
+		package_indexer.UpdatePackageIndexer(pd.Package.ID)
+	}
+}
+
+func (r *indexerNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	if setting.Indexer.PackageIndexerEnabled {
+		package_indexer.UpdatePackageIndexer(pd.Package.ID)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"context"
+	"errors"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	commit_indexer "code.gitea.io/gitea/modules/indexer/commits"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	package_indexer "code.gitea.io/gitea/modules/indexer/packages"
+	release_indexer "code.gitea.io/gitea/modules/indexer/releases"
+	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// The names of the indexers
+const (
+	IssueIndexer   = "issues"
+	CodeIndexer    = "code"
+	CommitIndexer  = "commits"
+	WikiIndexer    = "wiki"
+	ReleaseIndexer = "releases"
+	PackageIndexer = "packages"
+	StatsIndexer   = "stats"
+)
+
+// Status is the health of an indexer
+type Status struct {
+	Name      string
+	Type      string
+	Enabled   bool
+	Available bool
+	// CanRebuild is true if the whole index can be rebuilt by Rebuild
+	CanRebuild bool
+	// CanReindexRepo is true if the contents of a repository can be reindexed by ReindexRepo
+	CanReindexRepo bool
+	// Rebuild is the progress of rebuilding the index, it's nil if the index isn't being rebuilt
+	Rebuild *indexer.Progress
+	// IsRepoIndexer is true if the indexer indexes the contents of the repositories one by one,
+	// then IndexedRepos of TotalRepos repositories have been indexed
+	IsRepoIndexer bool
+	IndexedRepos  int64
+	TotalRepos    int64
+}
+
+// indexerDefinition defines how to get the status of an indexer and how to reindex a repository with it,
+// updateRepo is nil if the indexer doesn't index the contents of repositories
+type indexerDefinition struct {
+	name        string
+	typ         func() string
+	enabled     func() bool
+	isAvailable func(ctx context.Context) bool
+	// repoIndexerTypes are the statuses of the repository contents indexed by the indexer,
+	// the first one is used to count the indexed repositories
+	repoIndexerTypes []repo_model.RepoIndexerType
+	updateRepo       func(ctx context.Context, repo *repo_model.Repository) error
+	rebuild          func(ctx context.Context) error
+	rebuildProgress  func() *indexer.Progress
+	// canRebuild reports whether the backend in use can be rebuilt by rebuild, it's nil if all of them can
+	canRebuild func() bool
+}
+
+func (def *indexerDefinition) isRebuildable() bool {
+	return def.rebuild != nil && (def.canRebuild == nil || def.canRebuild())
+}
+
+var indexerDefinitions = []*indexerDefinition{
+	{
+		name:        IssueIndexer,
+		typ:         func() string { return setting.Indexer.IssueType },
+		enabled:     func() bool { return true },
+		isAvailable: issue_indexer.IsAvailable,
+		updateRepo: func(ctx context.Context, repo *repo_model.Repository) error {
+			issue_indexer.UpdateRepoIndexer(ctx, repo.ID)
+			return nil
+		},
+		rebuild:         issue_indexer.RebuildIssueIndexer,
+		rebuildProgress: issue_indexer.RebuildProgress,
+	},
+	{
+		name:             CodeIndexer,
+		typ:              func() string { return setting.Indexer.RepoType },
+		enabled:          func() bool { return setting.Indexer.RepoIndexerEnabled },
+		isAvailable:      code_indexer.IsAvailable,
+		repoIndexerTypes: []repo_model.RepoIndexerType{repo_model.RepoIndexerTypeCode, repo_model.RepoIndexerTypeSymbol},
+		updateRepo: func(ctx context.Context, repo *repo_model.Repository) error {
+			// the indexed branches and tags are reindexed too
+			if err := repo_model.DeleteIndexerRefs(ctx, repo.ID); err != nil {
+				return err
+			}
+			code_indexer.UpdateRepoIndexer(repo)
+			return nil
+		},
+		rebuild:         code_indexer.RebuildCodeIndexer,
+		rebuildProgress: code_indexer.RebuildProgress,
+		canRebuild:      code_indexer.CanRebuild,
+	},
+	{
+		name:             CommitIndexer,
+		typ:              func() string { return setting.Indexer.CommitType },
+		enabled:          func() bool { return setting.Indexer.CommitIndexerEnabled },
+		isAvailable:      commit_indexer.IsAvailable,
+		repoIndexerTypes: []repo_model.RepoIndexerType{repo_model.RepoIndexerTypeCommit},
+		updateRepo: func(_ context.Context, repo *repo_model.Repository) error {
+			commit_indexer.UpdateRepoIndexer(repo)
+			return nil
+		},
+	},
+	{
+		name:             WikiIndexer,
+		typ:              func() string { return setting.Indexer.WikiType },
+		enabled:          func() bool { return setting.Indexer.WikiIndexerEnabled },
+		isAvailable:      wiki_indexer.IsAvailable,
+		repoIndexerTypes: []repo_model.RepoIndexerType{repo_model.RepoIndexerTypeWiki},
+		updateRepo: func(_ context.Context, repo *repo_model.Repository) error {
+			wiki_indexer.UpdateRepoIndexer(repo)
+			return nil
+		},
+	},
+	{
+		name:        ReleaseIndexer,
+		typ:         func() string { return setting.Indexer.ReleaseType },
+		enabled:     func() bool { return setting.Indexer.ReleaseIndexerEnabled },
+		isAvailable: release_indexer.IsAvailable,
+		updateRepo: func(ctx context.Context, repo *repo_model.Repository) error {
+			return release_indexer.UpdateRepoIndexer(ctx, repo.ID)
+		},
+	},
+	{
+		name:        PackageIndexer,
+		typ:         func() string { return setting.Indexer.PackageType },
+		enabled:     func() bool { return setting.Indexer.PackageIndexerEnabled },
+		isAvailable: package_indexer.IsAvailable,
+	},
+	{
+		name:             StatsIndexer,
+		typ:              func() string { return "db" },
+		enabled:          func() bool { return true },
+		isAvailable:      func(context.Context) bool { return true },
+		repoIndexerTypes: []repo_model.RepoIndexerType{repo_model.RepoIndexerTypeStats},
+		updateRepo: func(_ context.Context, repo *repo_model.Repository) error {
+			return stats_indexer.UpdateRepoIndexer(repo)
+		},
+	},
+}
+
+func getIndexerDefinition(name string) (*indexerDefinition, error) {
+	for _, def := range indexerDefinitions {
+		if def.name == name {
+			return def, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("indexer %q does not exist", name)
+}
+
+// GetStatuses returns the health of all the indexers
+func GetStatuses(ctx context.Context) ([]*Status, error) {
+	statuses := make([]*Status, 0, len(indexerDefinitions))
+	for _, def := range indexerDefinitions {
+		status := &Status{
+			Name:           def.name,
+			Type:           def.typ(),
+			Enabled:        def.enabled(),
+			CanRebuild:     def.isRebuildable(),
+			CanReindexRepo: def.updateRepo != nil,
+			IsRepoIndexer:  len(def.repoIndexerTypes) > 0,
+		}
+		if status.Enabled {
+			status.Available = def.isAvailable(ctx)
+		}
+		if def.rebuildProgress != nil {
+			status.Rebuild = def.rebuildProgress()
+		}
+		if status.IsRepoIndexer && status.Enabled {
+			var err error
+			status.IndexedRepos, status.TotalRepos, err = repo_model.CountIndexedRepos(ctx, def.repoIndexerTypes[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Rebuild starts rebuilding the whole index of the indexer in the background
+func Rebuild(name string) error {
+	def, err := getIndexerDefinition(name)
+	if err != nil {
+		return err
+	}
+	if !def.isRebuildable() {
+		return util.NewInvalidArgumentErrorf("indexer %q can't be rebuilt", name)
+	}
+	if def.rebuildProgress() != nil {
+		return util.NewAlreadyExistErrorf("indexer %q is already being rebuilt", name)
+	}
+
+	go graceful.GetManager().RunWithShutdownContext(func(ctx context.Context) {
+		ctx, _, finished := process.GetManager().AddTypedContext(ctx, "Service: Rebuild indexer "+name, process.SystemProcessType, true)
+		defer finished()
+		if err := def.rebuild(ctx); err != nil && !errors.Is(err, issue_indexer.ErrRebuildInProgress) && !errors.Is(err, code_indexer.ErrRebuildInProgress) {
+			log.Error("Rebuilding indexer %s failed: %v", name, err)
+		}
+	})
+	return nil
+}
+
+// ReindexRepo reindexes all the contents of the repository indexed by the indexer from scratch
+func ReindexRepo(ctx context.Context, name string, repo *repo_model.Repository) error {
+	def, err := getIndexerDefinition(name)
+	if err != nil {
+		return err
+	}
+	if !def.enabled() {
+		return util.NewInvalidArgumentErrorf("indexer %q is not enabled", name)
+	}
+	if def.updateRepo == nil {
+		return util.NewInvalidArgumentErrorf("indexer %q doesn't index repositories", name)
+	}
+
+	for _, indexerType := range def.repoIndexerTypes {
+		if err := repo_model.DeleteIndexerStatus(ctx, repo, indexerType); err != nil {
+			return err
+		}
+	}
+	return def.updateRepo(ctx, repo)
+}
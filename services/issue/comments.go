@@ -62,15 +62,21 @@ func CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_m
 		}
 	}
 
-	comment, err := issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
-		Type:        issues_model.CommentTypeComment,
-		Doer:        doer,
-		Repo:        repo,
-		Issue:       issue,
-		Content:     content,
-		Attachments: attachments,
-	})
-	if err != nil {
+	var comment *issues_model.Comment
+	if err := db.WithTx(ctx, func(ctx context.Context) (err error) {
+		comment, err = issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
+			Type:        issues_model.CommentTypeComment,
+			Doer:        doer,
+			Repo:        repo,
+			Issue:       issue,
+			Content:     content,
+			Attachments: attachments,
+		})
+		if err != nil {
+			return err
+		}
+		return notify_service.CreateIssueCommentInTx(ctx, doer, repo, issue, comment)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -46,7 +46,7 @@ func NewIssue(ctx context.Context, repo *repo_model.Repository, issue *issues_mo
 				return err
 			}
 		}
-		return nil
+		return notify_service.NewIssueInTx(ctx, issue)
 	}); err != nil {
 		return err
 	}
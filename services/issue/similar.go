@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+)
+
+// DefaultSimilarIssuesLimit is the number of similar issues suggested when creating an issue
+const DefaultSimilarIssuesLimit = 5
+
+// FindSimilarIssues returns the open and closed issues of the repository which are similar to the title and content
+// of a new issue, the most similar ones first, so the possible duplicates can be found before it's created
+func FindSimilarIssues(ctx context.Context, repo *repo_model.Repository, title, content string, limit int) (issues_model.IssueList, error) {
+	matches, err := issue_indexer.SearchSimilarIssues(ctx, &issue_indexer.SearchOptions{
+		SimilarTitle:   title,
+		SimilarContent: content,
+		RepoIDs:        []int64{repo.ID},
+		IsPull:         optional.Some(false),
+		Paginator:      &db.ListOptions{Page: 1, PageSize: limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		issue.Repo = repo
+	}
+	return issues, nil
+}
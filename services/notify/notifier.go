@@ -9,7 +9,9 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -74,9 +76,30 @@ type Notifier interface {
 
 	PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
 	PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
+	PackageAccessUpdate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
 
 	ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository)
 
+	AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User)
+	RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User)
+	AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User)
+	RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User)
+	AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository)
+	RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository)
+
+	AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode)
+	ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode)
+	RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User)
+
+	CreateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch)
+	UpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch)
+	DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch)
+
+	StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool)
+
+	CreateUser(ctx context.Context, doer, u *user_model.User)
+	SuspendUser(ctx context.Context, doer, u *user_model.User, suspended bool)
+
 	CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus)
 
 	WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob, task *actions_model.ActionTask)
@@ -9,7 +9,9 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -363,6 +365,13 @@ func PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_mode
 	}
 }
 
+// PackageAccessUpdate notifies a change of the visibility or the access grants of a package to notifiers
+func PackageAccessUpdate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	for _, notifier := range notifiers {
+		notifier.PackageAccessUpdate(ctx, doer, pd)
+	}
+}
+
 // ChangeDefaultBranch notifies change default branch to notifiers
 func ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
@@ -370,6 +379,111 @@ func ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	}
 }
 
+// AddOrgMember notifies adding a member to an organization to notifiers
+func AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.AddOrgMember(ctx, doer, org, member)
+	}
+}
+
+// RemoveOrgMember notifies removing a member from an organization to notifiers
+func RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.RemoveOrgMember(ctx, doer, org, member)
+	}
+}
+
+// AddTeamMember notifies adding a member to a team to notifiers
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.AddTeamMember(ctx, doer, team, member)
+	}
+}
+
+// RemoveTeamMember notifies removing a member from a team to notifiers
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.RemoveTeamMember(ctx, doer, team, member)
+	}
+}
+
+// AddTeamRepository notifies granting a team access to a repository to notifiers
+func AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	for _, notifier := range notifiers {
+		notifier.AddTeamRepository(ctx, doer, team, repo)
+	}
+}
+
+// RemoveTeamRepository notifies revoking the access of a team to a repository to notifiers
+func RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	for _, notifier := range notifiers {
+		notifier.RemoveTeamRepository(ctx, doer, team, repo)
+	}
+}
+
+// AddCollaborator notifies adding a collaborator to a repository to notifiers
+func AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	for _, notifier := range notifiers {
+		notifier.AddCollaborator(ctx, doer, repo, collaborator, mode)
+	}
+}
+
+// ChangeCollaboratorAccessMode notifies changing the access mode of a collaborator to notifiers
+func ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	for _, notifier := range notifiers {
+		notifier.ChangeCollaboratorAccessMode(ctx, doer, repo, collaborator, mode)
+	}
+}
+
+// RemoveCollaborator notifies removing a collaborator from a repository to notifiers
+func RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.RemoveCollaborator(ctx, doer, repo, collaborator)
+	}
+}
+
+// CreateProtectedBranch notifies creating a branch protection rule to notifiers
+func CreateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	for _, notifier := range notifiers {
+		notifier.CreateProtectedBranch(ctx, doer, repo, rule)
+	}
+}
+
+// UpdateProtectedBranch notifies updating a branch protection rule to notifiers
+func UpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	for _, notifier := range notifiers {
+		notifier.UpdateProtectedBranch(ctx, doer, repo, rule)
+	}
+}
+
+// DeleteProtectedBranch notifies deleting a branch protection rule to notifiers
+func DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	for _, notifier := range notifiers {
+		notifier.DeleteProtectedBranch(ctx, doer, repo, rule)
+	}
+}
+
+// StarRepository notifies starring or unstarring a repository to notifiers
+func StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) {
+	for _, notifier := range notifiers {
+		notifier.StarRepository(ctx, doer, repo, star)
+	}
+}
+
+// CreateUser notifies creating a user account to notifiers
+func CreateUser(ctx context.Context, doer, u *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.CreateUser(ctx, doer, u)
+	}
+}
+
+// SuspendUser notifies prohibiting or allowing a user account to sign in to notifiers
+func SuspendUser(ctx context.Context, doer, u *user_model.User, suspended bool) {
+	for _, notifier := range notifiers {
+		notifier.SuspendUser(ctx, doer, u, suspended)
+	}
+}
+
 func CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
 	for _, notifier := range notifiers {
 		notifier.CreateCommitStatus(ctx, repo, commit, sender, status)
@@ -9,7 +9,9 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -207,10 +209,74 @@ func (*NullNotifier) PackageCreate(ctx context.Context, doer *user_model.User, p
 func (*NullNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
 }
 
+// PackageAccessUpdate places a place holder function
+func (*NullNotifier) PackageAccessUpdate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+}
+
 // ChangeDefaultBranch places a place holder function
 func (*NullNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 }
 
+// AddOrgMember places a place holder function
+func (*NullNotifier) AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+}
+
+// RemoveOrgMember places a place holder function
+func (*NullNotifier) RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+}
+
+// AddTeamMember places a place holder function
+func (*NullNotifier) AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+}
+
+// RemoveTeamMember places a place holder function
+func (*NullNotifier) RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+}
+
+// AddTeamRepository places a place holder function
+func (*NullNotifier) AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+}
+
+// RemoveTeamRepository places a place holder function
+func (*NullNotifier) RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+}
+
+// AddCollaborator places a place holder function
+func (*NullNotifier) AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+}
+
+// ChangeCollaboratorAccessMode places a place holder function
+func (*NullNotifier) ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+}
+
+// RemoveCollaborator places a place holder function
+func (*NullNotifier) RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+}
+
+// CreateProtectedBranch places a place holder function
+func (*NullNotifier) CreateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+}
+
+// UpdateProtectedBranch places a place holder function
+func (*NullNotifier) UpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+}
+
+// DeleteProtectedBranch places a place holder function
+func (*NullNotifier) DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+}
+
+// StarRepository places a place holder function
+func (*NullNotifier) StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) {
+}
+
+// CreateUser places a place holder function
+func (*NullNotifier) CreateUser(ctx context.Context, doer, u *user_model.User) {
+}
+
+// SuspendUser places a place holder function
+func (*NullNotifier) SuspendUser(ctx context.Context, doer, u *user_model.User, suspended bool) {
+}
+
 func (*NullNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
 }
 
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package notify
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// TxNotifier is notified of some events inside the database transaction of the change,
+// returning an error rolls the change back.
+type TxNotifier interface {
+	Notifier
+
+	NewIssueInTx(ctx context.Context, issue *issues_model.Issue) error
+	CreateIssueCommentInTx(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment) error
+}
+
+var txNotifiers []TxNotifier
+
+// RegisterTxNotifier registers a notifier which also receives NewIssue and CreateIssueComment inside
+// the database transaction of the change, before the usual notification once it has been committed.
+func RegisterTxNotifier(notifier TxNotifier) {
+	txNotifiers = append(txNotifiers, notifier)
+	RegisterNotifier(notifier)
+}
+
+// NewIssueInTx notifies new issue to the transactional notifiers, it must be called in the transaction creating the issue
+func NewIssueInTx(ctx context.Context, issue *issues_model.Issue) error {
+	for _, notifier := range txNotifiers {
+		if err := notifier.NewIssueInTx(ctx, issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateIssueCommentInTx notifies issue comment to the transactional notifiers, it must be called in the transaction creating the comment
+func CreateIssueCommentInTx(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment) error {
+	for _, notifier := range txNotifiers {
+		if err := notifier.CreateIssueCommentInTx(ctx, doer, repo, issue, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -12,12 +12,14 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
 	repo_service "code.gitea.io/gitea/services/repository"
 
 	"xorm.io/builder"
@@ -204,6 +206,7 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 		&organization.TeamUser{OrgID: t.OrgID, TeamID: t.ID},
 		&organization.TeamUnit{TeamID: t.ID},
 		&organization.TeamInvite{TeamID: t.ID},
+		&packages_model.PackageAccess{TeamID: t.ID},
 		&issues_model.Review{Type: issues_model.ReviewTypeRequest, ReviewerTeamID: t.ID}, // batch delete the binding relationship between team and PR (request review from team)
 	); err != nil {
 		return err
@@ -225,7 +228,7 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 
 // AddTeamMember adds new membership of given team to given organization,
 // the user will have membership to given organization automatically when needed.
-func AddTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
 	if user_model.IsUserBlockedBy(ctx, user, team.OrgID) {
 		return user_model.ErrBlockedUser
 	}
@@ -235,6 +238,11 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		return err
 	}
 
+	isOrgMember, err := organization.IsOrganizationMember(ctx, team.OrgID, user.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := organization.AddOrgUser(ctx, team.OrgID, user.ID); err != nil {
 		return err
 	}
@@ -296,6 +304,15 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		return err
 	}
 
+	if !isOrgMember {
+		org, err := organization.GetOrgByID(ctx, team.OrgID)
+		if err != nil {
+			return err
+		}
+		notify_service.AddOrgMember(ctx, doer, org, user)
+	}
+	notify_service.AddTeamMember(ctx, doer, team, user)
+
 	// this behaviour may spend much time so run it in a goroutine
 	// FIXME: Update watch repos batchly
 	if setting.Service.AutoWatchNewRepos {
@@ -387,20 +404,35 @@ func removeInvalidOrgUser(ctx context.Context, orgID int64, user *user_model.Use
 			return err
 		}
 
-		return RemoveOrgUser(ctx, org, user)
+		return removeOrgUser(ctx, org, user)
 	}
 	return nil
 }
 
 // RemoveTeamMember removes member from given team of given organization.
-func RemoveTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
-	ctx, committer, err := db.TxContext(ctx)
-	if err != nil {
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
+	isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
+	if err != nil || !isMember {
 		return err
 	}
-	defer committer.Close()
-	if err := removeTeamMember(ctx, team, user); err != nil {
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		return removeTeamMember(ctx, team, user)
+	}); err != nil {
 		return err
 	}
-	return committer.Commit()
+
+	notify_service.RemoveTeamMember(ctx, doer, team, user)
+
+	// the user is removed from the organization together with the last team
+	isOrgMember, err := organization.IsOrganizationMember(ctx, team.OrgID, user.ID)
+	if err != nil || isOrgMember {
+		return err
+	}
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		return err
+	}
+	notify_service.RemoveOrgMember(ctx, doer, org, user)
+	return nil
 }
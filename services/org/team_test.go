@@ -25,7 +25,7 @@ func TestTeam_AddMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	test := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, AddTeamMember(db.DefaultContext, team, user))
+		assert.NoError(t, AddTeamMember(db.DefaultContext, user, team, user))
 		unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID}, &user_model.User{ID: team.OrgID})
 	}
@@ -44,7 +44,7 @@ func TestTeam_RemoveMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	testSuccess := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, RemoveTeamMember(db.DefaultContext, team, user))
+		assert.NoError(t, RemoveTeamMember(db.DefaultContext, user, team, user))
 		unittest.AssertNotExistsBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID})
 	}
@@ -59,7 +59,7 @@ func TestTeam_RemoveMember(t *testing.T) {
 	testSuccess(team2, user2)
 	testSuccess(team3, user2)
 
-	err := RemoveTeamMember(db.DefaultContext, team1, user2)
+	err := RemoveTeamMember(db.DefaultContext, user2, team1, user2)
 	assert.True(t, organization.IsErrLastOrgOwner(err))
 }
 
@@ -128,7 +128,7 @@ func TestAddTeamMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	test := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, AddTeamMember(db.DefaultContext, team, user))
+		assert.NoError(t, AddTeamMember(db.DefaultContext, user, team, user))
 		unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID}, &user_model.User{ID: team.OrgID})
 	}
@@ -147,7 +147,7 @@ func TestRemoveTeamMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	testSuccess := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, RemoveTeamMember(db.DefaultContext, team, user))
+		assert.NoError(t, RemoveTeamMember(db.DefaultContext, user, team, user))
 		unittest.AssertNotExistsBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID})
 	}
@@ -162,7 +162,7 @@ func TestRemoveTeamMember(t *testing.T) {
 	testSuccess(team2, user2)
 	testSuccess(team3, user2)
 
-	err := RemoveTeamMember(db.DefaultContext, team1, user2)
+	err := RemoveTeamMember(db.DefaultContext, user2, team1, user2)
 	assert.True(t, organization.IsErrLastOrgOwner(err))
 }
 
@@ -177,7 +177,7 @@ func TestRepository_RecalculateAccesses3(t *testing.T) {
 
 	// adding user29 to team5 should add an explicit access row for repo 23
 	// even though repo 23 is public
-	assert.NoError(t, AddTeamMember(db.DefaultContext, team5, user29))
+	assert.NoError(t, AddTeamMember(db.DefaultContext, user29, team5, user29))
 
 	has, err = db.GetEngine(db.DefaultContext).Get(&access_model.Access{UserID: user29.ID, RepoID: 23})
 	assert.NoError(t, err)
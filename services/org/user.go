@@ -13,10 +13,25 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	notify_service "code.gitea.io/gitea/services/notify"
 )
 
 // RemoveOrgUser removes user from given organization.
-func RemoveOrgUser(ctx context.Context, org *organization.Organization, user *user_model.User) error {
+func RemoveOrgUser(ctx context.Context, doer *user_model.User, org *organization.Organization, user *user_model.User) error {
+	isMember, err := organization.IsOrganizationMember(ctx, org.ID, user.ID)
+	if err != nil || !isMember {
+		return err
+	}
+
+	if err := removeOrgUser(ctx, org, user); err != nil {
+		return err
+	}
+
+	notify_service.RemoveOrgMember(ctx, doer, org, user)
+	return nil
+}
+
+func removeOrgUser(ctx context.Context, org *organization.Organization, user *user_model.User) error {
 	ou := new(organization.OrgUser)
 
 	has, err := db.GetEngine(ctx).
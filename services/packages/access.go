@@ -0,0 +1,177 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+var (
+	ErrPackageAccessDenied = util.NewPermissionDeniedErrorf("insufficient permissions for the package")
+	// ErrSharedRepositoryIndex is returned if the access to a package with a shared repository index should be restricted
+	ErrSharedRepositoryIndex = util.NewInvalidArgumentErrorf("the repository index is shared by all packages of the owner, the access follows the owner")
+)
+
+// CheckPackageAccess checks the access mode of the access scope of the context for the package.
+// Without an access scope (for example in cron tasks) every access is allowed.
+func CheckPackageAccess(ctx context.Context, p *packages_model.Package, mode perm.AccessMode) error {
+	scope := packages_model.GetAccessScope(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	accessMode, err := scope.AccessMode(ctx, p)
+	if err != nil {
+		return err
+	}
+	if accessMode < mode {
+		return ErrPackageAccessDenied
+	}
+	return nil
+}
+
+// WithOwnerRepositoryScope returns a context to build the repository files of an owner with,
+// the repository files cover all packages of the owner regardless of the access scope of the doer
+func WithOwnerRepositoryScope(ctx context.Context) context.Context {
+	return packages_model.WithAccessScope(ctx, nil)
+}
+
+// checkPackageVersionAccess checks the access mode of the access scope of the context for the package of the version
+func checkPackageVersionAccess(ctx context.Context, packageVersionID int64, mode perm.AccessMode) error {
+	if packages_model.GetAccessScope(ctx) == nil {
+		return nil
+	}
+
+	pv, err := packages_model.GetVersionByID(ctx, packageVersionID)
+	if err != nil {
+		return err
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+	return CheckPackageAccess(ctx, p, mode)
+}
+
+// SetPackageVisibility changes the visibility of a package
+func SetPackageVisibility(ctx context.Context, doer *user_model.User, p *packages_model.Package, visibility packages_model.Visibility) error {
+	if p.Visibility == visibility {
+		return nil
+	}
+	if visibility != packages_model.VisibilityInherit && p.Type.HasSharedRepositoryIndex() {
+		return ErrSharedRepositoryIndex
+	}
+
+	if err := packages_model.SetVisibility(ctx, p.ID, visibility); err != nil {
+		return err
+	}
+	p.Visibility = visibility
+
+	notifyPackageAccessUpdate(ctx, doer, p)
+
+	return nil
+}
+
+// GrantTeamAccess grants a team of the owner organization access to a package
+func GrantTeamAccess(ctx context.Context, doer *user_model.User, p *packages_model.Package, team *organization.Team, mode perm.AccessMode) error {
+	if team.OrgID != p.OwnerID {
+		return util.NewInvalidArgumentErrorf("team does not belong to the package owner")
+	}
+	return grantAccess(ctx, doer, p, &packages_model.PackageAccess{PackageID: p.ID, TeamID: team.ID, AccessMode: mode})
+}
+
+// GrantUserAccess grants a user access to a package
+func GrantUserAccess(ctx context.Context, doer *user_model.User, p *packages_model.Package, u *user_model.User, mode perm.AccessMode) error {
+	if u.IsOrganization() || u.ID == p.OwnerID {
+		return util.NewInvalidArgumentErrorf("access can only be granted to users other than the package owner")
+	}
+	return grantAccess(ctx, doer, p, &packages_model.PackageAccess{PackageID: p.ID, UserID: u.ID, AccessMode: mode})
+}
+
+func grantAccess(ctx context.Context, doer *user_model.User, p *packages_model.Package, pa *packages_model.PackageAccess) error {
+	if pa.AccessMode != perm.AccessModeRead && pa.AccessMode != perm.AccessModeWrite {
+		return util.NewInvalidArgumentErrorf("access mode must be read or write")
+	}
+	if p.Type.HasSharedRepositoryIndex() {
+		return ErrSharedRepositoryIndex
+	}
+
+	if err := packages_model.SetAccess(ctx, pa); err != nil {
+		return err
+	}
+
+	notifyPackageAccessUpdate(ctx, doer, p)
+
+	return nil
+}
+
+// RevokeTeamAccess removes the access grant of a team
+func RevokeTeamAccess(ctx context.Context, doer *user_model.User, p *packages_model.Package, team *organization.Team) error {
+	return revokeAccess(ctx, doer, p, team.ID, 0)
+}
+
+// RevokeUserAccess removes the access grant of a user
+func RevokeUserAccess(ctx context.Context, doer *user_model.User, p *packages_model.Package, u *user_model.User) error {
+	return revokeAccess(ctx, doer, p, 0, u.ID)
+}
+
+func revokeAccess(ctx context.Context, doer *user_model.User, p *packages_model.Package, teamID, userID int64) error {
+	deleted, err := packages_model.DeleteAccess(ctx, p.ID, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return util.NewNotExistErrorf("access grant does not exist")
+	}
+
+	notifyPackageAccessUpdate(ctx, doer, p)
+
+	return nil
+}
+
+// notifyPackageAccessUpdate notifies the access change with the latest version of the package
+func notifyPackageAccessUpdate(ctx context.Context, doer *user_model.User, p *packages_model.Package) {
+	// the lookup must not be restricted by the access scope of the doer
+	ctx = packages_model.WithAccessScope(ctx, nil)
+
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		PackageID:  p.ID,
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		log.Error("Error getting latest package version: %v", err)
+		return
+	}
+	if len(pvs) == 0 {
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pvs[0])
+	if err != nil {
+		log.Error("Error getting package descriptor: %v", err)
+		return
+	}
+
+	log.Info("Package access of %s/%s/%s updated by %s: visibility %s", pd.Owner.Name, p.Type, p.Name, doer.Name, p.Visibility)
+
+	notify_service.PackageAccessUpdate(ctx, doer, pd)
+}
+
+// ParseGrantAccessMode returns the access mode of a grant permission name
+func ParseGrantAccessMode(permission string) (perm.AccessMode, error) {
+	mode := perm.ParseAccessMode(permission, perm.AccessModeRead, perm.AccessModeWrite)
+	if mode == perm.AccessModeNone {
+		return mode, util.NewInvalidArgumentErrorf("invalid permission %q", permission)
+	}
+	return mode, nil
+}
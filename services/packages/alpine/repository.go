@@ -74,6 +74,8 @@ func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, err
 
 // BuildAllRepositoryFiles (re)builds all repository files for every available branches, repositories and architectures
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -119,6 +121,8 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 
 // BuildSpecificRepositoryFiles builds index files for the repository
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, branch, repository, architecture string) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -131,6 +131,8 @@ func SignData(ctx context.Context, ownerID int64, r io.Reader) ([]byte, error) {
 
 // BuildAllRepositoryFiles (re)builds all repository files for every available repositories and architectures
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -170,6 +172,8 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 
 // BuildSpecificRepositoryFiles builds index files for the repository
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, repository, architecture string) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -22,11 +22,12 @@ type packageClaims struct {
 	PackageMeta
 }
 type PackageMeta struct {
-	UserID int64
-	Scope  auth_model.AccessTokenScope
+	UserID        int64
+	Scope         auth_model.AccessTokenScope
+	ActionsTaskID int64 `json:",omitempty"`
 }
 
-func CreateAuthorizationToken(u *user_model.User, packageScope auth_model.AccessTokenScope) (string, error) {
+func CreateAuthorizationToken(u *user_model.User, packageScope auth_model.AccessTokenScope, actionsTaskID int64) (string, error) {
 	now := time.Now()
 
 	claims := packageClaims{
@@ -35,8 +36,9 @@ func CreateAuthorizationToken(u *user_model.User, packageScope auth_model.Access
 			NotBefore: jwt.NewNumericDate(now),
 		},
 		PackageMeta: PackageMeta{
-			UserID: u.ID,
-			Scope:  packageScope,
+			UserID:        u.ID,
+			Scope:         packageScope,
+			ActionsTaskID: actionsTaskID,
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
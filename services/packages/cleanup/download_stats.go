@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// CleanupDownloadStats rolls up the daily download statistics older than rollupAfter into monthly statistics
+// and deletes the statistics older than retention. A zero duration disables the step.
+func CleanupDownloadStats(ctx context.Context, rollupAfter, retention time.Duration) error {
+	now := time.Now()
+
+	if rollupAfter > 0 {
+		n, err := packages_model.RollupDownloadStats(ctx, now.Add(-rollupAfter))
+		if err != nil {
+			return err
+		}
+		log.Debug("Rolled up %d daily package download statistics", n)
+	}
+
+	if retention > 0 {
+		n, err := packages_model.DeleteDownloadStatsBefore(ctx, now.Add(-retention))
+		if err != nil {
+			return err
+		}
+		log.Debug("Deleted %d expired package download statistics", n)
+	}
+
+	n, err := packages_model.DeleteOrphanedDownloadStats(ctx)
+	if err != nil {
+		return err
+	}
+	log.Debug("Deleted %d package download statistics of removed versions", n)
+
+	return nil
+}
@@ -5,6 +5,7 @@ package container
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -104,5 +105,25 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 		}
 	}
 
+	// Keep signatures, SBOMs and attestations as long as the manifest they refer to exists
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject)
+	if err != nil {
+		return false, err
+	}
+	for _, pp := range pps {
+		_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    p.OwnerID,
+			Image:      p.LowerName,
+			Digest:     pp.Value,
+			IsManifest: true,
+		})
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return false, err
+		}
+	}
+
 	return false, nil
 }
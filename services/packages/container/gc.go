@@ -0,0 +1,255 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// GarbageCollectOptions provides options for GarbageCollect function
+type GarbageCollectOptions struct {
+	LogDetail func(format string, v ...any)
+	// AutoFix removes the unreachable manifests and blobs. Otherwise only a report is created.
+	AutoFix bool
+	// OwnerID limits the collection to the images of an owner
+	OwnerID int64
+	// OlderThan protects manifests created later because a client may still push the manifests referring to them
+	OlderThan time.Time
+}
+
+// OwnerStorageReport contains the storage usage of the images of an owner
+type OwnerStorageReport struct {
+	OwnerID int64
+	// Blobs and Size contain the distinct blobs used before the collection
+	Blobs                int64
+	Size                 int64
+	UnreachableManifests int
+	// ReclaimableBlobs and ReclaimableSize contain the removed blobs if AutoFix is set
+	ReclaimableBlobs int
+	ReclaimableSize  int64
+}
+
+// GarbageCollectReport contains the result of a garbage collection
+type GarbageCollectReport struct {
+	Owners               []*OwnerStorageReport
+	UnreachableManifests int
+	ReclaimableBlobs     int
+	ReclaimableSize      int64
+}
+
+// GarbageCollect finds the manifests which are neither reachable from a tag nor referenced by an image index or
+// as subject and removes them together with the blobs only they reference.
+// Blobs of uploads in progress are kept because they belong to the internal upload version until a manifest references them.
+func GarbageCollect(ctx context.Context, opts GarbageCollectOptions) (*GarbageCollectReport, error) {
+	log.Trace("Doing: ContainerGarbageCollect")
+	defer log.Trace("Finished: ContainerGarbageCollect")
+
+	if opts.LogDetail == nil {
+		opts.LogDetail = log.Debug
+	}
+
+	usages, err := container_model.GetStorageUsage(ctx, opts.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &GarbageCollectReport{
+		Owners: make([]*OwnerStorageReport, 0, len(usages)),
+	}
+	owners := make(map[int64]*OwnerStorageReport, len(usages))
+	for _, u := range usages {
+		osr := &OwnerStorageReport{
+			OwnerID: u.OwnerID,
+			Blobs:   u.BlobCount,
+			Size:    u.Size,
+		}
+		owners[u.OwnerID] = osr
+		report.Owners = append(report.Owners, osr)
+	}
+
+	images, err := container_model.GetImages(ctx, opts.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	unreachableVersions := make(map[int64][]int64)
+	candidateBlobs := make(map[int64]container.Set[int64])
+	for _, p := range images {
+		select {
+		case <-ctx.Done():
+			return nil, db.ErrCancelledf("While collecting container images")
+		default:
+		}
+
+		err := db.WithTx(ctx, func(ctx context.Context) error {
+			manifests, err := container_model.GetImageManifests(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, m := range findUnreachableManifests(manifests, opts.OlderThan) {
+				opts.LogDetail("Unreachable manifest %s@%s (%s) of owner %d", p.Name, m.Digest, m.Version.Version, p.OwnerID)
+
+				unreachableVersions[p.OwnerID] = append(unreachableVersions[p.OwnerID], m.Version.ID)
+
+				if !opts.AutoFix {
+					continue
+				}
+
+				pfs, err := packages_model.GetFilesByVersionID(ctx, m.Version.ID)
+				if err != nil {
+					return err
+				}
+				if candidateBlobs[p.OwnerID] == nil {
+					candidateBlobs[p.OwnerID] = make(container.Set[int64])
+				}
+				for _, pf := range pfs {
+					candidateBlobs[p.OwnerID].Add(pf.BlobID)
+				}
+
+				if err := packages_service.DeletePackageVersionAndReferences(ctx, m.Version); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for ownerID, versionIDs := range unreachableVersions {
+		osr, ok := owners[ownerID]
+		if !ok {
+			osr = &OwnerStorageReport{OwnerID: ownerID}
+			owners[ownerID] = osr
+			report.Owners = append(report.Owners, osr)
+		}
+		osr.UnreachableManifests = len(versionIDs)
+		report.UnreachableManifests += len(versionIDs)
+
+		if opts.AutoFix {
+			continue
+		}
+
+		pbs, err := container_model.GetBlobsOnlyReferencedByVersions(ctx, versionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, pb := range pbs {
+			osr.ReclaimableBlobs++
+			osr.ReclaimableSize += pb.Size
+		}
+	}
+
+	if opts.AutoFix {
+		contentStore := packages_module.NewContentStore()
+		for ownerID, blobIDs := range candidateBlobs {
+			osr := owners[ownerID]
+			for _, blobID := range blobIDs.Values() {
+				pb, err := packages_model.GetBlobByID(ctx, blobID)
+				if err != nil {
+					// The blob was shared with the images of another owner
+					if errors.Is(err, util.ErrNotExist) {
+						continue
+					}
+					return nil, err
+				}
+
+				// A concurrent upload may have referenced the blob again
+				deleted, err := packages_model.DeleteBlobIfUnreferenced(ctx, pb.ID)
+				if err != nil {
+					return nil, err
+				}
+				if !deleted {
+					continue
+				}
+
+				osr.ReclaimableBlobs++
+				osr.ReclaimableSize += pb.Size
+
+				// A concurrent upload may have stored the same content again
+				if exists, err := packages_model.ExistPackageBlobWithSHA(ctx, pb.HashSHA256); err != nil {
+					return nil, err
+				} else if exists {
+					continue
+				}
+				if err := contentStore.Delete(packages_module.BlobHash256Key(pb.HashSHA256)); err != nil {
+					log.Error("Error deleting package blob [%v]: %v", pb.ID, err)
+				}
+			}
+		}
+	}
+
+	for _, osr := range report.Owners {
+		report.ReclaimableBlobs += osr.ReclaimableBlobs
+		report.ReclaimableSize += osr.ReclaimableSize
+	}
+
+	return report, nil
+}
+
+// findUnreachableManifests marks all manifests reachable from a tag, an image index or as subject of a signature, SBOM or
+// attestation and returns the other ones. Manifests created after olderThan are always reachable.
+func findUnreachableManifests(manifests []*container_model.ImageManifest, olderThan time.Time) []*container_model.ImageManifest {
+	byDigest := make(map[string][]*container_model.ImageManifest, len(manifests))
+	referrers := make(map[string][]*container_model.ImageManifest)
+	for _, m := range manifests {
+		byDigest[m.Digest] = append(byDigest[m.Digest], m)
+		for _, subject := range m.Subjects {
+			referrers[subject] = append(referrers[subject], m)
+		}
+	}
+
+	reachable := make(container.Set[int64])
+	queue := make([]*container_model.ImageManifest, 0, len(manifests))
+	mark := func(m *container_model.ImageManifest) {
+		if reachable.Add(m.Version.ID) {
+			queue = append(queue, m)
+		}
+	}
+
+	for _, m := range manifests {
+		if m.IsTagged || m.Digest == "" || m.Version.CreatedUnix.AsLocalTime().After(olderThan) {
+			mark(m)
+		}
+	}
+
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+
+		// The same manifest may be stored as tagged and untagged version
+		for _, other := range byDigest[m.Digest] {
+			mark(other)
+		}
+		for _, reference := range m.References {
+			for _, other := range byDigest[reference] {
+				mark(other)
+			}
+		}
+		for _, referrer := range referrers[m.Digest] {
+			mark(referrer)
+		}
+	}
+
+	unreachable := make([]*container_model.ImageManifest, 0, len(manifests)-len(reachable))
+	for _, m := range manifests {
+		if !reachable.Contains(m.Version.ID) {
+			unreachable = append(unreachable, m)
+		}
+	}
+	return unreachable
+}
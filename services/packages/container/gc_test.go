@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"testing"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUnreachableManifests(t *testing.T) {
+	now := time.Now()
+	old := timeutil.TimeStamp(now.Add(-48 * time.Hour).Unix())
+
+	manifest := func(id int64, digest string, isTagged bool, created timeutil.TimeStamp) *container_model.ImageManifest {
+		return &container_model.ImageManifest{
+			Version: &packages_model.PackageVersion{
+				ID:          id,
+				Version:     digest,
+				CreatedUnix: created,
+			},
+			Digest:   digest,
+			IsTagged: isTagged,
+		}
+	}
+
+	tagged := manifest(1, "sha256:tagged", true, old)
+	taggedByDigest := manifest(2, "sha256:tagged", false, old)
+	index := manifest(3, "sha256:index", true, old)
+	index.References = []string{"sha256:amd64", "sha256:arm64"}
+	amd64 := manifest(4, "sha256:amd64", false, old)
+	arm64 := manifest(5, "sha256:arm64", false, old)
+	signature := manifest(6, "sha256:signature", false, old)
+	signature.Subjects = []string{"sha256:amd64"}
+	signatureOfSignature := manifest(7, "sha256:signature2", false, old)
+	signatureOfSignature.Subjects = []string{"sha256:signature"}
+	orphan := manifest(8, "sha256:orphan", false, old)
+	orphanSignature := manifest(9, "sha256:orphan-signature", false, old)
+	orphanSignature.Subjects = []string{"sha256:orphan"}
+	orphanIndex := manifest(10, "sha256:orphan-index", false, old)
+	orphanIndex.References = []string{"sha256:orphan-child"}
+	orphanChild := manifest(11, "sha256:orphan-child", false, old)
+	recent := manifest(12, "sha256:recent", false, timeutil.TimeStamp(now.Unix()))
+
+	unreachable := findUnreachableManifests([]*container_model.ImageManifest{
+		tagged, taggedByDigest, index, amd64, arm64, signature, signatureOfSignature, orphan, orphanSignature, orphanIndex, orphanChild, recent,
+	}, now.Add(-24*time.Hour))
+
+	assert.ElementsMatch(t, []*container_model.ImageManifest{orphan, orphanSignature, orphanIndex, orphanChild}, unreachable)
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/optional"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+)
+
+// Referrer is a manifest (signature, SBOM, attestation, ...) which refers to another manifest
+type Referrer struct {
+	Version      *packages_model.PackageVersion
+	Digest       string
+	MediaType    string
+	Size         int64
+	ArtifactType string
+	Kind         container_module.ArtifactKind
+	Annotations  map[string]string
+}
+
+// GetReferrers gets all manifests of the image which have the manifest with the digest as subject
+func GetReferrers(ctx context.Context, p *packages_model.Package, subject string) ([]*Referrer, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		PackageID:  p.ID,
+		IsInternal: optional.Some(false),
+		Properties: map[string]string{
+			container_module.PropertyManifestSubject: subject,
+		},
+		Sort: packages_model.SortCreatedAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(container.Set[string])
+	referrers := make([]*Referrer, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*container_module.Metadata)
+
+		for _, pfd := range pd.Files {
+			if pfd.File.LowerName != container_model.ManifestFilename {
+				continue
+			}
+
+			// A manifest can be stored as tagged and untagged version
+			d := pfd.Properties.GetByName(container_module.PropertyDigest)
+			if !seen.Add(d) {
+				break
+			}
+
+			referrers = append(referrers, &Referrer{
+				Version:      pd.Version,
+				Digest:       d,
+				MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+				Size:         pfd.Blob.Size,
+				ArtifactType: metadata.ArtifactType,
+				Kind:         container_module.GetArtifactKind(metadata.ArtifactType),
+				Annotations:  metadata.Annotations,
+			})
+			break
+		}
+	}
+
+	return referrers, nil
+}
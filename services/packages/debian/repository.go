@@ -23,8 +23,6 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	packages_service "code.gitea.io/gitea/services/packages"
 
-	"github.com/ProtonMail/go-crypto/openpgp"
-	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
 	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/ulikunitz/xz"
@@ -36,68 +34,20 @@ func GetOrCreateRepositoryVersion(ctx context.Context, ownerID int64) (*packages
 	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeDebian, debian_module.RepositoryPackage, debian_module.RepositoryVersion)
 }
 
-// GetOrCreateKeyPair gets or creates the PGP keys used to sign repository files
-func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
-	priv, err := user_model.GetSetting(ctx, ownerID, debian_module.SettingKeyPrivate)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	pub, err := user_model.GetSetting(ctx, ownerID, debian_module.SettingKeyPublic)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	if priv == "" || pub == "" {
-		priv, pub, err = generateKeypair()
-		if err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, debian_module.SettingKeyPrivate, priv); err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, debian_module.SettingKeyPublic, pub); err != nil {
-			return "", "", err
-		}
-	}
-
-	return priv, pub, nil
+// GetOrCreateSigningKeys gets or creates the active PGP keys used to sign repository files
+func GetOrCreateSigningKeys(ctx context.Context, ownerID int64) ([]*packages_model.PackageSigningKey, error) {
+	return packages_service.GetOrCreateSigningKeys(ctx, ownerID, packages_model.TypeDebian)
 }
 
-func generateKeypair() (string, string, error) {
-	e, err := openpgp.NewEntity("", "Debian Registry", "", nil)
-	if err != nil {
-		return "", "", err
-	}
-
-	var priv strings.Builder
-	var pub strings.Builder
-
-	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
-	if err != nil {
-		return "", "", err
-	}
-	if err := e.SerializePrivate(w, nil); err != nil {
-		return "", "", err
-	}
-	w.Close()
-
-	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
-	if err != nil {
-		return "", "", err
-	}
-	if err := e.Serialize(w); err != nil {
-		return "", "", err
-	}
-	w.Close()
-
-	return priv.String(), pub.String(), nil
+// GetPublicKeys gets the armored public keys of the active and the revoked signing keys
+func GetPublicKeys(ctx context.Context, ownerID int64) (string, error) {
+	return packages_service.GetPublicSigningKeys(ctx, ownerID, packages_model.TypeDebian)
 }
 
 // BuildAllRepositoryFiles (re)builds all repository files for every available distributions, components and architectures
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -144,6 +94,8 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 
 // BuildSpecificRepositoryFiles builds index files for the repository
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, distribution, component, architecture string) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -309,25 +261,25 @@ func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages
 
 	sort.Strings(architectures)
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	keys, err := GetOrCreateSigningKeys(ctx, ownerID)
 	if err != nil {
 		return err
 	}
 
-	block, err := armor.Decode(strings.NewReader(priv))
+	entities, err := packages_service.ReadSigningEntities(keys)
 	if err != nil {
 		return err
 	}
 
-	e, err := openpgp.ReadEntity(packet.NewReader(block.Body))
-	if err != nil {
-		return err
+	privateKeys := make([]*packet.PrivateKey, 0, len(entities))
+	for _, e := range entities {
+		privateKeys = append(privateKeys, e.PrivateKey)
 	}
 
 	inReleaseContent, _ := packages_module.NewHashedBuffer()
 	defer inReleaseContent.Close()
 
-	sw, err := clearsign.Encode(inReleaseContent, e.PrivateKey, nil)
+	sw, err := clearsign.EncodeMulti(inReleaseContent, privateKeys, nil)
 	if err != nil {
 		return err
 	}
@@ -373,7 +325,7 @@ func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages
 	releaseGpgContent, _ := packages_module.NewHashedBuffer()
 	defer releaseGpgContent.Close()
 
-	if err := openpgp.ArmoredDetachSign(releaseGpgContent, e, bytes.NewReader(buf.Bytes()), nil); err != nil {
+	if err := packages_service.ArmoredDetachSignAll(releaseGpgContent, entities, buf.Bytes()); err != nil {
 		return err
 	}
 
@@ -0,0 +1,209 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"sort"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+// GetOrCreateKeyPair gets or creates the RSA keys used to sign the registry resources
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, ownerID, hex_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, ownerID, hex_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = util.GenerateKeyPair(2048)
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, hex_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, hex_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+// signResource signs the payload with the key of the owner and returns the gzipped Signed message
+func signResource(ctx context.Context, ownerID int64, payload []byte) ([]byte, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(priv))
+	if block == nil {
+		return nil, errors.New("failed to decode the private key")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return hex_module.BuildSigned(payload, signature)
+}
+
+// BuildNames builds the signed /names resource with all packages of the owner
+func BuildNames(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := packages_model.GetPackagesByType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[int64]*hex_module.NamesEntry, len(ps))
+	for _, p := range ps {
+		entries[p.ID] = &hex_module.NamesEntry{Name: p.Name}
+	}
+	for _, pv := range pvs {
+		if e, ok := entries[pv.PackageID]; ok {
+			if t := pv.CreatedUnix.AsTime(); t.After(e.UpdatedAt) {
+				e.UpdatedAt = t
+			}
+		}
+	}
+
+	names := make([]*hex_module.NamesEntry, 0, len(entries))
+	for _, e := range entries {
+		// Packages without versions are not part of the registry
+		if e.UpdatedAt.IsZero() {
+			continue
+		}
+		names = append(names, e)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].Name < names[j].Name
+	})
+
+	return signResource(ctx, owner.ID, hex_module.BuildNames(owner.Name, names))
+}
+
+// BuildVersions builds the signed /versions resource with the versions of all packages of the owner
+func BuildVersions(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := packages_model.GetPackagesByType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsByPackage := make(map[int64][]string, len(ps))
+	for _, pv := range pvs {
+		versionsByPackage[pv.PackageID] = append(versionsByPackage[pv.PackageID], pv.Version)
+	}
+
+	entries := make([]*hex_module.VersionsEntry, 0, len(ps))
+	for _, p := range ps {
+		versions, ok := versionsByPackage[p.ID]
+		if !ok {
+			continue
+		}
+		sortVersions(versions)
+
+		entries = append(entries, &hex_module.VersionsEntry{
+			Name:     p.Name,
+			Versions: versions,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return signResource(ctx, owner.ID, hex_module.BuildVersions(owner.Name, entries))
+}
+
+// BuildPackage builds the signed /packages/<name> resource with the releases of the package
+func BuildPackage(ctx context.Context, owner *user_model.User, name string) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeHex, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*hex_module.Metadata)
+
+		innerChecksum, err := hex.DecodeString(metadata.InnerChecksum)
+		if err != nil {
+			return nil, err
+		}
+		outerChecksum, err := hex.DecodeString(pd.Files[0].Blob.HashSHA256)
+		if err != nil {
+			return nil, err
+		}
+
+		releases = append(releases, &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: innerChecksum,
+			OuterChecksum: outerChecksum,
+			Dependencies:  metadata.Requirements,
+		})
+	}
+
+	return signResource(ctx, owner.ID, hex_module.BuildPackage(owner.Name, pds[0].Package.Name, releases))
+}
+
+func sortVersions(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		vi, erri := version.NewSemver(versions[i])
+		vj, errj := version.NewSemver(versions[j])
+		if erri != nil || errj != nil {
+			return versions[i] < versions[j]
+		}
+		return vi.LessThan(vj)
+	})
+}
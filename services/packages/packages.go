@@ -9,13 +9,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/httplib"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
@@ -76,7 +80,7 @@ func CreatePackageOrAddFileToExisting(ctx context.Context, pvci *PackageCreation
 }
 
 func createPackageAndAddFile(ctx context.Context, pvci *PackageCreationInfo, pfci *PackageFileCreationInfo, allowDuplicate bool) (*packages_model.PackageVersion, *packages_model.PackageFile, error) {
-	dbCtx, committer, err := db.TxContext(ctx)
+	dbCtx, committer, err := packages_model.TxContext(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -139,6 +143,10 @@ func createPackageAndVersion(ctx context.Context, pvci *PackageCreationInfo, all
 		packageCreated = false
 	}
 
+	if err := CheckPackageAccess(ctx, p, perm.AccessModeWrite); err != nil {
+		return nil, false, err
+	}
+
 	if packageCreated {
 		for name, value := range pvci.PackageProperties {
 			if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypePackage, p.ID, name, value); err != nil {
@@ -194,6 +202,10 @@ func AddFileToExistingPackage(ctx context.Context, pvi *PackageInfo, pfci *Packa
 			return nil, nil, false, err
 		}
 
+		if err := checkPackageVersionAccess(ctx, pv.ID, perm.AccessModeWrite); err != nil {
+			return nil, nil, false, err
+		}
+
 		return addFileToPackageVersion(ctx, pv, pvi, pfci)
 	})
 }
@@ -207,7 +219,7 @@ func AddFileToPackageVersionInternal(ctx context.Context, pv *packages_model.Pac
 }
 
 func addFileToPackageWrapper(ctx context.Context, fn func(ctx context.Context) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error)) (*packages_model.PackageFile, error) {
-	ctx, committer, err := db.TxContext(ctx)
+	ctx, committer, err := packages_model.TxContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -353,6 +365,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 	switch packageType {
 	case packages_model.TypeAlpine:
 		typeSpecificSize = setting.Packages.LimitSizeAlpine
+	case packages_model.TypeAnsible:
+		typeSpecificSize = setting.Packages.LimitSizeAnsible
 	case packages_model.TypeArch:
 		typeSpecificSize = setting.Packages.LimitSizeArch
 	case packages_model.TypeCargo:
@@ -377,6 +391,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		typeSpecificSize = setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
 	case packages_model.TypeNpm:
@@ -393,6 +409,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
 		typeSpecificSize = setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		typeSpecificSize = setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
 		typeSpecificSize = setting.Packages.LimitSizeVagrant
 	}
@@ -468,6 +486,10 @@ func RemovePackageVersionByNameAndVersion(ctx context.Context, doer *user_model.
 
 // RemovePackageVersion deletes the package version and all associated files
 func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion) error {
+	if err := checkPackageVersionAccess(ctx, pv.ID, perm.AccessModeWrite); err != nil {
+		return err
+	}
+
 	dbCtx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return err
@@ -481,7 +503,7 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 
 	log.Trace("Deleting package: %v", pv.ID)
 
-	if err := DeletePackageVersionAndReferences(dbCtx, pv); err != nil {
+	if err := deletePackageVersionAndReferences(dbCtx, pv); err != nil {
 		return err
 	}
 
@@ -496,10 +518,14 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 
 // RemovePackageFileAndVersionIfUnreferenced deletes the package file and the version if there are no referenced files afterwards
 func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_model.User, pf *packages_model.PackageFile) error {
+	if err := checkPackageVersionAccess(ctx, pf.VersionID, perm.AccessModeWrite); err != nil {
+		return err
+	}
+
 	var pd *packages_model.PackageDescriptor
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		if err := DeletePackageFile(ctx, pf); err != nil {
+		if err := deletePackageFile(ctx, pf); err != nil {
 			return err
 		}
 
@@ -518,7 +544,7 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 				return err
 			}
 
-			if err := DeletePackageVersionAndReferences(ctx, pv); err != nil {
+			if err := deletePackageVersionAndReferences(ctx, pv); err != nil {
 				return err
 			}
 		}
@@ -537,17 +563,28 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 
 // DeletePackageVersionAndReferences deletes the package version and its properties and files
 func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.PackageVersion) error {
+	if err := checkPackageVersionAccess(ctx, pv.ID, perm.AccessModeWrite); err != nil {
+		return err
+	}
+	return deletePackageVersionAndReferences(ctx, pv)
+}
+
+func deletePackageVersionAndReferences(ctx context.Context, pv *packages_model.PackageVersion) error {
 	if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeVersion, pv.ID); err != nil {
 		return err
 	}
 
+	if err := packages_model.DeleteProvenancesByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
 	if err != nil {
 		return err
 	}
 
 	for _, pf := range pfs {
-		if err := DeletePackageFile(ctx, pf); err != nil {
+		if err := deletePackageFile(ctx, pf); err != nil {
 			return err
 		}
 	}
@@ -557,6 +594,13 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 
 // DeletePackageFile deletes the package file and its properties
 func DeletePackageFile(ctx context.Context, pf *packages_model.PackageFile) error {
+	if err := checkPackageVersionAccess(ctx, pf.VersionID, perm.AccessModeWrite); err != nil {
+		return err
+	}
+	return deletePackageFile(ctx, pf)
+}
+
+func deletePackageFile(ctx context.Context, pf *packages_model.PackageFile) error {
 	if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 		return err
 	}
@@ -625,11 +669,24 @@ func GetPackageBlobStream(ctx context.Context, pf *packages_model.PackageFile, p
 			if err := packages_model.IncrementDownloadCounter(ctx, pf.VersionID); err != nil {
 				log.Error("Error incrementing download counter: %v", err)
 			}
+			if setting.Packages.DownloadStatsEnabled {
+				if err := packages_model.RecordDownload(ctx, pf.VersionID, downloadClient(ctx), time.Now()); err != nil {
+					log.Error("Error recording download statistics: %v", err)
+				}
+			}
 		}
 	}
 	return s, u, pf, err
 }
 
+// downloadClient returns the name of the client of the request in the context
+func downloadClient(ctx context.Context) string {
+	if req, ok := ctx.Value(httplib.RequestContextKey).(*http.Request); ok {
+		return packages_module.ClientFromUserAgent(req.UserAgent())
+	}
+	return packages_module.ClientOther
+}
+
 // RemoveAllPackages for User
 func RemoveAllPackages(ctx context.Context, userID int64) (int, error) {
 	count := 0
@@ -649,7 +706,7 @@ func RemoveAllPackages(ctx context.Context, userID int64) (int, error) {
 			break
 		}
 		for _, pv := range pkgVersions {
-			if err := DeletePackageVersionAndReferences(ctx, pv); err != nil {
+			if err := deletePackageVersionAndReferences(ctx, pv); err != nil {
 				return count, fmt.Errorf("unable to delete package %d:%s[%d]. Error: %w", pv.PackageID, pv.Version, pv.ID, err)
 			}
 			count++
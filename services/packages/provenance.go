@@ -0,0 +1,78 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"io"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/packages/provenance"
+)
+
+// AddProvenance attaches an in-toto provenance document to the package version.
+// The signatures of the document are not verified, the attachment only records
+// if its subjects are files of the version and if its source is the recorded build source.
+func AddProvenance(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor, r io.Reader) (*packages_model.PackageProvenance, error) {
+	if err := CheckPackageAccess(ctx, pd.Package, perm.AccessModeWrite); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, provenance.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	doc, err := provenance.ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pp := &packages_model.PackageProvenance{
+		VersionID:          pd.Version.ID,
+		CreatorID:          doer.ID,
+		PredicateType:      doc.Statement.PredicateType,
+		HasSignatures:      doc.HasSignatures,
+		MatchesFiles:       matchesFiles(pd, doc),
+		MatchesBuildSource: matchesBuildSource(pd, doc),
+		Content:            string(data),
+	}
+	if doc.Source != nil {
+		pp.SourceURI = doc.Source.URI
+		pp.SourceDigest = doc.Source.Digest
+	}
+
+	if err := packages_model.InsertProvenance(ctx, pp); err != nil {
+		return nil, err
+	}
+	return pp, nil
+}
+
+// DeleteProvenance removes a provenance attachment of the package version
+func DeleteProvenance(ctx context.Context, pd *packages_model.PackageDescriptor, provenanceID int64) error {
+	if err := CheckPackageAccess(ctx, pd.Package, perm.AccessModeWrite); err != nil {
+		return err
+	}
+	return packages_model.DeleteProvenanceByID(ctx, pd.Version.ID, provenanceID)
+}
+
+func matchesFiles(pd *packages_model.PackageDescriptor, doc *provenance.Document) bool {
+	fileDigests := make(container.Set[string], len(pd.Files))
+	for _, pfd := range pd.Files {
+		fileDigests.Add(pfd.Blob.HashSHA256)
+	}
+
+	for _, digest := range doc.Statement.SubjectDigests() {
+		if !fileDigests.Contains(digest) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesBuildSource(pd *packages_model.PackageDescriptor, doc *provenance.Document) bool {
+	return pd.Version.SourceCommitSHA != "" && doc.Source != nil && doc.Source.Digest == pd.Version.SourceCommitSHA
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// UpstreamProperty is the name of the version property which stores the URL a file was pulled from
+const UpstreamProperty = "proxy.upstream"
+
+// maxMetadataSize is the maximum size of a metadata document of an upstream registry
+const maxMetadataSize = 64 * 1024 * 1024
+
+var (
+	ErrNotAllowed          = util.NewPermissionDeniedErrorf("the package is not in the allowlist of the proxy")
+	ErrUpstreamNotExist    = util.NewNotExistErrorf("the upstream registry does not have the requested file")
+	ErrUpstreamUnavailable = errors.New("the upstream registry is unavailable")
+	ErrChecksumMismatch    = util.NewInvalidArgumentErrorf("the checksum of the upstream file does not match")
+)
+
+var httpClient *http.Client
+
+// Init creates the HTTP client used to pull from the upstream registries
+func Init() error {
+	allowList := hostmatcher.ParseHostMatchList("packages.PROXY_ALLOWED_HOST_LIST", setting.Packages.ProxyAllowedHostList)
+
+	dialContext := hostmatcher.NewDialContext("packages proxy", allowList, nil, setting.Proxy.ProxyURLFixed)
+
+	// the timeout applies to connecting and waiting for the response, the download of large files may take longer
+	httpClient = &http.Client{
+		Transport: &http.Transport{
+			Proxy: proxy.Proxy(),
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				ctx, cancel := context.WithTimeout(ctx, setting.Packages.ProxyTimeout)
+				defer cancel()
+				return dialContext(ctx, network, addr)
+			},
+			TLSHandshakeTimeout:   setting.Packages.ProxyTimeout,
+			ResponseHeaderTimeout: setting.Packages.ProxyTimeout,
+		},
+	}
+	return nil
+}
+
+// GetOwnerProxy returns the enabled proxy of the owner for the package type, or nil if there is none
+func GetOwnerProxy(ctx context.Context, ownerID int64, packageType packages_model.Type) (*packages_model.PackageProxy, error) {
+	if !packages_model.IsProxyType(packageType) {
+		return nil, nil
+	}
+	pp, err := packages_model.GetProxyByOwnerAndType(ctx, ownerID, packageType)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageProxyNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !pp.Enabled {
+		return nil, nil
+	}
+	return pp, nil
+}
+
+// ResolveURL resolves a reference of an upstream document against the upstream URL of the proxy
+func ResolveURL(pp *packages_model.PackageProxy, ref string) (string, error) {
+	base, err := url.Parse(strings.TrimSuffix(pp.UpstreamURL, "/") + "/")
+	if err != nil {
+		return "", err
+	}
+	u, err := base.Parse(strings.TrimPrefix(ref, "/"))
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func get(ctx context.Context, fileURL, accept string) (*http.Response, error) {
+	if httpClient == nil {
+		return nil, errors.New("the packages proxy is not initialized")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound, http.StatusGone:
+		resp.Body.Close()
+		return nil, ErrUpstreamNotExist
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s returned %s", ErrUpstreamUnavailable, fileURL, resp.Status)
+	}
+}
+
+// FetchMetadata returns the metadata document at the path of the upstream registry.
+// The cached document is used while it is younger than the TTL of the proxy, or regardless of its age
+// if the upstream registry is unavailable, so that the known packages can still be installed offline.
+func FetchMetadata(ctx context.Context, pp *packages_model.PackageProxy, path, accept string) ([]byte, error) {
+	cached, err := packages_model.GetProxyMetadata(ctx, pp.ID, path)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && time.Since(cached.FetchedUnix.AsTime()) < time.Duration(pp.MetadataTTL)*time.Second {
+		return cached.Content, nil
+	}
+
+	content, err := fetchMetadata(ctx, pp, path, accept)
+	if err != nil {
+		if errors.Is(err, ErrUpstreamUnavailable) && cached != nil {
+			log.Warn("Package proxy [%d]: serving the cached %s fetched at %v: %v", pp.ID, path, cached.FetchedUnix.AsTime(), err)
+			return cached.Content, nil
+		}
+		return nil, err
+	}
+
+	if err := packages_model.SetProxyMetadata(ctx, pp.ID, path, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func fetchMetadata(ctx context.Context, pp *packages_model.PackageProxy, path, accept string) ([]byte, error) {
+	u, err := ResolveURL(pp, path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := get(ctx, u, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
+	}
+	if len(content) > maxMetadataSize {
+		return nil, fmt.Errorf("the metadata document %s is too large", u)
+	}
+	return content, nil
+}
+
+// CachedMetadata returns the cached metadata document at the path regardless of its age, or nil if there is none
+func CachedMetadata(ctx context.Context, pp *packages_model.PackageProxy, path string) ([]byte, error) {
+	cached, err := packages_model.GetProxyMetadata(ctx, pp.ID, path)
+	if err != nil || cached == nil {
+		return nil, err
+	}
+	return cached.Content, nil
+}
+
+// FetchFile downloads a file from the upstream registry, the URL may be relative to the upstream URL of the proxy
+func FetchFile(ctx context.Context, pp *packages_model.PackageProxy, ref string) (*packages_module.HashedBuffer, error) {
+	u, err := ResolveURL(pp, ref)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := get(ctx, u, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
+	}
+	return buf, nil
+}
+
+// VerifyChecksum checks the hex encoded checksum of the downloaded file, the algorithm is one of md5, sha1, sha256 and sha512
+func VerifyChecksum(buf *packages_module.HashedBuffer, algorithm, expected string) error {
+	hashMD5, hashSHA1, hashSHA256, hashSHA512 := buf.Sums()
+
+	var sum []byte
+	switch algorithm {
+	case "md5":
+		sum = hashMD5
+	case "sha1":
+		sum = hashSHA1
+	case "sha256":
+		sum = hashSHA256
+	case "sha512":
+		sum = hashSHA512
+	default:
+		return fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+
+	expectedSum, err := hex.DecodeString(strings.TrimSpace(expected))
+	if err != nil || !bytes.Equal(sum, expectedSum) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// PullLockKey is the key of the global lock which serializes the pulls of a package version
+func PullLockKey(pp *packages_model.PackageProxy, name, version string) string {
+	return fmt.Sprintf("pkg_proxy_%d_%s_%s", pp.ID, strings.ToLower(name), version)
+}
+
+// CreatePackageFromUpstream stores a file pulled from the upstream registry of the proxy as a package of its owner
+func CreatePackageFromUpstream(ctx context.Context, pp *packages_model.PackageProxy, pvci *packages_service.PackageCreationInfo, pfci *packages_service.PackageFileCreationInfo) (*packages_model.PackageVersion, *packages_model.PackageFile, error) {
+	pvci.Creator = pvci.Owner
+	pfci.Creator = pvci.Owner
+	if pvci.VersionProperties == nil {
+		pvci.VersionProperties = make(map[string]string)
+	}
+	pvci.VersionProperties[UpstreamProperty] = pp.UpstreamURL
+
+	// the package is cached on behalf of the owner, so the access scope of the reader does not apply
+	return packages_service.CreatePackageOrAddFileToExisting(packages_model.WithAccessScope(ctx, nil), pvci, pfci)
+}
@@ -10,10 +10,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/xml"
-	"errors"
 	"fmt"
 	"io"
-	"strings"
 	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -22,12 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/json"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
-	"code.gitea.io/gitea/modules/util"
 	packages_service "code.gitea.io/gitea/services/packages"
-
-	"github.com/ProtonMail/go-crypto/openpgp"
-	"github.com/ProtonMail/go-crypto/openpgp/armor"
-	"github.com/ProtonMail/go-crypto/openpgp/packet"
 )
 
 // GetOrCreateRepositoryVersion gets or creates the internal repository package
@@ -36,68 +29,20 @@ func GetOrCreateRepositoryVersion(ctx context.Context, ownerID int64) (*packages
 	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeRpm, rpm_module.RepositoryPackage, rpm_module.RepositoryVersion)
 }
 
-// GetOrCreateKeyPair gets or creates the PGP keys used to sign repository metadata files
-func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
-	priv, err := user_model.GetSetting(ctx, ownerID, rpm_module.SettingKeyPrivate)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	pub, err := user_model.GetSetting(ctx, ownerID, rpm_module.SettingKeyPublic)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	if priv == "" || pub == "" {
-		priv, pub, err = generateKeypair()
-		if err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, rpm_module.SettingKeyPrivate, priv); err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, rpm_module.SettingKeyPublic, pub); err != nil {
-			return "", "", err
-		}
-	}
-
-	return priv, pub, nil
+// GetOrCreateSigningKeys gets or creates the active PGP keys used to sign repository metadata files, newest first
+func GetOrCreateSigningKeys(ctx context.Context, ownerID int64) ([]*packages_model.PackageSigningKey, error) {
+	return packages_service.GetOrCreateSigningKeys(ctx, ownerID, packages_model.TypeRpm)
 }
 
-func generateKeypair() (string, string, error) {
-	e, err := openpgp.NewEntity("", "RPM Registry", "", nil)
-	if err != nil {
-		return "", "", err
-	}
-
-	var priv strings.Builder
-	var pub strings.Builder
-
-	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
-	if err != nil {
-		return "", "", err
-	}
-	if err := e.SerializePrivate(w, nil); err != nil {
-		return "", "", err
-	}
-	w.Close()
-
-	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
-	if err != nil {
-		return "", "", err
-	}
-	if err := e.Serialize(w); err != nil {
-		return "", "", err
-	}
-	w.Close()
-
-	return priv.String(), pub.String(), nil
+// GetPublicKeys gets the armored public keys of the active and the revoked signing keys
+func GetPublicKeys(ctx context.Context, ownerID int64) (string, error) {
+	return packages_service.GetPublicSigningKeys(ctx, ownerID, packages_model.TypeRpm)
 }
 
 // BuildAllRepositoryFiles (re)builds all repository files for every available group
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -160,6 +105,8 @@ type packageCache = map[*packages_model.PackageFile]*packageData
 
 // BuildSpecificRepositoryFiles builds metadata files for the repository
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, group string) error {
+	ctx = packages_service.WithOwnerRepositoryScope(ctx)
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -273,17 +220,12 @@ func buildRepomd(ctx context.Context, pv *packages_model.PackageVersion, ownerID
 		return err
 	}
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
-	if err != nil {
-		return err
-	}
-
-	block, err := armor.Decode(strings.NewReader(priv))
+	keys, err := GetOrCreateSigningKeys(ctx, ownerID)
 	if err != nil {
 		return err
 	}
 
-	e, err := openpgp.ReadEntity(packet.NewReader(block.Body))
+	entities, err := packages_service.ReadSigningEntities(keys)
 	if err != nil {
 		return err
 	}
@@ -291,7 +233,7 @@ func buildRepomd(ctx context.Context, pv *packages_model.PackageVersion, ownerID
 	repomdAscContent, _ := packages_module.NewHashedBuffer()
 	defer repomdAscContent.Close()
 
-	if err := openpgp.ArmoredDetachSign(repomdAscContent, e, bytes.NewReader(buf.Bytes()), nil); err != nil {
+	if err := packages_service.ArmoredDetachSignAll(repomdAscContent, entities, buf.Bytes()); err != nil {
 		return err
 	}
 
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	packages_service "code.gitea.io/gitea/services/packages"
+	debian_service "code.gitea.io/gitea/services/packages/debian"
+	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+)
+
+// RotateKey creates a new signing key for the repository metadata of the owner. The previous
+// keys are retired when the configured overlap window ends.
+func RotateKey(ctx context.Context, ownerID int64, packageType packages_model.Type) (*packages_model.PackageSigningKey, error) {
+	k, err := packages_service.RotateSigningKey(ctx, ownerID, packageType, setting.Packages.SigningKeyRotationOverlap)
+	if err != nil {
+		return nil, err
+	}
+	return k, rebuildRepositoryFiles(ctx, ownerID, packageType)
+}
+
+// RevokeKey revokes a compromised signing key and re-signs the repository metadata without it
+func RevokeKey(ctx context.Context, k *packages_model.PackageSigningKey) error {
+	if err := packages_service.RevokeSigningKey(ctx, k); err != nil {
+		return err
+	}
+	return rebuildRepositoryFiles(ctx, k.OwnerID, k.Type)
+}
+
+// UpdateKeysTask retires the signing keys whose overlap window ended and rotates the keys older than the rotation interval
+func UpdateKeysTask(ctx context.Context) error {
+	now := time.Now()
+
+	retire, err := packages_model.GetSigningKeySetsToRetire(ctx, timeutil.TimeStamp(now.Unix()))
+	if err != nil {
+		return err
+	}
+	for _, set := range retire {
+		n, err := packages_model.RetireSigningKeys(ctx, set.OwnerID, set.Type, timeutil.TimeStamp(now.Unix()))
+		if err != nil {
+			return err
+		}
+		log.Debug("Retired %d %s signing keys of owner %d", n, set.Type, set.OwnerID)
+
+		if err := rebuildRepositoryFiles(ctx, set.OwnerID, set.Type); err != nil {
+			return err
+		}
+	}
+
+	if setting.Packages.SigningKeyRotationInterval <= 0 {
+		return nil
+	}
+
+	rotate, err := packages_model.GetSigningKeySetsToRotate(ctx, timeutil.TimeStamp(now.Add(-setting.Packages.SigningKeyRotationInterval).Unix()))
+	if err != nil {
+		return err
+	}
+	for _, set := range rotate {
+		k, err := RotateKey(ctx, set.OwnerID, set.Type)
+		if err != nil {
+			return err
+		}
+		log.Debug("Rotated the %s signing key of owner %d to %s", set.Type, set.OwnerID, k.Fingerprint)
+	}
+	return nil
+}
+
+// rebuildRepositoryFiles re-signs the repository metadata after the key set changed
+func rebuildRepositoryFiles(ctx context.Context, ownerID int64, packageType packages_model.Type) error {
+	switch packageType {
+	case packages_model.TypeDebian:
+		if err := debian_service.BuildAllRepositoryFiles(ctx, ownerID); err != nil {
+			return fmt.Errorf("debian.BuildAllRepositoryFiles failed: %w", err)
+		}
+	case packages_model.TypeRpm:
+		if err := rpm_service.BuildAllRepositoryFiles(ctx, ownerID); err != nil {
+			return fmt.Errorf("rpm.BuildAllRepositoryFiles failed: %w", err)
+		}
+	}
+	return nil
+}
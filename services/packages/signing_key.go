@@ -0,0 +1,238 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+func signingKeyLockKey(ownerID int64, packageType packages_model.Type) string {
+	return fmt.Sprintf("packages_signing_key_%d_%s", ownerID, packageType)
+}
+
+// GetOrCreateSigningKeys gets the active PGP keys used to sign the repository metadata, newest first.
+// A key is created if the owner has no active key yet.
+func GetOrCreateSigningKeys(ctx context.Context, ownerID int64, packageType packages_model.Type) ([]*packages_model.PackageSigningKey, error) {
+	keys, err := packages_model.GetSigningKeys(ctx, ownerID, packageType, packages_model.SigningKeyStatusActive)
+	if err != nil || len(keys) > 0 {
+		return keys, err
+	}
+
+	releaser, err := globallock.Lock(ctx, signingKeyLockKey(ownerID, packageType))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	// another request may have created the key while waiting for the lock
+	keys, err = packages_model.GetSigningKeys(ctx, ownerID, packageType, packages_model.SigningKeyStatusActive)
+	if err != nil || len(keys) > 0 {
+		return keys, err
+	}
+
+	k, err := CreateSigningKey(ctx, ownerID, packageType)
+	if err != nil {
+		return nil, err
+	}
+	return []*packages_model.PackageSigningKey{k}, nil
+}
+
+// CreateSigningKey generates a new active signing key
+func CreateSigningKey(ctx context.Context, ownerID int64, packageType packages_model.Type) (*packages_model.PackageSigningKey, error) {
+	e, err := openpgp.NewEntity("", packageType.Name()+" Registry", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := armorEntity(e, openpgp.PrivateKeyType)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := armorEntity(e, openpgp.PublicKeyType)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &packages_model.PackageSigningKey{
+		OwnerID:     ownerID,
+		Type:        packageType,
+		Fingerprint: strings.ToUpper(hex.EncodeToString(e.PrimaryKey.Fingerprint)),
+		PublicKey:   pub,
+		PrivateKey:  priv,
+		Status:      packages_model.SigningKeyStatusActive,
+	}
+	if err := packages_model.InsertSigningKey(ctx, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// RotateSigningKey creates a new signing key. The previous active keys keep signing the
+// repository metadata until the overlap window ends so clients can pick up the new key.
+func RotateSigningKey(ctx context.Context, ownerID int64, packageType packages_model.Type, overlap time.Duration) (*packages_model.PackageSigningKey, error) {
+	releaser, err := globallock.Lock(ctx, signingKeyLockKey(ownerID, packageType))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	var k *packages_model.PackageSigningKey
+	return k, db.WithTx(ctx, func(ctx context.Context) error {
+		k, err = CreateSigningKey(ctx, ownerID, packageType)
+		if err != nil {
+			return err
+		}
+		return packages_model.ScheduleSigningKeysRetirement(ctx, ownerID, packageType, k.ID, timeutil.TimeStamp(time.Now().Add(overlap).Unix()))
+	})
+}
+
+// RevokeSigningKey revokes a compromised signing key. The public key stays published together
+// with the revocation signature. A new key is created if no other active key is left.
+func RevokeSigningKey(ctx context.Context, k *packages_model.PackageSigningKey) error {
+	if k.Status == packages_model.SigningKeyStatusRevoked {
+		return nil
+	}
+
+	releaser, err := globallock.Lock(ctx, signingKeyLockKey(k.OwnerID, k.Type))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	e, err := readEntity(k.PrivateKey)
+	if err != nil {
+		return err
+	}
+	if err := e.RevokeKey(packet.KeyCompromised, "", nil); err != nil {
+		return err
+	}
+	pub, err := armorEntity(e, openpgp.PublicKeyType)
+	if err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		k.PublicKey = pub
+		k.Status = packages_model.SigningKeyStatusRevoked
+		k.RevokedUnix = timeutil.TimeStampNow()
+		if err := packages_model.UpdateSigningKeyCols(ctx, k, "public_key", "status", "revoked_unix"); err != nil {
+			return err
+		}
+
+		active, err := packages_model.GetSigningKeys(ctx, k.OwnerID, k.Type, packages_model.SigningKeyStatusActive)
+		if err != nil {
+			return err
+		}
+		if len(active) == 0 {
+			_, err = CreateSigningKey(ctx, k.OwnerID, k.Type)
+		}
+		return err
+	})
+}
+
+// GetPublicSigningKeys gets the armored public keys of all active and revoked signing keys
+func GetPublicSigningKeys(ctx context.Context, ownerID int64, packageType packages_model.Type) (string, error) {
+	if _, err := GetOrCreateSigningKeys(ctx, ownerID, packageType); err != nil {
+		return "", err
+	}
+
+	keys, err := packages_model.GetSigningKeys(ctx, ownerID, packageType, packages_model.SigningKeyStatusActive, packages_model.SigningKeyStatusRevoked)
+	if err != nil {
+		return "", err
+	}
+
+	var keyring openpgp.EntityList
+	for _, k := range keys {
+		el, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.PublicKey))
+		if err != nil {
+			return "", err
+		}
+		keyring = append(keyring, el...)
+	}
+
+	var buf strings.Builder
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range keyring {
+		if err := e.Serialize(w); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ReadSigningEntities decodes the private keys of the signing keys
+func ReadSigningEntities(keys []*packages_model.PackageSigningKey) ([]*openpgp.Entity, error) {
+	entities := make([]*openpgp.Entity, 0, len(keys))
+	for _, k := range keys {
+		e, err := readEntity(k.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, e)
+	}
+	return entities, nil
+}
+
+// ArmoredDetachSignAll writes an armored block with a detached signature of every entity
+func ArmoredDetachSignAll(w io.Writer, entities []*openpgp.Entity, message []byte) error {
+	aw, err := armor.Encode(w, openpgp.SignatureType, nil)
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		if err := openpgp.DetachSign(aw, e, bytes.NewReader(message), nil); err != nil {
+			return err
+		}
+	}
+	return aw.Close()
+}
+
+func readEntity(armored string) (*openpgp.Entity, error) {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return nil, err
+	}
+	return openpgp.ReadEntity(packet.NewReader(block.Body))
+}
+
+func armorEntity(e *openpgp.Entity, blockType string) (string, error) {
+	var buf strings.Builder
+	w, err := armor.Encode(&buf, blockType, nil)
+	if err != nil {
+		return "", err
+	}
+	if blockType == openpgp.PrivateKeyType {
+		err = e.SerializePrivate(w, nil)
+	} else {
+		err = e.Serialize(w)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// getSigningKey gets the newest active key of the owner, it signs the checksums of the providers
+func getSigningKey(ctx context.Context, ownerID int64) (*packages_model.PackageSigningKey, error) {
+	keys, err := packages_service.GetOrCreateSigningKeys(ctx, ownerID, packages_model.TypeTerraform)
+	if err != nil {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
+// GetPublicKey returns the armored public key and its key ID in the format expected by the provider registry protocol
+func GetPublicKey(ctx context.Context, ownerID int64) (string, string, error) {
+	k, err := getSigningKey(ctx, ownerID)
+	if err != nil {
+		return "", "", err
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.PublicKey))
+	if err != nil {
+		return "", "", err
+	}
+	if len(keyring) == 0 {
+		return "", "", errors.New("the public key is empty")
+	}
+
+	return k.PublicKey, strings.ToUpper(keyring[0].PrimaryKey.KeyIdString()), nil
+}
+
+// SignData creates a binary detached signature of the data with the key of the owner
+func SignData(ctx context.Context, ownerID int64, r io.Reader) ([]byte, error) {
+	k, err := getSigningKey(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := packages_service.ReadSigningEntities([]*packages_model.PackageSigningKey{k})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := openpgp.DetachSign(buf, entities[0], r, nil); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// BuildSHA256Sums creates the content of the SHA256SUMS file of a provider version from its archives
+func BuildSHA256Sums(pd *packages_model.PackageDescriptor) []byte {
+	lines := make([]string, 0, len(pd.Files))
+	for _, pfd := range pd.Files {
+		if !strings.HasSuffix(pfd.File.Name, ".zip") {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s\n", pfd.Blob.HashSHA256, pfd.File.Name))
+	}
+	sort.Strings(lines)
+
+	return []byte(strings.Join(lines, ""))
+}
@@ -8,17 +8,26 @@ import (
 
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
+	notify_service "code.gitea.io/gitea/services/notify"
 )
 
-func CreateOrUpdateProtectedBranch(ctx context.Context, repo *repo_model.Repository,
+func CreateOrUpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
 	protectBranch *git_model.ProtectedBranch, whitelistOptions git_model.WhitelistOptions,
 ) error {
+	isNew := protectBranch.ID == 0
 	err := git_model.UpdateProtectBranch(ctx, repo, protectBranch, whitelistOptions)
 	if err != nil {
 		return err
 	}
 
+	if isNew {
+		notify_service.CreateProtectedBranch(ctx, doer, repo, protectBranch)
+	} else {
+		notify_service.UpdateProtectedBranch(ctx, doer, repo, protectBranch)
+	}
+
 	isPlainRule := !git_model.IsRuleNameSpecial(protectBranch.RuleName)
 	var isBranchExist bool
 	if isPlainRule {
@@ -47,3 +56,13 @@ func CreateOrUpdateProtectedBranch(ctx context.Context, repo *repo_model.Reposit
 
 	return nil
 }
+
+// DeleteProtectedBranch deletes the given branch protection rule of the repository
+func DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) error {
+	if err := git_model.DeleteProtectedBranch(ctx, repo, rule.ID); err != nil {
+		return err
+	}
+
+	notify_service.DeleteProtectedBranch(ctx, doer, repo, rule)
+	return nil
+}
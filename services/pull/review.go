@@ -116,17 +116,23 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 			return nil, err
 		}
 
-		comment, err := createCodeComment(ctx,
-			doer,
-			issue.Repo,
-			issue,
-			content,
-			treePath,
-			line,
-			replyReviewID,
-			attachments,
-		)
-		if err != nil {
+		var comment *issues_model.Comment
+		if err := db.WithTx(ctx, func(ctx context.Context) (err error) {
+			comment, err = createCodeComment(ctx,
+				doer,
+				issue.Repo,
+				issue,
+				content,
+				treePath,
+				line,
+				replyReviewID,
+				attachments,
+			)
+			if err != nil {
+				return err
+			}
+			return notify_service.CreateIssueCommentInTx(ctx, doer, issue.Repo, issue, comment)
+		}); err != nil {
 			return nil, err
 		}
 
@@ -17,8 +17,10 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/graceful"
+	release_indexer "code.gitea.io/gitea/modules/indexer/releases"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
@@ -354,6 +356,9 @@ func UpdateRelease(ctx context.Context, doer *user_model.User, gitRepo *git.Repo
 			return nil
 		}
 		notify_service.NewRelease(gitRepo.Ctx, rel)
+	} else if setting.Indexer.ReleaseIndexerEnabled {
+		// the release may have been published before, the indexer removes it
+		release_indexer.UpdateReleaseIndexer(rel.ID)
 	}
 	return nil
 }
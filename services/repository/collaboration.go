@@ -14,11 +14,12 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	notify_service "code.gitea.io/gitea/services/notify"
 
 	"xorm.io/builder"
 )
 
-func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
+func AddOrUpdateCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
 	// only allow valid access modes, read, write and admin
 	if mode < perm.AccessModeRead || mode > perm.AccessModeAdmin {
 		return perm.ErrInvalidAccessMode
@@ -32,7 +33,8 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 		return user_model.ErrBlockedUser
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	var changed, isNew bool
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
 			"repo_id": repo.ID,
 			"user_id": u.ID,
@@ -52,20 +54,68 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 				}); err != nil {
 				return err
 			}
-		} else if err = db.Insert(ctx, &repo_model.Collaboration{
-			RepoID: repo.ID,
-			UserID: u.ID,
-			Mode:   mode,
-		}); err != nil {
-			return err
+		} else {
+			if err = db.Insert(ctx, &repo_model.Collaboration{
+				RepoID: repo.ID,
+				UserID: u.ID,
+				Mode:   mode,
+			}); err != nil {
+				return err
+			}
+			isNew = true
 		}
+		changed = true
 
 		return access_model.RecalculateUserAccess(ctx, repo, u.ID)
+	}); err != nil {
+		return err
+	}
+
+	if changed {
+		if isNew {
+			notify_service.AddCollaborator(ctx, doer, repo, u, mode)
+		} else {
+			notify_service.ChangeCollaboratorAccessMode(ctx, doer, repo, u, mode)
+		}
+	}
+	return nil
+}
+
+// ChangeCollaborationAccessMode sets new access mode for the collaboration.
+func ChangeCollaborationAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) error {
+	// Discard invalid input
+	if mode <= perm.AccessModeNone || mode > perm.AccessModeOwner {
+		return nil
+	}
+
+	collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
+		"repo_id": repo.ID,
+		"user_id": collaborator.ID,
 	})
+	if err != nil || !has || collaboration.Mode == mode {
+		return err
+	}
+
+	if err := repo_model.ChangeCollaborationAccessMode(ctx, repo, collaborator.ID, mode); err != nil {
+		return err
+	}
+
+	notify_service.ChangeCollaboratorAccessMode(ctx, doer, repo, collaborator, mode)
+	return nil
 }
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
+	removed, err := deleteCollaboration(ctx, repo, collaborator)
+	if err != nil || !removed {
+		return err
+	}
+
+	notify_service.RemoveCollaborator(ctx, doer, repo, collaborator)
+	return nil
+}
+
+func deleteCollaboration(ctx context.Context, repo *repo_model.Repository, collaborator *user_model.User) (removed bool, err error) {
 	collaboration := &repo_model.Collaboration{
 		RepoID: repo.ID,
 		UserID: collaborator.ID,
@@ -73,38 +123,38 @@ func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, colla
 
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer committer.Close()
 
 	if has, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
-		return err
+		return false, err
 	} else if has == 0 {
-		return committer.Commit()
+		return false, committer.Commit()
 	}
 
 	if err := repo.LoadOwner(ctx); err != nil {
-		return err
+		return false, err
 	}
 
 	if err = access_model.RecalculateAccesses(ctx, repo); err != nil {
-		return err
+		return false, err
 	}
 
 	if err = repo_model.WatchRepo(ctx, collaborator, repo, false); err != nil {
-		return err
+		return false, err
 	}
 
 	if err = ReconsiderWatches(ctx, repo, collaborator); err != nil {
-		return err
+		return false, err
 	}
 
 	// Unassign a user from any issue (s)he has been assigned to in the repository
 	if err := ReconsiderRepoIssuesAssignee(ctx, repo, collaborator); err != nil {
-		return err
+		return false, err
 	}
 
-	return committer.Commit()
+	return true, committer.Commit()
 }
 
 func ReconsiderRepoIssuesAssignee(ctx context.Context, repo *repo_model.Repository, user *user_model.User) error {
@@ -0,0 +1,26 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// StarRepo stars or unstars the repository for the doer
+func StarRepo(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) error {
+	if repo_model.IsStaring(ctx, doer.ID, repo.ID) == star {
+		return nil
+	}
+
+	if err := repo_model.StarRepo(ctx, doer, repo, star); err != nil {
+		return err
+	}
+
+	notify_service.StarRepository(ctx, doer, repo, star)
+	return nil
+}
@@ -432,7 +432,7 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 			return err
 		}
 		if !hasAccess {
-			if err := AddOrUpdateCollaborator(ctx, repo, newOwner, perm.AccessModeRead); err != nil {
+			if err := AddOrUpdateCollaborator(ctx, doer, repo, newOwner, perm.AccessModeRead); err != nil {
 				return err
 			}
 		}
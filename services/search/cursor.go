@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package search
+
+import (
+	"encoding/base64"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Cursor records how many results of each type have been returned by the previous pages,
+// so the next page can continue from there. It is passed to clients as an opaque string.
+type Cursor map[Type]int
+
+// ParseCursor parses a cursor returned by Cursor.String, an empty string is the cursor of the first page
+func ParseCursor(s string) (Cursor, error) {
+	cursor := Cursor{}
+	if s == "" {
+		return cursor, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid cursor")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid cursor")
+	}
+	for tp, offset := range cursor {
+		if !tp.IsValid() || offset < 0 {
+			return nil, util.NewInvalidArgumentErrorf("invalid cursor")
+		}
+	}
+	return cursor, nil
+}
+
+// String encodes the cursor to pass it to clients
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c) // a map of ints can always be marshaled
+	return base64.RawURLEncoding.EncodeToString(data)
+}
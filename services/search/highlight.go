@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package search
+
+import (
+	"html"
+	"html/template"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxFragmentLength is the number of characters of a field kept around the first match
+const maxFragmentLength = 160
+
+// Highlight a part of a field of a result containing the searched terms
+type Highlight struct {
+	Field    string
+	Fragment string
+	// Matches are the [start, end) positions of the searched terms in the fragment, counted in characters
+	Matches [][2]int
+}
+
+// HTML returns the fragment with the matches marked
+func (h *Highlight) HTML() template.HTML {
+	runes := []rune(h.Fragment)
+	var sb strings.Builder
+	last := 0
+	for _, m := range h.Matches {
+		sb.WriteString(html.EscapeString(string(runes[last:m[0]])))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(string(runes[m[0]:m[1]])))
+		sb.WriteString("</mark>")
+		last = m[1]
+	}
+	sb.WriteString(html.EscapeString(string(runes[last:])))
+	return template.HTML(sb.String())
+}
+
+// keywordTerms returns the lower case terms of the keyword which are looked for in the results
+func keywordTerms(keyword string) []string {
+	return strings.Fields(strings.ToLower(keyword))
+}
+
+// newHighlight returns the highlight of the terms in the text of a field, or nil if none of them occurs
+func newHighlight(field, text string, terms []string) *Highlight {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	lowerRunes := []rune(strings.ToLower(text))
+	if len(runes) != len(lowerRunes) {
+		// the lower case of some characters has a different length, their positions can't be matched
+		return nil
+	}
+	lower := string(lowerRunes)
+
+	var matches [][2]int
+	for _, term := range terms {
+		for pos := 0; pos < len(lower); {
+			i := strings.Index(lower[pos:], term)
+			if i < 0 {
+				break
+			}
+			start := utf8.RuneCountInString(lower[:pos+i])
+			matches = append(matches, [2]int{start, start + utf8.RuneCountInString(term)})
+			pos += i + len(term)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	matches = mergeMatches(matches)
+
+	// keep the fragment around the first match
+	start := 0
+	if len(runes) > maxFragmentLength {
+		start = min(max(matches[0][0]-maxFragmentLength/4, 0), len(runes)-maxFragmentLength)
+	}
+	end := min(start+maxFragmentLength, len(runes))
+
+	h := &Highlight{Field: field, Fragment: string(runes[start:end])}
+	for _, m := range matches {
+		if m[0] >= start && m[1] <= end {
+			h.Matches = append(h.Matches, [2]int{m[0] - start, m[1] - start})
+		}
+	}
+	return h
+}
+
+// mergeMatches sorts the matches by position and merges the overlapping ones
+func mergeMatches(matches [][2]int) [][2]int {
+	slices.SortFunc(matches, func(a, b [2]int) int { return a[0] - b[0] })
+	merged := matches[:1]
+	for _, m := range matches[1:] {
+		if last := &merged[len(merged)-1]; m[0] <= last[1] {
+			last[1] = max(last[1], m[1])
+		} else {
+			merged = append(merged, m)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,506 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Type is the type of the searched items
+type Type string
+
+const (
+	TypeRepository Type = "repository"
+	TypeCode       Type = "code"
+	TypeIssue      Type = "issue"
+	TypeUser       Type = "user"
+	TypePackage    Type = "package"
+)
+
+// Types are all the searched types, the results of the first ones are preferred when the scores are equal
+var Types = []Type{TypeRepository, TypeCode, TypeIssue, TypeUser, TypePackage}
+
+// IsValid returns whether the type is known
+func (t Type) IsValid() bool {
+	return slices.Contains(Types, t)
+}
+
+// IsEnabled returns whether the items of the type can be searched with the current settings
+func (t Type) IsEnabled() bool {
+	switch t {
+	case TypeRepository:
+		return true
+	case TypeCode:
+		return setting.Indexer.RepoIndexerEnabled && !setting.Service.Explore.DisableCodePage && !unit.TypeCode.UnitGlobalDisabled()
+	case TypeIssue:
+		return !unit.TypeIssues.UnitGlobalDisabled() || !unit.TypePullRequests.UnitGlobalDisabled()
+	case TypeUser:
+		return !setting.Service.Explore.DisableUsersPage
+	case TypePackage:
+		return setting.Packages.Enabled && !unit.TypePackages.UnitGlobalDisabled()
+	}
+	return false
+}
+
+// EnabledTypes returns the types which can be searched
+func EnabledTypes() []Type {
+	types := make([]Type, 0, len(Types))
+	for _, t := range Types {
+		if t.IsEnabled() {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// rrfRankConstant is the constant of the reciprocal rank fusion, it reduces the advantage of the first ranks
+// so that a result ranked first by a search is not preferred over all the results of the other searches
+const rrfRankConstant = 60
+
+// Options the options of a search across all types
+type Options struct {
+	Doer    *user_model.User
+	Keyword string
+	Types   []Type // the types to search, all the enabled types if empty
+	Cursor  Cursor // the cursor returned by the previous page, nil for the first page
+	Limit   int    // the maximum number of results of the page
+}
+
+// Result a found item, only the field of its type and Repository are set
+type Result struct {
+	Type Type
+	// Score is the relevance of the result. The results of each type are ranked by their own search,
+	// the ranks are combined by reciprocal rank fusion, and the results whose name matches the keyword are boosted.
+	Score      float64
+	Highlights []*Highlight
+
+	Repository *repo_model.Repository // the found repository, or the repository of the found code or issue
+	Code       *code_indexer.Result
+	Issue      *issues_model.Issue
+	User       *user_model.User
+	Package    *packages_model.PackageDescriptor
+}
+
+// Title returns the name the result is shown with
+func (r *Result) Title() string {
+	switch r.Type {
+	case TypeRepository:
+		return r.Repository.FullName()
+	case TypeCode:
+		return r.Repository.FullName() + "/" + r.Code.Filename
+	case TypeIssue:
+		return fmt.Sprintf("%s#%d %s", r.Repository.FullName(), r.Issue.Index, r.Issue.Title)
+	case TypeUser:
+		return r.User.Name
+	case TypePackage:
+		return r.Package.Owner.Name + "/" + r.Package.Package.Name
+	}
+	return ""
+}
+
+// HTMLURL returns the url of the page of the result
+func (r *Result) HTMLURL() string {
+	switch r.Type {
+	case TypeRepository:
+		return r.Repository.HTMLURL()
+	case TypeCode:
+		return r.Repository.HTMLURL() + "/src/commit/" + util.PathEscapeSegments(r.Code.CommitID) + "/" + util.PathEscapeSegments(r.Code.Filename)
+	case TypeIssue:
+		return r.Issue.HTMLURL()
+	case TypeUser:
+		return r.User.HTMLURL()
+	case TypePackage:
+		return r.Package.VersionHTMLURL()
+	}
+	return ""
+}
+
+// Response a page of results
+type Response struct {
+	Counts      map[Type]int64 // the total number of matches of each searched type
+	Results     []*Result
+	Unavailable []Type // the searched types whose indexers are not available
+	NextCursor  Cursor // the cursor of the next page, nil if there are no more results
+}
+
+// errUnavailable is returned by the searches whose indexer is not available
+var errUnavailable = errors.New("indexer is not available")
+
+// searchFunc searches the items of a type, it returns the results from the offset in the order of their rank and
+// the total number of matches. The results the doer can't access are nil, so they still count in the offsets.
+type searchFunc func(ctx context.Context, opts *Options, terms []string, offset int) ([]*Result, int64, error)
+
+var searchFuncs = map[Type]searchFunc{
+	TypeRepository: searchRepositories,
+	TypeCode:       searchCode,
+	TypeIssue:      searchIssues,
+	TypeUser:       searchUsers,
+	TypePackage:    searchPackages,
+}
+
+// Search searches the items of all types by the keyword and merges the results by score
+func Search(ctx context.Context, opts *Options) (*Response, error) {
+	types := EnabledTypes()
+	if len(opts.Types) > 0 {
+		types = slices.DeleteFunc(types, func(t Type) bool { return !slices.Contains(opts.Types, t) })
+	}
+	terms := keywordTerms(opts.Keyword)
+
+	resp := &Response{Counts: make(map[Type]int64, len(types))}
+	candidates := make(map[Type][]*Result, len(types))
+	for _, t := range types {
+		results, total, err := searchFuncs[t](ctx, opts, terms, opts.Cursor[t])
+		if errors.Is(err, errUnavailable) {
+			log.Warn("Unable to search %s: %v", t, err)
+			resp.Unavailable = append(resp.Unavailable, t)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		resp.Counts[t] = total
+		candidates[t] = results
+	}
+
+	// merge the results by taking the best next result of the types one by one,
+	// so that the results of each type are always consumed in their order and the cursor can continue from there
+	next := make(map[Type]int, len(types))
+	for len(resp.Results) < opts.Limit {
+		var best *Result
+		for _, t := range types {
+			for next[t] < len(candidates[t]) && candidates[t][next[t]] == nil {
+				next[t]++ // skip the results the doer can't access
+			}
+			if next[t] < len(candidates[t]) {
+				if r := candidates[t][next[t]]; best == nil || r.Score > best.Score {
+					best = r
+				}
+			}
+		}
+		if best == nil {
+			break
+		}
+		resp.Results = append(resp.Results, best)
+		next[best.Type]++
+	}
+
+	cursor := Cursor{}
+	hasMore := false
+	for _, t := range types {
+		if _, ok := resp.Counts[t]; !ok {
+			continue
+		}
+		cursor[t] = opts.Cursor[t] + next[t]
+		hasMore = hasMore || int64(cursor[t]) < resp.Counts[t]
+	}
+	if hasMore {
+		resp.NextCursor = cursor
+	}
+	return resp, nil
+}
+
+// score returns the score of the result at the offset in the results of its search
+func score(offset int, name, keyword string) float64 {
+	boost := 1.0
+	name, keyword = strings.ToLower(name), strings.ToLower(keyword)
+	if name == keyword {
+		boost = 2
+	} else if strings.HasPrefix(name, keyword) {
+		boost = 1.5
+	}
+	return boost / float64(rrfRankConstant+offset+1)
+}
+
+// nonNilHighlights returns the highlights of the fields which contain the terms
+func nonNilHighlights(highlights ...*Highlight) []*Highlight {
+	return slices.DeleteFunc(highlights, func(h *Highlight) bool { return h == nil })
+}
+
+// searchWindow returns the results from the offset of a search which is only paginated by pages
+func searchWindow[T any](offset, limit int, search func(listOptions db.ListOptions) ([]T, int64, error)) ([]T, int64, error) {
+	skip := offset % limit
+	items, total, err := search(db.ListOptions{Page: offset/limit + 1, PageSize: limit})
+	if err != nil {
+		return nil, 0, err
+	}
+	items = items[min(skip, len(items)):]
+	if skip > 0 && int64(offset+len(items)) < total {
+		more, _, err := search(db.ListOptions{Page: offset/limit + 2, PageSize: limit})
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, more[:min(skip, len(more))]...)
+	}
+	return items, total, nil
+}
+
+// permissionCache caches the permissions of the doer in the repositories of the results
+type permissionCache map[int64]access_model.Permission
+
+func (c permissionCache) get(ctx context.Context, repo *repo_model.Repository, doer *user_model.User) (access_model.Permission, error) {
+	if perm, ok := c[repo.ID]; ok {
+		return perm, nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return perm, err
+	}
+	c[repo.ID] = perm
+	return perm, nil
+}
+
+func searchRepositories(ctx context.Context, opts *Options, terms []string, offset int) ([]*Result, int64, error) {
+	repos, total, err := searchWindow(offset, opts.Limit, func(listOptions db.ListOptions) ([]*repo_model.Repository, int64, error) {
+		return repo_model.SearchRepository(ctx, &repo_model.SearchRepoOptions{
+			ListOptions:        listOptions,
+			Actor:              opts.Doer,
+			OrderBy:            db.SearchOrderByStarsReverse,
+			Private:            opts.Doer != nil,
+			Keyword:            opts.Keyword,
+			AllPublic:          true,
+			AllLimited:         true,
+			IncludeDescription: setting.UI.SearchRepoDescription,
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	perms := permissionCache{}
+	results := make([]*Result, len(repos))
+	for i, repo := range repos {
+		perm, err := perms.get(ctx, repo, opts.Doer)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !perm.HasAnyUnitAccessOrEveryoneAccess() {
+			continue
+		}
+		results[i] = &Result{
+			Type:       TypeRepository,
+			Score:      score(offset+i, repo.Name, opts.Keyword),
+			Highlights: nonNilHighlights(newHighlight("name", repo.FullName(), terms), newHighlight("description", repo.Description, terms)),
+			Repository: repo,
+		}
+	}
+	return results, total, nil
+}
+
+// maxCodeHighlights is the maximum number of matched lines highlighted for a file
+const maxCodeHighlights = 3
+
+func searchCode(ctx context.Context, opts *Options, terms []string, offset int) ([]*Result, int64, error) {
+	var repoIDs []int64
+	if opts.Doer == nil || !opts.Doer.IsAdmin {
+		var err error
+		repoIDs, err = repo_model.FindUserCodeAccessibleRepoIDs(ctx, opts.Doer)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(repoIDs) == 0 {
+			return nil, 0, nil
+		}
+	}
+
+	total, codeResults, _, err := code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+		RepoIDs:   repoIDs,
+		Keyword:   opts.Keyword,
+		Paginator: db.NewAbsoluteListOptions(offset, opts.Limit),
+	})
+	if err != nil {
+		if !code_indexer.IsAvailable(ctx) {
+			return nil, 0, errors.Join(errUnavailable, err)
+		}
+		return nil, 0, err
+	}
+
+	loadRepoIDs := make([]int64, 0, len(codeResults))
+	for _, r := range codeResults {
+		loadRepoIDs = append(loadRepoIDs, r.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, loadRepoIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	perms := permissionCache{}
+	results := make([]*Result, len(codeResults))
+	for i, r := range codeResults {
+		repo, ok := repos[r.RepoID]
+		if !ok {
+			continue // the repository has been deleted
+		}
+		perm, err := perms.get(ctx, repo, opts.Doer)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !perm.CanRead(unit.TypeCode) {
+			continue
+		}
+
+		highlights := nonNilHighlights(newHighlight("path", r.Filename, terms))
+		for _, line := range r.Lines {
+			if len(highlights) > maxCodeHighlights {
+				break
+			}
+			if h := newHighlight("content", line.PlainContent(), terms); h != nil {
+				highlights = append(highlights, h)
+			}
+		}
+		results[i] = &Result{
+			Type:       TypeCode,
+			Score:      score(offset+i, path.Base(r.Filename), opts.Keyword),
+			Highlights: highlights,
+			Repository: repo,
+			Code:       r,
+		}
+	}
+	return results, int64(total), nil
+}
+
+func searchIssues(ctx context.Context, opts *Options, terms []string, offset int) ([]*Result, int64, error) {
+	// find the repositories the doer can access, the public ones are filtered by the indexer
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, &repo_model.SearchRepoOptions{
+		Actor:      opts.Doer,
+		Private:    opts.Doer != nil,
+		AllLimited: opts.Doer != nil,
+		OrderBy:    db.SearchOrderByAlphabetically,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, total, err := searchWindow(offset, opts.Limit, func(listOptions db.ListOptions) ([]int64, int64, error) {
+		return issue_indexer.SearchIssues(ctx, &issue_indexer.SearchOptions{
+			Keyword:   opts.Keyword,
+			RepoIDs:   repoIDs,
+			AllPublic: true,
+			SortBy:    issue_indexer.SortByUpdatedDesc,
+			Paginator: &listOptions,
+		})
+	})
+	if err != nil {
+		if !issue_indexer.IsAvailable(ctx) {
+			return nil, 0, errors.Join(errUnavailable, err)
+		}
+		return nil, 0, err
+	}
+
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return nil, 0, err
+	}
+	issuesByID := make(map[int64]*issues_model.Issue, len(issues))
+	for _, issue := range issues {
+		issuesByID[issue.ID] = issue
+	}
+
+	perms := permissionCache{}
+	results := make([]*Result, len(ids))
+	for i, id := range ids {
+		issue, ok := issuesByID[id]
+		if !ok {
+			continue // the issue has been deleted
+		}
+		perm, err := perms.get(ctx, issue.Repo, opts.Doer)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+			continue
+		}
+		results[i] = &Result{
+			Type:       TypeIssue,
+			Score:      score(offset+i, issue.Title, opts.Keyword),
+			Highlights: nonNilHighlights(newHighlight("title", issue.Title, terms), newHighlight("body", issue.Content, terms)),
+			Repository: issue.Repo,
+			Issue:      issue,
+		}
+	}
+	return results, total, nil
+}
+
+func searchUsers(ctx context.Context, opts *Options, terms []string, offset int) ([]*Result, int64, error) {
+	users, total, err := searchWindow(offset, opts.Limit, func(listOptions db.ListOptions) ([]*user_model.User, int64, error) {
+		return user_model.SearchUsers(ctx, &user_model.SearchUserOptions{
+			ListOptions: listOptions,
+			Actor:       opts.Doer,
+			Keyword:     opts.Keyword,
+			Type:        user_model.UserTypeIndividual,
+			IsActive:    optional.Some(true),
+			Visible:     []structs.VisibleType{structs.VisibleTypePublic, structs.VisibleTypeLimited, structs.VisibleTypePrivate},
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*Result, len(users))
+	for i, u := range users {
+		if !user_model.IsUserVisibleToViewer(ctx, u, opts.Doer) {
+			continue
+		}
+		results[i] = &Result{
+			Type:       TypeUser,
+			Score:      score(offset+i, u.Name, opts.Keyword),
+			Highlights: nonNilHighlights(newHighlight("name", u.Name, terms), newHighlight("full_name", u.FullName, terms)),
+			User:       u,
+		}
+	}
+	return results, total, nil
+}
+
+func searchPackages(ctx context.Context, opts *Options, terms []string, offset int) ([]*Result, int64, error) {
+	pvs, total, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerCond:   user_model.BuildCanSeeUserCondition(opts.Doer),
+		PackageCond: packages_model.UserReadableCond(opts.Doer),
+		Name:        packages_model.SearchValue{Value: opts.Keyword},
+		IsInternal:  optional.Some(false),
+		Paginator:   db.NewAbsoluteListOptions(offset, opts.Limit),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*Result, len(pds))
+	for i, pd := range pds {
+		if !organization.HasOrgOrUserVisible(ctx, pd.Owner, opts.Doer) {
+			continue
+		}
+		results[i] = &Result{
+			Type:       TypePackage,
+			Score:      score(offset+i, pd.Package.Name, opts.Keyword),
+			Highlights: nonNilHighlights(newHighlight("name", pd.Package.Name, terms)),
+			Package:    pd,
+		}
+	}
+	return results, total, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package search
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	cursor, err := ParseCursor("")
+	require.NoError(t, err)
+	assert.Empty(t, cursor)
+
+	cursor = Cursor{TypeRepository: 3, TypeIssue: 10}
+	parsed, err := ParseCursor(cursor.String())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+
+	for _, s := range []string{
+		"not base64!",
+		Cursor{"unknown": 1}.String(),
+		Cursor{TypeCode: -1}.String(),
+	} {
+		_, err = ParseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNewHighlight(t *testing.T) {
+	assert.Nil(t, newHighlight("name", "gitea", keywordTerms("forgejo")))
+
+	h := newHighlight("description", "A painless  self-hosted Git service, git everywhere", keywordTerms("GIT hosted"))
+	require.NotNil(t, h)
+	assert.Equal(t, "A painless self-hosted Git service, git everywhere", h.Fragment)
+	assert.Equal(t, [][2]int{{16, 22}, {23, 26}, {36, 39}}, h.Matches)
+	assert.Equal(t, template.HTML("A painless self-<mark>hosted</mark> <mark>Git</mark> service, <mark>git</mark> everywhere"), h.HTML())
+
+	// the overlapping matches are merged
+	h = newHighlight("name", "gitea", keywordTerms("git tea"))
+	assert.Equal(t, [][2]int{{0, 5}}, h.Matches)
+
+	// long texts are cut around the first match
+	text := strings.Repeat("a ", 200) + "needle" + strings.Repeat(" b", 200)
+	h = newHighlight("body", text, keywordTerms("needle"))
+	assert.Len(t, []rune(h.Fragment), maxFragmentLength)
+	assert.Equal(t, [][2]int{{maxFragmentLength / 4, maxFragmentLength/4 + 6}}, h.Matches)
+	assert.Equal(t, "<mark>", string(h.HTML()[maxFragmentLength/4:maxFragmentLength/4+6]))
+
+	// the positions are counted in characters
+	h = newHighlight("title", "über Gitea", keywordTerms("gitea"))
+	assert.Equal(t, [][2]int{{5, 10}}, h.Matches)
+}
+
+func TestScore(t *testing.T) {
+	assert.Greater(t, score(0, "gitea", "gitea"), score(0, "gitea-mirror", "gitea"))
+	assert.Greater(t, score(0, "gitea-mirror", "gitea"), score(0, "my-gitea", "gitea"))
+	assert.Greater(t, score(0, "my-gitea", "gitea"), score(1, "my-gitea", "gitea"))
+	// an exact match ranked lower is still preferred over the first result of another search
+	assert.Greater(t, score(10, "Gitea", "gitea"), score(0, "my-gitea", "gitea"))
+}
+
+func TestSearchWindow(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	search := func(listOptions db.ListOptions) ([]int, int64, error) {
+		start := min((listOptions.Page-1)*listOptions.PageSize, len(items))
+		end := min(start+listOptions.PageSize, len(items))
+		return items[start:end], int64(len(items)), nil
+	}
+
+	cases := []struct {
+		offset, limit int
+		expected      []int
+	}{
+		{0, 4, []int{0, 1, 2, 3}},
+		{4, 4, []int{4, 5, 6, 7}},
+		{3, 4, []int{3, 4, 5, 6}},
+		{9, 4, []int{9, 10}},
+		{11, 4, []int{}},
+	}
+	for _, c := range cases {
+		window, total, err := searchWindow(c.offset, c.limit, search)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(items), total)
+		assert.Equal(t, c.expected, window, "offset %d", c.offset)
+	}
+}
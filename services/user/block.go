@@ -109,10 +109,10 @@ func BlockUser(ctx context.Context, doer, blocker, blockee *user_model.User, not
 		}
 
 		// remove each other from repository collaborations
-		if err := removeCollaborations(ctx, blocker, blockee); err != nil {
+		if err := removeCollaborations(ctx, doer, blocker, blockee); err != nil {
 			return err
 		}
-		if err := removeCollaborations(ctx, blockee, blocker); err != nil {
+		if err := removeCollaborations(ctx, doer, blockee, blocker); err != nil {
 			return err
 		}
 
@@ -244,7 +244,7 @@ func unassignIssues(ctx context.Context, assignee, repoOwner *user_model.User) e
 	}
 }
 
-func removeCollaborations(ctx context.Context, repoOwner, collaborator *user_model.User) error {
+func removeCollaborations(ctx context.Context, doer, repoOwner, collaborator *user_model.User) error {
 	opts := &repo_model.FindCollaborationOptions{
 		ListOptions: db.ListOptions{
 			Page:     1,
@@ -270,7 +270,7 @@ func removeCollaborations(ctx context.Context, repoOwner, collaborator *user_mod
 				return err
 			}
 
-			if err := repo_service.DeleteCollaboration(ctx, repo, collaborator); err != nil {
+			if err := repo_service.DeleteCollaboration(ctx, doer, repo, collaborator); err != nil {
 				return err
 			}
 		}
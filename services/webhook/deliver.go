@@ -23,7 +23,6 @@ import (
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/hostmatcher"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/proxy"
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
@@ -201,20 +200,9 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		Headers: map[string]string{},
 	}
 
-	// OK We're now ready to attempt to deliver the task - we must double check that it
-	// has not been delivered in the meantime
-	updated, err := webhook_model.MarkTaskDelivered(ctx, t)
-	if err != nil {
-		log.Error("MarkTaskDelivered[%d]: %v", t.ID, err)
-		return fmt.Errorf("unable to mark task[%d] delivered in the db: %w", t.ID, err)
-	}
-	if !updated {
-		// This webhook task has already been attempted to be delivered or is in the process of being delivered
-		log.Trace("Webhook Task[%d] already delivered", t.ID)
-		return nil
-	}
-
-	// All code from this point will update the hook task
+	// All code from this point will update the hook task, the task is only marked as delivered
+	// after the attempt has been recorded, so an interrupted delivery is attempted again
+	// once the lease on the task has expired
 	defer func() {
 		t.Delivered = timeutil.TimeStampNanoNow()
 		if t.IsSucceed {
@@ -250,8 +238,15 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
-	resp, err := webhookHTTPClient.Do(req.WithContext(ctx))
+	client, err := getWebhookHTTPClient(w)
 	if err != nil {
+		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
+		return fmt.Errorf("unable to deliver webhook task[%d] in %s due to invalid transport settings: %w", t.ID, w.URL, err)
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		err = describeDeliveryError(err)
 		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
 		return fmt.Errorf("unable to deliver webhook task[%d] in %s due to error in http client: %w", t.ID, w.URL, err)
 	}
@@ -274,9 +269,10 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 }
 
 var (
-	webhookHTTPClient *http.Client
-	once              sync.Once
-	hostMatchers      []glob.Glob
+	webhookHTTPClient  *http.Client
+	allowedHostMatcher *hostmatcher.HostMatchList
+	once               sync.Once
+	hostMatchers       []glob.Glob
 )
 
 func webhookProxy(allowList *hostmatcher.HostMatchList) func(req *http.Request) (*url.URL, error) {
@@ -315,7 +311,7 @@ func Init() error {
 	if allowedHostListValue == "" {
 		allowedHostListValue = hostmatcher.MatchBuiltinExternal
 	}
-	allowedHostMatcher := hostmatcher.ParseHostMatchList("webhook.ALLOWED_HOST_LIST", allowedHostListValue)
+	allowedHostMatcher = hostmatcher.ParseHostMatchList("webhook.ALLOWED_HOST_LIST", allowedHostListValue)
 
 	webhookHTTPClient = &http.Client{
 		Timeout: timeout,
@@ -332,37 +328,7 @@ func Init() error {
 	}
 	go graceful.GetManager().RunWithCancel(hookQueue)
 
-	go graceful.GetManager().RunWithShutdownContext(populateWebhookSendingQueue)
+	go graceful.GetManager().RunWithShutdownContext(runOutboxRelay)
 
 	return nil
 }
-
-func populateWebhookSendingQueue(ctx context.Context) {
-	ctx, _, finished := process.GetManager().AddContext(ctx, "Webhook: Populate sending queue")
-	defer finished()
-
-	lowerID := int64(0)
-	for {
-		taskIDs, err := webhook_model.FindUndeliveredHookTaskIDs(ctx, lowerID)
-		if err != nil {
-			log.Error("Unable to populate webhook queue as FindUndeliveredHookTaskIDs failed: %v", err)
-			return
-		}
-		if len(taskIDs) == 0 {
-			return
-		}
-		lowerID = taskIDs[len(taskIDs)-1]
-
-		for _, taskID := range taskIDs {
-			select {
-			case <-ctx.Done():
-				log.Warn("Shutdown before Webhook Sending queue finishing being populated")
-				return
-			default:
-			}
-			if err := enqueueHookTask(taskID); err != nil {
-				log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", taskID, err)
-			}
-		}
-	}
-}
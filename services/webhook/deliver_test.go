@@ -239,6 +239,8 @@ func TestWebhookDeliverSpecificTypes(t *testing.T) {
 		webhook_module.MATRIX:     {httpMethod: "PUT"},
 		webhook_module.WECHATWORK: {},
 		webhook_module.PACKAGIST:  {},
+		webhook_module.GOOGLECHAT: {},
+		webhook_module.NTFY:       {},
 	}
 
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
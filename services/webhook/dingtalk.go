@@ -182,6 +182,48 @@ func (dingtalkConvertor) WorkflowJob(p *api.WorkflowJobPayload) (DingtalkPayload
 	return createDingtalkPayload(text, text, "Workflow Job", p.WorkflowJob.HTMLURL), nil
 }
 
+func (dingtalkConvertor) Organization(p *api.OrganizationPayload) (DingtalkPayload, error) {
+	text, _, link := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "Organization", link), nil
+}
+
+func (dingtalkConvertor) Membership(p *api.MembershipPayload) (DingtalkPayload, error) {
+	text, _, link := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "Team Membership", link), nil
+}
+
+func (dingtalkConvertor) Team(p *api.TeamPayload) (DingtalkPayload, error) {
+	text, _, link := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "Team Access", link), nil
+}
+
+func (dingtalkConvertor) Member(p *api.MemberPayload) (DingtalkPayload, error) {
+	text, _, link := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "Collaborator", link), nil
+}
+
+func (dingtalkConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (DingtalkPayload, error) {
+	text, _, link := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "Branch Protection", link), nil
+}
+
+func (dingtalkConvertor) Star(p *api.StarPayload) (DingtalkPayload, error) {
+	text, _, link := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "Star", link), nil
+}
+
+func (dingtalkConvertor) User(p *api.UserPayload) (DingtalkPayload, error) {
+	text, _, link := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "User", link), nil
+}
+
 func createDingtalkPayload(title, text, singleTitle, singleURL string) DingtalkPayload {
 	return DingtalkPayload{
 		MsgType: "actionCard",
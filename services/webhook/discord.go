@@ -277,6 +277,48 @@ func (d discordConvertor) WorkflowJob(p *api.WorkflowJobPayload) (DiscordPayload
 	return d.createPayload(p.Sender, text, "", p.WorkflowJob.HTMLURL, color), nil
 }
 
+func (d discordConvertor) Organization(p *api.OrganizationPayload) (DiscordPayload, error) {
+	text, color, link := getOrganizationPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Membership(p *api.MembershipPayload) (DiscordPayload, error) {
+	text, color, link := getMembershipPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Team(p *api.TeamPayload) (DiscordPayload, error) {
+	text, color, link := getTeamPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Member(p *api.MemberPayload) (DiscordPayload, error) {
+	text, color, link := getMemberPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (DiscordPayload, error) {
+	text, color, link := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Star(p *api.StarPayload) (DiscordPayload, error) {
+	text, color, link := getStarPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) User(p *api.UserPayload) (DiscordPayload, error) {
+	text, color, link := getUserPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
 func newDiscordRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
 	meta := &DiscordMeta{}
 	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
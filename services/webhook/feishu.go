@@ -178,6 +178,48 @@ func (feishuConvertor) WorkflowJob(p *api.WorkflowJobPayload) (FeishuPayload, er
 	return newFeishuTextPayload(text), nil
 }
 
+func (feishuConvertor) Organization(p *api.OrganizationPayload) (FeishuPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (feishuConvertor) Membership(p *api.MembershipPayload) (FeishuPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (feishuConvertor) Team(p *api.TeamPayload) (FeishuPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (feishuConvertor) Member(p *api.MemberPayload) (FeishuPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (feishuConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (FeishuPayload, error) {
+	text, _, _ := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (feishuConvertor) Star(p *api.StarPayload) (FeishuPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (feishuConvertor) User(p *api.UserPayload) (FeishuPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
 func newFeishuRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
 	var pc payloadConvertor[FeishuPayload] = feishuConvertor{}
 	return newJSONRequest(pc, w, t, true)
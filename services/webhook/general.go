@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"html"
 	"net/url"
+	"strconv"
 	"strings"
 
 	user_model "code.gitea.io/gitea/models/user"
@@ -301,6 +302,9 @@ func getPackagePayloadInfo(p *api.PackagePayload, linkFormatter linkFormatter, w
 	case api.HookPackageDeleted:
 		text = fmt.Sprintf("Package deleted: %s", refLink)
 		color = redColor
+	case api.HookPackageAccessUpdated:
+		text = fmt.Sprintf("Package access updated: %s", refLink)
+		color = yellowColor
 	}
 	if withSender {
 		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
@@ -356,6 +360,152 @@ func getWorkflowJobPayloadInfo(p *api.WorkflowJobPayload, linkFormatter linkForm
 	return text, color
 }
 
+func getOrganizationPayloadInfo(p *api.OrganizationPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = setting.AppURL + url.PathEscape(p.Organization.Name)
+	orgLink := linkFormatter(link, p.Organization.Name)
+	memberLink := linkFormatter(p.Member.HTMLURL, p.Member.UserName)
+
+	switch p.Action {
+	case api.HookOrganizationMemberAdded:
+		text = fmt.Sprintf("[%s] Member %s added", orgLink, memberLink)
+		color = greenColor
+	case api.HookOrganizationMemberRemoved:
+		text = fmt.Sprintf("[%s] Member %s removed", orgLink, memberLink)
+		color = redColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
+func getMembershipPayloadInfo(p *api.MembershipPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = setting.AppURL + "org/" + url.PathEscape(p.Organization.Name) + "/teams/" + url.PathEscape(p.Team.Name)
+	teamLink := linkFormatter(link, p.Organization.Name+"/"+p.Team.Name)
+	memberLink := linkFormatter(p.Member.HTMLURL, p.Member.UserName)
+
+	switch p.Action {
+	case api.HookMembershipAdded:
+		text = fmt.Sprintf("[%s] Member %s added to team", teamLink, memberLink)
+		color = greenColor
+	case api.HookMembershipRemoved:
+		text = fmt.Sprintf("[%s] Member %s removed from team", teamLink, memberLink)
+		color = redColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
+func getTeamPayloadInfo(p *api.TeamPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.Repository.HTMLURL + "/settings/collaboration"
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+	teamLink := linkFormatter(setting.AppURL+"org/"+url.PathEscape(p.Organization.Name)+"/teams/"+url.PathEscape(p.Team.Name), p.Team.Name)
+
+	switch p.Action {
+	case api.HookTeamAddedToRepository:
+		text = fmt.Sprintf("[%s] Team %s granted %s access", repoLink, teamLink, p.Team.Permission)
+		color = greenColor
+	case api.HookTeamRemovedFromRepository:
+		text = fmt.Sprintf("[%s] Team %s access removed", repoLink, teamLink)
+		color = redColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
+func getMemberPayloadInfo(p *api.MemberPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.Repository.HTMLURL + "/settings/collaboration"
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+	memberLink := linkFormatter(p.Member.HTMLURL, p.Member.UserName)
+
+	switch p.Action {
+	case api.HookMemberAdded:
+		text = fmt.Sprintf("[%s] Collaborator %s added with %s access", repoLink, memberLink, p.Permission)
+		color = greenColor
+	case api.HookMemberEdited:
+		text = fmt.Sprintf("[%s] Collaborator %s access changed to %s", repoLink, memberLink, p.Permission)
+		color = yellowColor
+	case api.HookMemberRemoved:
+		text = fmt.Sprintf("[%s] Collaborator %s removed", repoLink, memberLink)
+		color = redColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
+func getBranchProtectionRulePayloadInfo(p *api.BranchProtectionRulePayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.Repository.HTMLURL + "/settings/branches"
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+
+	switch p.Action {
+	case api.HookBranchProtectionRuleCreated:
+		text = fmt.Sprintf("[%s] Branch protection rule '%s' created", repoLink, p.Rule.RuleName)
+		color = greenColor
+	case api.HookBranchProtectionRuleEdited:
+		text = fmt.Sprintf("[%s] Branch protection rule '%s' edited", repoLink, p.Rule.RuleName)
+		color = yellowColor
+	case api.HookBranchProtectionRuleDeleted:
+		text = fmt.Sprintf("[%s] Branch protection rule '%s' deleted", repoLink, p.Rule.RuleName)
+		color = redColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
+func getStarPayloadInfo(p *api.StarPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.Repository.HTMLURL
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+
+	switch p.Action {
+	case api.HookStarCreated:
+		text = fmt.Sprintf("[%s] Repository starred", repoLink)
+		color = yellowColor
+	case api.HookStarDeleted:
+		text = fmt.Sprintf("[%s] Repository unstarred", repoLink)
+		color = greyColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
+func getUserPayloadInfo(p *api.UserPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.User.HTMLURL
+	userLink := linkFormatter(p.User.HTMLURL, p.User.UserName)
+
+	switch p.Action {
+	case api.HookUserCreated:
+		text = fmt.Sprintf("User account %s created", userLink)
+		color = greenColor
+	case api.HookUserSuspended:
+		text = fmt.Sprintf("User account %s suspended", userLink)
+		color = redColor
+	case api.HookUserUnsuspended:
+		text = fmt.Sprintf("User account %s unsuspended", userLink)
+		color = yellowColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, color, link
+}
+
 // ToHook convert models.Webhook to api.Hook
 // This function is not part of the convert package to prevent an import cycle
 func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
@@ -369,6 +519,11 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		config["username"] = s.Username
 		config["icon_url"] = s.IconURL
 		config["color"] = s.Color
+	} else if w.Type == webhook_module.NTFY {
+		s := GetNtfyHook(w)
+		config["topic"] = s.Topic
+		config["priority"] = strconv.Itoa(s.Priority)
+		config["tags"] = strings.Join(s.Tags, ",")
 	}
 
 	authorizationHeader, err := w.HeaderAuthorization()
@@ -384,6 +539,9 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		Config:              config,
 		Events:              w.EventsArray(),
 		AuthorizationHeader: authorizationHeader,
+		ClientCertificate:   w.ClientCertificate,
+		CACertificate:       w.CACertificate,
+		TLSServerName:       w.TLSServerName,
 		Updated:             w.UpdatedUnix.AsTime(),
 		Created:             w.CreatedUnix.AsTime(),
 		BranchFilter:        w.BranchFilter,
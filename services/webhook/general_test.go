@@ -341,6 +341,55 @@ func packageTestPayload() *api.PackagePayload {
 	}
 }
 
+func memberTestPayload() *api.MemberPayload {
+	return &api.MemberPayload{
+		Action: api.HookMemberAdded,
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+		Member: &api.User{
+			UserName: "user2",
+			HTMLURL:  "http://localhost:3000/user2",
+		},
+		Permission: "write",
+		Repository: &api.Repository{
+			HTMLURL:  "http://localhost:3000/test/repo",
+			Name:     "repo",
+			FullName: "test/repo",
+		},
+	}
+}
+
+func starTestPayload() *api.StarPayload {
+	return &api.StarPayload{
+		Action: api.HookStarCreated,
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+		Repository: &api.Repository{
+			HTMLURL:  "http://localhost:3000/test/repo",
+			Name:     "repo",
+			FullName: "test/repo",
+		},
+	}
+}
+
+func userTestPayload() *api.UserPayload {
+	return &api.UserPayload{
+		Action: api.HookUserCreated,
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+		User: &api.User{
+			UserName: "user2",
+			HTMLURL:  "http://localhost:3000/user2",
+		},
+	}
+}
+
 func TestGetIssuesPayloadInfo(t *testing.T) {
 	p := issueTestPayload()
 
@@ -647,3 +696,71 @@ func TestGetIssueCommentPayloadInfo(t *testing.T) {
 		assert.Equal(t, c.color, color, "case %d", i)
 	}
 }
+
+func TestGetMemberPayloadInfo(t *testing.T) {
+	p := memberTestPayload()
+
+	cases := []struct {
+		action api.HookMemberAction
+		text   string
+		color  int
+	}{
+		{
+			api.HookMemberAdded,
+			"[test/repo] Collaborator user2 added with write access by user1",
+			greenColor,
+		},
+		{
+			api.HookMemberEdited,
+			"[test/repo] Collaborator user2 access changed to write by user1",
+			yellowColor,
+		},
+		{
+			api.HookMemberRemoved,
+			"[test/repo] Collaborator user2 removed by user1",
+			redColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.Action = c.action
+		text, color, link := getMemberPayloadInfo(p, noneLinkFormatter, true)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+		assert.Equal(t, "http://localhost:3000/test/repo/settings/collaboration", link, "case %d", i)
+	}
+}
+
+func TestGetUserPayloadInfo(t *testing.T) {
+	p := userTestPayload()
+
+	cases := []struct {
+		action api.HookUserAction
+		text   string
+		color  int
+	}{
+		{
+			api.HookUserCreated,
+			"User account user2 created",
+			greenColor,
+		},
+		{
+			api.HookUserSuspended,
+			"User account user2 suspended",
+			redColor,
+		},
+		{
+			api.HookUserUnsuspended,
+			"User account user2 unsuspended",
+			yellowColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.Action = c.action
+		text, color, link := getUserPayloadInfo(p, noneLinkFormatter, false)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+		assert.Equal(t, "http://localhost:3000/user2", link, "case %d", i)
+	}
+}
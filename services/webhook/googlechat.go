@@ -0,0 +1,322 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+type (
+	// GoogleChatOpenLink is the action of a button opening a link
+	GoogleChatOpenLink struct {
+		URL string `json:"url"`
+	}
+
+	// GoogleChatOnClick is the action of a button
+	GoogleChatOnClick struct {
+		OpenLink GoogleChatOpenLink `json:"openLink"`
+	}
+
+	// GoogleChatButton is a text button of a card
+	GoogleChatButton struct {
+		Text    string            `json:"text"`
+		OnClick GoogleChatOnClick `json:"onClick"`
+	}
+
+	// GoogleChatButtonList is a widget with a list of buttons
+	GoogleChatButtonList struct {
+		Buttons []GoogleChatButton `json:"buttons"`
+	}
+
+	// GoogleChatTextParagraph is a widget with formatted text
+	GoogleChatTextParagraph struct {
+		Text string `json:"text"`
+	}
+
+	// GoogleChatWidget is an element of a card section, only one of its fields is set
+	GoogleChatWidget struct {
+		TextParagraph *GoogleChatTextParagraph `json:"textParagraph,omitempty"`
+		ButtonList    *GoogleChatButtonList    `json:"buttonList,omitempty"`
+	}
+
+	// GoogleChatCardSection is a section of a card
+	GoogleChatCardSection struct {
+		Widgets []GoogleChatWidget `json:"widgets"`
+	}
+
+	// GoogleChatCardHeader is the header of a card
+	GoogleChatCardHeader struct {
+		Title     string `json:"title"`
+		Subtitle  string `json:"subtitle,omitempty"`
+		ImageURL  string `json:"imageUrl,omitempty"`
+		ImageType string `json:"imageType,omitempty"`
+	}
+
+	// GoogleChatCard is a card of a message
+	GoogleChatCard struct {
+		Header   GoogleChatCardHeader    `json:"header"`
+		Sections []GoogleChatCardSection `json:"sections,omitempty"`
+	}
+
+	// GoogleChatCardV2 is a card with its identifier
+	GoogleChatCardV2 struct {
+		CardID string         `json:"cardId"`
+		Card   GoogleChatCard `json:"card"`
+	}
+
+	// GoogleChatPayload represents a Google Chat message with cards v2
+	GoogleChatPayload struct {
+		CardsV2 []GoogleChatCardV2 `json:"cardsV2"`
+	}
+)
+
+type googleChatConvertor struct{}
+
+// Create implements PayloadConvertor Create method
+func (gc googleChatConvertor) Create(p *api.CreatePayload) (GoogleChatPayload, error) {
+	refName := git.RefName(p.Ref).ShortName()
+	title := fmt.Sprintf("[%s] %s %s created", p.Repo.FullName, p.RefType, refName)
+
+	return createGoogleChatPayload(p.Sender, title, "", p.Repo.HTMLURL+"/src/"+util.PathEscapeSegments(refName)), nil
+}
+
+// Delete implements PayloadConvertor Delete method
+func (gc googleChatConvertor) Delete(p *api.DeletePayload) (GoogleChatPayload, error) {
+	refName := git.RefName(p.Ref).ShortName()
+	title := fmt.Sprintf("[%s] %s %s deleted", p.Repo.FullName, p.RefType, refName)
+
+	return createGoogleChatPayload(p.Sender, title, "", p.Repo.HTMLURL), nil
+}
+
+// Fork implements PayloadConvertor Fork method
+func (gc googleChatConvertor) Fork(p *api.ForkPayload) (GoogleChatPayload, error) {
+	title := fmt.Sprintf("%s is forked to %s", p.Forkee.FullName, p.Repo.FullName)
+
+	return createGoogleChatPayload(p.Sender, title, "", p.Repo.HTMLURL), nil
+}
+
+// Push implements PayloadConvertor Push method
+func (gc googleChatConvertor) Push(p *api.PushPayload) (GoogleChatPayload, error) {
+	var (
+		branchName = git.RefName(p.Ref).ShortName()
+		commitDesc string
+	)
+
+	var titleLink string
+	if p.TotalCommits == 1 {
+		commitDesc = "1 new commit"
+		titleLink = p.Commits[0].URL
+	} else {
+		commitDesc = fmt.Sprintf("%d new commits", p.TotalCommits)
+		titleLink = p.CompareURL
+	}
+	if titleLink == "" {
+		titleLink = p.Repo.HTMLURL + "/src/" + util.PathEscapeSegments(branchName)
+	}
+
+	title := fmt.Sprintf("[%s:%s] %s", p.Repo.FullName, branchName, commitDesc)
+
+	var text strings.Builder
+	// for each commit, generate a line with the summary of its message
+	for i, commit := range p.Commits {
+		message := strings.TrimRight(strings.SplitN(commit.Message, "\n", 2)[0], "\r")
+		if utf8.RuneCountInString(message) > 50 {
+			message = fmt.Sprintf("%.47s...", message)
+		}
+		fmt.Fprintf(&text, "%s %s - %s", htmlLinkFormatter(commit.URL, commit.ID[:7]), html.EscapeString(message), html.EscapeString(commit.Author.Name))
+		// add linebreak to each commit but the last
+		if i < len(p.Commits)-1 {
+			text.WriteString("<br>")
+		}
+	}
+
+	return createGoogleChatPayload(p.Sender, title, text.String(), titleLink), nil
+}
+
+// Issue implements PayloadConvertor Issue method
+func (gc googleChatConvertor) Issue(p *api.IssuePayload) (GoogleChatPayload, error) {
+	title, _, extraMarkdown, _ := getIssuesPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, googleChatFormatText(extraMarkdown), p.Issue.HTMLURL), nil
+}
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (gc googleChatConvertor) IssueComment(p *api.IssueCommentPayload) (GoogleChatPayload, error) {
+	title, _, _ := getIssueCommentPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, googleChatFormatText(p.Comment.Body), p.Comment.HTMLURL), nil
+}
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (gc googleChatConvertor) PullRequest(p *api.PullRequestPayload) (GoogleChatPayload, error) {
+	title, _, extraMarkdown, _ := getPullRequestPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, googleChatFormatText(extraMarkdown), p.PullRequest.HTMLURL), nil
+}
+
+// Review implements PayloadConvertor Review method
+func (gc googleChatConvertor) Review(p *api.PullRequestPayload, event webhook_module.HookEventType) (GoogleChatPayload, error) {
+	var text, title string
+	switch p.Action {
+	case api.HookIssueReviewed:
+		action, err := parseHookPullRequestEventType(event)
+		if err != nil {
+			return GoogleChatPayload{}, err
+		}
+
+		title = fmt.Sprintf("[%s] Pull request review %s: #%d %s", p.Repository.FullName, action, p.Index, p.PullRequest.Title)
+		text = p.Review.Content
+	}
+
+	return createGoogleChatPayload(p.Sender, title, googleChatFormatText(text), p.PullRequest.HTMLURL), nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (gc googleChatConvertor) Repository(p *api.RepositoryPayload) (GoogleChatPayload, error) {
+	var title, url string
+	switch p.Action {
+	case api.HookRepoCreated:
+		title = fmt.Sprintf("[%s] Repository created", p.Repository.FullName)
+		url = p.Repository.HTMLURL
+	case api.HookRepoDeleted:
+		title = fmt.Sprintf("[%s] Repository deleted", p.Repository.FullName)
+	}
+
+	return createGoogleChatPayload(p.Sender, title, "", url), nil
+}
+
+// Wiki implements PayloadConvertor Wiki method
+func (gc googleChatConvertor) Wiki(p *api.WikiPayload) (GoogleChatPayload, error) {
+	title, _, _ := getWikiPayloadInfo(p, noneLinkFormatter, false)
+	htmlLink := p.Repository.HTMLURL + "/wiki/" + url.PathEscape(p.Page)
+
+	var text string
+	if p.Action != api.HookWikiDeleted {
+		text = p.Comment
+	}
+
+	return createGoogleChatPayload(p.Sender, title, googleChatFormatText(text), htmlLink), nil
+}
+
+// Release implements PayloadConvertor Release method
+func (gc googleChatConvertor) Release(p *api.ReleasePayload) (GoogleChatPayload, error) {
+	title, _ := getReleasePayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, googleChatFormatText(p.Release.Note), p.Release.HTMLURL), nil
+}
+
+func (gc googleChatConvertor) Package(p *api.PackagePayload) (GoogleChatPayload, error) {
+	title, _ := getPackagePayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", p.Package.HTMLURL), nil
+}
+
+func (gc googleChatConvertor) Status(p *api.CommitStatusPayload) (GoogleChatPayload, error) {
+	title, _ := getStatusPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", p.TargetURL), nil
+}
+
+func (gc googleChatConvertor) WorkflowJob(p *api.WorkflowJobPayload) (GoogleChatPayload, error) {
+	title, _ := getWorkflowJobPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", p.WorkflowJob.HTMLURL), nil
+}
+
+func (gc googleChatConvertor) Organization(p *api.OrganizationPayload) (GoogleChatPayload, error) {
+	title, _, link := getOrganizationPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+func (gc googleChatConvertor) Membership(p *api.MembershipPayload) (GoogleChatPayload, error) {
+	title, _, link := getMembershipPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+func (gc googleChatConvertor) Team(p *api.TeamPayload) (GoogleChatPayload, error) {
+	title, _, link := getTeamPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+func (gc googleChatConvertor) Member(p *api.MemberPayload) (GoogleChatPayload, error) {
+	title, _, link := getMemberPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+func (gc googleChatConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (GoogleChatPayload, error) {
+	title, _, link := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+func (gc googleChatConvertor) Star(p *api.StarPayload) (GoogleChatPayload, error) {
+	title, _, link := getStarPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+func (gc googleChatConvertor) User(p *api.UserPayload) (GoogleChatPayload, error) {
+	title, _, link := getUserPayloadInfo(p, noneLinkFormatter, false)
+
+	return createGoogleChatPayload(p.Sender, title, "", link), nil
+}
+
+// googleChatFormatText escapes the text for a text paragraph, which only supports a few HTML tags
+func googleChatFormatText(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\r\n", "\n")
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+func createGoogleChatPayload(s *api.User, title, text, link string) GoogleChatPayload {
+	var widgets []GoogleChatWidget
+	if text != "" {
+		widgets = append(widgets, GoogleChatWidget{TextParagraph: &GoogleChatTextParagraph{Text: text}})
+	}
+	if link != "" {
+		widgets = append(widgets, GoogleChatWidget{ButtonList: &GoogleChatButtonList{
+			Buttons: []GoogleChatButton{{Text: "Open", OnClick: GoogleChatOnClick{OpenLink: GoogleChatOpenLink{URL: link}}}},
+		}})
+	}
+
+	card := GoogleChatCard{
+		Header: GoogleChatCardHeader{
+			Title:     title,
+			Subtitle:  s.UserName,
+			ImageURL:  s.AvatarURL,
+			ImageType: "CIRCLE",
+		},
+	}
+	if len(widgets) > 0 {
+		card.Sections = []GoogleChatCardSection{{Widgets: widgets}}
+	}
+
+	return GoogleChatPayload{
+		CardsV2: []GoogleChatCardV2{{CardID: "gitea", Card: card}},
+	}
+}
+
+func newGoogleChatRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	var pc payloadConvertor[GoogleChatPayload] = googleChatConvertor{}
+	return newJSONRequest(pc, w, t, true)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.GOOGLECHAT, newGoogleChatRequest)
+}
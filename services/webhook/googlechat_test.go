@@ -0,0 +1,150 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoogleChatPayload(t *testing.T) {
+	gc := googleChatConvertor{}
+
+	assertCard := func(t *testing.T, pl GoogleChatPayload, p *api.User, title string) GoogleChatCard {
+		require.Len(t, pl.CardsV2, 1)
+		card := pl.CardsV2[0].Card
+		assert.Equal(t, title, card.Header.Title)
+		assert.Equal(t, p.UserName, card.Header.Subtitle)
+		assert.Equal(t, p.AvatarURL, card.Header.ImageURL)
+		return card
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		p := createTestPayload()
+
+		pl, err := gc.Create(p)
+		require.NoError(t, err)
+
+		card := assertCard(t, pl, p.Sender, "[test/repo] branch test created")
+		require.Len(t, card.Sections, 1)
+		require.Len(t, card.Sections[0].Widgets, 1)
+		assert.Nil(t, card.Sections[0].Widgets[0].TextParagraph)
+		assert.Equal(t, "http://localhost:3000/test/repo/src/test", card.Sections[0].Widgets[0].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		p := pushTestPayload()
+
+		pl, err := gc.Push(p)
+		require.NoError(t, err)
+
+		card := assertCard(t, pl, p.Sender, "[test/repo:test] 2 new commits")
+		require.Len(t, card.Sections[0].Widgets, 2)
+		assert.Equal(t, `<a href="http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778">2020558</a> commit message - user1<br><a href="http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778">2020558</a> commit message - user1`, card.Sections[0].Widgets[0].TextParagraph.Text)
+		assert.Equal(t, "http://localhost:3000/test/repo/src/test", card.Sections[0].Widgets[1].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		p := issueTestPayload()
+		p.Issue.Body = "issue <body>\nsecond line"
+
+		p.Action = api.HookIssueOpened
+		pl, err := gc.Issue(p)
+		require.NoError(t, err)
+
+		card := assertCard(t, pl, p.Sender, "[test/repo] Issue opened: #2 crash")
+		assert.Equal(t, "issue &lt;body&gt;<br>second line", card.Sections[0].Widgets[0].TextParagraph.Text)
+		assert.Equal(t, "http://localhost:3000/test/repo/issues/2", card.Sections[0].Widgets[1].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+
+		p.Action = api.HookIssueClosed
+		pl, err = gc.Issue(p)
+		require.NoError(t, err)
+
+		card = assertCard(t, pl, p.Sender, "[test/repo] Issue closed: #2 crash")
+		require.Len(t, card.Sections[0].Widgets, 1)
+	})
+
+	t.Run("IssueComment", func(t *testing.T) {
+		p := issueCommentTestPayload()
+
+		pl, err := gc.IssueComment(p)
+		require.NoError(t, err)
+
+		card := assertCard(t, pl, p.Sender, "[test/repo] New comment on issue #2 crash")
+		assert.Equal(t, "more info needed", card.Sections[0].Widgets[0].TextParagraph.Text)
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		p := pullRequestTestPayload()
+		p.Action = api.HookIssueReviewed
+
+		pl, err := gc.Review(p, webhook_module.HookEventPullRequestReviewApproved)
+		require.NoError(t, err)
+
+		card := assertCard(t, pl, p.Sender, "[test/repo] Pull request review approved: #12 Fix bug")
+		assert.Equal(t, "good job", card.Sections[0].Widgets[0].TextParagraph.Text)
+	})
+
+	t.Run("Repository", func(t *testing.T) {
+		p := repositoryTestPayload()
+		p.Action = api.HookRepoDeleted
+
+		pl, err := gc.Repository(p)
+		require.NoError(t, err)
+
+		card := assertCard(t, pl, p.Sender, "[test/repo] Repository deleted")
+		assert.Empty(t, card.Sections)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		p := pullReleaseTestPayload()
+
+		pl, err := gc.Release(p)
+		require.NoError(t, err)
+
+		assertCard(t, pl, p.Sender, "[test/repo] Release created: v1.0")
+	})
+}
+
+func TestGoogleChatJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.GOOGLECHAT,
+		URL:        "https://chat.googleapis.com/v1/spaces/AAAA/messages?key=key&token=token",
+		Meta:       `{}`,
+		HTTPMethod: "POST",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newGoogleChatRequest(t.Context(), hook, task)
+	require.NotNil(t, req)
+	require.NotNil(t, reqBody)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://chat.googleapis.com/v1/spaces/AAAA/messages?key=key&token=token", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	var body GoogleChatPayload
+	err = json.NewDecoder(req.Body).Decode(&body)
+	assert.NoError(t, err)
+	require.Len(t, body.CardsV2, 1)
+	assert.Equal(t, "[test/repo:test] 2 new commits", body.CardsV2[0].Card.Header.Title)
+}
@@ -240,6 +240,8 @@ func (m matrixConvertor) Package(p *api.PackagePayload) (MatrixPayload, error) {
 		text = fmt.Sprintf("[%s] Package published by %s", packageLink, senderLink)
 	case api.HookPackageDeleted:
 		text = fmt.Sprintf("[%s] Package deleted by %s", packageLink, senderLink)
+	case api.HookPackageAccessUpdated:
+		text = fmt.Sprintf("[%s] Package access updated by %s", packageLink, senderLink)
 	}
 
 	return m.newPayload(text)
@@ -258,6 +260,48 @@ func (m matrixConvertor) WorkflowJob(p *api.WorkflowJobPayload) (MatrixPayload,
 	return m.newPayload(text)
 }
 
+func (m matrixConvertor) Organization(p *api.OrganizationPayload) (MatrixPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Membership(p *api.MembershipPayload) (MatrixPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Team(p *api.TeamPayload) (MatrixPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Member(p *api.MemberPayload) (MatrixPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (MatrixPayload, error) {
+	text, _, _ := getBranchProtectionRulePayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Star(p *api.StarPayload) (MatrixPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) User(p *api.UserPayload) (MatrixPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
 var urlRegex = regexp.MustCompile(`<a [^>]*?href="([^">]*?)">(.*?)</a>`)
 
 func getMessageBody(htmlText string) string {
@@ -331,6 +331,104 @@ func (msteamsConvertor) WorkflowJob(p *api.WorkflowJobPayload) (MSTeamsPayload,
 	), nil
 }
 
+func (msteamsConvertor) Organization(p *api.OrganizationPayload) (MSTeamsPayload, error) {
+	title, color, link := getOrganizationPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		&MSTeamsFact{"Member:", p.Member.UserName},
+	), nil
+}
+
+func (msteamsConvertor) Membership(p *api.MembershipPayload) (MSTeamsPayload, error) {
+	title, color, link := getMembershipPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		&MSTeamsFact{"Team:", p.Team.Name},
+	), nil
+}
+
+func (msteamsConvertor) Team(p *api.TeamPayload) (MSTeamsPayload, error) {
+	title, color, link := getTeamPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		&MSTeamsFact{"Team:", p.Team.Name},
+	), nil
+}
+
+func (msteamsConvertor) Member(p *api.MemberPayload) (MSTeamsPayload, error) {
+	title, color, link := getMemberPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		&MSTeamsFact{"Collaborator:", p.Member.UserName},
+	), nil
+}
+
+func (msteamsConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (MSTeamsPayload, error) {
+	title, color, link := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		&MSTeamsFact{"Rule:", p.Rule.RuleName},
+	), nil
+}
+
+func (msteamsConvertor) Star(p *api.StarPayload) (MSTeamsPayload, error) {
+	title, color, link := getStarPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (msteamsConvertor) User(p *api.UserPayload) (MSTeamsPayload, error) {
+	title, color, link := getUserPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		&MSTeamsFact{"User:", p.User.UserName},
+	), nil
+}
+
 func createMSTeamsPayload(r *api.Repository, s *api.User, title, text, actionTarget string, color int, fact *MSTeamsFact) MSTeamsPayload {
 	facts := make([]MSTeamsFact, 0, 2)
 	if r != nil {
@@ -6,6 +6,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
@@ -29,18 +30,34 @@ import (
 )
 
 func init() {
-	notify_service.RegisterNotifier(NewNotifier())
+	// the hook tasks of new issues and comments are written in the transaction creating them
+	notify_service.RegisterTxNotifier(&webhookNotifier{prepare: PrepareWebhooks, transactional: true})
 }
 
+// PayloadPreparer handles a payload which has been built for an event
+type PayloadPreparer func(ctx context.Context, source EventSource, event webhook_module.HookEventType, p api.Payloader) error
+
 type webhookNotifier struct {
 	notify_service.NullNotifier
+	prepare PayloadPreparer
+	// transactional is set when the payloads of new issues and comments are prepared in the transaction creating them
+	transactional bool
 }
 
-var _ notify_service.Notifier = &webhookNotifier{}
+var (
+	_ notify_service.Notifier   = &webhookNotifier{}
+	_ notify_service.TxNotifier = &webhookNotifier{}
+)
 
 // NewNotifier create a new webhookNotifier notifier
 func NewNotifier() notify_service.Notifier {
-	return &webhookNotifier{}
+	return NewPayloadNotifier(PrepareWebhooks)
+}
+
+// NewPayloadNotifier creates a notifier which builds the same payloads as the webhooks,
+// but passes them to prepare instead of the hook task queue
+func NewPayloadNotifier(prepare PayloadPreparer) notify_service.Notifier {
+	return &webhookNotifier{prepare: prepare}
 }
 
 func (m *webhookNotifier) IssueClearLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
@@ -62,7 +79,7 @@ func (m *webhookNotifier) IssueClearLabels(ctx context.Context, doer *user_model
 			return
 		}
 
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestLabel, &api.PullRequestPayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestLabel, &api.PullRequestPayload{
 			Action:      api.HookIssueLabelCleared,
 			Index:       issue.Index,
 			PullRequest: convert.ToAPIPullRequest(ctx, issue.PullRequest, doer),
@@ -70,7 +87,7 @@ func (m *webhookNotifier) IssueClearLabels(ctx context.Context, doer *user_model
 			Sender:      convert.ToUser(ctx, doer, nil),
 		})
 	} else {
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueLabel, &api.IssuePayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueLabel, &api.IssuePayload{
 			Action:     api.HookIssueLabelCleared,
 			Index:      issue.Index,
 			Issue:      convert.ToAPIIssue(ctx, doer, issue),
@@ -88,7 +105,7 @@ func (m *webhookNotifier) ForkRepository(ctx context.Context, doer *user_model.U
 	permission, _ := access_model.GetUserRepoPermission(ctx, repo, doer)
 
 	// forked webhook
-	if err := PrepareWebhooks(ctx, EventSource{Repository: oldRepo}, webhook_module.HookEventFork, &api.ForkPayload{
+	if err := m.prepare(ctx, EventSource{Repository: oldRepo}, webhook_module.HookEventFork, &api.ForkPayload{
 		Forkee: convert.ToRepo(ctx, oldRepo, oldPermission),
 		Repo:   convert.ToRepo(ctx, repo, permission),
 		Sender: convert.ToUser(ctx, doer, nil),
@@ -100,7 +117,7 @@ func (m *webhookNotifier) ForkRepository(ctx context.Context, doer *user_model.U
 
 	// Add to hook queue for created repo after session commit.
 	if u.IsOrganization() {
-		if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
+		if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
 			Action:       api.HookRepoCreated,
 			Repository:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 			Organization: convert.ToUser(ctx, u, nil),
@@ -113,7 +130,7 @@ func (m *webhookNotifier) ForkRepository(ctx context.Context, doer *user_model.U
 
 func (m *webhookNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	// Add to hook queue for created repo after session commit.
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
 		Action:       api.HookRepoCreated,
 		Repository:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 		Organization: convert.ToUser(ctx, u, nil),
@@ -124,7 +141,7 @@ func (m *webhookNotifier) CreateRepository(ctx context.Context, doer, u *user_mo
 }
 
 func (m *webhookNotifier) DeleteRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
 		Action:       api.HookRepoDeleted,
 		Repository:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 		Organization: convert.ToUser(ctx, repo.MustOwner(ctx), nil),
@@ -136,7 +153,7 @@ func (m *webhookNotifier) DeleteRepository(ctx context.Context, doer *user_model
 
 func (m *webhookNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	// Add to hook queue for created repo after session commit.
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepository, &api.RepositoryPayload{
 		Action:       api.HookRepoCreated,
 		Repository:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 		Organization: convert.ToUser(ctx, u, nil),
@@ -166,7 +183,7 @@ func (m *webhookNotifier) IssueChangeAssignee(ctx context.Context, doer *user_mo
 			apiPullRequest.Action = api.HookIssueAssigned
 		}
 		// Assignee comment triggers a webhook
-		if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestAssign, apiPullRequest); err != nil {
+		if err := m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestAssign, apiPullRequest); err != nil {
 			log.Error("PrepareWebhooks [is_pull: %v, remove_assignee: %v]: %v", issue.IsPull, removed, err)
 			return
 		}
@@ -184,7 +201,7 @@ func (m *webhookNotifier) IssueChangeAssignee(ctx context.Context, doer *user_mo
 			apiIssue.Action = api.HookIssueAssigned
 		}
 		// Assignee comment triggers a webhook
-		if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueAssign, apiIssue); err != nil {
+		if err := m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueAssign, apiIssue); err != nil {
 			log.Error("PrepareWebhooks [is_pull: %v, remove_assignee: %v]: %v", issue.IsPull, removed, err)
 			return
 		}
@@ -199,7 +216,7 @@ func (m *webhookNotifier) IssueChangeTitle(ctx context.Context, doer *user_model
 			log.Error("LoadPullRequest failed: %v", err)
 			return
 		}
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
 			Action: api.HookIssueEdited,
 			Index:  issue.Index,
 			Changes: &api.ChangesPayload{
@@ -212,7 +229,7 @@ func (m *webhookNotifier) IssueChangeTitle(ctx context.Context, doer *user_model
 			Sender:      convert.ToUser(ctx, doer, nil),
 		})
 	} else {
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
 			Action: api.HookIssueEdited,
 			Index:  issue.Index,
 			Changes: &api.ChangesPayload{
@@ -252,7 +269,7 @@ func (m *webhookNotifier) IssueChangeStatus(ctx context.Context, doer *user_mode
 		} else {
 			apiPullRequest.Action = api.HookIssueReOpened
 		}
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, apiPullRequest)
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, apiPullRequest)
 	} else {
 		apiIssue := &api.IssuePayload{
 			Index:      issue.Index,
@@ -266,7 +283,7 @@ func (m *webhookNotifier) IssueChangeStatus(ctx context.Context, doer *user_mode
 		} else {
 			apiIssue.Action = api.HookIssueReOpened
 		}
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, apiIssue)
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, apiIssue)
 	}
 	if err != nil {
 		log.Error("PrepareWebhooks [is_pull: %v, is_closed: %v]: %v", issue.IsPull, isClosed, err)
@@ -274,25 +291,31 @@ func (m *webhookNotifier) IssueChangeStatus(ctx context.Context, doer *user_mode
 }
 
 func (m *webhookNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
-	if err := issue.LoadRepo(ctx); err != nil {
-		log.Error("issue.LoadRepo: %v", err)
+	if m.transactional {
+		enqueuePendingHookTasks(ctx, EventSource{Repository: issue.Repo})
 		return
 	}
+	if err := m.NewIssueInTx(ctx, issue); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) NewIssueInTx(ctx context.Context, issue *issues_model.Issue) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return fmt.Errorf("issue.LoadRepo: %w", err)
+	}
 	if err := issue.LoadPoster(ctx); err != nil {
-		log.Error("issue.LoadPoster: %v", err)
-		return
+		return fmt.Errorf("issue.LoadPoster: %w", err)
 	}
 
 	permission, _ := access_model.GetUserRepoPermission(ctx, issue.Repo, issue.Poster)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
+	return m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
 		Action:     api.HookIssueOpened,
 		Index:      issue.Index,
 		Issue:      convert.ToAPIIssue(ctx, issue.Poster, issue),
 		Repository: convert.ToRepo(ctx, issue.Repo, permission),
 		Sender:     convert.ToUser(ctx, issue.Poster, nil),
-	}); err != nil {
-		log.Error("PrepareWebhooks: %v", err)
-	}
+	})
 }
 
 func (m *webhookNotifier) NewPullRequest(ctx context.Context, pull *issues_model.PullRequest, mentions []*user_model.User) {
@@ -310,7 +333,7 @@ func (m *webhookNotifier) NewPullRequest(ctx context.Context, pull *issues_model
 	}
 
 	permission, _ := access_model.GetUserRepoPermission(ctx, pull.Issue.Repo, pull.Issue.Poster)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: pull.Issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
+	if err := m.prepare(ctx, EventSource{Repository: pull.Issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
 		Action:      api.HookIssueOpened,
 		Index:       pull.Issue.Index,
 		PullRequest: convert.ToAPIPullRequest(ctx, pull, pull.Issue.Poster),
@@ -334,7 +357,7 @@ func (m *webhookNotifier) IssueChangeContent(ctx context.Context, doer *user_mod
 			log.Error("LoadPullRequest: %v", err)
 			return
 		}
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
 			Action: api.HookIssueEdited,
 			Index:  issue.Index,
 			Changes: &api.ChangesPayload{
@@ -347,7 +370,7 @@ func (m *webhookNotifier) IssueChangeContent(ctx context.Context, doer *user_mod
 			Sender:      convert.ToUser(ctx, doer, nil),
 		})
 	} else {
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
 			Action: api.HookIssueEdited,
 			Index:  issue.Index,
 			Changes: &api.ChangesPayload{
@@ -390,7 +413,7 @@ func (m *webhookNotifier) UpdateComment(ctx context.Context, doer *user_model.Us
 	}
 
 	permission, _ := access_model.GetUserRepoPermission(ctx, c.Issue.Repo, doer)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: c.Issue.Repo}, eventType, &api.IssueCommentPayload{
+	if err := m.prepare(ctx, EventSource{Repository: c.Issue.Repo}, eventType, &api.IssueCommentPayload{
 		Action:      api.HookIssueCommentEdited,
 		Issue:       convert.ToAPIIssue(ctx, doer, c.Issue),
 		PullRequest: pullRequest,
@@ -411,13 +434,24 @@ func (m *webhookNotifier) UpdateComment(ctx context.Context, doer *user_model.Us
 func (m *webhookNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
 	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
 ) {
+	if m.transactional {
+		enqueuePendingHookTasks(ctx, EventSource{Repository: repo})
+		return
+	}
+	if err := m.CreateIssueCommentInTx(ctx, doer, repo, issue, comment); err != nil {
+		log.Error("PrepareWebhooks [comment_id: %d]: %v", comment.ID, err)
+	}
+}
+
+func (m *webhookNotifier) CreateIssueCommentInTx(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
+	issue *issues_model.Issue, comment *issues_model.Comment,
+) error {
 	var eventType webhook_module.HookEventType
 	var pullRequest *api.PullRequest
 	if issue.IsPull {
 		eventType = webhook_module.HookEventPullRequestComment
 		if err := issue.LoadPullRequest(ctx); err != nil {
-			log.Error("LoadPullRequest: %v", err)
-			return
+			return fmt.Errorf("LoadPullRequest: %w", err)
 		}
 		pullRequest = convert.ToAPIPullRequest(ctx, issue.PullRequest, doer)
 	} else {
@@ -425,7 +459,7 @@ func (m *webhookNotifier) CreateIssueComment(ctx context.Context, doer *user_mod
 	}
 
 	permission, _ := access_model.GetUserRepoPermission(ctx, repo, doer)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, eventType, &api.IssueCommentPayload{
+	return m.prepare(ctx, EventSource{Repository: issue.Repo}, eventType, &api.IssueCommentPayload{
 		Action:      api.HookIssueCommentCreated,
 		Issue:       convert.ToAPIIssue(ctx, doer, issue),
 		PullRequest: pullRequest,
@@ -433,9 +467,7 @@ func (m *webhookNotifier) CreateIssueComment(ctx context.Context, doer *user_mod
 		Repository:  convert.ToRepo(ctx, repo, permission),
 		Sender:      convert.ToUser(ctx, doer, nil),
 		IsPull:      issue.IsPull,
-	}); err != nil {
-		log.Error("PrepareWebhooks [comment_id: %d]: %v", comment.ID, err)
-	}
+	})
 }
 
 func (m *webhookNotifier) DeleteComment(ctx context.Context, doer *user_model.User, comment *issues_model.Comment) {
@@ -465,7 +497,7 @@ func (m *webhookNotifier) DeleteComment(ctx context.Context, doer *user_model.Us
 	}
 
 	permission, _ := access_model.GetUserRepoPermission(ctx, comment.Issue.Repo, doer)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: comment.Issue.Repo}, eventType, &api.IssueCommentPayload{
+	if err := m.prepare(ctx, EventSource{Repository: comment.Issue.Repo}, eventType, &api.IssueCommentPayload{
 		Action:      api.HookIssueCommentDeleted,
 		Issue:       convert.ToAPIIssue(ctx, doer, comment.Issue),
 		PullRequest: pullRequest,
@@ -480,7 +512,7 @@ func (m *webhookNotifier) DeleteComment(ctx context.Context, doer *user_model.Us
 
 func (m *webhookNotifier) NewWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
 	// Add to hook queue for created wiki page.
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
 		Action:     api.HookWikiCreated,
 		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 		Sender:     convert.ToUser(ctx, doer, nil),
@@ -493,7 +525,7 @@ func (m *webhookNotifier) NewWikiPage(ctx context.Context, doer *user_model.User
 
 func (m *webhookNotifier) EditWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
 	// Add to hook queue for edit wiki page.
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
 		Action:     api.HookWikiEdited,
 		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 		Sender:     convert.ToUser(ctx, doer, nil),
@@ -506,7 +538,7 @@ func (m *webhookNotifier) EditWikiPage(ctx context.Context, doer *user_model.Use
 
 func (m *webhookNotifier) DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page string) {
 	// Add to hook queue for edit wiki page.
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
 		Action:     api.HookWikiDeleted,
 		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
 		Sender:     convert.ToUser(ctx, doer, nil),
@@ -541,7 +573,7 @@ func (m *webhookNotifier) IssueChangeLabels(ctx context.Context, doer *user_mode
 			log.Error("LoadIssue: %v", err)
 			return
 		}
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestLabel, &api.PullRequestPayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestLabel, &api.PullRequestPayload{
 			Action:      api.HookIssueLabelUpdated,
 			Index:       issue.Index,
 			PullRequest: convert.ToAPIPullRequest(ctx, issue.PullRequest, doer),
@@ -549,7 +581,7 @@ func (m *webhookNotifier) IssueChangeLabels(ctx context.Context, doer *user_mode
 			Sender:      convert.ToUser(ctx, doer, nil),
 		})
 	} else {
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueLabel, &api.IssuePayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueLabel, &api.IssuePayload{
 			Action:     api.HookIssueLabelUpdated,
 			Index:      issue.Index,
 			Issue:      convert.ToAPIIssue(ctx, doer, issue),
@@ -583,7 +615,7 @@ func (m *webhookNotifier) IssueChangeMilestone(ctx context.Context, doer *user_m
 			log.Error("LoadIssue: %v", err)
 			return
 		}
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestMilestone, &api.PullRequestPayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestMilestone, &api.PullRequestPayload{
 			Action:      hookAction,
 			Index:       issue.Index,
 			PullRequest: convert.ToAPIPullRequest(ctx, issue.PullRequest, doer),
@@ -591,7 +623,7 @@ func (m *webhookNotifier) IssueChangeMilestone(ctx context.Context, doer *user_m
 			Sender:      convert.ToUser(ctx, doer, nil),
 		})
 	} else {
-		err = PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueMilestone, &api.IssuePayload{
+		err = m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssueMilestone, &api.IssuePayload{
 			Action:     hookAction,
 			Index:      issue.Index,
 			Issue:      convert.ToAPIIssue(ctx, doer, issue),
@@ -612,7 +644,7 @@ func (m *webhookNotifier) PushCommits(ctx context.Context, pusher *user_model.Us
 		return
 	}
 
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventPush, &api.PushPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventPush, &api.PushPayload{
 		Ref:          opts.RefFullName.String(),
 		Before:       opts.OldCommitID,
 		After:        opts.NewCommitID,
@@ -633,7 +665,7 @@ func (m *webhookNotifier) AutoMergePullRequest(ctx context.Context, doer *user_m
 	m.MergePullRequest(ctx, doer, pr)
 }
 
-func (*webhookNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+func (m *webhookNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 	// Reload pull request information.
 	if err := pr.LoadAttributes(ctx); err != nil {
 		log.Error("LoadAttributes: %v", err)
@@ -665,7 +697,7 @@ func (*webhookNotifier) MergePullRequest(ctx context.Context, doer *user_model.U
 		Action:      api.HookIssueClosed,
 	}
 
-	if err := PrepareWebhooks(ctx, EventSource{Repository: pr.Issue.Repo}, webhook_module.HookEventPullRequest, apiPullRequest); err != nil {
+	if err := m.prepare(ctx, EventSource{Repository: pr.Issue.Repo}, webhook_module.HookEventPullRequest, apiPullRequest); err != nil {
 		log.Error("PrepareWebhooks: %v", err)
 	}
 }
@@ -679,7 +711,7 @@ func (m *webhookNotifier) PullRequestChangeTargetBranch(ctx context.Context, doe
 	issue := pr.Issue
 
 	mode, _ := access_model.GetUserRepoPermission(ctx, issue.Repo, issue.Poster)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
+	if err := m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequest, &api.PullRequestPayload{
 		Action: api.HookIssueEdited,
 		Index:  issue.Index,
 		Changes: &api.ChangesPayload{
@@ -721,7 +753,7 @@ func (m *webhookNotifier) PullRequestReview(ctx context.Context, pr *issues_mode
 		log.Error("models.GetUserRepoPermission: %v", err)
 		return
 	}
-	if err := PrepareWebhooks(ctx, EventSource{Repository: review.Issue.Repo}, reviewHookType, &api.PullRequestPayload{
+	if err := m.prepare(ctx, EventSource{Repository: review.Issue.Repo}, reviewHookType, &api.PullRequestPayload{
 		Action:            api.HookIssueReviewed,
 		Index:             review.Issue.Index,
 		PullRequest:       convert.ToAPIPullRequest(ctx, pr, review.Reviewer),
@@ -759,7 +791,7 @@ func (m *webhookNotifier) PullRequestReviewRequest(ctx context.Context, doer *us
 	} else {
 		apiPullRequest.Action = api.HookIssueReviewRequestRemoved
 	}
-	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestReviewRequest, apiPullRequest); err != nil {
+	if err := m.prepare(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventPullRequestReviewRequest, apiPullRequest); err != nil {
 		log.Error("PrepareWebhooks [review_requested: %v]: %v", isRequest, err)
 		return
 	}
@@ -768,7 +800,7 @@ func (m *webhookNotifier) PullRequestReviewRequest(ctx context.Context, doer *us
 func (m *webhookNotifier) CreateRef(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, refFullName git.RefName, refID string) {
 	apiPusher := convert.ToUser(ctx, pusher, nil)
 	apiRepo := convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeNone})
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventCreate, &api.CreatePayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventCreate, &api.CreatePayload{
 		Ref:     refFullName.ShortName(), // FIXME: should it be a full ref name? But it will break the existing webhooks?
 		Sha:     refID,
 		RefType: string(refFullName.RefType()),
@@ -789,7 +821,7 @@ func (m *webhookNotifier) PullRequestSynchronized(ctx context.Context, doer *use
 		return
 	}
 
-	if err := PrepareWebhooks(ctx, EventSource{Repository: pr.Issue.Repo}, webhook_module.HookEventPullRequestSync, &api.PullRequestPayload{
+	if err := m.prepare(ctx, EventSource{Repository: pr.Issue.Repo}, webhook_module.HookEventPullRequestSync, &api.PullRequestPayload{
 		Action:      api.HookIssueSynchronized,
 		Index:       pr.Issue.Index,
 		PullRequest: convert.ToAPIPullRequest(ctx, pr, doer),
@@ -803,7 +835,7 @@ func (m *webhookNotifier) PullRequestSynchronized(ctx context.Context, doer *use
 func (m *webhookNotifier) DeleteRef(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
 	apiPusher := convert.ToUser(ctx, pusher, nil)
 	apiRepo := convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner})
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventDelete, &api.DeletePayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventDelete, &api.DeletePayload{
 		Ref:        refFullName.ShortName(), // FIXME: should it be a full ref name? But it will break the existing webhooks?
 		RefType:    string(refFullName.RefType()),
 		PusherType: api.PusherTypeUser,
@@ -814,14 +846,14 @@ func (m *webhookNotifier) DeleteRef(ctx context.Context, pusher *user_model.User
 	}
 }
 
-func sendReleaseHook(ctx context.Context, doer *user_model.User, rel *repo_model.Release, action api.HookReleaseAction) {
+func (m *webhookNotifier) sendReleaseHook(ctx context.Context, doer *user_model.User, rel *repo_model.Release, action api.HookReleaseAction) {
 	if err := rel.LoadAttributes(ctx); err != nil {
 		log.Error("LoadAttributes: %v", err)
 		return
 	}
 
 	permission, _ := access_model.GetUserRepoPermission(ctx, rel.Repo, doer)
-	if err := PrepareWebhooks(ctx, EventSource{Repository: rel.Repo}, webhook_module.HookEventRelease, &api.ReleasePayload{
+	if err := m.prepare(ctx, EventSource{Repository: rel.Repo}, webhook_module.HookEventRelease, &api.ReleasePayload{
 		Action:     action,
 		Release:    convert.ToAPIRelease(ctx, rel.Repo, rel),
 		Repository: convert.ToRepo(ctx, rel.Repo, permission),
@@ -832,15 +864,15 @@ func sendReleaseHook(ctx context.Context, doer *user_model.User, rel *repo_model
 }
 
 func (m *webhookNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
-	sendReleaseHook(ctx, rel.Publisher, rel, api.HookReleasePublished)
+	m.sendReleaseHook(ctx, rel.Publisher, rel, api.HookReleasePublished)
 }
 
 func (m *webhookNotifier) UpdateRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
-	sendReleaseHook(ctx, doer, rel, api.HookReleaseUpdated)
+	m.sendReleaseHook(ctx, doer, rel, api.HookReleaseUpdated)
 }
 
 func (m *webhookNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
-	sendReleaseHook(ctx, doer, rel, api.HookReleaseDeleted)
+	m.sendReleaseHook(ctx, doer, rel, api.HookReleaseDeleted)
 }
 
 func (m *webhookNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -851,7 +883,7 @@ func (m *webhookNotifier) SyncPushCommits(ctx context.Context, pusher *user_mode
 		return
 	}
 
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventPush, &api.PushPayload{
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventPush, &api.PushPayload{
 		Ref:          opts.RefFullName.String(),
 		Before:       opts.OldCommitID,
 		After:        opts.NewCommitID,
@@ -897,7 +929,7 @@ func (m *webhookNotifier) CreateCommitStatus(ctx context.Context, repo *repo_mod
 		t := status.UpdatedUnix.AsTime().UTC()
 		payload.UpdatedAt = &t
 	}
-	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventStatus, &payload); err != nil {
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventStatus, &payload); err != nil {
 		log.Error("PrepareWebhooks: %v", err)
 	}
 }
@@ -911,14 +943,18 @@ func (m *webhookNotifier) SyncDeleteRef(ctx context.Context, pusher *user_model.
 }
 
 func (m *webhookNotifier) PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
-	notifyPackage(ctx, doer, pd, api.HookPackageCreated)
+	m.notifyPackage(ctx, doer, pd, api.HookPackageCreated)
 }
 
 func (m *webhookNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
-	notifyPackage(ctx, doer, pd, api.HookPackageDeleted)
+	m.notifyPackage(ctx, doer, pd, api.HookPackageDeleted)
 }
 
-func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_model.PackageDescriptor, action api.HookPackageAction) {
+func (m *webhookNotifier) PackageAccessUpdate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	m.notifyPackage(ctx, doer, pd, api.HookPackageAccessUpdated)
+}
+
+func (m *webhookNotifier) notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_model.PackageDescriptor, action api.HookPackageAction) {
 	source := EventSource{
 		Repository: pd.Repository,
 		Owner:      pd.Owner,
@@ -930,14 +966,24 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 		return
 	}
 
+	var access *api.PackageAccess
+	if action == api.HookPackageAccessUpdated {
+		access, err = convert.ToPackageAccess(ctx, pd.Package, sender)
+		if err != nil {
+			log.Error("Error converting package access: %v", err)
+			return
+		}
+	}
+
 	var org *api.Organization
 	if pd.Owner.IsOrganization() {
 		org = convert.ToOrganization(ctx, organization.OrgFromUser(pd.Owner))
 	}
 
-	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventPackage, &api.PackagePayload{
+	if err := m.prepare(ctx, source, webhook_module.HookEventPackage, &api.PackagePayload{
 		Action:       action,
 		Package:      apiPackage,
+		Access:       access,
 		Organization: org,
 		Sender:       convert.ToUser(ctx, sender, nil),
 	}); err != nil {
@@ -945,7 +991,7 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 	}
 }
 
-func (*webhookNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob, task *actions_model.ActionTask) {
+func (m *webhookNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob, task *actions_model.ActionTask) {
 	source := EventSource{
 		Repository: repo,
 		Owner:      repo.Owner,
@@ -998,7 +1044,7 @@ func (*webhookNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_
 		}
 	}
 
-	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventWorkflowJob, &api.WorkflowJobPayload{
+	if err := m.prepare(ctx, source, webhook_module.HookEventWorkflowJob, &api.WorkflowJobPayload{
 		Action: status,
 		WorkflowJob: &api.ActionWorkflowJob{
 			ID: job.ID,
@@ -1055,3 +1101,179 @@ func toActionStatus(status actions_model.Status) (string, string) {
 	}
 	return action, conclusion
 }
+
+func (m *webhookNotifier) AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	m.notifyOrganization(ctx, doer, org, member, api.HookOrganizationMemberAdded)
+}
+
+func (m *webhookNotifier) RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	m.notifyOrganization(ctx, doer, org, member, api.HookOrganizationMemberRemoved)
+}
+
+func (m *webhookNotifier) notifyOrganization(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User, action api.HookOrganizationAction) {
+	if err := m.prepare(ctx, EventSource{Owner: org.AsUser()}, webhook_module.HookEventOrganization, &api.OrganizationPayload{
+		Action:       action,
+		Member:       convert.ToUser(ctx, member, nil),
+		Organization: convert.ToOrganization(ctx, org),
+		Sender:       convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	m.notifyMembership(ctx, doer, team, member, api.HookMembershipAdded)
+}
+
+func (m *webhookNotifier) RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	m.notifyMembership(ctx, doer, team, member, api.HookMembershipRemoved)
+}
+
+func (m *webhookNotifier) notifyMembership(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User, action api.HookMembershipAction) {
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		log.Error("GetOrgByID[%d]: %v", team.OrgID, err)
+		return
+	}
+	apiTeam, err := convert.ToTeam(ctx, team)
+	if err != nil {
+		log.Error("ToTeam: %v", err)
+		return
+	}
+
+	if err := m.prepare(ctx, EventSource{Owner: org.AsUser()}, webhook_module.HookEventMembership, &api.MembershipPayload{
+		Action:       action,
+		Member:       convert.ToUser(ctx, member, nil),
+		Team:         apiTeam,
+		Organization: convert.ToOrganization(ctx, org),
+		Sender:       convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	m.notifyTeam(ctx, doer, team, repo, api.HookTeamAddedToRepository)
+}
+
+func (m *webhookNotifier) RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	m.notifyTeam(ctx, doer, team, repo, api.HookTeamRemovedFromRepository)
+}
+
+func (m *webhookNotifier) notifyTeam(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository, action api.HookTeamAction) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		log.Error("LoadOwner: %v", err)
+		return
+	}
+	apiTeam, err := convert.ToTeam(ctx, team)
+	if err != nil {
+		log.Error("ToTeam: %v", err)
+		return
+	}
+
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventTeam, &api.TeamPayload{
+		Action:       action,
+		Team:         apiTeam,
+		Repository:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Organization: convert.ToOrganization(ctx, organization.OrgFromUser(repo.Owner)),
+		Sender:       convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	m.notifyMember(ctx, doer, repo, collaborator, mode, api.HookMemberAdded)
+}
+
+func (m *webhookNotifier) ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	m.notifyMember(ctx, doer, repo, collaborator, mode, api.HookMemberEdited)
+}
+
+func (m *webhookNotifier) RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+	m.notifyMember(ctx, doer, repo, collaborator, perm.AccessModeNone, api.HookMemberRemoved)
+}
+
+func (m *webhookNotifier) notifyMember(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode, action api.HookMemberAction) {
+	payload := &api.MemberPayload{
+		Action:     action,
+		Member:     convert.ToUser(ctx, collaborator, nil),
+		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}
+	if mode != perm.AccessModeNone {
+		payload.Permission = mode.ToString()
+	}
+
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventMember, payload); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) CreateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	m.notifyBranchProtectionRule(ctx, doer, repo, rule, api.HookBranchProtectionRuleCreated)
+}
+
+func (m *webhookNotifier) UpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	m.notifyBranchProtectionRule(ctx, doer, repo, rule, api.HookBranchProtectionRuleEdited)
+}
+
+func (m *webhookNotifier) DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	m.notifyBranchProtectionRule(ctx, doer, repo, rule, api.HookBranchProtectionRuleDeleted)
+}
+
+func (m *webhookNotifier) notifyBranchProtectionRule(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch, action api.HookBranchProtectionRuleAction) {
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventBranchProtectionRule, &api.BranchProtectionRulePayload{
+		Action:     action,
+		Rule:       convert.ToBranchProtection(ctx, rule, repo),
+		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) {
+	payload := &api.StarPayload{
+		Action:     api.HookStarDeleted,
+		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}
+	if star {
+		now := time.Now().UTC()
+		payload.Action = api.HookStarCreated
+		payload.StarredAt = &now
+	}
+
+	if err := m.prepare(ctx, EventSource{Repository: repo}, webhook_module.HookEventStar, payload); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) CreateUser(ctx context.Context, doer, u *user_model.User) {
+	m.notifyUser(ctx, doer, u, api.HookUserCreated)
+}
+
+func (m *webhookNotifier) SuspendUser(ctx context.Context, doer, u *user_model.User, suspended bool) {
+	if suspended {
+		m.notifyUser(ctx, doer, u, api.HookUserSuspended)
+	} else {
+		m.notifyUser(ctx, doer, u, api.HookUserUnsuspended)
+	}
+}
+
+// notifyUser sends the event only to system webhooks, because an account doesn't belong to any repository or owner
+func (m *webhookNotifier) notifyUser(ctx context.Context, doer, u *user_model.User, action api.HookUserAction) {
+	if doer == nil {
+		// a self sign-up or an account created by an external authentication source
+		doer = u
+	}
+
+	if err := m.prepare(ctx, EventSource{}, webhook_module.HookEventUser, &api.UserPayload{
+		Action: action,
+		User:   convert.ToUser(ctx, u, nil),
+		Sender: convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+type (
+	// NtfyPayload represents a message published to a ntfy topic as JSON
+	NtfyPayload struct {
+		Topic    string   `json:"topic"`
+		Title    string   `json:"title,omitempty"`
+		Message  string   `json:"message"`
+		Markdown bool     `json:"markdown,omitempty"`
+		Tags     []string `json:"tags,omitempty"`
+		Priority int      `json:"priority,omitempty"`
+		Click    string   `json:"click,omitempty"`
+	}
+
+	// NtfyMeta contains the ntfy metadata
+	NtfyMeta struct {
+		Topic    string   `json:"topic"`
+		Priority int      `json:"priority"`
+		Tags     []string `json:"tags"`
+	}
+)
+
+// Priorities of the ntfy messages, 0 uses the default priority of the server
+const (
+	NtfyPriorityMin = 1
+	NtfyPriorityMax = 5
+)
+
+var ntfyTopicPattern = regexp.MustCompile(`^[-_A-Za-z0-9]{1,64}$`)
+
+// IsValidNtfyTopic returns true if the topic name is accepted by ntfy
+func IsValidNtfyTopic(topic string) bool {
+	return ntfyTopicPattern.MatchString(topic)
+}
+
+// IsValidNtfyPriority returns true if the priority is a ntfy priority or 0 for the default one
+func IsValidNtfyPriority(priority int) bool {
+	return priority == 0 || (priority >= NtfyPriorityMin && priority <= NtfyPriorityMax)
+}
+
+// ParseNtfyTags splits a comma separated list of tags
+func ParseNtfyTags(tags string) []string {
+	var result []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// GetNtfyHook returns ntfy metadata
+func GetNtfyHook(w *webhook_model.Webhook) *NtfyMeta {
+	s := &NtfyMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetNtfyHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+type ntfyConvertor struct {
+	Topic    string
+	Priority int
+	Tags     []string
+}
+
+// Create implements PayloadConvertor Create method
+func (n ntfyConvertor) Create(p *api.CreatePayload) (NtfyPayload, error) {
+	refName := git.RefName(p.Ref).ShortName()
+	title := fmt.Sprintf("[%s] %s %s created by %s", p.Repo.FullName, p.RefType, refName, p.Sender.UserName)
+
+	return n.createPayload(title, "", p.Repo.HTMLURL+"/src/"+util.PathEscapeSegments(refName)), nil
+}
+
+// Delete implements PayloadConvertor Delete method
+func (n ntfyConvertor) Delete(p *api.DeletePayload) (NtfyPayload, error) {
+	refName := git.RefName(p.Ref).ShortName()
+	title := fmt.Sprintf("[%s] %s %s deleted by %s", p.Repo.FullName, p.RefType, refName, p.Sender.UserName)
+
+	return n.createPayload(title, "", p.Repo.HTMLURL), nil
+}
+
+// Fork implements PayloadConvertor Fork method
+func (n ntfyConvertor) Fork(p *api.ForkPayload) (NtfyPayload, error) {
+	title := fmt.Sprintf("%s is forked to %s", p.Forkee.FullName, p.Repo.FullName)
+
+	return n.createPayload(title, "", p.Repo.HTMLURL), nil
+}
+
+// Push implements PayloadConvertor Push method
+func (n ntfyConvertor) Push(p *api.PushPayload) (NtfyPayload, error) {
+	var (
+		branchName = git.RefName(p.Ref).ShortName()
+		commitDesc string
+	)
+
+	var titleLink string
+	if p.TotalCommits == 1 {
+		commitDesc = "1 new commit"
+		titleLink = p.Commits[0].URL
+	} else {
+		commitDesc = fmt.Sprintf("%d new commits", p.TotalCommits)
+		titleLink = p.CompareURL
+	}
+	if titleLink == "" {
+		titleLink = p.Repo.HTMLURL + "/src/" + util.PathEscapeSegments(branchName)
+	}
+
+	title := fmt.Sprintf("[%s:%s] %s pushed by %s", p.Repo.FullName, branchName, commitDesc, p.Pusher.UserName)
+
+	var text strings.Builder
+	// for each commit, generate a line with the summary of its message
+	for i, commit := range p.Commits {
+		message := strings.TrimRight(strings.SplitN(commit.Message, "\n", 2)[0], "\r")
+		if utf8.RuneCountInString(message) > 50 {
+			message = fmt.Sprintf("%.47s...", message)
+		}
+		fmt.Fprintf(&text, "[%s](%s) %s - %s", commit.ID[:7], commit.URL, message, commit.Author.Name)
+		// add linebreak to each commit but the last
+		if i < len(p.Commits)-1 {
+			text.WriteString("\n")
+		}
+	}
+
+	return n.createPayload(title, text.String(), titleLink), nil
+}
+
+// Issue implements PayloadConvertor Issue method
+func (n ntfyConvertor) Issue(p *api.IssuePayload) (NtfyPayload, error) {
+	title, _, extraMarkdown, _ := getIssuesPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, extraMarkdown, p.Issue.HTMLURL), nil
+}
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (n ntfyConvertor) IssueComment(p *api.IssueCommentPayload) (NtfyPayload, error) {
+	title, _, _ := getIssueCommentPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, p.Comment.Body, p.Comment.HTMLURL), nil
+}
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (n ntfyConvertor) PullRequest(p *api.PullRequestPayload) (NtfyPayload, error) {
+	title, _, extraMarkdown, _ := getPullRequestPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, extraMarkdown, p.PullRequest.HTMLURL), nil
+}
+
+// Review implements PayloadConvertor Review method
+func (n ntfyConvertor) Review(p *api.PullRequestPayload, event webhook_module.HookEventType) (NtfyPayload, error) {
+	var text, title string
+	switch p.Action {
+	case api.HookIssueReviewed:
+		action, err := parseHookPullRequestEventType(event)
+		if err != nil {
+			return NtfyPayload{}, err
+		}
+
+		title = fmt.Sprintf("[%s] Pull request review %s: #%d %s by %s", p.Repository.FullName, action, p.Index, p.PullRequest.Title, p.Sender.UserName)
+		text = p.Review.Content
+	}
+
+	return n.createPayload(title, text, p.PullRequest.HTMLURL), nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (n ntfyConvertor) Repository(p *api.RepositoryPayload) (NtfyPayload, error) {
+	var title, url string
+	switch p.Action {
+	case api.HookRepoCreated:
+		title = fmt.Sprintf("[%s] Repository created by %s", p.Repository.FullName, p.Sender.UserName)
+		url = p.Repository.HTMLURL
+	case api.HookRepoDeleted:
+		title = fmt.Sprintf("[%s] Repository deleted by %s", p.Repository.FullName, p.Sender.UserName)
+	}
+
+	return n.createPayload(title, "", url), nil
+}
+
+// Wiki implements PayloadConvertor Wiki method
+func (n ntfyConvertor) Wiki(p *api.WikiPayload) (NtfyPayload, error) {
+	title, _, _ := getWikiPayloadInfo(p, noneLinkFormatter, true)
+	htmlLink := p.Repository.HTMLURL + "/wiki/" + url.PathEscape(p.Page)
+
+	var text string
+	if p.Action != api.HookWikiDeleted {
+		text = p.Comment
+	}
+
+	return n.createPayload(title, text, htmlLink), nil
+}
+
+// Release implements PayloadConvertor Release method
+func (n ntfyConvertor) Release(p *api.ReleasePayload) (NtfyPayload, error) {
+	title, _ := getReleasePayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, p.Release.Note, p.Release.HTMLURL), nil
+}
+
+func (n ntfyConvertor) Package(p *api.PackagePayload) (NtfyPayload, error) {
+	title, _ := getPackagePayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", p.Package.HTMLURL), nil
+}
+
+func (n ntfyConvertor) Status(p *api.CommitStatusPayload) (NtfyPayload, error) {
+	title, _ := getStatusPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", p.TargetURL), nil
+}
+
+func (n ntfyConvertor) WorkflowJob(p *api.WorkflowJobPayload) (NtfyPayload, error) {
+	title, _ := getWorkflowJobPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", p.WorkflowJob.HTMLURL), nil
+}
+
+func (n ntfyConvertor) Organization(p *api.OrganizationPayload) (NtfyPayload, error) {
+	title, _, link := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) Membership(p *api.MembershipPayload) (NtfyPayload, error) {
+	title, _, link := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) Team(p *api.TeamPayload) (NtfyPayload, error) {
+	title, _, link := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) Member(p *api.MemberPayload) (NtfyPayload, error) {
+	title, _, link := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (NtfyPayload, error) {
+	title, _, link := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) Star(p *api.StarPayload) (NtfyPayload, error) {
+	title, _, link := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) User(p *api.UserPayload) (NtfyPayload, error) {
+	title, _, link := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return n.createPayload(title, "", link), nil
+}
+
+func (n ntfyConvertor) createPayload(title, text, click string) NtfyPayload {
+	payload := NtfyPayload{
+		Topic:    n.Topic,
+		Title:    title,
+		Message:  strings.TrimSpace(text),
+		Markdown: true,
+		Tags:     n.Tags,
+		Priority: n.Priority,
+		Click:    click,
+	}
+	if payload.Message == "" {
+		// ntfy replaces an empty message with "triggered", so the title is sent as the message
+		payload.Title, payload.Message = "", title
+	}
+	return payload
+}
+
+func newNtfyRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &NtfyMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newNtfyRequest meta json: %w", err)
+	}
+	var pc payloadConvertor[NtfyPayload] = ntfyConvertor{
+		Topic:    meta.Topic,
+		Priority: meta.Priority,
+		Tags:     meta.Tags,
+	}
+	return newJSONRequest(pc, w, t, true)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.NTFY, newNtfyRequest)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNtfyPayload(t *testing.T) {
+	nc := ntfyConvertor{Topic: "gitea", Priority: 4, Tags: []string{"gitea", "warning"}}
+
+	t.Run("Create", func(t *testing.T) {
+		p := createTestPayload()
+
+		pl, err := nc.Create(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "gitea", pl.Topic)
+		assert.Empty(t, pl.Title)
+		assert.Equal(t, "[test/repo] branch test created by user1", pl.Message)
+		assert.Equal(t, 4, pl.Priority)
+		assert.Equal(t, []string{"gitea", "warning"}, pl.Tags)
+		assert.Equal(t, "http://localhost:3000/test/repo/src/test", pl.Click)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		p := pushTestPayload()
+
+		pl, err := nc.Push(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo:test] 2 new commits pushed by user1", pl.Title)
+		assert.Equal(t, "[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1", pl.Message)
+		assert.True(t, pl.Markdown)
+		assert.Equal(t, "http://localhost:3000/test/repo/src/test", pl.Click)
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		p := issueTestPayload()
+
+		p.Action = api.HookIssueOpened
+		pl, err := nc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo] Issue opened: #2 crash by user1", pl.Title)
+		assert.Equal(t, "issue body", pl.Message)
+		assert.Equal(t, "http://localhost:3000/test/repo/issues/2", pl.Click)
+
+		p.Action = api.HookIssueClosed
+		pl, err = nc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Empty(t, pl.Title)
+		assert.Equal(t, "[test/repo] Issue closed: #2 crash by user1", pl.Message)
+	})
+
+	t.Run("IssueComment", func(t *testing.T) {
+		p := issueCommentTestPayload()
+
+		pl, err := nc.IssueComment(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo] New comment on issue #2 crash by user1", pl.Title)
+		assert.Equal(t, "more info needed", pl.Message)
+		assert.Equal(t, "http://localhost:3000/test/repo/issues/2#issuecomment-4", pl.Click)
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		p := pullRequestTestPayload()
+		p.Action = api.HookIssueReviewed
+
+		pl, err := nc.Review(p, webhook_module.HookEventPullRequestReviewApproved)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo] Pull request review approved: #12 Fix bug by user1", pl.Title)
+		assert.Equal(t, "good job", pl.Message)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		p := pullReleaseTestPayload()
+
+		pl, err := nc.Release(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo] Release created: v1.0 by user1", pl.Title)
+		assert.Equal(t, "Note of first stable release", pl.Message)
+		assert.Equal(t, "http://localhost:3000/test/repo/releases/tag/v1.0", pl.Click)
+	})
+}
+
+func TestNtfyValidation(t *testing.T) {
+	assert.True(t, IsValidNtfyTopic("gitea_alerts-1"))
+	assert.False(t, IsValidNtfyTopic(""))
+	assert.False(t, IsValidNtfyTopic("gitea/alerts"))
+
+	assert.True(t, IsValidNtfyPriority(0))
+	assert.True(t, IsValidNtfyPriority(5))
+	assert.False(t, IsValidNtfyPriority(6))
+
+	assert.Equal(t, []string{"gitea", "warning"}, ParseNtfyTags(" gitea, ,warning,"))
+	assert.Empty(t, ParseNtfyTags(""))
+}
+
+func TestNtfyJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.NTFY,
+		URL:        "https://ntfy.example.com",
+		Meta:       `{"topic":"gitea","priority":5,"tags":["rotating_light"]}`,
+		HTTPMethod: "POST",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newNtfyRequest(t.Context(), hook, task)
+	require.NotNil(t, req)
+	require.NotNil(t, reqBody)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://ntfy.example.com", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	var body NtfyPayload
+	err = json.NewDecoder(req.Body).Decode(&body)
+	assert.NoError(t, err)
+	assert.Equal(t, "gitea", body.Topic)
+	assert.Equal(t, 5, body.Priority)
+	assert.Equal(t, []string{"rotating_light"}, body.Tags)
+	assert.Equal(t, "[test/repo:test] 2 new commits pushed by user1", body.Title)
+}
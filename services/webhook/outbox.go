@@ -0,0 +1,148 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// The hook_task table works as a transactional outbox: a task is written in the same
+// database transaction as the change triggering it, and it's only enqueued once it is visible.
+// Every instance delivers a task only after it has claimed a lease on it, so a task is delivered
+// at least once, even if the instance delivering it is stopped before recording the delivery.
+// A task which has been attempted DELIVERY_MAX_ATTEMPTS times isn't claimed anymore,
+// until an admin retries it.
+
+const outboxRelayLockKey = "webhook_outbox_relay"
+
+// nodeName identifies this instance in the delivery leases
+var nodeName = func() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	return hostname + ":" + strconv.Itoa(os.Getpid())
+}()
+
+func getHookTaskLockKey(taskID int64) string {
+	return fmt.Sprintf("webhook_task_%d", taskID)
+}
+
+// deliverHookTask claims a lease on the hook task and delivers it,
+// it does nothing if another instance is delivering the task or it has already been delivered.
+func deliverHookTask(ctx context.Context, taskID int64) error {
+	_, err := globallock.TryLockAndDo(ctx, getHookTaskLockKey(taskID), func(ctx context.Context) error {
+		task, err := webhook_model.GetHookTaskByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if task.IsDelivered {
+			// Already delivered in the meantime
+			log.Trace("Task[%d] has already been delivered", task.ID)
+			return nil
+		}
+
+		claimed, err := webhook_model.ClaimHookTask(ctx, task, nodeName, setting.Webhook.DeliveryLease, setting.Webhook.DeliveryMaxAttempts)
+		if err != nil {
+			return fmt.Errorf("unable to claim task[%d]: %w", task.ID, err)
+		}
+		if !claimed {
+			log.Trace("Task[%d] is claimed by another instance, or has reached the maximum delivery attempts", task.ID)
+			return nil
+		}
+
+		return Deliver(ctx, task)
+	})
+	return err
+}
+
+// relayHookTasks enqueues the hook tasks which are not delivered and not claimed by any instance
+func relayHookTasks(ctx context.Context) error {
+	lowerID := int64(0)
+	for {
+		taskIDs, err := webhook_model.FindClaimableHookTaskIDs(ctx, lowerID, setting.Webhook.DeliveryMaxAttempts)
+		if err != nil {
+			return fmt.Errorf("FindClaimableHookTaskIDs: %w", err)
+		}
+		if len(taskIDs) == 0 {
+			return nil
+		}
+		lowerID = taskIDs[len(taskIDs)-1]
+
+		for _, taskID := range taskIDs {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err := enqueueHookTask(taskID); err != nil {
+				log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", taskID, err)
+			}
+		}
+	}
+}
+
+// enqueuePendingHookTasks enqueues the hook tasks of the source's webhooks which have been written
+// in a database transaction, it must be called once the transaction has been committed.
+// The outbox relay enqueues them later if it fails.
+func enqueuePendingHookTasks(ctx context.Context, source EventSource) {
+	ws, err := getActiveWebhooks(ctx, source)
+	if err != nil {
+		log.Error("Unable to get the webhooks: %v", err)
+		return
+	}
+	hookIDs := make([]int64, 0, len(ws))
+	for _, w := range ws {
+		hookIDs = append(hookIDs, w.ID)
+	}
+	taskIDs, err := webhook_model.FindUnclaimedHookTaskIDs(ctx, hookIDs)
+	if err != nil {
+		log.Error("FindUnclaimedHookTaskIDs: %v", err)
+		return
+	}
+	for _, taskID := range taskIDs {
+		if err := enqueueHookTask(taskID); err != nil {
+			log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", taskID, err)
+		}
+	}
+}
+
+// runOutboxRelay periodically enqueues the claimable hook tasks, only one instance relays at a time
+func runOutboxRelay(ctx context.Context) {
+	ctx, _, finished := process.GetManager().AddTypedContext(ctx, "Webhook: Outbox relay", process.SystemProcessType, true)
+	defer finished()
+
+	ticker := time.NewTicker(setting.Webhook.OutboxPollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := globallock.TryLockAndDo(ctx, outboxRelayLockKey, relayHookTasks); err != nil && ctx.Err() == nil {
+			log.Error("Unable to relay hook tasks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RetryHookTask resets the delivery attempts of an undelivered hook task and enqueues it again,
+// it's used to deliver the tasks which have reached the maximum delivery attempts.
+func RetryHookTask(ctx context.Context, taskID int64) error {
+	if err := webhook_model.ResetHookTaskAttempts(ctx, taskID); err != nil {
+		return err
+	}
+	return enqueueHookTask(taskID)
+}
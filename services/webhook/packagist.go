@@ -118,6 +118,34 @@ func (pc packagistConvertor) WorkflowJob(_ *api.WorkflowJobPayload) (PackagistPa
 	return PackagistPayload{}, nil
 }
 
+func (pc packagistConvertor) Organization(_ *api.OrganizationPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Membership(_ *api.MembershipPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Team(_ *api.TeamPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Member(_ *api.MemberPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) BranchProtectionRule(_ *api.BranchProtectionRulePayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Star(_ *api.StarPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) User(_ *api.UserPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
 func newPackagistRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
 	meta := &PackagistMeta{}
 	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
@@ -30,6 +30,13 @@ type payloadConvertor[T any] interface {
 	Package(*api.PackagePayload) (T, error)
 	Status(*api.CommitStatusPayload) (T, error)
 	WorkflowJob(*api.WorkflowJobPayload) (T, error)
+	Organization(*api.OrganizationPayload) (T, error)
+	Membership(*api.MembershipPayload) (T, error)
+	Team(*api.TeamPayload) (T, error)
+	Member(*api.MemberPayload) (T, error)
+	BranchProtectionRule(*api.BranchProtectionRulePayload) (T, error)
+	Star(*api.StarPayload) (T, error)
+	User(*api.UserPayload) (T, error)
 }
 
 func convertUnmarshalledJSON[T, P any](convert func(P) (T, error), data []byte) (t T, err error) {
@@ -83,6 +90,20 @@ func newPayload[T any](rc payloadConvertor[T], data []byte, event webhook_module
 		return convertUnmarshalledJSON(rc.Status, data)
 	case webhook_module.HookEventWorkflowJob:
 		return convertUnmarshalledJSON(rc.WorkflowJob, data)
+	case webhook_module.HookEventOrganization:
+		return convertUnmarshalledJSON(rc.Organization, data)
+	case webhook_module.HookEventMembership:
+		return convertUnmarshalledJSON(rc.Membership, data)
+	case webhook_module.HookEventTeam:
+		return convertUnmarshalledJSON(rc.Team, data)
+	case webhook_module.HookEventMember:
+		return convertUnmarshalledJSON(rc.Member, data)
+	case webhook_module.HookEventBranchProtectionRule:
+		return convertUnmarshalledJSON(rc.BranchProtectionRule, data)
+	case webhook_module.HookEventStar:
+		return convertUnmarshalledJSON(rc.Star, data)
+	case webhook_module.HookEventUser:
+		return convertUnmarshalledJSON(rc.User, data)
 	}
 	return t, fmt.Errorf("newPayload unsupported event: %s", event)
 }
@@ -179,6 +179,48 @@ func (s slackConvertor) WorkflowJob(p *api.WorkflowJobPayload) (SlackPayload, er
 	return s.createPayload(text, nil), nil
 }
 
+func (s slackConvertor) Organization(p *api.OrganizationPayload) (SlackPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Membership(p *api.MembershipPayload) (SlackPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Team(p *api.TeamPayload) (SlackPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Member(p *api.MemberPayload) (SlackPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (SlackPayload, error) {
+	text, _, _ := getBranchProtectionRulePayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Star(p *api.StarPayload) (SlackPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) User(p *api.UserPayload) (SlackPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
 // Push implements payloadConvertor Push method
 func (s slackConvertor) Push(p *api.PushPayload) (SlackPayload, error) {
 	// n new commits
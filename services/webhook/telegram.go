@@ -186,6 +186,48 @@ func (telegramConvertor) WorkflowJob(p *api.WorkflowJobPayload) (TelegramPayload
 	return createTelegramPayloadHTML(text), nil
 }
 
+func (telegramConvertor) Organization(p *api.OrganizationPayload) (TelegramPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (telegramConvertor) Membership(p *api.MembershipPayload) (TelegramPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (telegramConvertor) Team(p *api.TeamPayload) (TelegramPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (telegramConvertor) Member(p *api.MemberPayload) (TelegramPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (telegramConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (TelegramPayload, error) {
+	text, _, _ := getBranchProtectionRulePayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (telegramConvertor) Star(p *api.StarPayload) (TelegramPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (telegramConvertor) User(p *api.UserPayload) (TelegramPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
 func createTelegramPayloadHTML(msgHTML string) TelegramPayload {
 	// https://core.telegram.org/bots/api#formatting-options
 	return TelegramPayload{
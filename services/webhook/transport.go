@@ -0,0 +1,191 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// webhookClient is an HTTP client built for the transport settings of a webhook
+type webhookClient struct {
+	updated timeutil.TimeStamp
+	client  *http.Client
+}
+
+// webhookClients caches the HTTP clients of the webhooks with their own transport settings, by webhook ID
+var webhookClients sync.Map
+
+// ErrInvalidTransport represents an error in the transport settings of a webhook
+type ErrInvalidTransport struct {
+	Field string
+	Err   error
+}
+
+func (err ErrInvalidTransport) Error() string {
+	return fmt.Sprintf("invalid %s: %v", err.Field, err.Err)
+}
+
+func (err ErrInvalidTransport) Unwrap() error {
+	return err.Err
+}
+
+// IsErrInvalidTransport checks if an error is a ErrInvalidTransport.
+func IsErrInvalidTransport(err error) bool {
+	var e ErrInvalidTransport
+	return errors.As(err, &e)
+}
+
+func newWebhookTLSConfig(w *webhook_model.Webhook) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: setting.Webhook.SkipTLSVerify,
+		ServerName:         w.TLSServerName,
+	}
+
+	if w.CACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(w.CACertificate)) {
+			return nil, ErrInvalidTransport{Field: "CA certificate", Err: errors.New("no PEM encoded certificate found")}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientKey, err := w.ClientKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the client key: %w", err)
+	}
+	switch {
+	case w.ClientCertificate != "" && clientKey != "":
+		cert, err := tls.X509KeyPair([]byte(w.ClientCertificate), []byte(clientKey))
+		if err != nil {
+			return nil, ErrInvalidTransport{Field: "client certificate", Err: err}
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case w.ClientCertificate != "":
+		return nil, ErrInvalidTransport{Field: "client certificate", Err: errors.New("the private key is missing")}
+	case clientKey != "":
+		return nil, ErrInvalidTransport{Field: "client key", Err: errors.New("the certificate is missing")}
+	}
+
+	return tlsConfig, nil
+}
+
+func parseWebhookProxyURL(w *webhook_model.Webhook) (*url.URL, error) {
+	rawURL, err := w.ProxyURL()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the proxy URL: %w", err)
+	}
+	if rawURL == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, ErrInvalidTransport{Field: "proxy URL", Err: err}
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, ErrInvalidTransport{Field: "proxy URL", Err: fmt.Errorf("unsupported scheme %q", proxyURL.Scheme)}
+	}
+	if proxyURL.Host == "" {
+		return nil, ErrInvalidTransport{Field: "proxy URL", Err: errors.New("the host is missing")}
+	}
+	return proxyURL, nil
+}
+
+// ValidateTransport checks the transport settings of a webhook, it should be called before saving the webhook
+func ValidateTransport(w *webhook_model.Webhook) error {
+	if _, err := newWebhookTLSConfig(w); err != nil {
+		return err
+	}
+	_, err := parseWebhookProxyURL(w)
+	return err
+}
+
+func newWebhookHTTPClient(w *webhook_model.Webhook) (*http.Client, error) {
+	tlsConfig, err := newWebhookTLSConfig(w)
+	if err != nil {
+		return nil, err
+	}
+	proxyURL, err := parseWebhookProxyURL(w)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyFunc := webhookProxy(allowedHostMatcher)
+	// only the instance proxy is trusted to be dialed without checking the allow list
+	trustedProxyURL := setting.Webhook.ProxyURLFixed
+	if proxyURL != nil {
+		proxyFunc = func(req *http.Request) (*url.URL, error) {
+			if !allowedHostMatcher.MatchHostName(req.URL.Host) {
+				return nil, fmt.Errorf("webhook can only call allowed HTTP servers (check your %s setting), deny '%s'", allowedHostMatcher.SettingKeyHint, req.URL.Host)
+			}
+			return proxyURL, nil
+		}
+		// the proxy of the webhook is set by its owner, the dialer checks it against the allow list like any receiver
+		trustedProxyURL = nil
+	}
+
+	return &http.Client{
+		Timeout: webhookHTTPClient.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+			Proxy:           proxyFunc,
+			DialContext:     hostmatcher.NewDialContext("webhook", allowedHostMatcher, nil, trustedProxyURL),
+		},
+	}, nil
+}
+
+// getWebhookHTTPClient returns the HTTP client to deliver the tasks of the webhook
+func getWebhookHTTPClient(w *webhook_model.Webhook) (*http.Client, error) {
+	if !w.HasCustomTransport() {
+		return webhookHTTPClient, nil
+	}
+
+	if v, ok := webhookClients.Load(w.ID); ok {
+		if c := v.(*webhookClient); c.updated == w.UpdatedUnix {
+			return c.client, nil
+		}
+	}
+
+	client, err := newWebhookHTTPClient(w)
+	if err != nil {
+		return nil, err
+	}
+	if old, loaded := webhookClients.Swap(w.ID, &webhookClient{updated: w.UpdatedUnix, client: client}); loaded {
+		old.(*webhookClient).client.CloseIdleConnections()
+	}
+	return client, nil
+}
+
+// describeDeliveryError adds a hint about the webhook settings to fix to the common TLS errors
+func describeDeliveryError(err error) error {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &unknownAuthorityErr):
+		return fmt.Errorf("the certificate of the receiver is not signed by a trusted CA, check the CA certificate of the webhook: %w", err)
+	case errors.As(err, &hostnameErr):
+		return fmt.Errorf("the certificate of the receiver doesn't match the host name, check the TLS server name of the webhook: %w", err)
+	case errors.As(err, &certificateInvalidErr):
+		return fmt.Errorf("the certificate of the receiver is invalid: %w", err)
+	case errors.As(err, &opErr) && opErr.Op == "remote error":
+		// the receiver sent a TLS alert, usually because it requires a client certificate or doesn't accept it
+		return fmt.Errorf("the receiver rejected the TLS handshake, check the client certificate of the webhook: %w", err)
+	}
+	return err
+}
@@ -66,7 +66,8 @@ func getPayloadBranch(p api.Payloader) string {
 	return ""
 }
 
-// EventSource represents the source of a webhook action. Repository and/or Owner must be set.
+// EventSource represents the source of a webhook action. Repository and/or Owner should be set,
+// an empty source only triggers the system webhooks.
 type EventSource struct {
 	Repository *repo_model.Repository
 	Owner      *user_model.User
@@ -77,24 +78,12 @@ func handler(items ...int64) []int64 {
 	ctx := graceful.GetManager().HammerContext()
 
 	for _, taskID := range items {
-		task, err := webhook_model.GetHookTaskByID(ctx, taskID)
-		if err != nil {
+		if err := deliverHookTask(ctx, taskID); err != nil {
 			if errors.Is(err, util.ErrNotExist) {
-				log.Warn("GetHookTaskByID[%d] warn: %v", taskID, err)
+				log.Warn("Unable to deliver webhook task[%d]: %v", taskID, err)
 			} else {
-				log.Error("GetHookTaskByID[%d] failed: %v", taskID, err)
+				log.Error("Unable to deliver webhook task[%d]: %v", taskID, err)
 			}
-			continue
-		}
-
-		if task.IsDelivered {
-			// Already delivered in the meantime
-			log.Trace("Task[%d] has already been delivered", task.ID)
-			continue
-		}
-
-		if err := Deliver(ctx, task); err != nil {
-			log.Error("Unable to deliver webhook task[%d]: %v", task.ID, err)
 		}
 	}
 
@@ -127,6 +116,8 @@ func checkBranch(w *webhook_model.Webhook, branch string) bool {
 // PrepareWebhook creates a hook task and enqueues it for processing.
 // The payload is saved as-is. The adjustments depending on the webhook type happen
 // right before delivery, in the [Deliver] method.
+// If ctx is in a database transaction, the task is written in that transaction
+// and the outbox relay enqueues it once the transaction has been committed.
 func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook_module.HookEventType, p api.Payloader) error {
 	// Skip sending if webhooks are disabled.
 	if setting.DisableWebhooks {
@@ -169,11 +160,15 @@ func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook
 		return fmt.Errorf("CreateHookTask for %s: %w", event, err)
 	}
 
+	if db.InTransaction(ctx) {
+		// the task isn't visible to the delivery workers before the transaction is committed
+		return nil
+	}
 	return enqueueHookTask(task.ID)
 }
 
-// PrepareWebhooks adds new webhooks to task queue for given payload.
-func PrepareWebhooks(ctx context.Context, source EventSource, event webhook_module.HookEventType, p api.Payloader) error {
+// getActiveWebhooks returns the active webhooks of the repository and its owner, and the active system webhooks
+func getActiveWebhooks(ctx context.Context, source EventSource) ([]*webhook_model.Webhook, error) {
 	owner := source.Owner
 
 	var ws []*webhook_model.Webhook
@@ -184,7 +179,7 @@ func PrepareWebhooks(ctx context.Context, source EventSource, event webhook_modu
 			IsActive: optional.Some(true),
 		})
 		if err != nil {
-			return fmt.Errorf("ListWebhooksByOpts: %w", err)
+			return nil, fmt.Errorf("ListWebhooksByOpts: %w", err)
 		}
 		ws = append(ws, repoHooks...)
 
@@ -198,7 +193,7 @@ func PrepareWebhooks(ctx context.Context, source EventSource, event webhook_modu
 			IsActive: optional.Some(true),
 		})
 		if err != nil {
-			return fmt.Errorf("ListWebhooksByOpts: %w", err)
+			return nil, fmt.Errorf("ListWebhooksByOpts: %w", err)
 		}
 		ws = append(ws, ownerHooks...)
 	}
@@ -206,12 +201,16 @@ func PrepareWebhooks(ctx context.Context, source EventSource, event webhook_modu
 	// Add any admin-defined system webhooks
 	systemHooks, err := webhook_model.GetSystemWebhooks(ctx, optional.Some(true))
 	if err != nil {
-		return fmt.Errorf("GetSystemWebhooks: %w", err)
+		return nil, fmt.Errorf("GetSystemWebhooks: %w", err)
 	}
-	ws = append(ws, systemHooks...)
+	return append(ws, systemHooks...), nil
+}
 
-	if len(ws) == 0 {
-		return nil
+// PrepareWebhooks adds new webhooks to task queue for given payload.
+func PrepareWebhooks(ctx context.Context, source EventSource, event webhook_module.HookEventType, p api.Payloader) error {
+	ws, err := getActiveWebhooks(ctx, source)
+	if err != nil {
+		return err
 	}
 
 	for _, w := range ws {
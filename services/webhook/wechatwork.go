@@ -187,6 +187,48 @@ func (wc wechatworkConvertor) WorkflowJob(p *api.WorkflowJobPayload) (Wechatwork
 	return newWechatworkMarkdownPayload(text), nil
 }
 
+func (wc wechatworkConvertor) Organization(p *api.OrganizationPayload) (WechatworkPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Membership(p *api.MembershipPayload) (WechatworkPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Team(p *api.TeamPayload) (WechatworkPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Member(p *api.MemberPayload) (WechatworkPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) BranchProtectionRule(p *api.BranchProtectionRulePayload) (WechatworkPayload, error) {
+	text, _, _ := getBranchProtectionRulePayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Star(p *api.StarPayload) (WechatworkPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) User(p *api.UserPayload) (WechatworkPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
 func newWechatworkRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
 	var pc payloadConvertor[WechatworkPayload] = wechatworkConvertor{}
 	return newJSONRequest(pc, w, t, true)
@@ -19,8 +19,10 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/globallock"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -364,6 +366,9 @@ func DeleteWiki(ctx context.Context, repo *repo_model.Repository) error {
 	}
 
 	system_model.RemoveAllWithNotice(ctx, "Delete repository wiki", repo.WikiPath())
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
 	return nil
 }
 
@@ -371,7 +376,7 @@ func ChangeDefaultWikiBranch(ctx context.Context, repo *repo_model.Repository, n
 	if !git.IsValidRefPattern(newBranch) {
 		return fmt.Errorf("invalid branch name: %s", newBranch)
 	}
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		repo.DefaultWikiBranch = newBranch
 		if err := repo_model.UpdateRepositoryCols(ctx, repo, "default_wiki_branch"); err != nil {
 			return fmt.Errorf("unable to update database: %w", err)
@@ -402,5 +407,12 @@ func ChangeDefaultWikiBranch(ctx context.Context, repo *repo_model.Repository, n
 			return fmt.Errorf("unable to rename default branch: %w", err)
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
+	return nil
 }
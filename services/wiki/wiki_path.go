@@ -10,6 +10,7 @@ import (
 
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/git"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/convert"
@@ -170,3 +171,20 @@ func ToWikiPageMetaData(wikiName WebPath, lastCommit *git.Commit, repo *repo_mod
 		LastCommit: convert.ToWikiCommit(lastCommit),
 	}
 }
+
+// ToWikiSearchResult converts a page found by the wiki indexer to a WikiSearchResult
+func ToWikiSearchResult(repo *repo_model.Repository, result *wiki_indexer.Result) (*api.WikiSearchResult, error) {
+	wikiName, err := GitPathToWebPath(result.Path)
+	if err != nil {
+		return nil, err
+	}
+	subURL := string(wikiName)
+	_, title := WebPathToUserTitle(wikiName)
+	return &api.WikiSearchResult{
+		Repository: repo.FullName(),
+		Title:      title,
+		HTMLURL:    util.URLJoin(repo.HTMLURL(), "wiki", subURL),
+		SubURL:     subURL,
+		Snippet:    result.Snippet,
+	}, nil
+}
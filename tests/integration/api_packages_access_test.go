@@ -0,0 +1,296 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageVisibilityAndAccess(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	privateOrg := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 23})
+	orgMember := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5}) // member of team "team14WriteAuth" with package write access
+	outsider := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+
+	token := getUserToken(t, admin.Name, auth_model.AccessTokenScopeWritePackage)
+	memberToken := getUserToken(t, orgMember.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageURL := func(name, version string) string {
+		return fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", privateOrg.Name, name, version)
+	}
+	accessURL := func(name string) string {
+		return fmt.Sprintf("/api/v1/packages/%s/generic/%s/-/access", privateOrg.Name, name)
+	}
+
+	uploadPackage := func(doer *user_model.User, name, version string, expectedStatus int) {
+		req := NewRequestWithBody(t, "PUT", packageURL(name, version), bytes.NewReader([]byte{1})).
+			AddBasicAuth(doer.Name)
+		MakeRequest(t, req, expectedStatus)
+	}
+	downloadPackage := func(doer *user_model.User, name string, expectedStatus int) {
+		req := NewRequest(t, "GET", packageURL(name, "1.0"))
+		if doer != nil {
+			req.AddBasicAuth(doer.Name)
+		}
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	uploadPackage(admin, "public-package", "1.0", http.StatusCreated)
+	uploadPackage(admin, "private-package", "1.0", http.StatusCreated)
+
+	t.Run("Visibility", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		downloadPackage(nil, "public-package", http.StatusUnauthorized)
+
+		req := NewRequestWithJSON(t, "PATCH", accessURL("public-package"), &api.EditPackageAccessOption{Visibility: "public"}).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var access *api.PackageAccess
+		DecodeJSON(t, resp, &access)
+		assert.Equal(t, "public", access.Visibility)
+
+		req = NewRequestWithJSON(t, "PATCH", accessURL("private-package"), &api.EditPackageAccessOption{Visibility: "private"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithJSON(t, "PATCH", accessURL("private-package"), &api.EditPackageAccessOption{Visibility: "invalid"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// public packages of private owners are readable by everyone
+		downloadPackage(nil, "public-package", http.StatusOK)
+		downloadPackage(outsider, "public-package", http.StatusOK)
+		downloadPackage(nil, "private-package", http.StatusNotFound)
+
+		// private packages are hidden from organization members without explicit access
+		downloadPackage(orgMember, "public-package", http.StatusOK)
+		downloadPackage(orgMember, "private-package", http.StatusNotFound)
+		uploadPackage(orgMember, "private-package", "1.1", http.StatusForbidden)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s", privateOrg.Name)).
+			AddTokenAuth(memberToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var packages []*api.Package
+		DecodeJSON(t, resp, &packages)
+		assert.Len(t, packages, 1)
+		assert.Equal(t, "public-package", packages[0].Name)
+
+		// only administrators of the owner can change the access settings
+		req = NewRequest(t, "GET", accessURL("public-package")).
+			AddTokenAuth(memberToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Grants", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		collaboratorURL := accessURL("private-package") + "/collaborators/" + outsider.Name
+		teamURL := accessURL("private-package") + "/teams/team14WriteAuth"
+
+		req := NewRequestWithJSON(t, "PUT", collaboratorURL, &api.PackageAccessGrantOption{Permission: "admin"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", collaboratorURL, &api.PackageAccessGrantOption{Permission: "read"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		downloadPackage(outsider, "private-package", http.StatusOK)
+		uploadPackage(outsider, "private-package", "1.1", http.StatusUnauthorized)
+		// the grant does not extend to other packages of the owner
+		downloadPackage(outsider, "other-package", http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "PUT", collaboratorURL, &api.PackageAccessGrantOption{Permission: "write"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		uploadPackage(outsider, "private-package", "1.1", http.StatusCreated)
+		uploadPackage(outsider, "other-package", "1.0", http.StatusForbidden)
+
+		req = NewRequestWithJSON(t, "PUT", teamURL, &api.PackageAccessGrantOption{Permission: "read"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		downloadPackage(orgMember, "private-package", http.StatusOK)
+		uploadPackage(orgMember, "private-package", "1.2", http.StatusForbidden)
+
+		req = NewRequest(t, "GET", accessURL("private-package")).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var access *api.PackageAccess
+		DecodeJSON(t, resp, &access)
+		assert.Equal(t, "private", access.Visibility)
+		assert.Len(t, access.Teams, 1)
+		assert.Equal(t, "team14WriteAuth", access.Teams[0].Team.Name)
+		assert.Equal(t, "read", access.Teams[0].Permission)
+		assert.Len(t, access.Collaborators, 1)
+		assert.Equal(t, outsider.Name, access.Collaborators[0].User.UserName)
+		assert.Equal(t, "write", access.Collaborators[0].Permission)
+
+		req = NewRequest(t, "DELETE", collaboratorURL).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "DELETE", collaboratorURL).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		downloadPackage(outsider, "private-package", http.StatusNotFound)
+
+		req = NewRequest(t, "DELETE", teamURL).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		downloadPackage(orgMember, "private-package", http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "PUT", accessURL("private-package")+"/teams/unknown", &api.PackageAccessGrantOption{Permission: "read"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("RepositoryPackages", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 40, OwnerID: privateOrg.ID}) // public_repo_on_private_org
+		for _, name := range []string{"public-package", "private-package"} {
+			p := unittest.AssertExistsAndLoadBean(t, &packages_model.Package{OwnerID: privateOrg.ID, LowerName: name})
+			assert.NoError(t, packages_model.SetRepositoryLink(db.DefaultContext, p.ID, repo.ID))
+		}
+
+		// the members can read the repository but not the private package linked to it
+		resp := loginUser(t, orgMember.Name).MakeRequest(t, NewRequest(t, "GET", repo.Link()+"/packages"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "public-package")
+		assert.NotContains(t, resp.Body.String(), "private-package")
+
+		resp = loginUser(t, admin.Name).MakeRequest(t, NewRequest(t, "GET", repo.Link()+"/packages"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "private-package")
+	})
+
+	t.Run("PublicOnlyToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the admin is a public user, so the packages inheriting the visibility are public
+		adminPackageURL := func(name string) string {
+			return fmt.Sprintf("/api/packages/%s/generic/%s/1.0/file.bin", admin.Name, name)
+		}
+		for _, name := range []string{"public-only-inherit", "public-only-private"} {
+			req := NewRequestWithBody(t, "PUT", adminPackageURL(name), bytes.NewReader([]byte{1})).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+		}
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/packages/%s/generic/public-only-private/-/access", admin.Name), &api.EditPackageAccessOption{Visibility: "private"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		publicOnlyToken := getUserToken(t, admin.Name, auth_model.AccessTokenScopeReadPackage, auth_model.AccessTokenScopePublicOnly)
+
+		req = NewRequest(t, "GET", adminPackageURL("public-only-inherit")).
+			AddTokenAuth(publicOnlyToken)
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "GET", adminPackageURL("public-only-private")).
+			AddTokenAuth(publicOnlyToken)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/public-only-private/1.0", admin.Name)).
+			AddTokenAuth(publicOnlyToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s?type=generic", admin.Name)).
+			AddTokenAuth(publicOnlyToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var packages []*api.Package
+		DecodeJSON(t, resp, &packages)
+		names := make([]string, 0, len(packages))
+		for _, pd := range packages {
+			names = append(names, pd.Name)
+		}
+		assert.Contains(t, names, "public-only-inherit")
+		assert.NotContains(t, names, "public-only-private")
+
+		// the private packages of private owners stay inaccessible
+		req = NewRequest(t, "GET", packageURL("private-package", "1.0")).
+			AddTokenAuth(publicOnlyToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		imageURL := fmt.Sprintf("%sv2/%s/private-image", setting.AppURL, privateOrg.Name)
+
+		configContent := `{}`
+		configDigest := "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+		manifestContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","config":{"mediaType":"` + oci.MediaTypeImageConfig + `","digest":"` + configDigest + `","size":2},"layers":[]}`
+
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", imageURL, configDigest), strings.NewReader(configContent)).
+			AddBasicAuth(admin.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", imageURL+"/manifests/latest", strings.NewReader(manifestContent)).
+			AddBasicAuth(admin.Name).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/packages/%s/container/private-image/-/access", privateOrg.Name), &api.EditPackageAccessOption{Visibility: "private"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", imageURL+"/manifests/latest").
+			AddBasicAuth(admin.Name)
+		MakeRequest(t, req, http.StatusOK)
+
+		// blobs, manifests and tags of the private image are hidden from organization members without explicit access
+		req = NewRequest(t, "HEAD", fmt.Sprintf("%s/blobs/%s", imageURL, configDigest)).
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequest(t, "GET", imageURL+"/manifests/latest").
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequest(t, "GET", imageURL+"/tags/list").
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequest(t, "DELETE", imageURL+"/manifests/latest").
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", imageURL, configDigest), strings.NewReader(configContent)).
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithJSON(t, "PUT", fmt.Sprintf("/api/v1/packages/%s/container/private-image/-/access/teams/team14WriteAuth", privateOrg.Name), &api.PackageAccessGrantOption{Permission: "read"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", imageURL+"/manifests/latest").
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "GET", imageURL+"/tags/list").
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "DELETE", imageURL+"/manifests/latest").
+			AddBasicAuth(orgMember.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,295 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	ansible_module "code.gitea.io/gitea/modules/packages/ansible"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAnsible(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	collectionNamespace := "gitea"
+	collectionName := "test_collection"
+	fullName := collectionNamespace + "." + collectionName
+	packageVersion := "1.0.0"
+	packageDescription := "Test Description"
+	packageReadme := "# Test Readme"
+
+	root := fmt.Sprintf("/api/packages/%s/ansible", user.Name)
+
+	createCollection := func(version string) []byte {
+		sum := func(s string) string {
+			h := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(h[:])
+		}
+
+		filesJSON := fmt.Sprintf(`{"files":[{"name":".","ftype":"dir","chksum_type":null,"chksum_sha256":null,"format":1},{"name":"README.md","ftype":"file","chksum_type":"sha256","chksum_sha256":"%s","format":1}],"format":1}`, sum(packageReadme))
+		manifestJSON := fmt.Sprintf(`{"collection_info":{"namespace":"%s","name":"%s","version":"%s","authors":["Gitea Authors"],"readme":"README.md","tags":["gitea","testing"],"description":"%s","license":["MIT"],"dependencies":{"community.general":">=1.0.0"},"repository":"https://gitea.io/gitea/gitea"},"file_manifest_file":{"name":"FILES.json","ftype":"file","chksum_type":"sha256","chksum_sha256":"%s","format":1},"format":1}`,
+			collectionNamespace, collectionName, version, packageDescription, sum(filesJSON))
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for _, file := range [][2]string{{"MANIFEST.json", manifestJSON}, {"FILES.json", filesJSON}, {"README.md", packageReadme}} {
+			tw.WriteHeader(&tar.Header{
+				Name: file[0],
+				Mode: 0o600,
+				Size: int64(len(file[1])),
+			})
+			tw.Write([]byte(file[1]))
+		}
+		tw.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	content := createCollection(packageVersion)
+	contentSum := sha256.Sum256(content)
+
+	uploadCollection := func(t *testing.T, content []byte, checksum string, expectedStatus int) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		if checksum != "" {
+			writer.WriteField("sha256", checksum)
+		}
+		part, _ := writer.CreateFormFile("file", "collection.tar.gz")
+		part.Write(content)
+		writer.Close()
+
+		req := NewRequestWithBody(t, "POST", root+"/api/v3/artifacts/collections", body)
+		req.Header.Add("Content-Type", writer.FormDataContentType())
+		if expectedStatus != http.StatusUnauthorized {
+			req.SetHeader("Authorization", token)
+		}
+		return MakeRequest(t, req, expectedStatus)
+	}
+
+	t.Run("Discovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/api/"), http.StatusOK)
+
+		var result struct {
+			AvailableVersions map[string]string `json:"available_versions"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "v3/", result.AvailableVersions["v3"])
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadCollection(t, content, "", http.StatusUnauthorized)
+		uploadCollection(t, []byte{1, 2, 3}, "", http.StatusBadRequest)
+		uploadCollection(t, content, "0000", http.StatusBadRequest)
+
+		resp := uploadCollection(t, content, hex.EncodeToString(contentSum[:]), http.StatusAccepted)
+
+		var task struct {
+			Task string `json:"task"`
+		}
+		DecodeJSON(t, resp, &task)
+		assert.Contains(t, task.Task, root+"/api/v3/imports/collections/")
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeAnsible)
+		require.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &ansible_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, fullName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+
+		metadata := pd.Metadata.(*ansible_module.Metadata)
+		assert.Equal(t, packageDescription, metadata.Description)
+		assert.Equal(t, packageReadme, metadata.Readme)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, metadata.Dependencies)
+
+		pfs, err := packages.GetFilesByVersionID(db.DefaultContext, pvs[0].ID)
+		require.NoError(t, err)
+		assert.Len(t, pfs, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s-%s.tar.gz", collectionNamespace, collectionName, packageVersion), pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/api/v3/imports/collections/%d/", root, pvs[0].ID)), http.StatusOK)
+
+		var state struct {
+			State string `json:"state"`
+		}
+		DecodeJSON(t, resp, &state)
+		assert.Equal(t, "completed", state.State)
+
+		uploadCollection(t, content, "", http.StatusConflict)
+
+		uploadCollection(t, createCollection("1.1.0"), "", http.StatusAccepted)
+	})
+
+	t.Run("Collections", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/api/v3/collections/"), http.StatusOK)
+
+		var result struct {
+			Meta struct {
+				Count int64 `json:"count"`
+			} `json:"meta"`
+			Data []struct {
+				Namespace      string `json:"namespace"`
+				Name           string `json:"name"`
+				HighestVersion struct {
+					Version string `json:"version"`
+				} `json:"highest_version"`
+			} `json:"data"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 1, result.Meta.Count)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, collectionNamespace, result.Data[0].Namespace)
+		assert.Equal(t, collectionName, result.Data[0].Name)
+		assert.Equal(t, "1.1.0", result.Data[0].HighestVersion.Version)
+	})
+
+	t.Run("Collection", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/api/v3/collections/gitea/unknown/"), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/api/v3/collections/%s/%s/", root, collectionNamespace, collectionName)), http.StatusOK)
+
+		var result struct {
+			VersionsURL    string `json:"versions_url"`
+			HighestVersion struct {
+				Version string `json:"version"`
+			} `json:"highest_version"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "1.1.0", result.HighestVersion.Version)
+		assert.Contains(t, result.VersionsURL, fmt.Sprintf("%s/api/v3/collections/%s/%s/versions/", root, collectionNamespace, collectionName))
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/api/v3/collections/%s/%s/versions/?limit=1", root, collectionNamespace, collectionName)), http.StatusOK)
+
+		var result struct {
+			Meta struct {
+				Count int64 `json:"count"`
+			} `json:"meta"`
+			Links struct {
+				Next *string `json:"next"`
+			} `json:"links"`
+			Data []struct {
+				Version string `json:"version"`
+			} `json:"data"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 2, result.Meta.Count)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, "1.1.0", result.Data[0].Version)
+		require.NotNil(t, result.Links.Next)
+		assert.Contains(t, *result.Links.Next, "offset=1")
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("%s/api/v3/collections/%s/%s/versions/", root, collectionNamespace, collectionName)
+
+		MakeRequest(t, NewRequest(t, "GET", url+"0.0.1/"), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", url+packageVersion+"/"), http.StatusOK)
+
+		var result struct {
+			Version     string `json:"version"`
+			DownloadURL string `json:"download_url"`
+			Artifact    struct {
+				Filename string `json:"filename"`
+				SHA256   string `json:"sha256"`
+				Size     int64  `json:"size"`
+			} `json:"artifact"`
+			Metadata struct {
+				Dependencies map[string]string `json:"dependencies"`
+				Tags         []string          `json:"tags"`
+			} `json:"metadata"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, packageVersion, result.Version)
+		assert.Equal(t, hex.EncodeToString(contentSum[:]), result.Artifact.SHA256)
+		assert.EqualValues(t, len(content), result.Artifact.Size)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, result.Metadata.Dependencies)
+		assert.Equal(t, []string{"gitea", "testing"}, result.Metadata.Tags)
+		assert.Contains(t, result.DownloadURL, root+"/download/"+result.Artifact.Filename)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/download/invalid.tar.gz"), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/download/%s-%s-0.0.1.tar.gz", root, collectionNamespace, collectionName)), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/download/%s-%s-%s.tar.gz", root, collectionNamespace, collectionName, packageVersion)), http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/ansible/%s/%s", user.Name, fullName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		body := resp.Body.String()
+		assert.Contains(t, body, "ansible-galaxy collection install "+fullName)
+		assert.Contains(t, body, packageDescription)
+		assert.Contains(t, body, "community.general")
+		assert.Contains(t, body, "testing")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("%s/api/v3/collections/%s/%s/versions/%s/", root, collectionNamespace, collectionName, packageVersion)
+
+		MakeRequest(t, NewRequest(t, "DELETE", url), http.StatusUnauthorized)
+
+		req := NewRequest(t, "DELETE", url)
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", url)
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeAnsible)
+		require.NoError(t, err)
+		assert.Len(t, pvs, 1)
+	})
+}
@@ -9,9 +9,11 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
@@ -24,6 +26,7 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/test"
 	package_service "code.gitea.io/gitea/services/packages"
+	container_service "code.gitea.io/gitea/services/packages/container"
 	"code.gitea.io/gitea/tests"
 
 	oci "github.com/opencontainers/image-spec/specs-go/v1"
@@ -802,3 +805,314 @@ func TestPackageContainer(t *testing.T) {
 		session.MakeRequest(t, req, http.StatusSeeOther)
 	})
 }
+
+func TestPackageContainerReferrers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	image := "referrers"
+	url := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	req := NewRequest(t, "GET", fmt.Sprintf("%sv2/token", setting.AppURL)).
+		AddBasicAuth(user.Name)
+	resp := MakeRequest(t, req, http.StatusOK)
+
+	tokenResponse := &struct {
+		Token string `json:"token"`
+	}{}
+	DecodeJSON(t, resp, &tokenResponse)
+	userToken := fmt.Sprintf("Bearer %s", tokenResponse.Token)
+
+	digestOf := func(content string) string {
+		return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(content)))
+	}
+	uploadBlob := func(t *testing.T, content string) string {
+		d := digestOf(content)
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, d), strings.NewReader(content)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusCreated)
+		return d
+	}
+	uploadManifest := func(t *testing.T, reference, content string) *httptest.ResponseRecorder {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, reference), strings.NewReader(content)).
+			AddTokenAuth(userToken).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		return MakeRequest(t, req, http.StatusCreated)
+	}
+
+	configContent := `{"architecture":"amd64","os":"linux"}`
+	layerContent := "layer"
+	imageManifestContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","config":{"mediaType":"` + oci.MediaTypeImageConfig + `","digest":"` + digestOf(configContent) + `","size":` + fmt.Sprint(len(configContent)) + `},"layers":[{"mediaType":"` + oci.MediaTypeImageLayerGzip + `","digest":"` + digestOf(layerContent) + `","size":` + fmt.Sprint(len(layerContent)) + `}]}`
+	imageDigest := digestOf(imageManifestContent)
+
+	signatureArtifactType := "application/vnd.dev.cosign.artifact.sig.v1+json"
+	sbomArtifactType := "application/spdx+json"
+
+	emptyConfig := `{}`
+	artifactManifest := func(artifactType, layerMediaType, layer string) string {
+		return `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","artifactType":"` + artifactType + `","config":{"mediaType":"` + oci.MediaTypeEmptyJSON + `","digest":"` + digestOf(emptyConfig) + `","size":2},"layers":[{"mediaType":"` + layerMediaType + `","digest":"` + digestOf(layer) + `","size":` + fmt.Sprint(len(layer)) + `}],"subject":{"mediaType":"` + oci.MediaTypeImageManifest + `","digest":"` + imageDigest + `","size":` + fmt.Sprint(len(imageManifestContent)) + `},"annotations":{"org.example.key":"value"}}`
+	}
+	signatureContent := "signature"
+	signatureManifestContent := artifactManifest(signatureArtifactType, "application/vnd.dev.sigstore.bundle.v0.3+json", signatureContent)
+	signatureDigest := digestOf(signatureManifestContent)
+	sbomContent := `{"spdxVersion":"SPDX-2.3"}`
+	sbomManifestContent := artifactManifest(sbomArtifactType, sbomArtifactType, sbomContent)
+	sbomDigest := digestOf(sbomManifestContent)
+
+	getReferrers := func(t *testing.T, query string) (*oci.Index, *httptest.ResponseRecorder) {
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s%s", url, imageDigest, query)).
+			AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+
+		var index oci.Index
+		DecodeJSON(t, resp, &index)
+		return &index, resp
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadBlob(t, configContent)
+		uploadBlob(t, layerContent)
+		uploadManifest(t, "v1", imageManifestContent)
+
+		// An artifact can be pushed before the image it refers to
+		uploadBlob(t, emptyConfig)
+		uploadBlob(t, signatureContent)
+		resp := uploadManifest(t, signatureDigest, signatureManifestContent)
+		assert.Equal(t, imageDigest, resp.Header().Get("OCI-Subject"))
+
+		uploadBlob(t, sbomContent)
+		resp = uploadManifest(t, sbomDigest, sbomManifestContent)
+		assert.Equal(t, imageDigest, resp.Header().Get("OCI-Subject"))
+
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeContainer, image, signatureDigest)
+		assert.NoError(t, err)
+
+		pd, err := packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{imageDigest}, func() []string {
+			values := []string{}
+			for _, pp := range pd.VersionProperties {
+				if pp.Name == container_module.PropertyManifestSubject {
+					values = append(values, pp.Value)
+				}
+			}
+			return values
+		}())
+
+		metadata := pd.Metadata.(*container_module.Metadata)
+		assert.Equal(t, imageDigest, metadata.Subject)
+		assert.Equal(t, signatureArtifactType, metadata.ArtifactType)
+		assert.Equal(t, map[string]string{"org.example.key": "value"}, metadata.Annotations)
+		assert.Empty(t, metadata.Platform)
+	})
+
+	t.Run("GetReferrers", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		index, resp := getReferrers(t, "")
+		assert.Empty(t, resp.Header().Get("OCI-Filters-Applied"))
+		assert.EqualValues(t, 2, index.SchemaVersion)
+		assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+		assert.Len(t, index.Manifests, 2)
+		for _, m := range index.Manifests {
+			assert.Equal(t, oci.MediaTypeImageManifest, m.MediaType)
+			assert.Equal(t, "value", m.Annotations["org.example.key"])
+			switch string(m.Digest) {
+			case signatureDigest:
+				assert.Equal(t, signatureArtifactType, m.ArtifactType)
+				assert.EqualValues(t, len(signatureManifestContent), m.Size)
+			case sbomDigest:
+				assert.Equal(t, sbomArtifactType, m.ArtifactType)
+				assert.EqualValues(t, len(sbomManifestContent), m.Size)
+			default:
+				assert.Fail(t, "unexpected referrer", m.Digest)
+			}
+		}
+
+		index, resp = getReferrers(t, "?artifactType="+strings.ReplaceAll(sbomArtifactType, "+", "%2B"))
+		assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+		assert.Len(t, index.Manifests, 1)
+		assert.EqualValues(t, sbomDigest, index.Manifests[0].Digest)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, signatureDigest)).
+			AddTokenAuth(userToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var empty oci.Index
+		DecodeJSON(t, resp, &empty)
+		assert.NotNil(t, empty.Manifests)
+		assert.Empty(t, empty.Manifests)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%sv2/%s/unknown/referrers/%s", setting.AppURL, user.Name, imageDigest)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/invalid", url)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/container/%s/v1", user.Name, image))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		body := resp.Body.String()
+		assert.Contains(t, body, "Signature")
+		assert.Contains(t, body, "SBOM")
+		assert.Contains(t, body, "application/spdx")
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		p, err := packages_model.GetPackageByName(db.DefaultContext, user.ID, packages_model.TypeContainer, image)
+		assert.NoError(t, err)
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeContainer, image, signatureDigest)
+		assert.NoError(t, err)
+
+		skip, err := container_service.ShouldBeSkipped(db.DefaultContext, nil, p, pv)
+		assert.NoError(t, err)
+		assert.True(t, skip)
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("%s/manifests/v1", url)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusAccepted)
+
+		skip, err = container_service.ShouldBeSkipped(db.DefaultContext, nil, p, pv)
+		assert.NoError(t, err)
+		assert.False(t, skip)
+	})
+}
+
+func TestPackageContainerGarbageCollect(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	image := "gc"
+	url := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	req := NewRequest(t, "GET", fmt.Sprintf("%sv2/token", setting.AppURL)).
+		AddBasicAuth(user.Name)
+	resp := MakeRequest(t, req, http.StatusOK)
+
+	tokenResponse := &struct {
+		Token string `json:"token"`
+	}{}
+	DecodeJSON(t, resp, &tokenResponse)
+	userToken := fmt.Sprintf("Bearer %s", tokenResponse.Token)
+
+	digestOf := func(content string) string {
+		return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(content)))
+	}
+	uploadBlob := func(t *testing.T, content string) string {
+		d := digestOf(content)
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, d), strings.NewReader(content)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusCreated)
+		return d
+	}
+	imageManifest := func(config, layer string) string {
+		return `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","config":{"mediaType":"` + oci.MediaTypeImageConfig + `","digest":"` + digestOf(config) + `","size":` + fmt.Sprint(len(config)) + `},"layers":[{"mediaType":"` + oci.MediaTypeImageLayerGzip + `","digest":"` + digestOf(layer) + `","size":` + fmt.Sprint(len(layer)) + `}]}`
+	}
+	uploadManifest := func(t *testing.T, reference, content string) {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, reference), strings.NewReader(content)).
+			AddTokenAuth(userToken).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+	headBlob := func(t *testing.T, digest string, expectedStatus int) {
+		req := NewRequest(t, "HEAD", fmt.Sprintf("%s/blobs/%s", url, digest)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	configContent := `{"architecture":"amd64","os":"linux"}`
+	uploadBlob(t, configContent)
+
+	// tagged image
+	taggedLayer := "tagged layer"
+	uploadBlob(t, taggedLayer)
+	uploadManifest(t, "v1", imageManifest(configContent, taggedLayer))
+
+	// image which was tagged "v2" before the tag got pushed again, only the config is shared
+	orphanLayer := "orphan layer"
+	uploadBlob(t, orphanLayer)
+	orphanManifest := imageManifest(configContent, orphanLayer)
+	uploadManifest(t, digestOf(orphanManifest), orphanManifest)
+
+	// blob of an upload in progress which is not referenced by a manifest yet
+	pendingLayer := uploadBlob(t, "pending layer")
+
+	gc := func(t *testing.T, autoFix bool) *container_service.GarbageCollectReport {
+		report, err := container_service.GarbageCollect(db.DefaultContext, container_service.GarbageCollectOptions{
+			AutoFix:   autoFix,
+			OwnerID:   user.ID,
+			OlderThan: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		return report
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		report := gc(t, false)
+		assert.Equal(t, 1, report.UnreachableManifests)
+		// orphan layer and orphan manifest
+		assert.Equal(t, 2, report.ReclaimableBlobs)
+		assert.EqualValues(t, len(orphanLayer)+len(orphanManifest), report.ReclaimableSize)
+
+		assert.Len(t, report.Owners, 1)
+		osr := report.Owners[0]
+		assert.Equal(t, user.ID, osr.OwnerID)
+		// config, tagged layer, tagged manifest, orphan layer, orphan manifest, pending layer
+		assert.EqualValues(t, 6, osr.Blobs)
+
+		uploadedSize := len(configContent) + len(taggedLayer) + len(imageManifest(configContent, taggedLayer)) + len(orphanLayer) + len(orphanManifest) + len("pending layer")
+		assert.EqualValues(t, uploadedSize, osr.Size)
+
+		headBlob(t, digestOf(orphanLayer), http.StatusOK)
+	})
+
+	t.Run("Recent", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		report, err := container_service.GarbageCollect(db.DefaultContext, container_service.GarbageCollectOptions{
+			OwnerID:   user.ID,
+			OlderThan: time.Now().Add(-time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.UnreachableManifests)
+	})
+
+	t.Run("AutoFix", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		report := gc(t, true)
+		assert.Equal(t, 1, report.UnreachableManifests)
+		assert.Equal(t, 2, report.ReclaimableBlobs)
+
+		headBlob(t, digestOf(orphanLayer), http.StatusNotFound)
+		headBlob(t, digestOf(taggedLayer), http.StatusOK)
+		headBlob(t, digestOf(configContent), http.StatusOK)
+		headBlob(t, pendingLayer, http.StatusOK)
+
+		req := NewRequest(t, "HEAD", fmt.Sprintf("%s/manifests/%s", url, digestOf(orphanManifest))).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequest(t, "HEAD", fmt.Sprintf("%s/manifests/v1", url)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusOK)
+
+		report = gc(t, false)
+		assert.Equal(t, 0, report.UnreachableManifests)
+		assert.Equal(t, 0, report.ReclaimableBlobs)
+	})
+}
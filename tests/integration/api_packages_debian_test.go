@@ -14,12 +14,14 @@ import (
 	"strings"
 	"testing"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/base"
 	debian_module "code.gitea.io/gitea/modules/packages/debian"
+	api "code.gitea.io/gitea/modules/structs"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
 	"code.gitea.io/gitea/tests"
 
@@ -235,6 +237,26 @@ func TestPackageDebian(t *testing.T) {
 		})
 	}
 
+	t.Run("Access", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+		accessURL := fmt.Sprintf("/api/v1/packages/%s/debian/%s/-/access", user.Name, packageName)
+
+		// the repository index is shared by all packages of the owner and would reveal restricted packages
+		req := NewRequestWithJSON(t, "PATCH", accessURL, &api.EditPackageAccessOption{Visibility: "private"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", accessURL+"/collaborators/user8", &api.PackageAccessGrantOption{Permission: "read"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PATCH", accessURL, &api.EditPackageAccessOption{Visibility: "inherit"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
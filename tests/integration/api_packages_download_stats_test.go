@@ -0,0 +1,110 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageDownloadStats(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadPackage)
+
+	packageName := "download-stats"
+	fileURL := func(version string) string {
+		return fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, version)
+	}
+	apiURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s", user.Name, packageName)
+
+	for _, version := range []string{"1.0", "2.0"} {
+		req := NewRequestWithBody(t, "PUT", fileURL(version), bytes.NewReader([]byte{1})).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	download := func(version, userAgent string) {
+		req := NewRequest(t, "GET", fileURL(version))
+		req.Header.Set("User-Agent", userAgent)
+		MakeRequest(t, req, http.StatusOK)
+	}
+	download("1.0", "curl/8.5.0")
+	download("1.0", "curl/8.5.0")
+	download("1.0", "Wget/1.21.4")
+	download("2.0", "curl/8.5.0")
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	getStats := func(t *testing.T, url string, expectedStatus int) []*api.PackageDownloadStats {
+		req := NewRequest(t, "GET", url).AddTokenAuth(token)
+		resp := MakeRequest(t, req, expectedStatus)
+
+		var stats []*api.PackageDownloadStats
+		if expectedStatus == http.StatusOK {
+			DecodeJSON(t, resp, &stats)
+		}
+		return stats
+	}
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		stats := getStats(t, apiURL+"/-/downloads", http.StatusOK)
+		assert.Len(t, stats, 1)
+		assert.True(t, today.Equal(stats[0].Date))
+		assert.Equal(t, "day", stats[0].Period)
+		assert.Empty(t, stats[0].Client)
+		assert.EqualValues(t, 4, stats[0].Count)
+
+		stats = getStats(t, apiURL+"/-/downloads?group_by=version", http.StatusOK)
+		assert.Len(t, stats, 2)
+		assert.Equal(t, "1.0", stats[0].Version)
+		assert.EqualValues(t, 3, stats[0].Count)
+		assert.Equal(t, "2.0", stats[1].Version)
+		assert.EqualValues(t, 1, stats[1].Count)
+
+		stats = getStats(t, apiURL+"/-/downloads?since="+today.Add(24*time.Hour).Format(time.RFC3339), http.StatusOK)
+		assert.Empty(t, stats)
+
+		getStats(t, apiURL+"/-/downloads?group_by=invalid", http.StatusUnprocessableEntity)
+		getStats(t, fmt.Sprintf("/api/v1/packages/%s/generic/unknown/-/downloads", user.Name), http.StatusNotFound)
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		stats := getStats(t, apiURL+"/1.0/downloads?group_by=client", http.StatusOK)
+		assert.Len(t, stats, 2)
+		assert.Equal(t, "curl", stats[0].Client)
+		assert.EqualValues(t, 2, stats[0].Count)
+		assert.Equal(t, "wget", stats[1].Client)
+		assert.EqualValues(t, 1, stats[1].Count)
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/%s/2.0/downloads", user.Name, packageName))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var stats []*api.PackageDownloadStats
+		DecodeJSON(t, resp, &stats)
+		assert.Len(t, stats, 2)
+		assert.Equal(t, "curl", stats[0].Client)
+		assert.EqualValues(t, 3, stats[0].Count)
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+	readToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadPackage)
+
+	packageName := "gitea_test"
+	packageVersion := "1.0.0-rc.1"
+	packageDescription := "Test Description"
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	createTarball := func(version string) ([]byte, string) {
+		metadata := fmt.Sprintf(`{<<"app">>,<<"%[1]s">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"%[3]s">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"name">>,<<"%[1]s">>}.
+{<<"requirements">>,[#{<<"app">> => <<"jason">>,<<"name">> => <<"jason">>,<<"optional">> => false,<<"repository">> => <<"hexpm">>,<<"requirement">> => <<"~> 1.4">>}]}.
+{<<"version">>,<<"%[2]s">>}.
+`, packageName, version, packageDescription)
+		contents := "dummy contents " + version
+
+		inner := sha256.Sum256([]byte("3" + metadata + contents))
+		checksum := strings.ToUpper(hex.EncodeToString(inner[:]))
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, file := range [][2]string{{"VERSION", "3"}, {"CHECKSUM", checksum}, {"metadata.config", metadata}, {"contents.tar.gz", contents}} {
+			tw.WriteHeader(&tar.Header{
+				Name: file[0],
+				Mode: 0o600,
+				Size: int64(len(file[1])),
+			})
+			tw.Write([]byte(file[1]))
+		}
+		tw.Close()
+
+		return buf.Bytes(), hex.EncodeToString(inner[:])
+	}
+
+	content, innerChecksum := createTarball(packageVersion)
+	outerChecksum := sha256.Sum256(content)
+
+	var publicKey *rsa.PublicKey
+
+	// readResource verifies the signature of the registry resource and returns the payload
+	readResource := func(t *testing.T, body []byte) []byte {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		data, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		fields := decodeProtobuf(t, data)
+		require.Len(t, fields[1], 1)
+		require.Len(t, fields[2], 1)
+
+		hash := sha512.Sum512(fields[1][0])
+		require.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, hash[:], fields[2][0]))
+
+		return fields[1][0]
+	}
+
+	t.Run("PublicKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/public_key"), http.StatusOK)
+
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		assert.Equal(t, "PUBLIC KEY", block.Type)
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+		publicKey = key.(*rsa.PublicKey)
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		req.SetHeader("Authorization", readToken)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader([]byte{1, 2, 3}))
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		req.SetHeader("Authorization", token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		assert.Equal(t, hex_module.ContentTypeErlang, resp.Header().Get("Content-Type"))
+		assert.Contains(t, resp.Body.String(), hex.EncodeToString(outerChecksum[:]))
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+
+		metadata := pd.Metadata.(*hex_module.Metadata)
+		assert.Equal(t, packageDescription, metadata.Description)
+		assert.Equal(t, innerChecksum, metadata.InnerChecksum)
+		assert.Len(t, metadata.Requirements, 1)
+
+		pfs, err := packages.GetFilesByVersionID(db.DefaultContext, pvs[0].ID)
+		require.NoError(t, err)
+		assert.Len(t, pfs, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s.tar", packageName, packageVersion), pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithBody(t, "POST", url+"?replace=true", bytes.NewReader(content))
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err = packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		content2, _ := createTarball("1.1.0")
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content2)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/names"), http.StatusOK)
+
+		names := decodeProtobuf(t, readResource(t, resp.Body.Bytes()))
+		assert.Equal(t, user.Name, string(names[2][0]))
+		require.Len(t, names[1], 1)
+		assert.Equal(t, packageName, string(decodeProtobuf(t, names[1][0])[1][0]))
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/versions"), http.StatusOK)
+
+		versions := decodeProtobuf(t, readResource(t, resp.Body.Bytes()))
+		assert.Equal(t, user.Name, string(versions[2][0]))
+		require.Len(t, versions[1], 1)
+
+		pkg := decodeProtobuf(t, versions[1][0])
+		assert.Equal(t, packageName, string(pkg[1][0]))
+		require.Len(t, pkg[2], 2)
+		assert.Equal(t, packageVersion, string(pkg[2][0]))
+		assert.Equal(t, "1.1.0", string(pkg[2][1]))
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/packages/unknown"), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/packages/"+packageName), http.StatusOK)
+
+		pkg := decodeProtobuf(t, readResource(t, resp.Body.Bytes()))
+		assert.Equal(t, packageName, string(pkg[2][0]))
+		assert.Equal(t, user.Name, string(pkg[3][0]))
+		require.Len(t, pkg[1], 2)
+
+		release := decodeProtobuf(t, pkg[1][0])
+		assert.Equal(t, packageVersion, string(release[1][0]))
+		assert.Equal(t, innerChecksum, hex.EncodeToString(release[2][0]))
+		assert.Equal(t, outerChecksum[:], release[5][0])
+		require.Len(t, release[3], 1)
+
+		dependency := decodeProtobuf(t, release[3][0])
+		assert.Equal(t, "jason", string(dependency[1][0]))
+		assert.Equal(t, "~> 1.4", string(dependency[2][0]))
+		assert.Equal(t, "hexpm", string(dependency[5][0]))
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-0.0.1.tar", root, packageName)), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-%s.tar", root, packageName, packageVersion)), http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/hex/%s/%s", user.Name, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		body := resp.Body.String()
+		assert.Contains(t, body, "mix hex.repo add "+user.Name)
+		assert.Contains(t, body, packageDescription)
+		assert.Contains(t, body, "jason")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("%s/api/packages/%s/releases/%s", root, packageName, packageVersion)
+
+		MakeRequest(t, NewRequest(t, "DELETE", url), http.StatusUnauthorized)
+
+		req := NewRequest(t, "DELETE", url)
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", url)
+		req.SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		assert.Len(t, pvs, 1)
+	})
+}
+
+// decodeProtobuf returns the length-delimited fields of a protobuf message by field number
+func decodeProtobuf(t *testing.T, data []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		require.GreaterOrEqual(t, n, 0)
+		data = data[n:]
+
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(data)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], v)
+			data = data[n:]
+		} else {
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			require.GreaterOrEqual(t, n, 0)
+			data = data[n:]
+		}
+	}
+	return fields
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	unit_model "code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageProvenance(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the running task 47 of the fixtures belongs to the user with id 1
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+	taskToken := "8061e833a55f6fc0157c98b883e91fcfeeb1a71a"
+	commitSHA := "c2d72f548424103f01ee1dc02889c1e2bff816b0"
+
+	packageName := "provenance"
+	packageVersion := "1.0.0"
+	content := []byte("built by actions")
+	contentSHA256 := sha256.Sum256(content)
+
+	apiURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s", user.Name, packageName, packageVersion)
+
+	t.Run("UploadWithTaskToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, packageVersion)
+
+		// the task can only write packages if the Packages unit of the repository is enabled
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader(content)).
+			AddTokenAuth(taskToken)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+		require.NoError(t, repo_service.UpdateRepositoryUnits(t.Context(), repo, []repo_model.RepoUnit{{RepoID: repo.ID, Type: unit_model.TypePackages}}, nil))
+
+		req = NewRequestWithBody(t, "PUT", url, bytes.NewReader(content)).
+			AddTokenAuth(taskToken)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeGeneric, packageName, packageVersion)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, pv.SourceRepoID)
+		assert.Equal(t, commitSHA, pv.SourceCommitSHA)
+		assert.Equal(t, "artifact.yaml", pv.SourceWorkflowID)
+		assert.EqualValues(t, 791, pv.SourceRunID)
+		assert.EqualValues(t, user_model.ActionsUserID, pv.CreatorID)
+
+		t.Run("OtherOwner", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", "/api/packages/user2/generic/provenance/1.0.0/file.bin", bytes.NewReader(content)).
+				AddTokenAuth(taskToken)
+			MakeRequest(t, req, http.StatusUnauthorized)
+		})
+
+		t.Run("OtherRepository", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			url := fmt.Sprintf("/api/packages/%s/generic/%s/1.0.1/file.bin", user.Name, packageName)
+
+			// packages linked to another repository are not writable by the task
+			require.NoError(t, packages_model.SetRepositoryLink(t.Context(), pv.PackageID, 1))
+			req := NewRequestWithBody(t, "PUT", url, bytes.NewReader(content)).
+				AddTokenAuth(taskToken)
+			MakeRequest(t, req, http.StatusForbidden)
+
+			require.NoError(t, packages_model.SetRepositoryLink(t.Context(), pv.PackageID, 4))
+			req = NewRequestWithBody(t, "PUT", url, bytes.NewReader(content)).
+				AddTokenAuth(taskToken)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/%s/1.0.1", user.Name, packageName)).
+				AddTokenAuth(taskToken)
+			MakeRequest(t, req, http.StatusNoContent)
+		})
+	})
+
+	t.Run("Source", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		getSource := func(t *testing.T) *api.PackageSource {
+			req := NewRequest(t, "GET", apiURL).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var p *api.Package
+			DecodeJSON(t, resp, &p)
+			return p.Source
+		}
+
+		source := getSource(t)
+		require.NotNil(t, source)
+		assert.Equal(t, "user5/repo4", source.Repository.FullName)
+		assert.Equal(t, commitSHA, source.CommitSHA)
+		assert.Equal(t, "artifact.yaml", source.Workflow)
+		assert.EqualValues(t, 791, source.RunID)
+		// the run is only linked if the actions of the repository are readable
+		assert.Empty(t, source.RunURL)
+
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+		require.NoError(t, repo_service.UpdateRepositoryUnits(t.Context(), repo, []repo_model.RepoUnit{{RepoID: repo.ID, Type: unit_model.TypeActions}}, nil))
+
+		source = getSource(t)
+		require.NotNil(t, source)
+		assert.True(t, strings.HasSuffix(source.RunURL, "/user5/repo4/actions/runs/187"))
+	})
+
+	t.Run("ListByCommit", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		cases := []struct {
+			Commit         string
+			ExpectedStatus int
+			ExpectedCount  int
+		}{
+			{commitSHA, http.StatusOK, 1},
+			{strings.ToUpper(commitSHA[:7]), http.StatusOK, 1},
+			{"0000000", http.StatusOK, 0},
+			{"c2d72f", http.StatusUnprocessableEntity, 0},
+			{"not-a-commit", http.StatusUnprocessableEntity, 0},
+		}
+
+		for _, c := range cases {
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s?commit=%s", user.Name, c.Commit)).AddTokenAuth(token)
+			resp := MakeRequest(t, req, c.ExpectedStatus)
+			if c.ExpectedStatus != http.StatusOK {
+				continue
+			}
+
+			var ps []*api.Package
+			DecodeJSON(t, resp, &ps)
+			assert.Len(t, ps, c.ExpectedCount, "commit %s", c.Commit)
+		}
+	})
+
+	statement := func(subjectDigest, sourceDigest string) string {
+		return fmt.Sprintf(`{
+  "_type": "https://in-toto.io/Statement/v1",
+  "subject": [{"name": "file.bin", "digest": {"sha256": "%s"}}],
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {
+    "buildDefinition": {
+      "resolvedDependencies": [{"uri": "git+https://example.com/user5/repo4", "digest": {"gitCommit": "%s"}}]
+    }
+  }
+}`, subjectDigest, sourceDigest)
+	}
+
+	provenanceURL := apiURL + "/provenance"
+
+	t.Run("Add", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", provenanceURL, strings.NewReader(statement(hex.EncodeToString(contentSHA256[:]), commitSHA))).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var pp *api.PackageProvenance
+		DecodeJSON(t, resp, &pp)
+		assert.Equal(t, "https://slsa.dev/provenance/v1", pp.PredicateType)
+		assert.Equal(t, "git+https://example.com/user5/repo4", pp.SourceURI)
+		assert.Equal(t, commitSHA, pp.SourceDigest)
+		assert.False(t, pp.HasSignatures)
+		assert.True(t, pp.MatchesFiles)
+		assert.True(t, pp.MatchesBuildSource)
+
+		req = NewRequestWithBody(t, "POST", provenanceURL, strings.NewReader(statement(strings.Repeat("0", 64), commitSHA))).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+
+		DecodeJSON(t, resp, &pp)
+		assert.False(t, pp.MatchesFiles)
+		assert.True(t, pp.MatchesBuildSource)
+
+		req = NewRequestWithBody(t, "POST", provenanceURL, strings.NewReader(statement(hex.EncodeToString(contentSHA256[:]), strings.Repeat("1", 40)))).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+
+		DecodeJSON(t, resp, &pp)
+		assert.True(t, pp.MatchesFiles)
+		assert.False(t, pp.MatchesBuildSource)
+
+		req = NewRequestWithBody(t, "POST", provenanceURL, strings.NewReader(`{"_type": "invalid"}`)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithBody(t, "POST", provenanceURL, strings.NewReader(statement(hex.EncodeToString(contentSHA256[:]), commitSHA))).
+			AddTokenAuth(getUserToken(t, "user2", auth_model.AccessTokenScopeWritePackage))
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("ListAndDelete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", provenanceURL).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var pps []*api.PackageProvenance
+		DecodeJSON(t, resp, &pps)
+		require.Len(t, pps, 3)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d", provenanceURL, pps[0].ID)).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, statement(hex.EncodeToString(contentSHA256[:]), commitSHA), resp.Body.String())
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", provenanceURL, pps[0].ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", provenanceURL, pps[0].ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", provenanceURL).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		DecodeJSON(t, resp, &pps)
+		assert.Len(t, pps, 2)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/%s/%s", user.Name, packageName, packageVersion))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find(fmt.Sprintf(`a[href="/user5/repo4/commit/%s"]`, commitSHA)).Length())
+		assert.Equal(t, 1, htmlDoc.Find(`a[href="/user5/repo4/actions/runs/187"]`).Length())
+	})
+}
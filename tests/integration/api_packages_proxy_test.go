@@ -0,0 +1,296 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	packages_proxy "code.gitea.io/gitea/services/packages/proxy"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageProxy(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	defer test.MockVariableValue(&setting.Packages.ProxyAllowedHostList, "loopback")()
+	defer test.MockVariableValue(&setting.Packages.ProxyTimeout, time.Second)()
+	require.NoError(t, packages_proxy.Init())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	content := "upstream content"
+	sumSHA1 := sha1.Sum([]byte(content))
+	sumSHA256 := sha256.Sum256([]byte(content))
+	sumSHA512 := sha512.Sum512([]byte(content))
+
+	var upstreamRequests atomic.Int64
+	var upstreamAvailable atomic.Bool
+	upstreamAvailable.Store(true)
+
+	var upstreamURL string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamRequests.Add(1)
+		if !upstreamAvailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/npm/upstream-package":
+			fmt.Fprintf(w, `{"name":"upstream-package","dist-tags":{"latest":"1.0.0"},"versions":{"1.0.0":{"name":"upstream-package","version":"1.0.0","description":"Upstream","dist":{"tarball":"%s/npm/upstream-package/-/upstream-package-1.0.0.tgz","integrity":"sha512-%s"}}}}`, upstreamURL, base64.StdEncoding.EncodeToString(sumSHA512[:]))
+		case "/npm/upstream-package/-/upstream-package-1.0.0.tgz":
+			fmt.Fprint(w, content)
+		case "/npm/slow-package":
+			fmt.Fprintf(w, `{"name":"slow-package","versions":{"1.0.0":{"dist":{"tarball":"%s/npm/slow-package/-/slow-package-1.0.0.tgz","integrity":"sha512-%s"}}}}`, upstreamURL, base64.StdEncoding.EncodeToString(sumSHA512[:]))
+		case "/npm/slow-package/-/slow-package-1.0.0.tgz":
+			// the download takes longer than the timeout, which only applies until the response starts
+			fmt.Fprint(w, content[:4])
+			w.(http.Flusher).Flush()
+			time.Sleep(1500 * time.Millisecond)
+			fmt.Fprint(w, content[4:])
+		case "/npm/bad-package":
+			fmt.Fprintf(w, `{"name":"bad-package","versions":{"1.0.0":{"dist":{"tarball":"%s/npm/bad-package/-/bad-package-1.0.0.tgz","shasum":"%s"}}}}`, upstreamURL, strings.Repeat("0", 40))
+		case "/npm/bad-package/-/bad-package-1.0.0.tgz":
+			fmt.Fprint(w, content)
+		case "/pypi/upstream-package/":
+			assert.Equal(t, "application/vnd.pypi.simple.v1+json", r.Header.Get("Accept"))
+			fmt.Fprintf(w, `{"name":"upstream-package","files":[{"filename":"upstream_package-1.0.0-py3-none-any.whl","url":"../../files/upstream_package-1.0.0-py3-none-any.whl","hashes":{"sha256":"%s"},"requires-python":">=3.8"}]}`, hex.EncodeToString(sumSHA256[:]))
+		case "/files/upstream_package-1.0.0-py3-none-any.whl":
+			fmt.Fprint(w, content)
+		case "/maven/com/gitea/upstream-project/1.0/upstream-project-1.0.jar":
+			fmt.Fprint(w, content)
+		case "/maven/com/gitea/upstream-project/1.0/upstream-project-1.0.jar.sha1":
+			fmt.Fprintf(w, "%s  upstream-project-1.0.jar", hex.EncodeToString(sumSHA1[:]))
+		case "/maven/com/gitea/upstream-project/maven-metadata.xml":
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><metadata><groupId>com.gitea</groupId><artifactId>upstream-project</artifactId><versioning><latest>1.0</latest><release>1.0</release><versions><version>1.0</version></versions></versioning></metadata>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+	upstreamURL = upstream.URL
+
+	createProxy := func(t *testing.T, packageType packages_model.Type, path, allowlist string) *packages_model.PackageProxy {
+		pp, err := packages_model.InsertProxy(db.DefaultContext, &packages_model.PackageProxy{
+			Enabled:     true,
+			OwnerID:     user.ID,
+			Type:        packageType,
+			UpstreamURL: upstreamURL + path,
+			MetadataTTL: 300,
+			Allowlist:   allowlist,
+		})
+		require.NoError(t, err)
+		return pp
+	}
+
+	assertPulledVersion := func(t *testing.T, packageType packages_model.Type, name, version string) {
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packageType, name, version)
+		require.NoError(t, err)
+		props, err := packages_model.GetPropertiesByName(db.DefaultContext, packages_model.PropertyTypeVersion, pv.ID, packages_proxy.UpstreamProperty)
+		require.NoError(t, err)
+		require.Len(t, props, 1)
+	}
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/proxies/add", map[string]string{
+			"_csrf":        GetUserCSRFToken(t, session),
+			"enabled":      "on",
+			"type":         string(packages_model.TypeNpm),
+			"upstream_url": upstreamURL,
+			"metadata_ttl": "60",
+			"allowlist":    "@scope/*\nupstream-*",
+			"action":       "save",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		pp, err := packages_model.GetProxyByOwnerAndType(db.DefaultContext, user.ID, packages_model.TypeNpm)
+		require.NoError(t, err)
+		assert.True(t, pp.Enabled)
+		assert.Equal(t, upstreamURL, pp.UpstreamURL)
+		assert.EqualValues(t, 60, pp.MetadataTTL)
+		assert.True(t, pp.IsAllowed("@Scope/Package"))
+		assert.False(t, pp.IsAllowed("other"))
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user/settings/packages/proxies/%d", pp.ID), map[string]string{
+			"_csrf":        GetUserCSRFToken(t, session),
+			"id":           fmt.Sprint(pp.ID),
+			"type":         string(packages_model.TypeNpm),
+			"upstream_url": upstreamURL,
+			"action":       "remove",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		unittest.AssertNotExistsBean(t, &packages_model.PackageProxy{ID: pp.ID})
+	})
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pp := createProxy(t, packages_model.TypeNpm, "/npm", "upstream-*\nbad-*\nslow-*")
+		defer packages_model.DeleteProxyByID(db.DefaultContext, pp.ID)
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		t.Run("PackageMetadata", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/upstream-package")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result map[string]any
+			DecodeJSON(t, resp, &result)
+			version := result["versions"].(map[string]any)["1.0.0"].(map[string]any)
+			tarball := version["dist"].(map[string]any)["tarball"].(string)
+			assert.Equal(t, fmt.Sprintf("%sapi/packages/%s/npm/upstream-package/-/1.0.0/upstream-package-1.0.0.tgz", setting.AppURL, user.Name), tarball)
+
+			// the metadata is cached until the TTL expires
+			requests := upstreamRequests.Load()
+			MakeRequest(t, NewRequest(t, "GET", root+"/upstream-package"), http.StatusOK)
+			assert.Equal(t, requests, upstreamRequests.Load())
+		})
+
+		t.Run("NotAllowed", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequest(t, "GET", root+"/other-package"), http.StatusNotFound)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/upstream-package/-/1.0.0/upstream-package-1.0.0.tgz")
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+
+			assertPulledVersion(t, packages_model.TypeNpm, "upstream-package", "1.0.0")
+
+			// the pulled file is served locally
+			requests := upstreamRequests.Load()
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+			assert.Equal(t, requests, upstreamRequests.Load())
+		})
+
+		t.Run("SlowDownload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", root+"/slow-package/-/1.0.0/slow-package-1.0.0.tgz"), http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+
+			assertPulledVersion(t, packages_model.TypeNpm, "slow-package", "1.0.0")
+		})
+
+		t.Run("ChecksumMismatch", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequest(t, "GET", root+"/bad-package/-/1.0.0/bad-package-1.0.0.tgz"), http.StatusBadGateway)
+
+			_, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeNpm, "bad-package", "1.0.0")
+			assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+		})
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pp := createProxy(t, packages_model.TypePyPI, "/pypi/", "")
+		defer packages_model.DeleteProxyByID(db.DefaultContext, pp.ID)
+
+		root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+
+		t.Run("PackageMetadata", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", root+"/simple/upstream-package"), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), fmt.Sprintf("%s/files/upstream-package/1.0.0/upstream_package-1.0.0-py3-none-any.whl#sha256=%s", root, hex.EncodeToString(sumSHA256[:])))
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/files/upstream-package/1.0.0/upstream_package-1.0.0-py3-none-any.whl")
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+
+			assertPulledVersion(t, packages_model.TypePyPI, "upstream-package", "1.0.0")
+
+			MakeRequest(t, NewRequest(t, "GET", root+"/files/upstream-package/2.0.0/upstream_package-2.0.0-py3-none-any.whl"), http.StatusNotFound)
+		})
+
+		t.Run("Offline", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			upstreamAvailable.Store(false)
+			defer upstreamAvailable.Store(true)
+
+			// the expired metadata is still served if the upstream index is unavailable
+			_, err := db.GetEngine(db.DefaultContext).Where("proxy_id = ?", pp.ID).Cols("fetched_unix").Update(&packages_model.PackageProxyMetadata{FetchedUnix: 1})
+			require.NoError(t, err)
+
+			resp := MakeRequest(t, NewRequest(t, "GET", root+"/simple/upstream-package"), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "upstream_package-1.0.0-py3-none-any.whl")
+
+			// an unknown package can not be served without the upstream index
+			MakeRequest(t, NewRequest(t, "GET", root+"/simple/unknown-package"), http.StatusBadGateway)
+		})
+	})
+
+	t.Run("Maven", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pp := createProxy(t, packages_model.TypeMaven, "/maven", "")
+		defer packages_model.DeleteProxyByID(db.DefaultContext, pp.ID)
+
+		root := fmt.Sprintf("/api/packages/%s/maven/com/gitea/upstream-project", user.Name)
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", root+"/1.0/upstream-project-1.0.jar"), http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+
+			assertPulledVersion(t, packages_model.TypeMaven, "com.gitea:upstream-project", "1.0")
+
+			resp = MakeRequest(t, NewRequest(t, "GET", root+"/1.0/upstream-project-1.0.jar.sha1"), http.StatusOK)
+			assert.Equal(t, hex.EncodeToString(sumSHA1[:]), resp.Body.String())
+		})
+
+		t.Run("Metadata", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", root+"/maven-metadata.xml"), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "<version>1.0</version>")
+		})
+
+		t.Run("Snapshot", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequest(t, "GET", root+"/1.1-SNAPSHOT/upstream-project-1.1-SNAPSHOT.jar"), http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	packages_signing_service "code.gitea.io/gitea/services/packages/signing"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/blakesmith/ar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageSigningKeys(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	debianURL := fmt.Sprintf("/api/packages/%s/debian", user.Name)
+	keysURL := fmt.Sprintf("/api/v1/packages/%s/-/signing_keys/debian", user.Name)
+
+	createArchive := func(name, version string) io.Reader {
+		var cbuf bytes.Buffer
+		zw := gzip.NewWriter(&cbuf)
+		tw := tar.NewWriter(zw)
+		control := fmt.Sprintf("Package: %s\nVersion: %s\nArchitecture: all\nDescription: Package Description\n", name, version)
+		tw.WriteHeader(&tar.Header{
+			Name: "control",
+			Mode: 0o600,
+			Size: int64(len(control)),
+		})
+		io.WriteString(tw, control)
+		tw.Close()
+		zw.Close()
+
+		var buf bytes.Buffer
+		aw := ar.NewWriter(&buf)
+		aw.WriteGlobalHeader()
+		aw.WriteHeader(&ar.Header{
+			Name: "control.tar.gz",
+			Mode: 0o600,
+			Size: int64(cbuf.Len()),
+		})
+		aw.Write(cbuf.Bytes())
+		return &buf
+	}
+
+	readSigners := func(t *testing.T, r io.Reader) []uint64 {
+		p := packet.NewReader(r)
+		var signers []uint64
+		for {
+			pkt, err := p.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if sig, ok := pkt.(*packet.Signature); ok {
+				signers = append(signers, *sig.IssuerKeyId)
+			}
+		}
+		return signers
+	}
+
+	getReleaseSigners := func(t *testing.T) []uint64 {
+		resp := MakeRequest(t, NewRequest(t, "GET", debianURL+"/dists/test/Release.gpg"), http.StatusOK)
+		block, err := armor.Decode(resp.Body)
+		require.NoError(t, err)
+		detached := readSigners(t, block.Body)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", debianURL+"/dists/test/InRelease"), http.StatusOK)
+		b, _ := clearsign.Decode(resp.Body.Bytes())
+		require.NotNil(t, b)
+		assert.ElementsMatch(t, detached, readSigners(t, b.ArmoredSignature.Body))
+
+		return detached
+	}
+
+	getRepositoryKeys := func(t *testing.T) openpgp.EntityList {
+		resp := MakeRequest(t, NewRequest(t, "GET", debianURL+"/repository.key"), http.StatusOK)
+		keyring, err := openpgp.ReadArmoredKeyRing(resp.Body)
+		require.NoError(t, err)
+		return keyring
+	}
+
+	listKeys := func(t *testing.T) []*api.PackageSigningKey {
+		resp := MakeRequest(t, NewRequest(t, "GET", keysURL).AddTokenAuth(token), http.StatusOK)
+		var keys []*api.PackageSigningKey
+		DecodeJSON(t, resp, &keys)
+		return keys
+	}
+
+	keyID := func(t *testing.T, fingerprint string) uint64 {
+		for _, e := range getRepositoryKeys(t) {
+			if strings.EqualFold(fmt.Sprintf("%X", e.PrimaryKey.Fingerprint), fingerprint) {
+				return e.PrimaryKey.KeyId
+			}
+		}
+		assert.Failf(t, "key not published", "fingerprint %s", fingerprint)
+		return 0
+	}
+
+	req := NewRequestWithBody(t, "PUT", debianURL+"/pool/test/main/upload", createArchive("gitea", "1.0.0")).
+		AddBasicAuth(user.Name)
+	MakeRequest(t, req, http.StatusCreated)
+
+	var first *api.PackageSigningKey
+
+	t.Run("Initial", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		keys := listKeys(t)
+		require.Len(t, keys, 1)
+		first = keys[0]
+		assert.Equal(t, "active", first.Status)
+		assert.Nil(t, first.RetireAt)
+
+		assert.Len(t, getRepositoryKeys(t), 1)
+		assert.Equal(t, []uint64{keyID(t, first.Fingerprint)}, getReleaseSigners(t))
+	})
+
+	var second *api.PackageSigningKey
+
+	t.Run("Rotate", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "POST", keysURL+"/rotate"), http.StatusUnauthorized)
+
+		resp := MakeRequest(t, NewRequest(t, "POST", keysURL+"/rotate").AddTokenAuth(token), http.StatusCreated)
+		DecodeJSON(t, resp, &second)
+		assert.Equal(t, "active", second.Status)
+		assert.NotEqual(t, first.Fingerprint, second.Fingerprint)
+
+		keys := listKeys(t)
+		require.Len(t, keys, 2)
+		assert.Equal(t, second.Fingerprint, keys[0].Fingerprint)
+		assert.Equal(t, "active", keys[1].Status)
+		assert.NotNil(t, keys[1].RetireAt)
+
+		assert.Len(t, getRepositoryKeys(t), 2)
+		assert.ElementsMatch(t, []uint64{keyID(t, first.Fingerprint), keyID(t, second.Fingerprint)}, getReleaseSigners(t))
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("%s/%d/revoke", keysURL, first.ID+1000)).AddTokenAuth(token), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("%s/%d/revoke", keysURL, first.ID)).AddTokenAuth(token), http.StatusOK)
+		var revoked *api.PackageSigningKey
+		DecodeJSON(t, resp, &revoked)
+		assert.Equal(t, "revoked", revoked.Status)
+		assert.NotNil(t, revoked.RevokedAt)
+
+		keyring := getRepositoryKeys(t)
+		assert.Len(t, keyring, 2)
+		for _, e := range keyring {
+			if e.PrimaryKey.KeyId == keyID(t, first.Fingerprint) {
+				assert.NotEmpty(t, e.Revocations)
+			} else {
+				assert.Empty(t, e.Revocations)
+			}
+		}
+
+		assert.Equal(t, []uint64{keyID(t, second.Fingerprint)}, getReleaseSigners(t))
+	})
+
+	t.Run("Retire", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.Packages.SigningKeyRotationOverlap, 0)()
+
+		var third *api.PackageSigningKey
+		resp := MakeRequest(t, NewRequest(t, "POST", keysURL+"/rotate").AddTokenAuth(token), http.StatusCreated)
+		DecodeJSON(t, resp, &third)
+
+		assert.NoError(t, packages_signing_service.UpdateKeysTask(t.Context()))
+
+		keys := listKeys(t)
+		require.Len(t, keys, 3)
+		assert.Equal(t, "active", keys[0].Status)
+		assert.Equal(t, "retired", keys[1].Status)
+		assert.Equal(t, "revoked", keys[2].Status)
+
+		keyring := getRepositoryKeys(t)
+		assert.Len(t, keyring, 2)
+
+		assert.Equal(t, []uint64{keyID(t, third.Fingerprint)}, getReleaseSigners(t))
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/packages"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 3, htmlDoc.Find(".flex-item code").Length())
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/signing_keys/debian/rotate", map[string]string{
+			"_csrf": htmlDoc.GetCSRF(),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		keys := listKeys(t)
+		require.Len(t, keys, 4)
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user/settings/packages/signing_keys/debian/%d/revoke", keys[0].ID), map[string]string{
+			"_csrf": htmlDoc.GetCSRF(),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		keys = listKeys(t)
+		require.Len(t, keys, 4)
+		assert.Equal(t, "revoked", keys[0].Status)
+		assert.Equal(t, "active", keys[1].Status)
+	})
+}
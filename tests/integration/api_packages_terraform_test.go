@@ -0,0 +1,314 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	root := fmt.Sprintf("/api/packages/%s/terraform", user.Name)
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/.well-known/terraform.json"), http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "consul"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# Consul"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": `variable "name" {}`, "README.md": readme} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		moduleURL := fmt.Sprintf("%s/modules/%s/%s/%s", root, moduleName, moduleSystem, moduleVersion)
+		protocolURL := fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/%s", user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", moduleURL, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/modules/-invalid/%s/%s", root, moduleSystem, moduleVersion), bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", moduleURL, strings.NewReader("invalid")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", moduleURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+			assert.NoError(t, err)
+			assert.NotNil(t, pd.SemVer)
+			assert.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindModule, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, readme, pd.Metadata.(*terraform_module.Metadata).Readme)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+
+			req = NewRequestWithBody(t, "PUT", moduleURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", protocolURL+"/versions"), http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+
+			MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/unknown/%s/versions", user.Name, moduleSystem)), http.StatusNotFound)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", protocolURL+"/"+moduleVersion+"/download"), http.StatusNoContent)
+
+			location := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, fmt.Sprintf("%s%s/%s-%s-%s.tar.gz", setting.AppURL, moduleURL[1:], moduleName, moduleSystem, moduleVersion), location)
+
+			resp = MakeRequest(t, NewRequest(t, "GET", strings.TrimPrefix(location, setting.AppURL[:len(setting.AppURL)-1])), http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+
+			MakeRequest(t, NewRequest(t, "GET", protocolURL+"/9.9.9/download"), http.StatusNotFound)
+		})
+
+		t.Run("View", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/terraform/%s/%s", user.Name, url.PathEscape(moduleName+"/"+moduleSystem), moduleVersion)), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), fmt.Sprintf(`module "%s"`, moduleName))
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequest(t, "DELETE", moduleURL), http.StatusUnauthorized)
+			MakeRequest(t, NewRequest(t, "DELETE", moduleURL).AddTokenAuth(token), http.StatusNoContent)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			assert.NoError(t, err)
+			assert.Empty(t, pvs)
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "2.0.0"
+
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("terraform-provider-example_v2.0.0")
+		w.Write([]byte("binary"))
+		zw.Close()
+		content := buf.Bytes()
+		sum := sha256.Sum256(content)
+
+		archiveFilename := fmt.Sprintf("terraform-provider-%s_%s_linux_amd64.zip", providerType, providerVersion)
+		sumsFilename := fmt.Sprintf("terraform-provider-%s_%s_SHA256SUMS", providerType, providerVersion)
+		versionURL := fmt.Sprintf("%s/providers/%s/%s", root, providerType, providerVersion)
+		protocolURL := fmt.Sprintf("/api/packages/-/terraform/providers/v1/%s/%s", user.Name, providerType)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", versionURL+"/"+archiveFilename, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			// the filename must match the type and the version
+			req = NewRequestWithBody(t, "PUT", versionURL+"/terraform-provider-other_2.0.0_linux_amd64.zip", bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+archiveFilename, strings.NewReader("invalid")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+archiveFilename, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+archiveFilename, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+
+			req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/terraform-provider-%s_%s_manifest.json", versionURL, providerType, providerVersion), strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+			assert.NoError(t, err)
+			assert.Equal(t, providerType, pd.Package.Name)
+			assert.Equal(t, terraform_module.KindProvider, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, []string{"6.0"}, pd.Metadata.(*terraform_module.Metadata).Protocols)
+			assert.Len(t, pd.Files, 2)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", protocolURL+"/versions"), http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			require.Len(t, result.Versions[0].Platforms, 1)
+			assert.Equal(t, "linux", result.Versions[0].Platforms[0].OS)
+			assert.Equal(t, "amd64", result.Versions[0].Platforms[0].Arch)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequest(t, "GET", protocolURL+"/"+providerVersion+"/download/darwin/arm64"), http.StatusNotFound)
+
+			resp := MakeRequest(t, NewRequest(t, "GET", protocolURL+"/"+providerVersion+"/download/linux/amd64"), http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				SHASumsURL          string   `json:"shasums_url"`
+				SHASumsSignatureURL string   `json:"shasums_signature_url"`
+				SHASum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, archiveFilename, result.Filename)
+			assert.Equal(t, hex.EncodeToString(sum[:]), result.SHASum)
+			assert.Equal(t, setting.AppURL+versionURL[1:]+"/"+archiveFilename, result.DownloadURL)
+			assert.Equal(t, setting.AppURL+versionURL[1:]+"/"+sumsFilename, result.SHASumsURL)
+			assert.Equal(t, result.SHASumsURL+".sig", result.SHASumsSignatureURL)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			resp = MakeRequest(t, NewRequest(t, "GET", versionURL+"/"+archiveFilename), http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+
+			resp = MakeRequest(t, NewRequest(t, "GET", versionURL+"/"+sumsFilename), http.StatusOK)
+			sums := resp.Body.Bytes()
+			assert.Equal(t, fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archiveFilename), string(sums))
+
+			resp = MakeRequest(t, NewRequest(t, "GET", versionURL+"/"+sumsFilename+".sig"), http.StatusOK)
+			signature := resp.Body.Bytes()
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			assert.Equal(t, strings.ToUpper(keyring[0].PrimaryKey.KeyIdString()), result.SigningKeys.GPGPublicKeys[0].KeyID)
+			_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(sums), bytes.NewReader(signature), nil)
+			assert.NoError(t, err)
+
+			resp = MakeRequest(t, NewRequest(t, "GET", root+"/repository.key"), http.StatusOK)
+			assert.Equal(t, result.SigningKeys.GPGPublicKeys[0].ASCIIArmor, resp.Body.String())
+		})
+
+		t.Run("View", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/terraform/%s/%s", user.Name, providerType, providerVersion)), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "required_providers")
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequest(t, "DELETE", versionURL).AddTokenAuth(token), http.StatusNoContent)
+			MakeRequest(t, NewRequest(t, "GET", protocolURL+"/versions"), http.StatusNotFound)
+		})
+	})
+}
@@ -7,13 +7,21 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"testing"
+	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAPIGetWikiPage(t *testing.T) {
@@ -251,3 +259,30 @@ func TestAPIListPageRevisions(t *testing.T) {
 
 	assert.Equal(t, dummyrevisions, revisions)
 }
+
+func TestAPISearchAllWikisPublicOnly(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Indexer.WikiIndexerEnabled, true)()
+	defer test.MockVariableValue(&setting.Indexer.WikiType, "bleve")()
+	defer test.MockVariableValue(&setting.Indexer.WikiPath, filepath.Join(setting.AppDataPath, "indexers", "wiki.bleve"))()
+	wiki_indexer.Init()
+
+	// the wiki of repo1 is indexed when the index is populated for the first time
+	searchHome := func(token string) []*api.WikiSearchResult {
+		req := NewRequest(t, "GET", "/api/v1/repos/wikis/search?q=home").
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var results []*api.WikiSearchResult
+		DecodeJSON(t, resp, &results)
+		return results
+	}
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+	require.Eventually(t, func() bool { return len(searchHome(token)) > 0 }, 10*time.Second, 100*time.Millisecond)
+
+	_, err := db.GetEngine(db.DefaultContext).ID(1).Cols("is_private").Update(&repo_model.Repository{IsPrivate: true})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, searchHome(token))
+	publicOnlyToken := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopePublicOnly)
+	assert.Empty(t, searchHome(publicOnlyToken))
+}
@@ -5,16 +5,28 @@ package integration
 
 import (
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
+	unit_model "code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func resultFilenames(doc *HTMLDoc) []string {
@@ -36,8 +48,8 @@ func TestSearchRepo(t *testing.T) {
 
 	testSearch(t, "/user2/repo1/search?q=Description&page=1", []string{"README.md"})
 
-	setting.Indexer.IncludePatterns = setting.IndexerGlobFromString("**.txt")
-	setting.Indexer.ExcludePatterns = setting.IndexerGlobFromString("**/y/**")
+	defer test.MockVariableValue(&setting.Indexer.IncludePatterns, setting.IndexerGlobFromString("**.txt"))()
+	defer test.MockVariableValue(&setting.Indexer.ExcludePatterns, setting.IndexerGlobFromString("**/y/**"))()
 
 	repo, err = repo_model.GetRepositoryByOwnerAndName(db.DefaultContext, "user2", "glob")
 	assert.NoError(t, err)
@@ -52,6 +64,193 @@ func TestSearchRepo(t *testing.T) {
 	testSearch(t, "/user2/glob/search?q=file5&page=1&t=match", []string{"x/b.txt", "a.txt"})
 }
 
+func TestSearchRepoSymbols(t *testing.T) {
+	onGiteaRun(t, testSearchRepoSymbols)
+}
+
+func testSearchRepoSymbols(t *testing.T, _ *url.URL) {
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo, err := repo_model.GetRepositoryByOwnerAndName(db.DefaultContext, "user2", "repo1")
+	require.NoError(t, err)
+
+	_, err = createFileInBranch(user, repo, "greeter.go", repo.DefaultBranch, `package main
+
+// Greeter greets people
+type Greeter struct{}
+
+// Greet returns a greeting
+func (g *Greeter) Greet(name string) string {
+	return "Hello " + name
+}
+
+func NewGreeter() *Greeter {
+	return &Greeter{}
+}
+`)
+	require.NoError(t, err)
+	_, err = createFileInBranch(user, repo, "main.go", repo.DefaultBranch, `package main
+
+func main() {
+	println(NewGreeter().Greet("world"))
+}
+`)
+	require.NoError(t, err)
+
+	code_indexer.UpdateRepoIndexer(repo)
+
+	t.Run("API", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		listSymbols := func(t *testing.T, query string) []*api.CodeSymbol {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/symbols?"+query), http.StatusOK)
+			var symbols []*api.CodeSymbol
+			DecodeJSON(t, resp, &symbols)
+			return symbols
+		}
+
+		symbols := listSymbols(t, "q=greet")
+		require.Len(t, symbols, 3)
+		assert.Equal(t, "Greet", symbols[0].Name)
+		assert.Equal(t, "method", symbols[0].Kind)
+		assert.Equal(t, "Greeter", symbols[0].Container)
+		assert.Equal(t, "greeter.go", symbols[0].Path)
+		assert.Equal(t, 7, symbols[0].Line)
+		assert.Equal(t, setting.AppURL+"user2/repo1/src/branch/master/greeter.go#L7", symbols[0].HTMLURL)
+		assert.Equal(t, "Greeter", symbols[1].Name)
+		assert.Equal(t, "type", symbols[1].Kind)
+		assert.Equal(t, "NewGreeter", symbols[2].Name)
+		assert.Equal(t, "function", symbols[2].Kind)
+
+		symbols = listSymbols(t, "q=greeter&exact=true")
+		require.Len(t, symbols, 1)
+		assert.Equal(t, "Greeter", symbols[0].Name)
+
+		symbols = listSymbols(t, "kind=type")
+		require.Len(t, symbols, 1)
+		assert.Equal(t, "Greeter", symbols[0].Name)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/symbols?kind=variable"), http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		testSearch(t, "/user2/repo1/search?q=symbol:NewGreeter", []string{"greeter.go"})
+		testSearch(t, "/user2/repo1/search?q=greet&search_mode=symbol", []string{"greeter.go", "greeter.go", "greeter.go"})
+	})
+
+	t.Run("Definitions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/src/branch/master/main.go"), http.StatusOK)
+		hrefs := make(map[string]string)
+		NewHTMLParser(t, resp.Body).Find("a.symbol-definition").Each(func(i int, selection *goquery.Selection) {
+			hrefs[selection.Text()], _ = selection.Attr("href")
+		})
+		assert.Equal(t, "/user2/repo1/src/branch/master/greeter.go#L11", hrefs["NewGreeter"])
+		assert.Equal(t, "/user2/repo1/src/branch/master/greeter.go#L7", hrefs["Greet"])
+		assert.NotContains(t, hrefs, "println")
+
+		// the definitions are not linked to themselves
+		resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/src/branch/master/greeter.go"), http.StatusOK)
+		hrefs = make(map[string]string)
+		NewHTMLParser(t, resp.Body).Find("a.symbol-definition").Each(func(i int, selection *goquery.Selection) {
+			hrefs[selection.Text()], _ = selection.Attr("href")
+		})
+		assert.Equal(t, "/user2/repo1/src/branch/master/greeter.go#L4", hrefs["Greeter"])
+		assert.NotContains(t, hrefs, "Greet")
+		assert.NotContains(t, hrefs, "NewGreeter")
+	})
+}
+
+func TestSearchRepoRefs(t *testing.T) {
+	onGiteaRun(t, testSearchRepoRefs)
+}
+
+func testSearchRepoRefs(t *testing.T, _ *url.URL) {
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo, err := repo_model.GetRepositoryByOwnerAndName(db.DefaultContext, "user2", "repo1")
+	require.NoError(t, err)
+
+	codeUnit, err := repo.GetUnit(db.DefaultContext, unit_model.TypeCode)
+	require.NoError(t, err)
+	codeUnit.Config = &repo_model.CodeConfig{IndexerRefPatterns: []string{"release/*"}}
+	require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, codeUnit))
+
+	code_indexer.UpdateRepoIndexer(repo)
+
+	// the new branch is indexed when it is pushed
+	resp, err := files_service.ChangeRepoFiles(git.DefaultContext, repo, user, &files_service.ChangeRepoFilesOptions{
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     "create",
+				TreePath:      "release-notes.txt",
+				ContentReader: strings.NewReader("The quokka release\n"),
+			},
+		},
+		OldBranch: repo.DefaultBranch,
+		NewBranch: "release/v1",
+	})
+	require.NoError(t, err)
+	commitID := resp.Commit.SHA
+
+	t.Run("Search", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		testSearch(t, "/user2/repo1/search?q=quokka", []string{})
+		testSearch(t, "/user2/repo1/search?q=quokka&ref=release/v1", []string{"release-notes.txt"})
+		testSearch(t, "/user2/repo1/search?q=quokka&ref=refs/heads/release/v1", []string{"release-notes.txt"})
+		// the files which are the same as in the default branch are found in both
+		testSearch(t, "/user2/repo1/search?q=Description&ref=release/v1", []string{"README.md"})
+		testSearch(t, "/user2/repo1/search?q=Description", []string{"README.md"})
+
+		MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/search?q=quokka&ref=develop"), http.StatusNotFound)
+	})
+
+	t.Run("API", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/search/code?q=quokka&ref=release/v1"), http.StatusOK)
+		var results []*api.CodeSearchResult
+		DecodeJSON(t, resp, &results)
+		require.Len(t, results, 1)
+		assert.Equal(t, "release-notes.txt", results[0].Path)
+		assert.Equal(t, commitID, results[0].CommitID)
+		assert.Equal(t, setting.AppURL+"user2/repo1/src/commit/"+commitID+"/release-notes.txt", results[0].HTMLURL)
+		require.Len(t, results[0].Lines, 1)
+		assert.Equal(t, 1, results[0].Lines[0].Num)
+		assert.Equal(t, "The quokka release", results[0].Lines[0].Content)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/search/code?q=quokka"), http.StatusOK)
+		DecodeJSON(t, resp, &results)
+		assert.Empty(t, results)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/search/code?q=quokka&ref=develop"), http.StatusUnprocessableEntity)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/search/code"), http.StatusUnprocessableEntity)
+	})
+
+	t.Run("DeleteBranch", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		require.NoError(t, repo_service.DeleteBranch(db.DefaultContext, user, repo, gitRepo, "release/v1", nil))
+
+		unittest.AssertNotExistsBean(t, &repo_model.RepoIndexerRef{RepoID: repo.ID})
+		total, _, _, err := code_indexer.PerformSearch(db.DefaultContext, &code_indexer.SearchOptions{
+			RepoIDs:   []int64{repo.ID},
+			Keyword:   "quokka",
+			Ref:       "refs/heads/release/v1",
+			Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+
+		MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/search?q=quokka&ref=release/v1"), http.StatusNotFound)
+	})
+}
+
 func testSearch(t *testing.T, url string, expected []string) {
 	req := NewRequest(t, "GET", url)
 	resp := MakeRequest(t, req, http.StatusOK)
@@ -0,0 +1,24 @@
+import {createApp} from 'vue';
+
+export async function initPackageDownloadStats() {
+  const el = document.querySelector('#package-download-stats-chart');
+  if (!el) return;
+
+  const {default: PackageDownloadStats} = await import(/* webpackChunkName: "package-download-stats-graph" */'../components/PackageDownloadStats.vue');
+  try {
+    const View = createApp(PackageDownloadStats, {
+      url: el.getAttribute('data-url'),
+      days: Number(el.getAttribute('data-days')),
+      locale: {
+        loadingTitle: el.getAttribute('data-locale-loading-title'),
+        loadingTitleFailed: el.getAttribute('data-locale-loading-title-failed'),
+        loadingInfo: el.getAttribute('data-locale-loading-info'),
+        noDownloads: el.getAttribute('data-locale-no-downloads'),
+      },
+    });
+    View.mount(el);
+  } catch (err) {
+    console.error('PackageDownloadStats failed to load', err);
+    el.textContent = el.getAttribute('data-locale-component-failed-to-load');
+  }
+}
@@ -0,0 +1,39 @@
+import {debounce} from 'throttle-debounce';
+import {GET} from '../modules/fetch.ts';
+import {toggleElem} from '../utils/dom.ts';
+
+// show the existing issues similar to the new issue while its title and content are typed, to avoid duplicates
+export function initRepoIssueSimilar() {
+  const container = document.querySelector<HTMLElement>('#similar-issues');
+  if (!container) return;
+  const form = container.closest('form');
+  const titleInput = form.querySelector<HTMLInputElement>('input[name=title]');
+
+  let lastQuery = '';
+  const loadSimilarIssues = debounce(500, async () => {
+    const contentInput = form.querySelector<HTMLTextAreaElement>('textarea[name=content]');
+    const params = new URLSearchParams({title: titleInput.value.trim(), content: contentInput?.value.trim() ?? ''});
+    const query = params.toString();
+    if (query === lastQuery) return;
+    lastQuery = query;
+    if (!params.get('title')) {
+      toggleElem(container, false);
+      return;
+    }
+
+    try {
+      const resp = await GET(`${container.getAttribute('data-url')}?${query}`);
+      if (query !== lastQuery) return; // the title or content has changed while loading
+      container.innerHTML = await resp.text();
+      toggleElem(container, Boolean(container.querySelector('.flex-item')));
+    } catch (error) {
+      console.error('Error loading similar issues:', error);
+    }
+  });
+
+  titleInput.addEventListener('input', loadSimilarIssues);
+  form.addEventListener('change', (e) => {
+    if ((e.target as HTMLElement).matches('textarea[name=content]')) loadSimilarIssues();
+  });
+  loadSimilarIssues();
+}
@@ -49,10 +49,12 @@ import {initGlobalTooltips} from './modules/tippy.ts';
 import {initGiteaFomantic} from './modules/fomantic.ts';
 import {initSubmitEventPolyfill, onDomReady} from './utils/dom.ts';
 import {initRepoIssueList} from './features/repo-issue-list.ts';
+import {initRepoIssueSimilar} from './features/repo-issue-similar.ts';
 import {initCommonIssueListQuickGoto} from './features/common-issue-list.ts';
 import {initRepoContributors} from './features/contributors.ts';
 import {initRepoCodeFrequency} from './features/code-frequency.ts';
 import {initRepoRecentCommits} from './features/recent-commits.ts';
+import {initPackageDownloadStats} from './features/package-download-stats.ts';
 import {initRepoDiffCommitBranchesAndTags} from './features/repo-diff-commit.ts';
 import {initGlobalSelectorObserver} from './modules/observer.ts';
 import {initRepositorySearch} from './features/repo-search.ts';
@@ -130,6 +132,7 @@ onDomReady(() => {
     initRepoGraphGit,
     initRepoIssueContentHistory,
     initRepoIssueList,
+    initRepoIssueSimilar,
     initRepoIssueFilterItemLabel,
     initRepoIssueSidebarDependency,
     initRepoMigration,
@@ -148,6 +151,7 @@ onDomReady(() => {
     initRepoContributors,
     initRepoCodeFrequency,
     initRepoRecentCommits,
+    initPackageDownloadStats,
 
     initCommitStatuses,
     initCaptcha,